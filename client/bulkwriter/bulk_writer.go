@@ -0,0 +1,345 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/client/v2/column"
+	"github.com/milvus-io/milvus/client/v2/entity"
+)
+
+// BulkFileType is the type of files generated by bulk writer.
+type BulkFileType int32
+
+const (
+	BulkFileTypeParquet BulkFileType = 1
+	BulkFileTypeJSON    BulkFileType = 2
+	BulkFileTypeCSV     BulkFileType = 3
+)
+
+// Extension returns the file extension of the bulk file type.
+func (t BulkFileType) Extension() string {
+	switch t {
+	case BulkFileTypeParquet:
+		return ".parquet"
+	case BulkFileTypeJSON:
+		return ".json"
+	case BulkFileTypeCSV:
+		return ".csv"
+	default:
+		return ""
+	}
+}
+
+const (
+	// DefaultChunkSize is the default size threshold in bytes to roll a new file, 128MB.
+	DefaultChunkSize int64 = 128 * 1024 * 1024
+
+	// dynamicFieldName is the field name which the import reader accepts to hold dynamic values.
+	dynamicFieldName = "$meta"
+)
+
+// LocalBulkWriterOption is the option to create a LocalBulkWriter.
+type LocalBulkWriterOption struct {
+	Schema    *entity.Schema
+	LocalPath string
+	// ChunkSize is the threshold of buffered data size in bytes, a new file is rolled once exceeded.
+	ChunkSize int64
+	FileType  BulkFileType
+
+	// CSVSeparator and CSVNullKey shall match the "sep" and "nullkey" import options.
+	CSVSeparator rune
+	CSVNullKey   string
+}
+
+// NewLocalBulkWriterOption returns the option with default chunk size and parquet file type.
+func NewLocalBulkWriterOption(schema *entity.Schema, localPath string) *LocalBulkWriterOption {
+	return &LocalBulkWriterOption{
+		Schema:       schema,
+		LocalPath:    localPath,
+		ChunkSize:    DefaultChunkSize,
+		FileType:     BulkFileTypeParquet,
+		CSVSeparator: ',',
+	}
+}
+
+func (opt *LocalBulkWriterOption) WithChunkSize(chunkSize int64) *LocalBulkWriterOption {
+	opt.ChunkSize = chunkSize
+	return opt
+}
+
+func (opt *LocalBulkWriterOption) WithFileType(fileType BulkFileType) *LocalBulkWriterOption {
+	opt.FileType = fileType
+	return opt
+}
+
+func (opt *LocalBulkWriterOption) WithCSVSeparator(sep rune) *LocalBulkWriterOption {
+	opt.CSVSeparator = sep
+	return opt
+}
+
+func (opt *LocalBulkWriterOption) WithCSVNullKey(nullKey string) *LocalBulkWriterOption {
+	opt.CSVNullKey = nullKey
+	return opt
+}
+
+func (opt *LocalBulkWriterOption) validate() error {
+	if opt.Schema == nil {
+		return errors.New("bulk writer requires collection schema")
+	}
+	if opt.Schema.PKField() == nil {
+		return errors.New("collection schema has no primary key field")
+	}
+	if opt.LocalPath == "" {
+		return errors.New("bulk writer requires local path")
+	}
+	if opt.ChunkSize <= 0 {
+		return fmt.Errorf("invalid chunk size %d", opt.ChunkSize)
+	}
+	if opt.FileType.Extension() == "" {
+		return fmt.Errorf("unsupported bulk file type %d", opt.FileType)
+	}
+	if opt.FileType == BulkFileTypeCSV && lo.Contains([]rune{0, '\n', '\r', '"', 0xFFFD}, opt.CSVSeparator) {
+		return fmt.Errorf("unsupported csv separator: %q", opt.CSVSeparator)
+	}
+	return nil
+}
+
+// LocalBulkWriter validates rows against the collection schema, buffers them in memory
+// and writes them into local files which could be imported by the bulk import API.
+// Every file is rolled when the buffered data size reaches the chunk size.
+type LocalBulkWriter struct {
+	opt      *LocalBulkWriterOption
+	fields   []*entity.Field
+	dynamic  bool
+	writeDir string
+
+	mu         sync.Mutex
+	buffer     []map[string]any
+	bufferSize int64
+	fileSeq    int
+	totalRows  int64
+	batchFiles [][]string
+	closed     bool
+
+	// onFlush is invoked after a file is generated, returns the file path to record.
+	// It is used by RemoteBulkWriter to upload the local file.
+	onFlush func(ctx context.Context, file string) (string, error)
+}
+
+// NewLocalBulkWriter creates a LocalBulkWriter, the files are written into a new
+// sub-directory with random uuid name under the local path.
+func NewLocalBulkWriter(opt *LocalBulkWriterOption) (*LocalBulkWriter, error) {
+	if err := opt.validate(); err != nil {
+		return nil, err
+	}
+	writeDir := filepath.Join(opt.LocalPath, uuid.NewString())
+	if err := os.MkdirAll(writeDir, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalBulkWriter{
+		opt:      opt,
+		fields:   writableFields(opt.Schema),
+		dynamic:  opt.Schema.EnableDynamicField,
+		writeDir: writeDir,
+	}, nil
+}
+
+// writableFields returns the fields which values shall be provided in import files,
+// auto id primary key and function output fields are excluded.
+func writableFields(schema *entity.Schema) []*entity.Field {
+	outputs := make(map[string]struct{})
+	for _, function := range schema.Functions {
+		for _, name := range function.OutputFieldNames {
+			outputs[name] = struct{}{}
+		}
+	}
+	return lo.Filter(schema.Fields, func(field *entity.Field, _ int) bool {
+		if field.IsDynamic || (field.PrimaryKey && field.AutoID) {
+			return false
+		}
+		_, isOutput := outputs[field.Name]
+		return !isOutput
+	})
+}
+
+// Dir returns the directory where files are written.
+func (w *LocalBulkWriter) Dir() string {
+	return w.writeDir
+}
+
+// AppendRow validates one row and appends it into the buffer, a new file is generated
+// if the buffer size reaches the chunk size.
+// If the file fails to be generated, the row is removed from the buffer so the caller could retry it.
+func (w *LocalBulkWriter) AppendRow(ctx context.Context, row map[string]any) error {
+	converted, size, err := convertRow(w.opt.Schema, w.fields, w.dynamic, row)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return errors.New("bulk writer is closed")
+	}
+	w.buffer = append(w.buffer, converted)
+	w.bufferSize += size
+	if w.bufferSize >= w.opt.ChunkSize {
+		if err := w.flush(ctx); err != nil {
+			w.buffer = w.buffer[:len(w.buffer)-1]
+			w.bufferSize -= size
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendColumns appends a batch of columns, all the columns must have the same length.
+func (w *LocalBulkWriter) AppendColumns(ctx context.Context, columns ...column.Column) error {
+	if len(columns) == 0 {
+		return nil
+	}
+	rowNum := columns[0].Len()
+	for _, col := range columns {
+		if col.Len() != rowNum {
+			return fmt.Errorf("column %s has %d rows, but column %s has %d rows",
+				col.Name(), col.Len(), columns[0].Name(), rowNum)
+		}
+	}
+	for i := 0; i < rowNum; i++ {
+		row := make(map[string]any, len(columns))
+		for _, col := range columns {
+			if col.Nullable() {
+				isNull, err := col.IsNull(i)
+				if err != nil {
+					return err
+				}
+				if isNull {
+					row[col.Name()] = nil
+					continue
+				}
+			}
+			value, err := col.Get(i)
+			if err != nil {
+				return err
+			}
+			if col.Name() == dynamicFieldName {
+				// dynamic column holds the encoded json object, expand it into the row
+				if err := mergeDynamicValues(row, value); err != nil {
+					return err
+				}
+				continue
+			}
+			row[col.Name()] = value
+		}
+		if err := w.AppendRow(ctx, row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Commit writes the buffered rows into a new file.
+// If the file fails to be written or uploaded, no file is left and the rows stay buffered for the next Commit.
+func (w *LocalBulkWriter) Commit(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.buffer) == 0 {
+		return nil
+	}
+	return w.flush(ctx)
+}
+
+// BatchFiles returns the generated files, each item is a batch that could be passed to
+// the bulk import API directly.
+func (w *LocalBulkWriter) BatchFiles() [][]string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return lo.Map(w.batchFiles, func(files []string, _ int) []string {
+		return append([]string{}, files...)
+	})
+}
+
+// TotalRows returns the number of rows written into files.
+func (w *LocalBulkWriter) TotalRows() int64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.totalRows
+}
+
+// Close commits the buffered rows, and the writer is not writable after closed.
+// The writer stays open if the rows fail to commit, so Close can be retried.
+func (w *LocalBulkWriter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	if len(w.buffer) > 0 {
+		if err := w.flush(ctx); err != nil {
+			return err
+		}
+	}
+	w.closed = true
+	return nil
+}
+
+func (w *LocalBulkWriter) flush(ctx context.Context) error {
+	w.fileSeq++
+	file := filepath.Join(w.writeDir, strconv.Itoa(w.fileSeq)+w.opt.FileType.Extension())
+	var err error
+	switch w.opt.FileType {
+	case BulkFileTypeParquet:
+		err = writeParquetFile(file, w.fields, w.dynamic, w.buffer)
+	case BulkFileTypeJSON:
+		err = writeJSONFile(file, w.fields, w.dynamic, w.buffer)
+	case BulkFileTypeCSV:
+		err = writeCSVFile(file, w.fields, w.dynamic, w.buffer, w.opt.CSVSeparator, w.opt.CSVNullKey)
+	}
+	if err != nil {
+		_ = os.Remove(file)
+		w.fileSeq--
+		return fmt.Errorf("failed to write bulk file %s: %w", file, err)
+	}
+
+	if w.onFlush != nil {
+		flushed, err := w.onFlush(ctx, file)
+		if err != nil {
+			// the rows stay in the buffer and the file is written again by the next Commit or Close,
+			// so the local file is removed rather than left behind
+			_ = os.Remove(file)
+			w.fileSeq--
+			return err
+		}
+		file = flushed
+	}
+	w.batchFiles = append(w.batchFiles, []string{file})
+	w.totalRows += int64(len(w.buffer))
+	w.buffer = nil
+	w.bufferSize = 0
+	return nil
+}
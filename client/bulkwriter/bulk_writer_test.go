@@ -0,0 +1,377 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/parquet/file"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/client/v2/column"
+	"github.com/milvus-io/milvus/client/v2/entity"
+)
+
+type BulkWriterSuite struct {
+	suite.Suite
+
+	schema *entity.Schema
+}
+
+func (s *BulkWriterSuite) SetupTest() {
+	s.schema = entity.NewSchema().WithName("test_bulk_writer").WithDynamicFieldEnabled(true).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("int8").WithDataType(entity.FieldTypeInt8)).
+		WithField(entity.NewField().WithName("text").WithDataType(entity.FieldTypeVarChar).WithMaxLength(16).WithNullable(true)).
+		WithField(entity.NewField().WithName("meta").WithDataType(entity.FieldTypeJSON)).
+		WithField(entity.NewField().WithName("tags").WithDataType(entity.FieldTypeArray).
+			WithElementType(entity.FieldTypeInt32).WithMaxCapacity(4)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(4)).
+		WithField(entity.NewField().WithName("fp16").WithDataType(entity.FieldTypeFloat16Vector).WithDim(4)).
+		WithField(entity.NewField().WithName("binary").WithDataType(entity.FieldTypeBinaryVector).WithDim(16)).
+		WithField(entity.NewField().WithName("sparse").WithDataType(entity.FieldTypeSparseVector))
+}
+
+func (s *BulkWriterSuite) genRow(i int) map[string]any {
+	sparse, err := entity.NewSliceSparseEmbedding([]uint32{1, uint32(i + 10)}, []float32{0.5, 1.5})
+	s.Require().NoError(err)
+	row := map[string]any{
+		"id":     int64(i),
+		"int8":   int8(i % 100),
+		"meta":   map[string]any{"x": i},
+		"tags":   []int32{int32(i), int32(i + 1)},
+		"vector": []float32{0.1, 0.2, 0.3, float32(i)},
+		"fp16":   []float32{0.5, 0.25, 1, 2},
+		"binary": []byte{byte(i), 0xff},
+		"sparse": sparse,
+		"dyn":    fmt.Sprintf("dyn_%d", i),
+	}
+	if i%2 == 0 {
+		row["text"] = fmt.Sprintf("text_%d", i)
+	}
+	return row
+}
+
+func (s *BulkWriterSuite) newWriter(fileType BulkFileType, chunkSize int64) *LocalBulkWriter {
+	w, err := NewLocalBulkWriter(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).
+		WithFileType(fileType).
+		WithChunkSize(chunkSize))
+	s.Require().NoError(err)
+	return w
+}
+
+func (s *BulkWriterSuite) TestNewLocalBulkWriter() {
+	_, err := NewLocalBulkWriter(NewLocalBulkWriterOption(nil, s.T().TempDir()))
+	s.Error(err)
+	_, err = NewLocalBulkWriter(NewLocalBulkWriterOption(s.schema, ""))
+	s.Error(err)
+	_, err = NewLocalBulkWriter(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).WithChunkSize(0))
+	s.Error(err)
+	_, err = NewLocalBulkWriter(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).WithFileType(BulkFileType(100)))
+	s.Error(err)
+	_, err = NewLocalBulkWriter(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).
+		WithFileType(BulkFileTypeCSV).WithCSVSeparator('"'))
+	s.Error(err)
+}
+
+func (s *BulkWriterSuite) TestAppendRowValidation() {
+	ctx := context.Background()
+	w := s.newWriter(BulkFileTypeJSON, DefaultChunkSize)
+	defer w.Close(ctx)
+
+	s.NoError(w.AppendRow(ctx, s.genRow(1)))
+
+	cases := map[string]func(row map[string]any){
+		"missing_field":       func(row map[string]any) { delete(row, "vector") },
+		"int8_out_of_range":   func(row map[string]any) { row["int8"] = 1000 },
+		"wrong_type":          func(row map[string]any) { row["id"] = "1" },
+		"exceed_max_length":   func(row map[string]any) { row["text"] = "a string longer than 16" },
+		"exceed_max_capacity": func(row map[string]any) { row["tags"] = []int32{1, 2, 3, 4, 5} },
+		"wrong_element_type":  func(row map[string]any) { row["tags"] = []string{"a"} },
+		"wrong_dim":           func(row map[string]any) { row["vector"] = []float32{0.1} },
+		"wrong_binary_dim":    func(row map[string]any) { row["binary"] = []byte{1} },
+		"invalid_json":        func(row map[string]any) { row["meta"] = "{" },
+		"duplicated_dynamic":  func(row map[string]any) { row["$meta"] = map[string]any{"dyn": 1} },
+		"dynamic_field_name":  func(row map[string]any) { row["$meta"] = map[string]any{"id": 1} },
+	}
+	for name, fn := range cases {
+		s.Run(name, func() {
+			row := s.genRow(1)
+			fn(row)
+			s.Error(w.AppendRow(ctx, row))
+		})
+	}
+
+	// nullable field could be absent
+	row := s.genRow(1)
+	delete(row, "text")
+	s.NoError(w.AppendRow(ctx, row))
+}
+
+func (s *BulkWriterSuite) TestAppendRowSpecialFields() {
+	ctx := context.Background()
+	schema := entity.NewSchema().WithName("test_special_fields").
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true).WithIsAutoID(true)).
+		WithField(entity.NewField().WithName("text").WithDataType(entity.FieldTypeVarChar).WithMaxLength(64)).
+		WithField(entity.NewField().WithName("sparse").WithDataType(entity.FieldTypeSparseVector)).
+		WithFunction(entity.NewFunction().WithName("bm25").WithType(entity.FunctionTypeBM25).
+			WithInputFields("text").WithOutputFields("sparse"))
+	w, err := NewLocalBulkWriter(NewLocalBulkWriterOption(schema, s.T().TempDir()).WithFileType(BulkFileTypeJSON))
+	s.Require().NoError(err)
+	defer w.Close(ctx)
+
+	s.NoError(w.AppendRow(ctx, map[string]any{"text": "hello"}))
+	// auto id
+	s.Error(w.AppendRow(ctx, map[string]any{"id": int64(1), "text": "hello"}))
+	// function output
+	s.Error(w.AppendRow(ctx, map[string]any{"text": "hello", "sparse": map[uint32]float32{1: 0.1}}))
+	// dynamic field disabled
+	s.Error(w.AppendRow(ctx, map[string]any{"text": "hello", "dyn": 1}))
+}
+
+func (s *BulkWriterSuite) TestWriteJSON() {
+	ctx := context.Background()
+	w := s.newWriter(BulkFileTypeJSON, DefaultChunkSize)
+	for i := 0; i < 10; i++ {
+		s.NoError(w.AppendRow(ctx, s.genRow(i)))
+	}
+	s.NoError(w.Commit(ctx))
+	s.NoError(w.Close(ctx))
+	s.EqualValues(10, w.TotalRows())
+
+	files := w.BatchFiles()
+	s.Len(files, 1)
+	s.Equal(".json", filepath.Ext(files[0][0]))
+	bs, err := os.ReadFile(files[0][0])
+	s.NoError(err)
+	var rows []map[string]any
+	s.NoError(json.Unmarshal(bs, &rows))
+	s.Len(rows, 10)
+
+	s.EqualValues(0, rows[0]["id"])
+	s.Equal("text_0", rows[0]["text"])
+	s.Nil(rows[1]["text"])
+	s.Equal(map[string]any{"x": float64(0)}, rows[0]["meta"])
+	s.Equal([]any{float64(0), float64(1)}, rows[0]["tags"])
+	s.Equal([]any{float64(0.5), float64(0.25), float64(1), float64(2)}, rows[0]["fp16"])
+	s.Equal([]any{float64(3), float64(255)}, rows[3]["binary"])
+	s.Equal(map[string]any{"indices": []any{float64(1), float64(12)}, "values": []any{0.5, 1.5}}, rows[2]["sparse"])
+	s.Equal(map[string]any{"dyn": "dyn_5"}, rows[5]["$meta"])
+}
+
+func (s *BulkWriterSuite) TestWriteCSV() {
+	ctx := context.Background()
+	w, err := NewLocalBulkWriter(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).
+		WithFileType(BulkFileTypeCSV).
+		WithCSVSeparator('\t').
+		WithCSVNullKey("NULL"))
+	s.Require().NoError(err)
+	for i := 0; i < 5; i++ {
+		s.NoError(w.AppendRow(ctx, s.genRow(i)))
+	}
+	s.NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Len(files, 1)
+	f, err := os.Open(files[0][0])
+	s.Require().NoError(err)
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comma = '\t'
+	records, err := r.ReadAll()
+	s.NoError(err)
+	s.Len(records, 6)
+	s.Equal([]string{"id", "int8", "text", "meta", "tags", "vector", "fp16", "binary", "sparse", "$meta"}, records[0])
+	s.Equal([]string{
+		"1", "1", "NULL", `{"x":1}`, "[1,2]", "[0.1,0.2,0.3,1]", "[0.5,0.25,1,2]", "[1,255]",
+		`{"indices":[1,11],"values":[0.5,1.5]}`, `{"dyn":"dyn_1"}`,
+	}, records[2])
+}
+
+func (s *BulkWriterSuite) TestWriteParquet() {
+	ctx := context.Background()
+	w := s.newWriter(BulkFileTypeParquet, DefaultChunkSize)
+	for i := 0; i < 10; i++ {
+		s.NoError(w.AppendRow(ctx, s.genRow(i)))
+	}
+	s.NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Len(files, 1)
+	reader, err := file.OpenParquetFile(files[0][0], false)
+	s.Require().NoError(err)
+	defer reader.Close()
+	s.EqualValues(10, reader.NumRows())
+	s.Equal(10, reader.MetaData().Schema.Root().NumFields())
+}
+
+func (s *BulkWriterSuite) TestRollFiles() {
+	ctx := context.Background()
+	// each row is larger than the chunk size, every row generates a file
+	w := s.newWriter(BulkFileTypeJSON, 64)
+	for i := 0; i < 5; i++ {
+		s.NoError(w.AppendRow(ctx, s.genRow(i)))
+	}
+	s.Len(w.BatchFiles(), 5)
+	s.NoError(w.Close(ctx))
+	s.Error(w.AppendRow(ctx, s.genRow(5)))
+	s.EqualValues(5, w.TotalRows())
+}
+
+func (s *BulkWriterSuite) TestAppendColumns() {
+	ctx := context.Background()
+	schema := entity.NewSchema().WithName("test_columns").WithDynamicFieldEnabled(true).
+		WithField(entity.NewField().WithName("id").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("text").WithDataType(entity.FieldTypeVarChar).WithMaxLength(16)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(2))
+	w, err := NewLocalBulkWriter(NewLocalBulkWriterOption(schema, s.T().TempDir()).WithFileType(BulkFileTypeJSON))
+	s.Require().NoError(err)
+
+	s.NoError(w.AppendColumns(ctx,
+		column.NewColumnInt64("id", []int64{1, 2}),
+		column.NewColumnVarChar("text", []string{"a", "b"}),
+		column.NewColumnFloatVector("vector", 2, [][]float32{{0.1, 0.2}, {0.3, 0.4}}),
+		column.NewColumnJSONBytes("$meta", [][]byte{[]byte(`{"x":1}`), []byte(`{"y":2}`)}),
+	))
+	s.Error(w.AppendColumns(ctx,
+		column.NewColumnInt64("id", []int64{1, 2}),
+		column.NewColumnVarChar("text", []string{"a"}),
+	))
+	s.NoError(w.Close(ctx))
+
+	files := w.BatchFiles()
+	s.Len(files, 1)
+	bs, err := os.ReadFile(files[0][0])
+	s.NoError(err)
+	var rows []map[string]any
+	s.NoError(json.Unmarshal(bs, &rows))
+	s.Len(rows, 2)
+	s.Equal(map[string]any{"y": float64(2)}, rows[1]["$meta"])
+}
+
+func (s *BulkWriterSuite) TestRemoteBulkWriter() {
+	ctx := context.Background()
+	uploaded := make(map[string]string)
+	w, err := newRemoteBulkWriter(
+		NewRemoteBulkWriterOption(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).WithFileType(BulkFileTypeJSON),
+			"localhost:9000", "a-bucket", "bulk_data"),
+		func(ctx context.Context, localFile string, objectName string) error {
+			bs, err := os.ReadFile(localFile)
+			if err != nil {
+				return err
+			}
+			uploaded[objectName] = string(bs)
+			return nil
+		})
+	s.Require().NoError(err)
+
+	for i := 0; i < 3; i++ {
+		s.NoError(w.AppendRow(ctx, s.genRow(i)))
+	}
+
+	svr := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body BulkImportOption
+		s.NoError(json.NewDecoder(req.Body).Decode(&body))
+		s.Equal([][]string{{w.RemoteDir() + "/1.json"}}, body.Files)
+		s.Equal("part", body.PartitionName)
+		rw.Write([]byte(`{"status":0, "data":{"jobId": "123"}}`))
+	}))
+	defer svr.Close()
+
+	resp, err := w.BulkImport(ctx, NewBulkImportOption(svr.URL, "hello_milvus", nil).WithPartition("part"))
+	s.NoError(err)
+	s.Equal("123", resp.Data.JobID)
+	s.Len(uploaded, 1)
+	s.Contains(uploaded, w.RemoteDir()+"/1.json")
+	s.NoFileExists(filepath.Join(w.Dir(), "1.json"))
+
+	s.NoError(w.Close(ctx))
+	s.NoDirExists(w.Dir())
+
+	// upload failure, the local file is removed and the rows are uploaded by the next commit
+	failed := true
+	uploaded = make(map[string]string)
+	w, err = newRemoteBulkWriter(
+		NewRemoteBulkWriterOption(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).WithFileType(BulkFileTypeJSON),
+			"localhost:9000", "a-bucket", "bulk_data"),
+		func(ctx context.Context, localFile string, objectName string) error {
+			if failed {
+				return errors.New("mock error")
+			}
+			bs, err := os.ReadFile(localFile)
+			if err != nil {
+				return err
+			}
+			uploaded[objectName] = string(bs)
+			return nil
+		})
+	s.Require().NoError(err)
+	s.NoError(w.AppendRow(ctx, s.genRow(0)))
+	s.Error(w.Commit(ctx))
+	s.NoFileExists(filepath.Join(w.Dir(), "1.json"))
+	s.Empty(w.BatchFiles())
+	s.Equal(int64(0), w.TotalRows())
+
+	s.Error(w.Close(ctx))
+	failed = false
+	s.NoError(w.Close(ctx))
+	s.Equal([][]string{{w.RemoteDir() + "/1.json"}}, w.BatchFiles())
+	s.Equal(int64(1), w.TotalRows())
+	s.Len(uploaded, 1)
+	s.NoDirExists(w.Dir())
+
+	// upload failure when the chunk is rolled by AppendRow, the row is dropped so it could be retried
+	failed = true
+	uploaded = make(map[string]string)
+	w, err = newRemoteBulkWriter(
+		NewRemoteBulkWriterOption(NewLocalBulkWriterOption(s.schema, s.T().TempDir()).WithFileType(BulkFileTypeJSON).WithChunkSize(64),
+			"localhost:9000", "a-bucket", "bulk_data"),
+		func(ctx context.Context, localFile string, objectName string) error {
+			if failed {
+				return errors.New("mock error")
+			}
+			bs, err := os.ReadFile(localFile)
+			if err != nil {
+				return err
+			}
+			uploaded[objectName] = string(bs)
+			return nil
+		})
+	s.Require().NoError(err)
+	s.Error(w.AppendRow(ctx, s.genRow(0)))
+	failed = false
+	s.NoError(w.AppendRow(ctx, s.genRow(0)))
+	s.NoError(w.Close(ctx))
+	s.Equal([][]string{{w.RemoteDir() + "/1.json"}}, w.BatchFiles())
+	s.Equal(int64(1), w.TotalRows())
+	var rows []map[string]any
+	s.NoError(json.Unmarshal([]byte(uploaded[w.RemoteDir()+"/1.json"]), &rows))
+	s.Len(rows, 1)
+}
+
+func TestBulkWriter(t *testing.T) {
+	suite.Run(t, new(BulkWriterSuite))
+}
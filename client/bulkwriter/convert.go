@@ -0,0 +1,499 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/milvus-io/milvus/client/v2/entity"
+)
+
+// convertRow validates the row against the schema, and converts the values into the
+// canonical types handled by file writers:
+//
+//	Bool: bool, Int8/Int16/Int32/Int64/Timestamptz: int64, Float: float32, Double: float64,
+//	VarChar: string, JSON: json.RawMessage, Array: []any of element canonical type,
+//	FloatVector: []float32, BinaryVector/Float16Vector/BFloat16Vector: []byte,
+//	Int8Vector: []int8, SparseVector: entity.SparseEmbedding.
+//
+// Keys which are not fields of the schema are gathered into map[string]any keyed by "$meta"
+// if dynamic field is enabled. It returns the estimated memory size of the row as well.
+func convertRow(schema *entity.Schema, fields []*entity.Field, dynamic bool, row map[string]any) (map[string]any, int64, error) {
+	nameToField := make(map[string]*entity.Field, len(schema.Fields))
+	for _, field := range schema.Fields {
+		nameToField[field.Name] = field
+	}
+	writable := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		writable[field.Name] = struct{}{}
+	}
+
+	result := make(map[string]any, len(fields)+1)
+	var size int64
+	dynamicValues := make(map[string]any)
+	var metaValue any
+	for key, value := range row {
+		field, ok := nameToField[key]
+		if ok && !field.IsDynamic {
+			if _, ok := writable[key]; !ok {
+				if field.PrimaryKey && field.AutoID {
+					return nil, 0, fmt.Errorf("the primary key '%s' is auto-generated, no need to provide", key)
+				}
+				return nil, 0, fmt.Errorf("the field '%s' is output by function, no need to provide", key)
+			}
+			continue
+		}
+		if !dynamic {
+			return nil, 0, fmt.Errorf("field '%s' is not in schema and dynamic field is disabled", key)
+		}
+		if key == dynamicFieldName || (ok && field.IsDynamic) {
+			metaValue = value
+			continue
+		}
+		dynamicValues[key] = value
+	}
+	if err := mergeDynamicValues(dynamicValues, metaValue); err != nil {
+		return nil, 0, err
+	}
+	for key := range dynamicValues {
+		if _, ok := nameToField[key]; ok {
+			return nil, 0, fmt.Errorf("duplicated key in dynamic field, key=%s", key)
+		}
+	}
+
+	for _, field := range fields {
+		value, ok := row[field.Name]
+		if !ok || value == nil {
+			if field.Nullable || field.DefaultValue != nil {
+				result[field.Name] = nil
+				continue
+			}
+			return nil, 0, fmt.Errorf("value of field '%s' is missed", field.Name)
+		}
+		converted, err := convertValue(field, value)
+		if err != nil {
+			return nil, 0, err
+		}
+		result[field.Name] = converted
+		size += valueSize(converted)
+	}
+
+	if dynamic {
+		bs, err := json.Marshal(dynamicValues)
+		if err != nil {
+			return nil, 0, fmt.Errorf("illegal value for dynamic field: %w", err)
+		}
+		result[dynamicFieldName] = json.RawMessage(bs)
+		size += int64(len(bs))
+	}
+	return result, size, nil
+}
+
+// mergeDynamicValues expands the value of "$meta" into dst. The value could be a map,
+// or an encoded json object. The keys already in dst are not allowed.
+func mergeDynamicValues(dst map[string]any, value any) error {
+	var mp map[string]any
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		mp = v
+	case []byte:
+		if err := json.Unmarshal(v, &mp); err != nil {
+			return fmt.Errorf("illegal value for dynamic field, not a JSON object: %w", err)
+		}
+	case string:
+		if err := json.Unmarshal([]byte(v), &mp); err != nil {
+			return fmt.Errorf("illegal value for dynamic field, not a JSON object: %w", err)
+		}
+	default:
+		return fmt.Errorf("illegal value type %T for dynamic field", value)
+	}
+	for k, v := range mp {
+		if _, ok := dst[k]; ok {
+			return fmt.Errorf("duplicated key in dynamic field, key=%s", k)
+		}
+		dst[k] = v
+	}
+	return nil
+}
+
+func wrapTypeError(field *entity.Field, value any) error {
+	return fmt.Errorf("illegal value type %T for field '%s' with type %s", value, field.Name, field.DataType.Name())
+}
+
+func convertValue(field *entity.Field, value any) (any, error) {
+	switch field.DataType {
+	case entity.FieldTypeBool:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		return v, nil
+	case entity.FieldTypeInt8:
+		return convertInt(field, value, math.MinInt8, math.MaxInt8)
+	case entity.FieldTypeInt16:
+		return convertInt(field, value, math.MinInt16, math.MaxInt16)
+	case entity.FieldTypeInt32:
+		return convertInt(field, value, math.MinInt32, math.MaxInt32)
+	case entity.FieldTypeInt64:
+		return convertInt(field, value, math.MinInt64, math.MaxInt64)
+	case entity.FieldTypeTimestamptz:
+		if t, ok := value.(time.Time); ok {
+			return t.UnixMicro(), nil
+		}
+		return convertInt(field, value, math.MinInt64, math.MaxInt64)
+	case entity.FieldTypeFloat:
+		v, ok := toFloat64(value)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		if isInvalidFloat(v) || math.Abs(v) > math.MaxFloat32 {
+			return nil, fmt.Errorf("value %v is out of range for field '%s'", v, field.Name)
+		}
+		return float32(v), nil
+	case entity.FieldTypeDouble:
+		v, ok := toFloat64(value)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		if isInvalidFloat(v) {
+			return nil, fmt.Errorf("value %v is invalid for field '%s'", v, field.Name)
+		}
+		return v, nil
+	case entity.FieldTypeVarChar, entity.FieldTypeString:
+		v, ok := value.(string)
+		if !ok {
+			return nil, wrapTypeError(field, value)
+		}
+		return v, checkString(field, v)
+	case entity.FieldTypeJSON:
+		return convertJSON(field, value)
+	case entity.FieldTypeArray:
+		return convertArray(field, value)
+	case entity.FieldTypeFloatVector:
+		var vec []float32
+		switch v := value.(type) {
+		case entity.FloatVector:
+			vec = v
+		case []float32:
+			vec = v
+		case []float64:
+			vec = make([]float32, len(v))
+			for i, f := range v {
+				vec[i] = float32(f)
+			}
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+		for _, f := range vec {
+			if isInvalidFloat(float64(f)) {
+				return nil, fmt.Errorf("vector of field '%s' contains invalid value %v", field.Name, f)
+			}
+		}
+		return vec, checkDim(field, len(vec))
+	case entity.FieldTypeBinaryVector:
+		var vec []byte
+		switch v := value.(type) {
+		case entity.BinaryVector:
+			vec = v
+		case []byte:
+			vec = v
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+		return vec, checkDim(field, len(vec)*8)
+	case entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		var vec []byte
+		switch v := value.(type) {
+		case entity.Float16Vector:
+			vec = v
+		case entity.BFloat16Vector:
+			vec = v
+		case []byte:
+			vec = v
+		case entity.FloatVector:
+			vec = toHalfVector(field.DataType, v)
+		case []float32:
+			vec = toHalfVector(field.DataType, v)
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+		if len(vec)%2 != 0 {
+			return nil, fmt.Errorf("invalid byte length %d for field '%s'", len(vec), field.Name)
+		}
+		return vec, checkDim(field, len(vec)/2)
+	case entity.FieldTypeInt8Vector:
+		var vec []int8
+		switch v := value.(type) {
+		case entity.Int8Vector:
+			vec = v
+		case []int8:
+			vec = v
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+		return vec, checkDim(field, len(vec))
+	case entity.FieldTypeSparseVector:
+		switch v := value.(type) {
+		case entity.SparseEmbedding:
+			return v, nil
+		case map[uint32]float32:
+			positions := make([]uint32, 0, len(v))
+			values := make([]float32, 0, len(v))
+			for pos, val := range v {
+				positions = append(positions, pos)
+				values = append(values, val)
+			}
+			return entity.NewSliceSparseEmbedding(positions, values)
+		default:
+			return nil, wrapTypeError(field, value)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported data type %s of field '%s'", field.DataType.Name(), field.Name)
+	}
+}
+
+func toHalfVector(dataType entity.FieldType, vec entity.FloatVector) []byte {
+	if dataType == entity.FieldTypeFloat16Vector {
+		return vec.ToFloat16Vector()
+	}
+	return vec.ToBFloat16Vector()
+}
+
+func convertInt(field *entity.Field, value any, minValue, maxValue int64) (int64, error) {
+	v, ok := toInt64(value)
+	if !ok {
+		return 0, wrapTypeError(field, value)
+	}
+	if v < minValue || v > maxValue {
+		return 0, fmt.Errorf("value %d is out of range for field '%s' with type %s", v, field.Name, field.DataType.Name())
+	}
+	return v, nil
+}
+
+func toInt64(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case uint:
+		if uint64(v) > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v), true
+	case json.Number:
+		n, err := strconv.ParseInt(v.String(), 10, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func toFloat64(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	default:
+		n, ok := toInt64(value)
+		return float64(n), ok
+	}
+}
+
+func isInvalidFloat(v float64) bool {
+	return math.IsNaN(v) || math.IsInf(v, 0)
+}
+
+func checkString(field *entity.Field, v string) error {
+	if !utf8.ValidString(v) {
+		return fmt.Errorf("value of field '%s' is not a valid UTF-8 string", field.Name)
+	}
+	maxLength, err := getTypeParam(field, entity.TypeParamMaxLength)
+	if err != nil {
+		return err
+	}
+	if int64(len(v)) > maxLength {
+		return fmt.Errorf("length %d of field '%s' exceeds max length %d", len(v), field.Name, maxLength)
+	}
+	return nil
+}
+
+func getTypeParam(field *entity.Field, key string) (int64, error) {
+	str, ok := field.TypeParams[key]
+	if !ok {
+		return 0, fmt.Errorf("type param %s of field '%s' is not set", key, field.Name)
+	}
+	value, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("type param %s of field '%s' is invalid: %w", key, field.Name, err)
+	}
+	return value, nil
+}
+
+func checkDim(field *entity.Field, dim int) error {
+	expect, err := field.GetDim()
+	if err != nil {
+		return err
+	}
+	if int64(dim) != expect {
+		return fmt.Errorf("dim %d of field '%s' doesn't match the schema dim %d", dim, field.Name, expect)
+	}
+	return nil
+}
+
+func convertJSON(field *entity.Field, value any) (json.RawMessage, error) {
+	var bs []byte
+	switch v := value.(type) {
+	case []byte:
+		bs = v
+	case json.RawMessage:
+		bs = v
+	case string:
+		bs = []byte(v)
+	default:
+		var err error
+		if bs, err = json.Marshal(v); err != nil {
+			return nil, fmt.Errorf("illegal value for JSON field '%s': %w", field.Name, err)
+		}
+	}
+	if !json.Valid(bs) {
+		return nil, fmt.Errorf("illegal value for JSON field '%s', not a valid JSON", field.Name)
+	}
+	return bs, nil
+}
+
+func convertArray(field *entity.Field, value any) ([]any, error) {
+	var elements []any
+	switch v := value.(type) {
+	case []any:
+		elements = v
+	case []bool:
+		elements = toAnySlice(v)
+	case []int8:
+		elements = toAnySlice(v)
+	case []int16:
+		elements = toAnySlice(v)
+	case []int32:
+		elements = toAnySlice(v)
+	case []int64:
+		elements = toAnySlice(v)
+	case []int:
+		elements = toAnySlice(v)
+	case []float32:
+		elements = toAnySlice(v)
+	case []float64:
+		elements = toAnySlice(v)
+	case []string:
+		elements = toAnySlice(v)
+	default:
+		return nil, wrapTypeError(field, value)
+	}
+
+	maxCapacity, err := getTypeParam(field, entity.TypeParamMaxCapacity)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(elements)) > maxCapacity {
+		return nil, fmt.Errorf("array capacity %d of field '%s' exceeds max capacity %d", len(elements), field.Name, maxCapacity)
+	}
+
+	elementField := &entity.Field{
+		Name:       field.Name,
+		DataType:   field.ElementType,
+		TypeParams: field.TypeParams,
+	}
+	result := make([]any, len(elements))
+	for i, element := range elements {
+		if element == nil {
+			return nil, fmt.Errorf("array field '%s' doesn't support null element", field.Name)
+		}
+		converted, err := convertValue(elementField, element)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = converted
+	}
+	return result, nil
+}
+
+func toAnySlice[T any](values []T) []any {
+	result := make([]any, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+// valueSize estimates the memory size of a canonical value.
+func valueSize(value any) int64 {
+	switch v := value.(type) {
+	case bool, int8:
+		return 1
+	case int64, float64:
+		return 8
+	case float32:
+		return 4
+	case string:
+		return int64(len(v))
+	case json.RawMessage:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case []int8:
+		return int64(len(v))
+	case []float32:
+		return int64(len(v)) * 4
+	case entity.SparseEmbedding:
+		return int64(v.Len()) * 8
+	case []any:
+		var size int64
+		for _, element := range v {
+			size += valueSize(element)
+		}
+		return size
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,176 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/milvus-io/milvus/client/v2/entity"
+)
+
+// toJSONValue converts the canonical value into the form accepted by the json import reader.
+func toJSONValue(field *entity.Field, value any) any {
+	if value == nil {
+		return nil
+	}
+	switch field.DataType {
+	case entity.FieldTypeBinaryVector:
+		vec := value.([]byte)
+		result := make([]int, len(vec))
+		for i, b := range vec {
+			result[i] = int(b)
+		}
+		return result
+	case entity.FieldTypeFloat16Vector:
+		return []float32(entity.Float16Vector(value.([]byte)).ToFloat32Vector())
+	case entity.FieldTypeBFloat16Vector:
+		return []float32(entity.BFloat16Vector(value.([]byte)).ToFloat32Vector())
+	case entity.FieldTypeSparseVector:
+		return sparseToMap(value.(entity.SparseEmbedding))
+	default:
+		return value
+	}
+}
+
+func sparseToMap(sparse entity.SparseEmbedding) map[string]any {
+	indices := make([]uint32, 0, sparse.Len())
+	values := make([]float32, 0, sparse.Len())
+	for i := 0; i < sparse.Len(); i++ {
+		pos, value, _ := sparse.Get(i)
+		indices = append(indices, pos)
+		values = append(values, value)
+	}
+	return map[string]any{
+		"indices": indices,
+		"values":  values,
+	}
+}
+
+// writeJSONFile writes rows into a json file in the form of `[{row}, {row}, ...]`.
+func writeJSONFile(path string, fields []*entity.Field, dynamic bool, rows []map[string]any) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	if _, err := w.WriteString("[\n"); err != nil {
+		return err
+	}
+	for i, row := range rows {
+		obj := make(map[string]any, len(fields)+1)
+		for _, field := range fields {
+			obj[field.Name] = toJSONValue(field, row[field.Name])
+		}
+		if dynamic {
+			obj[dynamicFieldName] = row[dynamicFieldName]
+		}
+		bs, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		if i > 0 {
+			if _, err := w.WriteString(",\n"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(bs); err != nil {
+			return err
+		}
+	}
+	if _, err := w.WriteString("\n]\n"); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// toCSVValue formats the canonical value into a csv cell, null value is represented by nullKey,
+// vector, array and json values are encoded as json strings.
+func toCSVValue(field *entity.Field, value any, nullKey string) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nullKey, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return v, nil
+	case json.RawMessage:
+		return string(v), nil
+	default:
+		bs, err := json.Marshal(toJSONValue(field, value))
+		if err != nil {
+			return "", err
+		}
+		return string(bs), nil
+	}
+}
+
+// writeCSVFile writes rows into a csv file, the first line is the header of field names.
+func writeCSVFile(path string, fields []*entity.Field, dynamic bool, rows []map[string]any, sep rune, nullKey string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Comma = sep
+	header := make([]string, 0, len(fields)+1)
+	for _, field := range fields {
+		header = append(header, field.Name)
+	}
+	if dynamic {
+		header = append(header, dynamicFieldName)
+	}
+	if err := w.Write(header); err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for _, row := range rows {
+		for i, field := range fields {
+			if record[i], err = toCSVValue(field, row[field.Name], nullKey); err != nil {
+				return err
+			}
+		}
+		if dynamic {
+			record[len(fields)] = string(row[dynamicFieldName].(json.RawMessage))
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	return f.Sync()
+}
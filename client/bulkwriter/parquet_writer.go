@@ -0,0 +1,198 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/apache/arrow/go/v17/parquet"
+	"github.com/apache/arrow/go/v17/parquet/pqarrow"
+
+	"github.com/milvus-io/milvus/client/v2/entity"
+)
+
+// toArrowType returns the arrow type of field data type, which matches the parquet import reader.
+func toArrowType(dataType entity.FieldType, elementType entity.FieldType) (arrow.DataType, error) {
+	switch dataType {
+	case entity.FieldTypeBool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case entity.FieldTypeInt8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case entity.FieldTypeInt16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case entity.FieldTypeInt32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case entity.FieldTypeInt64, entity.FieldTypeTimestamptz:
+		return arrow.PrimitiveTypes.Int64, nil
+	case entity.FieldTypeFloat:
+		return arrow.PrimitiveTypes.Float32, nil
+	case entity.FieldTypeDouble:
+		return arrow.PrimitiveTypes.Float64, nil
+	case entity.FieldTypeVarChar, entity.FieldTypeString, entity.FieldTypeJSON, entity.FieldTypeSparseVector:
+		return arrow.BinaryTypes.String, nil
+	case entity.FieldTypeArray:
+		elemType, err := toArrowType(elementType, entity.FieldTypeNone)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elemType), nil
+	case entity.FieldTypeFloatVector:
+		return arrow.ListOf(arrow.PrimitiveTypes.Float32), nil
+	case entity.FieldTypeBinaryVector, entity.FieldTypeFloat16Vector, entity.FieldTypeBFloat16Vector:
+		return arrow.ListOf(arrow.PrimitiveTypes.Uint8), nil
+	case entity.FieldTypeInt8Vector:
+		return arrow.ListOf(arrow.PrimitiveTypes.Int8), nil
+	default:
+		return nil, fmt.Errorf("unsupported data type %s", dataType.Name())
+	}
+}
+
+func buildArrowSchema(fields []*entity.Field, dynamic bool) (*arrow.Schema, error) {
+	arrowFields := make([]arrow.Field, 0, len(fields)+1)
+	for _, field := range fields {
+		dataType, err := toArrowType(field.DataType, field.ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+		arrowFields = append(arrowFields, arrow.Field{
+			Name:     field.Name,
+			Type:     dataType,
+			Nullable: field.Nullable || field.DefaultValue != nil,
+		})
+	}
+	if dynamic {
+		arrowFields = append(arrowFields, arrow.Field{
+			Name: dynamicFieldName,
+			Type: arrow.BinaryTypes.String,
+		})
+	}
+	return arrow.NewSchema(arrowFields, nil), nil
+}
+
+// appendArrowValue appends the canonical value into the arrow builder.
+func appendArrowValue(builder array.Builder, value any) error {
+	if value == nil {
+		builder.AppendNull()
+		return nil
+	}
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		b.Append(value.(bool))
+	case *array.Int8Builder:
+		switch v := value.(type) {
+		case int64:
+			b.Append(int8(v))
+		case []int8:
+			b.AppendValues(v, nil)
+		}
+	case *array.Int16Builder:
+		b.Append(int16(value.(int64)))
+	case *array.Int32Builder:
+		b.Append(int32(value.(int64)))
+	case *array.Int64Builder:
+		b.Append(value.(int64))
+	case *array.Float32Builder:
+		switch v := value.(type) {
+		case float32:
+			b.Append(v)
+		case []float32:
+			b.AppendValues(v, nil)
+		}
+	case *array.Float64Builder:
+		b.Append(value.(float64))
+	case *array.Uint8Builder:
+		b.AppendValues(value.([]byte), nil)
+	case *array.StringBuilder:
+		switch v := value.(type) {
+		case string:
+			b.Append(v)
+		case json.RawMessage:
+			b.Append(string(v))
+		case entity.SparseEmbedding:
+			bs, err := json.Marshal(sparseToMap(v))
+			if err != nil {
+				return err
+			}
+			b.Append(string(bs))
+		default:
+			return fmt.Errorf("unexpected value type %T for string column", value)
+		}
+	case *array.ListBuilder:
+		b.Append(true)
+		valueBuilder := b.ValueBuilder()
+		if elements, ok := value.([]any); ok {
+			for _, element := range elements {
+				if err := appendArrowValue(valueBuilder, element); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		// vectors are appended into the value builder in batch
+		return appendArrowValue(valueBuilder, value)
+	default:
+		return fmt.Errorf("unexpected arrow builder %T", builder)
+	}
+	return nil
+}
+
+// writeParquetFile writes rows into a parquet file with one record batch.
+func writeParquetFile(path string, fields []*entity.Field, dynamic bool, rows []map[string]any) error {
+	schema, err := buildArrowSchema(fields, dynamic)
+	if err != nil {
+		return err
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	for _, row := range rows {
+		for i, field := range fields {
+			if err := appendArrowValue(builder.Field(i), row[field.Name]); err != nil {
+				return fmt.Errorf("field '%s': %w", field.Name, err)
+			}
+		}
+		if dynamic {
+			if err := appendArrowValue(builder.Field(len(fields)), row[dynamicFieldName]); err != nil {
+				return err
+			}
+		}
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	fw, err := pqarrow.NewFileWriter(schema, f,
+		parquet.NewWriterProperties(parquet.WithMaxRowGroupLength(int64(len(rows)))),
+		pqarrow.DefaultWriterProps())
+	if err != nil {
+		return err
+	}
+	if err := fw.Write(record); err != nil {
+		fw.Close()
+		return err
+	}
+	return fw.Close()
+}
@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulkwriter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// RemoteBulkWriterOption is the option to create a RemoteBulkWriter. The files are generated
+// under the local path first, and uploaded into the bucket of S3-compatible storage.
+type RemoteBulkWriterOption struct {
+	*LocalBulkWriterOption
+
+	Endpoint   string
+	AccessKey  string
+	SecretKey  string
+	BucketName string
+	// RemotePath is the object path prefix in the bucket
+	RemotePath string
+	UseSSL     bool
+	Region     string
+}
+
+// NewRemoteBulkWriterOption returns the option to upload files into the bucket of remote storage.
+func NewRemoteBulkWriterOption(localOpt *LocalBulkWriterOption, endpoint string, bucketName string, remotePath string) *RemoteBulkWriterOption {
+	return &RemoteBulkWriterOption{
+		LocalBulkWriterOption: localOpt,
+		Endpoint:              endpoint,
+		BucketName:            bucketName,
+		RemotePath:            remotePath,
+	}
+}
+
+func (opt *RemoteBulkWriterOption) WithCredential(accessKey, secretKey string) *RemoteBulkWriterOption {
+	opt.AccessKey = accessKey
+	opt.SecretKey = secretKey
+	return opt
+}
+
+func (opt *RemoteBulkWriterOption) WithSSL(useSSL bool) *RemoteBulkWriterOption {
+	opt.UseSSL = useSSL
+	return opt
+}
+
+func (opt *RemoteBulkWriterOption) WithRegion(region string) *RemoteBulkWriterOption {
+	opt.Region = region
+	return opt
+}
+
+// uploadFunc uploads the local file as the object in bucket.
+type uploadFunc func(ctx context.Context, localFile string, objectName string) error
+
+// RemoteBulkWriter works as LocalBulkWriter, except that each generated file is uploaded
+// into the remote storage and removed from local disk. BatchFiles returns the object paths
+// which could be used by the bulk import API directly.
+type RemoteBulkWriter struct {
+	*LocalBulkWriter
+
+	remoteDir string
+	upload    uploadFunc
+}
+
+// NewRemoteBulkWriter creates a RemoteBulkWriter with minio client, the bucket must exist.
+func NewRemoteBulkWriter(ctx context.Context, opt *RemoteBulkWriterOption) (*RemoteBulkWriter, error) {
+	if opt.LocalBulkWriterOption == nil {
+		return nil, fmt.Errorf("remote bulk writer requires local writer option")
+	}
+	cli, err := minio.New(opt.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opt.AccessKey, opt.SecretKey, ""),
+		Secure: opt.UseSSL,
+		Region: opt.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	exist, err := cli.BucketExists(ctx, opt.BucketName)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, fmt.Errorf("bucket %s doesn't exist", opt.BucketName)
+	}
+	return newRemoteBulkWriter(opt, func(ctx context.Context, localFile string, objectName string) error {
+		_, err := cli.FPutObject(ctx, opt.BucketName, objectName, localFile, minio.PutObjectOptions{})
+		return err
+	})
+}
+
+func newRemoteBulkWriter(opt *RemoteBulkWriterOption, upload uploadFunc) (*RemoteBulkWriter, error) {
+	local, err := NewLocalBulkWriter(opt.LocalBulkWriterOption)
+	if err != nil {
+		return nil, err
+	}
+	w := &RemoteBulkWriter{
+		LocalBulkWriter: local,
+		remoteDir:       path.Join(opt.RemotePath, filepath.Base(local.Dir())),
+		upload:          upload,
+	}
+	local.onFlush = w.uploadFile
+	return w, nil
+}
+
+func (w *RemoteBulkWriter) uploadFile(ctx context.Context, file string) (string, error) {
+	objectName := path.Join(w.remoteDir, filepath.Base(file))
+	if err := w.upload(ctx, file, objectName); err != nil {
+		return "", fmt.Errorf("failed to upload %s to %s: %w", file, objectName, err)
+	}
+	if err := os.Remove(file); err != nil {
+		return "", err
+	}
+	return objectName, nil
+}
+
+// RemoteDir returns the object path prefix of the uploaded files.
+func (w *RemoteBulkWriter) RemoteDir() string {
+	return w.remoteDir
+}
+
+// Close commits the buffered rows and removes the local directory.
+func (w *RemoteBulkWriter) Close(ctx context.Context) error {
+	if err := w.LocalBulkWriter.Close(ctx); err != nil {
+		return err
+	}
+	return os.RemoveAll(w.Dir())
+}
+
+// BulkImport commits the buffered rows, and calls the bulk import API with all the uploaded files,
+// the files of option are overwritten.
+func (w *RemoteBulkWriter) BulkImport(ctx context.Context, option *BulkImportOption) (*BulkImportResponse, error) {
+	if err := w.Commit(ctx); err != nil {
+		return nil, err
+	}
+	files := w.BatchFiles()
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no file to import")
+	}
+	option.Files = files
+	return BulkImport(ctx, option)
+}
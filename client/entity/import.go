@@ -0,0 +1,86 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package entity
+
+// ImportJobState is the state of import job, the values are the same as the import job states of server.
+type ImportJobState int32
+
+const (
+	ImportJobStatePending       ImportJobState = 1
+	ImportJobStatePreImporting  ImportJobState = 2
+	ImportJobStateImporting     ImportJobState = 3
+	ImportJobStateFailed        ImportJobState = 4
+	ImportJobStateCompleted     ImportJobState = 5
+	ImportJobStateIndexBuilding ImportJobState = 6
+	ImportJobStateSorting       ImportJobState = 7
+)
+
+func (s ImportJobState) String() string {
+	switch s {
+	case ImportJobStatePending:
+		return "Pending"
+	case ImportJobStatePreImporting:
+		return "PreImporting"
+	case ImportJobStateImporting:
+		return "Importing"
+	case ImportJobStateFailed:
+		return "Failed"
+	case ImportJobStateCompleted:
+		return "Completed"
+	case ImportJobStateIndexBuilding:
+		return "IndexBuilding"
+	case ImportJobStateSorting:
+		return "Sorting"
+	default:
+		return "None"
+	}
+}
+
+// ImportJob is the brief info of import job returned by ListImports.
+type ImportJob struct {
+	JobID          string
+	CollectionName string
+	State          ImportJobState
+	Reason         string
+	Progress       int64
+}
+
+// ImportJobProgress is the detail progress of import job.
+type ImportJobProgress struct {
+	JobID          string
+	CollectionName string
+	State          ImportJobState
+	Reason         string
+	Progress       int64
+	ImportedRows   int64
+	TotalRows      int64
+	StartTime      string
+	CompleteTime   string
+	Tasks          []ImportTaskProgress
+}
+
+// ImportTaskProgress is the progress of import task, each task handles a batch of files.
+type ImportTaskProgress struct {
+	FileName     string
+	FileSize     int64
+	State        string
+	Reason       string
+	Progress     int64
+	ImportedRows int64
+	TotalRows    int64
+	CompleteTime string
+}
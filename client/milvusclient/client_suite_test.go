@@ -25,9 +25,10 @@ const (
 type MockSuiteBase struct {
 	suite.Suite
 
-	lis  *bufconn.Listener
-	svr  *grpc.Server
-	mock *MilvusServiceServer
+	lis        *bufconn.Listener
+	svr        *grpc.Server
+	mock       *MilvusServiceServer
+	importMock *mockImportServer
 
 	client *Client
 }
@@ -39,6 +40,8 @@ func (s *MockSuiteBase) SetupSuite() {
 	s.mock = &MilvusServiceServer{}
 
 	milvuspb.RegisterMilvusServiceServer(s.svr, s.mock)
+	s.importMock = &mockImportServer{}
+	s.svr.RegisterService(&importServiceDesc, s.importMock)
 
 	go func() {
 		s.T().Log("start mock server")
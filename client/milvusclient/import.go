@@ -0,0 +1,183 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/client/v2/entity"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+// full method names of the ImportService served by proxy, see ImportService in proxy.proto.
+const (
+	importServiceImportV2          = "/milvus.proto.proxy.ImportService/ImportV2"
+	importServiceGetImportProgress = "/milvus.proto.proxy.ImportService/GetImportProgress"
+	importServiceListImports       = "/milvus.proto.proxy.ImportService/ListImports"
+)
+
+// importServiceClient calls the ImportService on the connection of client, the service is not
+// a part of MilvusService, so there is no generated client in the published proto package.
+type importServiceClient struct {
+	conn grpc.ClientConnInterface
+}
+
+func (c importServiceClient) ImportV2(ctx context.Context, req *internalpb.ImportRequest, opts ...grpc.CallOption) (*internalpb.ImportResponse, error) {
+	resp := &internalpb.ImportResponse{}
+	if err := c.conn.Invoke(ctx, importServiceImportV2, req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c importServiceClient) GetImportProgress(ctx context.Context, req *internalpb.GetImportProgressRequest, opts ...grpc.CallOption) (*internalpb.GetImportProgressResponse, error) {
+	resp := &internalpb.GetImportProgressResponse{}
+	if err := c.conn.Invoke(ctx, importServiceGetImportProgress, req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c importServiceClient) ListImports(ctx context.Context, req *internalpb.ListImportsRequest, opts ...grpc.CallOption) (*internalpb.ListImportsResponse, error) {
+	resp := &internalpb.ListImportsResponse{}
+	if err := c.conn.Invoke(ctx, importServiceListImports, req, resp, opts...); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *Client) callImportService(fn func(importService importServiceClient) error) error {
+	if c.conn == nil {
+		return merr.WrapErrServiceNotReady("SDK", 0, "not connected")
+	}
+	return fn(importServiceClient{conn: c.conn})
+}
+
+// Import creates an import job to import the files into collection, returns the job id.
+func (c *Client) Import(ctx context.Context, option ImportOption, callOptions ...grpc.CallOption) (string, error) {
+	req := option.Request()
+
+	var jobID string
+	err := c.callImportService(func(importService importServiceClient) error {
+		resp, err := importService.ImportV2(ctx, req, callOptions...)
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			return err
+		}
+		jobID = resp.GetJobID()
+		return nil
+	})
+	return jobID, err
+}
+
+// GetImportProgress returns the progress of the import job.
+func (c *Client) GetImportProgress(ctx context.Context, option GetImportProgressOption, callOptions ...grpc.CallOption) (*entity.ImportJobProgress, error) {
+	req := option.Request()
+
+	var progress *entity.ImportJobProgress
+	err := c.callImportService(func(importService importServiceClient) error {
+		resp, err := importService.GetImportProgress(ctx, req, callOptions...)
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			return err
+		}
+		progress = &entity.ImportJobProgress{
+			JobID:          req.GetJobID(),
+			CollectionName: resp.GetCollectionName(),
+			State:          entity.ImportJobState(resp.GetState()),
+			Reason:         resp.GetReason(),
+			Progress:       resp.GetProgress(),
+			ImportedRows:   resp.GetImportedRows(),
+			TotalRows:      resp.GetTotalRows(),
+			StartTime:      resp.GetStartTime(),
+			CompleteTime:   resp.GetCompleteTime(),
+		}
+		for _, task := range resp.GetTaskProgresses() {
+			progress.Tasks = append(progress.Tasks, entity.ImportTaskProgress{
+				FileName:     task.GetFileName(),
+				FileSize:     task.GetFileSize(),
+				State:        task.GetState(),
+				Reason:       task.GetReason(),
+				Progress:     task.GetProgress(),
+				ImportedRows: task.GetImportedRows(),
+				TotalRows:    task.GetTotalRows(),
+				CompleteTime: task.GetCompleteTime(),
+			})
+		}
+		return nil
+	})
+	return progress, err
+}
+
+// ListImports lists the import jobs of current database, or the collection if specified.
+func (c *Client) ListImports(ctx context.Context, option ListImportsOption, callOptions ...grpc.CallOption) ([]*entity.ImportJob, error) {
+	req := option.Request()
+
+	var jobs []*entity.ImportJob
+	err := c.callImportService(func(importService importServiceClient) error {
+		resp, err := importService.ListImports(ctx, req, callOptions...)
+		if err = merr.CheckRPCCall(resp, err); err != nil {
+			return err
+		}
+		jobs = make([]*entity.ImportJob, 0, len(resp.GetJobIDs()))
+		for i, jobID := range resp.GetJobIDs() {
+			job := &entity.ImportJob{JobID: jobID}
+			if i < len(resp.GetStates()) {
+				job.State = entity.ImportJobState(resp.GetStates()[i])
+			}
+			if i < len(resp.GetReasons()) {
+				job.Reason = resp.GetReasons()[i]
+			}
+			if i < len(resp.GetProgresses()) {
+				job.Progress = resp.GetProgresses()[i]
+			}
+			if i < len(resp.GetCollectionNames()) {
+				job.CollectionName = resp.GetCollectionNames()[i]
+			}
+			jobs = append(jobs, job)
+		}
+		return nil
+	})
+	return jobs, err
+}
+
+// WaitImport blocks until the import job completes or fails, or the context is done.
+// The last fetched progress is returned, with an error if the job failed.
+func (c *Client) WaitImport(ctx context.Context, option WaitImportOption, callOptions ...grpc.CallOption) (*entity.ImportJobProgress, error) {
+	ticker := time.NewTicker(option.CheckInterval())
+	defer ticker.Stop()
+	for {
+		progress, err := c.GetImportProgress(ctx, option, callOptions...)
+		if err != nil {
+			return nil, err
+		}
+		switch progress.State {
+		case entity.ImportJobStateCompleted:
+			return progress, nil
+		case entity.ImportJobStateFailed:
+			return progress, merr.WrapErrImportFailed(progress.Reason)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return progress, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/milvus-io/milvus/client/v2/entity"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+)
+
+// import option keys, shall be the same as the keys parsed by server in importutilv2.
+const (
+	importOptionTimeout        = "timeout"
+	importOptionSkipDQC        = "skip_disk_quota_check"
+	importOptionCSVSep         = "sep"
+	importOptionCSVNullKey     = "nullkey"
+	importOptionBackup         = "backup"
+	importOptionL0Import       = "l0_import"
+	importOptionStorageVersion = "storage_version"
+	importOptionStartTs        = "start_ts"
+	importOptionEndTs          = "end_ts"
+)
+
+type ImportOption interface {
+	Request() *internalpb.ImportRequest
+}
+
+var _ ImportOption = (*importOption)(nil)
+
+type importOption struct {
+	collectionName string
+	partitionName  string
+	files          [][]string
+	options        map[string]string
+}
+
+func (opt *importOption) Request() *internalpb.ImportRequest {
+	files := make([]*internalpb.ImportFile, 0, len(opt.files))
+	for _, paths := range opt.files {
+		files = append(files, &internalpb.ImportFile{Paths: paths})
+	}
+	return &internalpb.ImportRequest{
+		CollectionName: opt.collectionName,
+		PartitionName:  opt.partitionName,
+		Files:          files,
+		Options:        entity.MapKvPairs(opt.options),
+	}
+}
+
+// WithFiles appends a batch of files, which are imported by the same task.
+// For row-based files(json, parquet, csv...), each batch shall contain only one file.
+// For numpy files, each batch contains all the files of one segment, one file per field.
+func (opt *importOption) WithFiles(paths ...string) *importOption {
+	opt.files = append(opt.files, paths)
+	return opt
+}
+
+func (opt *importOption) WithPartition(partitionName string) *importOption {
+	opt.partitionName = partitionName
+	return opt
+}
+
+// WithTimeout sets the timeout of import job, the job fails if not completed in time.
+func (opt *importOption) WithTimeout(timeout time.Duration) *importOption {
+	return opt.WithOption(importOptionTimeout, timeout.String())
+}
+
+func (opt *importOption) WithSkipDiskQuotaCheck(skip bool) *importOption {
+	return opt.WithOption(importOptionSkipDQC, strconv.FormatBool(skip))
+}
+
+func (opt *importOption) WithCSVSeparator(sep rune) *importOption {
+	return opt.WithOption(importOptionCSVSep, string(sep))
+}
+
+func (opt *importOption) WithCSVNullKey(nullKey string) *importOption {
+	return opt.WithOption(importOptionCSVNullKey, nullKey)
+}
+
+// WithBackup sets whether the files are the binlogs from milvus-backup.
+func (opt *importOption) WithBackup(backup bool) *importOption {
+	return opt.WithOption(importOptionBackup, strconv.FormatBool(backup))
+}
+
+// WithL0Import sets whether to import l0 segments only, works with backup mode.
+func (opt *importOption) WithL0Import(l0Import bool) *importOption {
+	return opt.WithOption(importOptionL0Import, strconv.FormatBool(l0Import))
+}
+
+// WithTimeRange filters the data by timestamp in backup mode, zero means not limited.
+func (opt *importOption) WithTimeRange(startTs, endTs uint64) *importOption {
+	if startTs > 0 {
+		opt.WithOption(importOptionStartTs, strconv.FormatUint(startTs, 10))
+	}
+	if endTs > 0 {
+		opt.WithOption(importOptionEndTs, strconv.FormatUint(endTs, 10))
+	}
+	return opt
+}
+
+func (opt *importOption) WithStorageVersion(version int64) *importOption {
+	return opt.WithOption(importOptionStorageVersion, strconv.FormatInt(version, 10))
+}
+
+// WithOption sets the raw import option, for the options not provided by typed methods.
+func (opt *importOption) WithOption(key, value string) *importOption {
+	if opt.options == nil {
+		opt.options = make(map[string]string)
+	}
+	opt.options[key] = value
+	return opt
+}
+
+// NewImportOption returns the option to import files into collection, files could be
+// added by WithFiles.
+func NewImportOption(collectionName string) *importOption {
+	return &importOption{
+		collectionName: collectionName,
+	}
+}
+
+type GetImportProgressOption interface {
+	Request() *internalpb.GetImportProgressRequest
+}
+
+type getImportProgressOption struct {
+	jobID string
+}
+
+func (opt *getImportProgressOption) Request() *internalpb.GetImportProgressRequest {
+	return &internalpb.GetImportProgressRequest{
+		JobID: opt.jobID,
+	}
+}
+
+func NewGetImportProgressOption(jobID string) *getImportProgressOption {
+	return &getImportProgressOption{
+		jobID: jobID,
+	}
+}
+
+type ListImportsOption interface {
+	Request() *internalpb.ListImportsRequest
+}
+
+type listImportsOption struct {
+	collectionName string
+}
+
+func (opt *listImportsOption) Request() *internalpb.ListImportsRequest {
+	return &internalpb.ListImportsRequest{
+		CollectionName: opt.collectionName,
+	}
+}
+
+// WithCollectionName lists the import jobs of the collection only.
+func (opt *listImportsOption) WithCollectionName(collectionName string) *listImportsOption {
+	opt.collectionName = collectionName
+	return opt
+}
+
+func NewListImportsOption() *listImportsOption {
+	return &listImportsOption{}
+}
+
+type WaitImportOption interface {
+	GetImportProgressOption
+	CheckInterval() time.Duration
+}
+
+type waitImportOption struct {
+	*getImportProgressOption
+	interval time.Duration
+}
+
+func (opt *waitImportOption) CheckInterval() time.Duration {
+	return opt.interval
+}
+
+func (opt *waitImportOption) WithInterval(interval time.Duration) *waitImportOption {
+	opt.interval = interval
+	return opt
+}
+
+func NewWaitImportOption(jobID string) *waitImportOption {
+	return &waitImportOption{
+		getImportProgressOption: NewGetImportProgressOption(jobID),
+		interval:                time.Second,
+	}
+}
@@ -0,0 +1,226 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milvusclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus/client/v2/entity"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+// mockImportServer is the fake import service, each test case sets the handlers it needs.
+type mockImportServer struct {
+	importV2          func(context.Context, *internalpb.ImportRequest) (*internalpb.ImportResponse, error)
+	getImportProgress func(context.Context, *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error)
+	listImports       func(context.Context, *internalpb.ListImportsRequest) (*internalpb.ListImportsResponse, error)
+}
+
+func (m *mockImportServer) ImportV2(ctx context.Context, req *internalpb.ImportRequest) (*internalpb.ImportResponse, error) {
+	return m.importV2(ctx, req)
+}
+
+func (m *mockImportServer) GetImportProgress(ctx context.Context, req *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error) {
+	return m.getImportProgress(ctx, req)
+}
+
+func (m *mockImportServer) ListImports(ctx context.Context, req *internalpb.ListImportsRequest) (*internalpb.ListImportsResponse, error) {
+	return m.listImports(ctx, req)
+}
+
+func importServiceHandler[Req any, Resp any](call func(*mockImportServer, context.Context, *Req) (*Resp, error)) func(any, context.Context, func(any) error, grpc.UnaryServerInterceptor) (any, error) {
+	return func(srv any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+		req := new(Req)
+		if err := dec(req); err != nil {
+			return nil, err
+		}
+		return call(srv.(*mockImportServer), ctx, req)
+	}
+}
+
+// importServiceDesc is the service descriptor of the ImportService in proxy.proto.
+var importServiceDesc = grpc.ServiceDesc{
+	ServiceName: "milvus.proto.proxy.ImportService",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "ImportV2", Handler: importServiceHandler((*mockImportServer).ImportV2)},
+		{MethodName: "GetImportProgress", Handler: importServiceHandler((*mockImportServer).GetImportProgress)},
+		{MethodName: "ListImports", Handler: importServiceHandler((*mockImportServer).ListImports)},
+	},
+}
+
+type ImportSuite struct {
+	MockSuiteBase
+}
+
+func (s *ImportSuite) TestImport() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Run("success", func() {
+		s.importMock.importV2 = func(ctx context.Context, req *internalpb.ImportRequest) (*internalpb.ImportResponse, error) {
+			s.Equal("coll", req.GetCollectionName())
+			s.Equal("part", req.GetPartitionName())
+			s.Len(req.GetFiles(), 2)
+			s.Equal([]string{"a.parquet"}, req.GetFiles()[0].GetPaths())
+			s.Equal([]string{"b/id.npy", "b/vector.npy"}, req.GetFiles()[1].GetPaths())
+			options := make(map[string]string)
+			for _, kv := range req.GetOptions() {
+				options[kv.GetKey()] = kv.GetValue()
+			}
+			s.Equal(map[string]string{
+				"timeout":   "1h30m0s",
+				"backup":    "true",
+				"l0_import": "false",
+				"start_ts":  "100",
+				"end_ts":    "200",
+				"sep":       "|",
+				"custom":    "value",
+			}, options)
+			return &internalpb.ImportResponse{Status: merr.Success(), JobID: "1001"}, nil
+		}
+
+		jobID, err := s.client.Import(ctx, NewImportOption("coll").
+			WithPartition("part").
+			WithFiles("a.parquet").
+			WithFiles("b/id.npy", "b/vector.npy").
+			WithTimeout(90*time.Minute).
+			WithBackup(true).
+			WithL0Import(false).
+			WithTimeRange(100, 200).
+			WithCSVSeparator('|').
+			WithOption("custom", "value"))
+		s.NoError(err)
+		s.Equal("1001", jobID)
+	})
+
+	s.Run("failure", func() {
+		s.importMock.importV2 = func(ctx context.Context, req *internalpb.ImportRequest) (*internalpb.ImportResponse, error) {
+			return &internalpb.ImportResponse{Status: merr.Status(merr.WrapErrImportFailed("mock"))}, nil
+		}
+		_, err := s.client.Import(ctx, NewImportOption("coll").WithFiles("a.json"))
+		s.Error(err)
+	})
+}
+
+func (s *ImportSuite) TestGetImportProgress() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.importMock.getImportProgress = func(ctx context.Context, req *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error) {
+		s.Equal("1001", req.GetJobID())
+		return &internalpb.GetImportProgressResponse{
+			Status:         merr.Success(),
+			State:          internalpb.ImportJobState_Importing,
+			Progress:       50,
+			CollectionName: "coll",
+			ImportedRows:   100,
+			TotalRows:      200,
+			StartTime:      "2025-01-01T00:00:00Z",
+			TaskProgresses: []*internalpb.ImportTaskProgress{
+				{FileName: "a.parquet", FileSize: 1024, State: "InProgress", Progress: 50, ImportedRows: 100, TotalRows: 200},
+			},
+		}, nil
+	}
+
+	progress, err := s.client.GetImportProgress(ctx, NewGetImportProgressOption("1001"))
+	s.NoError(err)
+	s.Equal("1001", progress.JobID)
+	s.Equal("coll", progress.CollectionName)
+	s.Equal(entity.ImportJobStateImporting, progress.State)
+	s.EqualValues(50, progress.Progress)
+	s.EqualValues(200, progress.TotalRows)
+	s.Equal("2025-01-01T00:00:00Z", progress.StartTime)
+	s.Len(progress.Tasks, 1)
+	s.Equal("a.parquet", progress.Tasks[0].FileName)
+	s.EqualValues(100, progress.Tasks[0].ImportedRows)
+}
+
+func (s *ImportSuite) TestListImports() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.importMock.listImports = func(ctx context.Context, req *internalpb.ListImportsRequest) (*internalpb.ListImportsResponse, error) {
+		s.Equal("coll", req.GetCollectionName())
+		return &internalpb.ListImportsResponse{
+			Status:          merr.Success(),
+			JobIDs:          []string{"1", "2"},
+			States:          []internalpb.ImportJobState{internalpb.ImportJobState_Completed, internalpb.ImportJobState_Failed},
+			Reasons:         []string{"", "mock reason"},
+			Progresses:      []int64{100, 0},
+			CollectionNames: []string{"coll", "coll"},
+		}, nil
+	}
+
+	jobs, err := s.client.ListImports(ctx, NewListImportsOption().WithCollectionName("coll"))
+	s.NoError(err)
+	s.Len(jobs, 2)
+	s.Equal("1", jobs[0].JobID)
+	s.Equal(entity.ImportJobStateCompleted, jobs[0].State)
+	s.Equal(entity.ImportJobStateFailed, jobs[1].State)
+	s.Equal("mock reason", jobs[1].Reason)
+	s.Equal("coll", jobs[1].CollectionName)
+}
+
+func (s *ImportSuite) TestWaitImport() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Run("completed", func() {
+		calls := atomic.NewInt32(0)
+		s.importMock.getImportProgress = func(ctx context.Context, req *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error) {
+			if calls.Inc() < 3 {
+				return &internalpb.GetImportProgressResponse{Status: merr.Success(), State: internalpb.ImportJobState_Importing}, nil
+			}
+			return &internalpb.GetImportProgressResponse{Status: merr.Success(), State: internalpb.ImportJobState_Completed, Progress: 100}, nil
+		}
+		progress, err := s.client.WaitImport(ctx, NewWaitImportOption("1001").WithInterval(10*time.Millisecond))
+		s.NoError(err)
+		s.EqualValues(100, progress.Progress)
+		s.EqualValues(3, calls.Load())
+	})
+
+	s.Run("failed", func() {
+		s.importMock.getImportProgress = func(ctx context.Context, req *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error) {
+			return &internalpb.GetImportProgressResponse{Status: merr.Success(), State: internalpb.ImportJobState_Failed, Reason: "mock reason"}, nil
+		}
+		progress, err := s.client.WaitImport(ctx, NewWaitImportOption("1001").WithInterval(10*time.Millisecond))
+		s.Error(err)
+		s.Equal("mock reason", progress.Reason)
+	})
+
+	s.Run("context_done", func() {
+		s.importMock.getImportProgress = func(ctx context.Context, req *internalpb.GetImportProgressRequest) (*internalpb.GetImportProgressResponse, error) {
+			return &internalpb.GetImportProgressResponse{Status: merr.Success(), State: internalpb.ImportJobState_Importing}, nil
+		}
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := s.client.WaitImport(ctx, NewWaitImportOption("1001").WithInterval(10*time.Millisecond))
+		s.Error(err)
+	})
+}
+
+func TestImport(t *testing.T) {
+	suite.Run(t, new(ImportSuite))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
 	"google.golang.org/grpc"
@@ -38,6 +39,9 @@ const (
 	IteratorSearchLastBoundKey = "search_iter_last_bound"
 	IteratorSearchIDKey        = "search_iter_id"
 	CollectionIDKey            = `collection_id`
+	// ReduceStopForBestKey is the const query param key to let proxy stop reducing once any shard runs out,
+	// which keeps the returned primary keys continuous for query iterator.
+	ReduceStopForBestKey = "reduce_stop_for_best"
 
 	// Unlimited
 	Unlimited int64 = -1
@@ -211,3 +215,166 @@ func (c *Client) SearchIterator(ctx context.Context, option SearchIteratorOption
 
 	return newSearchIteratorV1(c)
 }
+
+// QueryIterator is the interface for query iterator.
+type QueryIterator interface {
+	// Next returns next batch of iterator
+	// when iterator reaches the end, return `io.EOF`.
+	Next(ctx context.Context) (ResultSet, error)
+}
+
+// queryIterator pages through the entities by primary key cursor.
+// All the batches are read at the mvcc timestamp pinned by the first batch,
+// so the data inserted or deleted during iteration will not affect the result.
+type queryIterator struct {
+	client      *Client
+	option      QueryIteratorOption
+	callOptions []grpc.CallOption
+	schema      *entity.Schema
+	pkField     *entity.Field
+	limit       int64
+
+	// cursor is the expression literal of the last returned primary key, empty for the first batch
+	cursor string
+	// sessionTs is the mvcc timestamp returned by the first batch
+	sessionTs uint64
+}
+
+func (it *queryIterator) Next(ctx context.Context) (ResultSet, error) {
+	// limit reached, return EOF
+	if it.limit == 0 {
+		return ResultSet{}, io.EOF
+	}
+
+	batchSize := it.option.BatchSize()
+	if it.limit != Unlimited && it.limit < int64(batchSize) {
+		batchSize = int(it.limit)
+	}
+
+	rs, err := it.next(ctx, batchSize)
+	if err != nil {
+		return rs, err
+	}
+
+	if it.limit != Unlimited {
+		it.limit -= int64(rs.Len())
+	}
+	return rs, nil
+}
+
+func (it *queryIterator) next(ctx context.Context, batchSize int) (ResultSet, error) {
+	opt := it.option.QueryOption()
+	opt.WithLimit(batchSize)
+	req, err := opt.Request()
+	if err != nil {
+		return ResultSet{}, err
+	}
+	req.Expr = it.cursorExpr(req.GetExpr())
+	// reuse the session ts as guarantee ts, proxy reads at this mvcc ts for iterator
+	req.GuaranteeTimestamp = it.sessionTs
+
+	var rs ResultSet
+	err = it.client.callService(func(milvusService milvuspb.MilvusServiceClient) error {
+		resp, err := milvusService.Query(ctx, req, it.callOptions...)
+		err = merr.CheckRPCCall(resp, err)
+		if err != nil {
+			return err
+		}
+
+		columns, err := it.client.parseSearchResult(it.schema, resp.GetOutputFields(), resp.GetFieldsData(), 0, 0, -1)
+		if err != nil {
+			return err
+		}
+		rs = ResultSet{
+			sch:    it.schema,
+			Fields: columns,
+		}
+		if len(columns) > 0 {
+			rs.ResultCount = columns[0].Len()
+		}
+		if rs.ResultCount == 0 {
+			return io.EOF
+		}
+
+		if it.sessionTs == 0 {
+			it.sessionTs = resp.GetSessionTs()
+		}
+		return it.updateCursor(rs)
+	})
+	return rs, err
+}
+
+func (it *queryIterator) updateCursor(rs ResultSet) error {
+	pkColumn := rs.GetColumn(it.pkField.Name)
+	if pkColumn == nil {
+		return fmt.Errorf("primary key field %s not found in query result", it.pkField.Name)
+	}
+
+	last := pkColumn.Len() - 1
+	switch it.pkField.DataType {
+	case entity.FieldTypeInt64:
+		pk, err := pkColumn.GetAsInt64(last)
+		if err != nil {
+			return err
+		}
+		it.cursor = strconv.FormatInt(pk, 10)
+	case entity.FieldTypeVarChar:
+		pk, err := pkColumn.GetAsString(last)
+		if err != nil {
+			return err
+		}
+		it.cursor = strconv.Quote(pk)
+	default:
+		return fmt.Errorf("unsupported primary key type %s", it.pkField.DataType.String())
+	}
+	return nil
+}
+
+// cursorExpr appends the primary key cursor to the user filter.
+func (it *queryIterator) cursorExpr(expr string) string {
+	if it.cursor == "" {
+		return expr
+	}
+	cursorExpr := fmt.Sprintf("%s > %s", it.pkField.Name, it.cursor)
+	if expr == "" {
+		return cursorExpr
+	}
+	return fmt.Sprintf("(%s) and %s", expr, cursorExpr)
+}
+
+func newQueryIterator(ctx context.Context, client *Client, option QueryIteratorOption, callOptions ...grpc.CallOption) (*queryIterator, error) {
+	opt := option.QueryOption()
+	collection, err := client.getCollection(ctx, opt.collectionName)
+	if err != nil {
+		return nil, err
+	}
+
+	pkField := collection.Schema.PKField()
+	if pkField == nil {
+		return nil, fmt.Errorf("primary key field not found in collection %s", opt.collectionName)
+	}
+	if collection.ID > 0 {
+		opt.queryParams[CollectionIDKey] = strconv.FormatInt(collection.ID, 10)
+	}
+
+	return &queryIterator{
+		client:      client,
+		option:      option,
+		callOptions: callOptions,
+		schema:      collection.Schema,
+		pkField:     pkField,
+		limit:       option.Limit(),
+	}, nil
+}
+
+// QueryIterator creates a query iterator from a collection.
+//
+// The iterator pages through the entities matching the filter in primary key order,
+// and reads all the batches at the same mvcc timestamp.
+func (c *Client) QueryIterator(ctx context.Context, option QueryIteratorOption, callOptions ...grpc.CallOption) (QueryIterator, error) {
+	if err := option.ValidateParams(); err != nil {
+		return nil, err
+	}
+
+	return newQueryIterator(ctx, c, option, callOptions...)
+}
@@ -18,6 +18,7 @@ package milvusclient
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/milvus-io/milvus/client/v2/entity"
 	"github.com/milvus-io/milvus/client/v2/index"
@@ -146,3 +147,97 @@ func NewSearchIteratorOption(collectionName string, vector entity.Vector) *searc
 		iteratorLimit: Unlimited,
 	}
 }
+
+type QueryIteratorOption interface {
+	// QueryOption returns the query option when iterate query
+	QueryOption() *queryOption
+	// BatchSize returns the entry count of each batch
+	BatchSize() int
+	// Limit returns the overall limit of entries to iterate
+	Limit() int64
+	// ValidateParams performs the static params validation
+	ValidateParams() error
+}
+
+type queryIteratorOption struct {
+	*queryOption
+	batchSize     int
+	iteratorLimit int64
+}
+
+func (opt *queryIteratorOption) QueryOption() *queryOption {
+	opt.queryParams[IteratorKey] = strconv.FormatBool(true)
+	opt.queryParams[ReduceStopForBestKey] = strconv.FormatBool(true)
+	return opt.queryOption
+}
+
+func (opt *queryIteratorOption) BatchSize() int {
+	return opt.batchSize
+}
+
+func (opt *queryIteratorOption) Limit() int64 {
+	return opt.iteratorLimit
+}
+
+// ValidateParams performs the static params validation
+func (opt *queryIteratorOption) ValidateParams() error {
+	if opt.batchSize <= 0 {
+		return fmt.Errorf("batch size must be greater than 0")
+	}
+	return nil
+}
+
+func (opt *queryIteratorOption) WithBatchSize(batchSize int) *queryIteratorOption {
+	opt.batchSize = batchSize
+	return opt
+}
+
+func (opt *queryIteratorOption) WithPartitions(partitionNames ...string) *queryIteratorOption {
+	opt.partitionNames = partitionNames
+	return opt
+}
+
+func (opt *queryIteratorOption) WithFilter(expr string) *queryIteratorOption {
+	opt.queryOption.WithFilter(expr)
+	return opt
+}
+
+func (opt *queryIteratorOption) WithTemplateParam(key string, val any) *queryIteratorOption {
+	opt.queryOption.WithTemplateParam(key, val)
+	return opt
+}
+
+func (opt *queryIteratorOption) WithOutputFields(fieldNames ...string) *queryIteratorOption {
+	opt.outputFields = fieldNames
+	return opt
+}
+
+func (opt *queryIteratorOption) WithConsistencyLevel(consistencyLevel entity.ConsistencyLevel) *queryIteratorOption {
+	opt.queryOption.WithConsistencyLevel(consistencyLevel)
+	return opt
+}
+
+func (opt *queryIteratorOption) WithQueryParam(key, value string) *queryIteratorOption {
+	opt.queryParams[key] = value
+	return opt
+}
+
+// WithIteratorLimit sets the limit of entries to iterate
+// if limit < 0, then it will be set to Unlimited
+func (opt *queryIteratorOption) WithIteratorLimit(limit int64) *queryIteratorOption {
+	if limit < 0 {
+		limit = Unlimited
+	}
+	opt.iteratorLimit = limit
+	return opt
+}
+
+func NewQueryIteratorOption(collectionName string) *queryIteratorOption {
+	queryOpt := NewQueryOption(collectionName)
+	queryOpt.queryParams = make(map[string]string)
+	return &queryIteratorOption{
+		queryOption:   queryOpt,
+		batchSize:     1000,
+		iteratorLimit: Unlimited,
+	}
+}
@@ -416,3 +416,167 @@ func (s *SearchIteratorSuite) TestNextWithLimit() {
 func TestSearchIterator(t *testing.T) {
 	suite.Run(t, new(SearchIteratorSuite))
 }
+
+type QueryIteratorSuite struct {
+	MockSuiteBase
+
+	schema *entity.Schema
+}
+
+func (s *QueryIteratorSuite) SetupSuite() {
+	s.MockSuiteBase.SetupSuite()
+	s.schema = entity.NewSchema().
+		WithField(entity.NewField().WithName("ID").WithDataType(entity.FieldTypeInt64).WithIsPrimaryKey(true)).
+		WithField(entity.NewField().WithName("Vector").WithDataType(entity.FieldTypeFloatVector).WithDim(128))
+}
+
+func (s *QueryIteratorSuite) TestQueryIteratorInit() {
+	ctx := context.Background()
+
+	s.Run("bad_batch_size", func() {
+		collectionName := fmt.Sprintf("coll_%s", s.randString(6))
+		_, err := s.client.QueryIterator(ctx, NewQueryIteratorOption(collectionName).WithBatchSize(0))
+		s.Error(err)
+	})
+
+	s.Run("describe_fail", func() {
+		collectionName := fmt.Sprintf("coll_%s", s.randString(6))
+		s.mock.EXPECT().DescribeCollection(mock.Anything, mock.Anything).Return(nil, merr.WrapErrCollectionNotFound(collectionName)).Once()
+		_, err := s.client.QueryIterator(ctx, NewQueryIteratorOption(collectionName))
+		s.Error(err)
+	})
+}
+
+func (s *QueryIteratorSuite) TestNext() {
+	ctx := context.Background()
+	collectionName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collectionName, s.schema)
+
+	iter, err := s.client.QueryIterator(ctx, NewQueryIteratorOption(collectionName).
+		WithFilter("ID >= 0").
+		WithPartitions("part").
+		WithOutputFields("ID").
+		WithBatchSize(3))
+	s.Require().NoError(err)
+
+	var sessionTs uint64 = 10000
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, qr *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		params := entity.KvPairsMap(qr.GetQueryParams())
+		s.Equal("true", params[IteratorKey])
+		s.Equal("true", params[ReduceStopForBestKey])
+		s.Equal("3", params[spLimit])
+		s.Equal([]string{"part"}, qr.GetPartitionNames())
+		s.Equal("ID >= 0", qr.GetExpr())
+		s.EqualValues(0, qr.GetGuaranteeTimestamp())
+		return &milvuspb.QueryResults{
+			Status:       merr.Success(),
+			OutputFields: []string{"ID"},
+			FieldsData:   []*schemapb.FieldData{s.getInt64FieldData("ID", []int64{1, 2, 3})},
+			SessionTs:    sessionTs,
+		}, nil
+	}).Once()
+
+	rs, err := iter.Next(ctx)
+	s.NoError(err)
+	s.Equal(3, rs.Len())
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, qr *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		s.Equal("(ID >= 0) and ID > 3", qr.GetExpr())
+		s.Equal(sessionTs, qr.GetGuaranteeTimestamp(), "session ts shall be reused")
+		return &milvuspb.QueryResults{
+			Status:       merr.Success(),
+			OutputFields: []string{"ID"},
+			FieldsData:   []*schemapb.FieldData{s.getInt64FieldData("ID", []int64{4})},
+		}, nil
+	}).Once()
+
+	rs, err = iter.Next(ctx)
+	s.NoError(err)
+	s.Equal(1, rs.Len())
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, qr *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		s.Equal("(ID >= 0) and ID > 4", qr.GetExpr())
+		return &milvuspb.QueryResults{
+			Status:       merr.Success(),
+			OutputFields: []string{"ID"},
+			FieldsData:   []*schemapb.FieldData{s.getInt64FieldData("ID", []int64{})},
+		}, nil
+	}).Once()
+
+	_, err = iter.Next(ctx)
+	s.ErrorIs(err, io.EOF, "no more data, return EOF")
+}
+
+func (s *QueryIteratorSuite) TestNextVarcharPK() {
+	ctx := context.Background()
+	collectionName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collectionName, entity.NewSchema().
+		WithField(entity.NewField().WithName("Name").WithDataType(entity.FieldTypeVarChar).WithIsPrimaryKey(true).WithMaxLength(64)).
+		WithField(entity.NewField().WithName("Vector").WithDataType(entity.FieldTypeFloatVector).WithDim(128)))
+
+	iter, err := s.client.QueryIterator(ctx, NewQueryIteratorOption(collectionName).WithBatchSize(2))
+	s.Require().NoError(err)
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).Return(&milvuspb.QueryResults{
+		Status:       merr.Success(),
+		OutputFields: []string{"Name"},
+		FieldsData:   []*schemapb.FieldData{s.getVarcharFieldData("Name", []string{"a", `b"c`})},
+	}, nil).Once()
+
+	_, err = iter.Next(ctx)
+	s.NoError(err)
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, qr *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		s.Equal(`Name > "b\"c"`, qr.GetExpr())
+		return &milvuspb.QueryResults{
+			Status:       merr.Success(),
+			OutputFields: []string{"Name"},
+			FieldsData:   []*schemapb.FieldData{s.getVarcharFieldData("Name", []string{})},
+		}, nil
+	}).Once()
+
+	_, err = iter.Next(ctx)
+	s.ErrorIs(err, io.EOF)
+}
+
+func (s *QueryIteratorSuite) TestNextWithLimit() {
+	ctx := context.Background()
+	collectionName := fmt.Sprintf("coll_%s", s.randString(6))
+	s.setupCache(collectionName, s.schema)
+
+	iter, err := s.client.QueryIterator(ctx, NewQueryIteratorOption(collectionName).WithBatchSize(3).WithIteratorLimit(4))
+	s.Require().NoError(err)
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, qr *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		s.Equal("3", entity.KvPairsMap(qr.GetQueryParams())[spLimit])
+		return &milvuspb.QueryResults{
+			Status:       merr.Success(),
+			OutputFields: []string{"ID"},
+			FieldsData:   []*schemapb.FieldData{s.getInt64FieldData("ID", []int64{1, 2, 3})},
+		}, nil
+	}).Once()
+
+	rs, err := iter.Next(ctx)
+	s.NoError(err)
+	s.Equal(3, rs.Len())
+
+	s.mock.EXPECT().Query(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, qr *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+		s.Equal("1", entity.KvPairsMap(qr.GetQueryParams())[spLimit], "batch size shall be cut by the remaining limit")
+		return &milvuspb.QueryResults{
+			Status:       merr.Success(),
+			OutputFields: []string{"ID"},
+			FieldsData:   []*schemapb.FieldData{s.getInt64FieldData("ID", []int64{4})},
+		}, nil
+	}).Once()
+
+	rs, err = iter.Next(ctx)
+	s.NoError(err)
+	s.Equal(1, rs.Len())
+
+	_, err = iter.Next(ctx)
+	s.ErrorIs(err, io.EOF, "limit reached, return EOF")
+}
+
+func TestQueryIterator(t *testing.T) {
+	suite.Run(t, new(QueryIteratorSuite))
+}
@@ -0,0 +1,99 @@
+package milvus
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	management "github.com/milvus-io/milvus/internal/http"
+)
+
+const (
+	BalanceCmd          = "balance"
+	BalanceTypeSimulate = "simulate"
+)
+
+type balance struct {
+	address     string
+	balancer    string
+	collection  int64
+	addNodes    string
+	removeNodes string
+	maxRounds   int
+	timeout     time.Duration
+}
+
+func (c *balance) execute(args []string, flags *flag.FlagSet) {
+	if len(args) < 3 || args[2] != BalanceTypeSimulate {
+		fmt.Fprintln(os.Stderr, balanceLine)
+		return
+	}
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, balanceLine)
+	}
+	c.formatFlags(args, flags)
+
+	body, err := c.simulate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to simulate balance: %s\n", err.Error())
+		os.Exit(1)
+	}
+	fmt.Fprintln(os.Stdout, body)
+}
+
+func (c *balance) formatFlags(args []string, flags *flag.FlagSet) {
+	flags.StringVar(&c.address, "address", "localhost:9091", "the http address of the proxy")
+	flags.StringVar(&c.balancer, "balancer", "", "the balancer to simulate, the configured one if empty")
+	flags.Int64Var(&c.collection, "collection", 0, "only simulate the replicas of the collection")
+	flags.StringVar(&c.addNodes, "addNodes", "", "the query nodes to add, rg:num[:memory capacity in MB] separated by comma")
+	flags.StringVar(&c.removeNodes, "removeNodes", "", "the query node ids to remove, separated by comma")
+	flags.IntVar(&c.maxRounds, "maxRounds", 0, "the max balance rounds to simulate")
+	flags.DurationVar(&c.timeout, "timeout", time.Minute, "the timeout of the request")
+	if err := flags.Parse(args[3:]); err != nil {
+		os.Exit(-1)
+	}
+}
+
+func (c *balance) simulate() (string, error) {
+	form := url.Values{}
+	if c.balancer != "" {
+		form.Set("balancer", c.balancer)
+	}
+	if c.collection != 0 {
+		form.Set("collection_id", strconv.FormatInt(c.collection, 10))
+	}
+	if c.addNodes != "" {
+		form.Set("add_nodes", c.addNodes)
+	}
+	if c.removeNodes != "" {
+		form.Set("remove_nodes", c.removeNodes)
+	}
+	if c.maxRounds > 0 {
+		form.Set("max_rounds", strconv.Itoa(c.maxRounds))
+	}
+
+	address := c.address
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	client := &http.Client{Timeout: c.timeout}
+	resp, err := client.PostForm(address+management.RouteSimulateQueryCoordBalance, form)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("status %d, %s", resp.StatusCode, string(body))
+	}
+	return string(body), nil
+}
@@ -13,7 +13,7 @@ const (
 
 var (
 	usageLine = fmt.Sprintf("Usage:\n"+
-		"%s\n%s\n%s\n%s\n", runLine, stopLine, mckLine, serverTypeLine)
+		"%s\n%s\n%s\n%s\n%s\n", runLine, stopLine, mckLine, balanceLine, serverTypeLine)
 
 	serverTypeLine = `
 [server type]
@@ -64,5 +64,25 @@ milvus mck run [flags]
 milvus mck cleanTrash [flags]
 	Clean the back inconsistent data
 	Tips: The flags is the same as its of the 'milvus mck [flags]'
+`
+	balanceLine = `
+milvus balance simulate [flags]
+	Simulate the querycoord balance plan without moving anything.
+	Tips: The flags are optional.
+[flags]
+	-address 'localhost:9091'
+		The http address of the proxy.
+	-balancer ''
+		The balancer to simulate, the configured one if empty.
+	-collection '0'
+		Only simulate the replicas of the collection.
+	-addNodes ''
+		The query nodes to add, in the format of rg:num[:memory capacity in MB] separated by comma.
+	-removeNodes ''
+		The query node ids to remove, separated by comma.
+	-maxRounds '0'
+		The max balance rounds to simulate, 100 if not set.
+	-timeout '1m'
+		The timeout of the request.
 `
 )
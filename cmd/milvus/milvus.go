@@ -53,6 +53,8 @@ func RunMilvus(args []string) {
 		c = &dryRun{}
 	case MckCmd:
 		c = &mck{}
+	case BalanceCmd:
+		c = &balance{}
 	default:
 		c = &defaultCommand{}
 	}
@@ -18,6 +18,8 @@
 
 #include <memory>
 
+#include <folly/CancellationToken.h>
+
 #include "common/Tracer.h"
 #include "common/Types.h"
 #include "knowhere/config.h"
@@ -46,6 +48,8 @@ struct SearchInfo {
     std::optional<std::string> json_path_;
     std::optional<milvus::DataType> json_type_;
     bool strict_cast_{false};
+    // cancelled when the caller gives up the request, checked between chunks
+    folly::CancellationToken cancel_token_;
 };
 
 using SearchInfoPtr = std::shared_ptr<SearchInfo>;
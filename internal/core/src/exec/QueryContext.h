@@ -20,9 +20,11 @@
 #include <string>
 #include <vector>
 
+#include <folly/CancellationToken.h>
 #include <folly/Executor.h>
 #include <folly/executors/CPUThreadPoolExecutor.h>
 #include <folly/Optional.h>
+#include <folly/futures/FutureException.h>
 
 #include "common/Common.h"
 #include "common/Types.h"
@@ -291,6 +293,20 @@ class QueryContext : public Context {
         return plan_options_;
     }
 
+    void
+    set_cancellation_token(const folly::CancellationToken& cancel_token) {
+        cancel_token_ = cancel_token;
+    }
+
+    // throw a FutureCancellation if the query is cancelled by the caller,
+    // e.g. the client is gone or the request is timeout.
+    void
+    throw_if_cancelled() const {
+        if (cancel_token_.isCancellationRequested()) {
+            throw folly::FutureCancellation();
+        }
+    }
+
  private:
     folly::Executor* executor_;
     //folly::Executor::KeepAlive<> executor_keepalive_;
@@ -316,6 +332,8 @@ class QueryContext : public Context {
     int32_t consistency_level_ = 0;
 
     query::PlanOptions plan_options_;
+
+    folly::CancellationToken cancel_token_;
 };
 
 // Represent the state of one thread of query execution.
@@ -54,7 +54,9 @@ PhyColumnExpr::Eval(EvalCtx& context, VectorPtr& result) {
         case DataType::DOUBLE:
             result = DoEval<double>(input);
             break;
-        case DataType::VARCHAR: {
+        case DataType::VARCHAR:
+        case DataType::JSON: {
+            // the values of JSON columns are evaluated as JSON text
             result = DoEval<std::string>(input);
             break;
         }
@@ -76,8 +78,8 @@ PhyColumnExpr::DoEval(OffsetVector* input) {
             return nullptr;
         }
 
-        auto res_vec = std::make_shared<ColumnVector>(
-            expr_->GetColumn().data_type_, real_batch_size);
+        auto res_vec = std::make_shared<ColumnVector>(ResultType(),
+                                                     real_batch_size);
         T* res_value = res_vec->RawAsValues<T>();
         TargetBitmapView valid_res(res_vec->GetValidRawData(), real_batch_size);
         valid_res.set();
@@ -102,12 +104,7 @@ PhyColumnExpr::DoEval(OffsetVector* input) {
                     return {0, offset};
                 }
             }();
-            auto chunk_data = segment_chunk_reader_.GetChunkDataAccessor(
-                expr_->GetColumn().data_type_,
-                expr_->GetColumn().field_id_,
-                chunk_id,
-                data_barrier,
-                pinned_index_);
+            auto chunk_data = GetChunkDataAccessor(chunk_id, data_barrier);
             auto chunk_data_by_offset = chunk_data(chunk_offset);
             if (!chunk_data_by_offset.has_value()) {
                 valid_res[processed_rows] = false;
@@ -127,17 +124,12 @@ PhyColumnExpr::DoEval(OffsetVector* input) {
             return nullptr;
         }
 
-        auto res_vec = std::make_shared<ColumnVector>(
-            expr_->GetColumn().data_type_, real_batch_size);
+        auto res_vec = std::make_shared<ColumnVector>(ResultType(),
+                                                     real_batch_size);
         T* res_value = res_vec->RawAsValues<T>();
         TargetBitmapView valid_res(res_vec->GetValidRawData(), real_batch_size);
         valid_res.set();
-        auto chunk_data = segment_chunk_reader_.GetMultipleChunkDataAccessor(
-            expr_->GetColumn().data_type_,
-            expr_->GetColumn().field_id_,
-            current_chunk_id_,
-            current_chunk_pos_,
-            pinned_index_);
+        auto chunk_data = GetMultipleChunkDataAccessor();
         for (int i = 0; i < real_batch_size; ++i) {
             auto data = chunk_data();
             if (!data.has_value()) {
@@ -153,8 +145,8 @@ PhyColumnExpr::DoEval(OffsetVector* input) {
             return nullptr;
         }
 
-        auto res_vec = std::make_shared<ColumnVector>(
-            expr_->GetColumn().data_type_, real_batch_size);
+        auto res_vec = std::make_shared<ColumnVector>(ResultType(),
+                                                     real_batch_size);
         T* res_value = res_vec->RawAsValues<T>();
         TargetBitmapView valid_res(res_vec->GetValidRawData(), real_batch_size);
         valid_res.set();
@@ -170,12 +162,7 @@ PhyColumnExpr::DoEval(OffsetVector* input) {
                     ? segment_chunk_reader_.active_count_ -
                           chunk_id * segment_chunk_reader_.SizePerChunk()
                     : segment_chunk_reader_.SizePerChunk();
-            auto chunk_data = segment_chunk_reader_.GetChunkDataAccessor(
-                expr_->GetColumn().data_type_,
-                expr_->GetColumn().field_id_,
-                chunk_id,
-                data_barrier,
-                pinned_index_);
+            auto chunk_data = GetChunkDataAccessor(chunk_id, data_barrier);
 
             for (int i = chunk_id == current_chunk_id_ ? current_chunk_pos_ : 0;
                  i < chunk_size;
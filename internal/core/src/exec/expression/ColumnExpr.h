@@ -42,7 +42,12 @@ class PhyColumnExpr : public Expr {
           expr_(expr) {
         auto& schema = segment->get_schema();
         auto& field_meta = schema[expr_->GetColumn().field_id_];
-        pinned_index_ = PinIndex(segment, field_meta);
+        if (expr_->GetColumn().data_type_ == DataType::JSON) {
+            // the values at the path are read from the raw data as JSON text
+            json_pointer_ = Json::pointer(expr_->GetColumn().nested_path_);
+        } else {
+            pinned_index_ = PinIndex(segment, field_meta);
+        }
         is_indexed_ = pinned_index_.size() > 0;
         if (segment->is_chunked()) {
             num_chunk_ =
@@ -113,6 +118,44 @@ class PhyColumnExpr : public Expr {
     VectorPtr
     DoEval(OffsetVector* input = nullptr);
 
+    DataType
+    ResultType() const {
+        return expr_->GetColumn().data_type_ == DataType::JSON
+                   ? DataType::VARCHAR
+                   : expr_->GetColumn().data_type_;
+    }
+
+    segcore::ChunkDataAccessor
+    GetChunkDataAccessor(int chunk_id, int data_barrier) const {
+        if (expr_->GetColumn().data_type_ == DataType::JSON) {
+            return segment_chunk_reader_.GetJsonChunkDataAccessor(
+                expr_->GetColumn().field_id_, chunk_id, json_pointer_);
+        }
+        return segment_chunk_reader_.GetChunkDataAccessor(
+            expr_->GetColumn().data_type_,
+            expr_->GetColumn().field_id_,
+            chunk_id,
+            data_barrier,
+            pinned_index_);
+    }
+
+    segcore::MultipleChunkDataAccessor
+    GetMultipleChunkDataAccessor() {
+        if (expr_->GetColumn().data_type_ == DataType::JSON) {
+            return segment_chunk_reader_.GetJsonMultipleChunkDataAccessor(
+                expr_->GetColumn().field_id_,
+                current_chunk_id_,
+                current_chunk_pos_,
+                json_pointer_);
+        }
+        return segment_chunk_reader_.GetMultipleChunkDataAccessor(
+            expr_->GetColumn().data_type_,
+            expr_->GetColumn().field_id_,
+            current_chunk_id_,
+            current_chunk_pos_,
+            pinned_index_);
+    }
+
     std::string
     ToString() const {
         return fmt::format("{}", expr_->ToString());
@@ -139,6 +182,8 @@ class PhyColumnExpr : public Expr {
     int64_t batch_size_;
     std::shared_ptr<const milvus::expr::ColumnExpr> expr_;
     std::vector<PinWrapper<const index::IndexBase*>> pinned_index_;
+    // the pointer of the nested path, only used for JSON columns
+    std::string json_pointer_;
 };
 
 }  //namespace exec
@@ -16,6 +16,8 @@
 
 #include "exec/expression/function/FunctionFactory.h"
 #include <mutex>
+#include "exec/expression/function/impl/CastFunctions.h"
+#include "exec/expression/function/impl/CompareFunctions.h"
 #include "exec/expression/function/impl/StringFunctions.h"
 #include "log/Log.h"
 
@@ -23,6 +25,13 @@ namespace milvus {
 namespace exec {
 namespace expression {
 
+static const std::vector<DataType> kNumericTypes{DataType::INT8,
+                                                 DataType::INT16,
+                                                 DataType::INT32,
+                                                 DataType::INT64,
+                                                 DataType::FLOAT,
+                                                 DataType::DOUBLE};
+
 std::string
 FilterFunctionRegisterKey::ToString() const {
     std::ostringstream oss;
@@ -56,16 +65,131 @@ FunctionFactory::RegisterAllFunctions() {
     RegisterFilterFunction("starts_with",
                            {DataType::VARCHAR, DataType::VARCHAR},
                            function::StartsWithVarchar);
+    RegisterFilterFunction("ends_with",
+                           {DataType::VARCHAR, DataType::VARCHAR},
+                           function::EndsWithVarchar);
+    RegisterFilterFunction("lower",
+                           {DataType::VARCHAR},
+                           function::LowerVarchar,
+                           DataType::VARCHAR);
+    RegisterFilterFunction("upper",
+                           {DataType::VARCHAR},
+                           function::UpperVarchar,
+                           DataType::VARCHAR);
+    RegisterFilterFunction("length",
+                           {DataType::VARCHAR},
+                           function::LengthVarchar,
+                           DataType::INT64);
+    RegisterFilterFunction("substring",
+                           {DataType::VARCHAR, DataType::INT64},
+                           function::SubstringVarchar,
+                           DataType::VARCHAR);
+    RegisterFilterFunction(
+        "substring",
+        {DataType::VARCHAR, DataType::INT64, DataType::INT64},
+        function::SubstringVarchar,
+        DataType::VARCHAR);
+    RegisterCompareFunctions();
+    RegisterCastFunctions();
     LOG_INFO("{} functions registered", GetFilterFunctionNum());
 }
 
+void
+FunctionFactory::RegisterCompareFunctions() {
+    using function::CompareFunction;
+    using function::CompareOp;
+    // compare functions are generated by the parser for the comparisons on
+    // the result of scalar functions, e.g. lower(a) == "x", the numbers of
+    // different types can be compared, e.g. int8(a) > 1 where the literal is
+    // INT64
+    std::vector<std::pair<DataType, DataType>> param_types{
+        {DataType::VARCHAR, DataType::VARCHAR}};
+    for (auto left : kNumericTypes) {
+        for (auto right : kNumericTypes) {
+            param_types.emplace_back(left, right);
+        }
+    }
+    for (auto [left, right] : param_types) {
+        RegisterFilterFunction(
+            "equal", {left, right}, CompareFunction<CompareOp::EQ>);
+        RegisterFilterFunction(
+            "not_equal", {left, right}, CompareFunction<CompareOp::NE>);
+        RegisterFilterFunction(
+            "less_than", {left, right}, CompareFunction<CompareOp::LT>);
+        RegisterFilterFunction(
+            "less_equal", {left, right}, CompareFunction<CompareOp::LE>);
+        RegisterFilterFunction(
+            "greater_than", {left, right}, CompareFunction<CompareOp::GT>);
+        RegisterFilterFunction(
+            "greater_equal", {left, right}, CompareFunction<CompareOp::GE>);
+    }
+}
+
+void
+FunctionFactory::RegisterCastFunctions() {
+    using function::CastFunction;
+    using function::CastJsonFunction;
+    // cast functions are generated by the parser for the casts written as
+    // calls, e.g. int64(meta["age"]), the values which cannot be converted
+    // are cast to null
+    std::vector<DataType> source_types{
+        DataType::BOOL, DataType::VARCHAR, DataType::JSON};
+    source_types.insert(
+        source_types.end(), kNumericTypes.begin(), kNumericTypes.end());
+    for (auto source : source_types) {
+        auto is_json = source == DataType::JSON;
+        RegisterFilterFunction("bool",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::BOOL>
+                                       : CastFunction<DataType::BOOL>,
+                               DataType::BOOL);
+        RegisterFilterFunction("int8",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::INT8>
+                                       : CastFunction<DataType::INT8>,
+                               DataType::INT8);
+        RegisterFilterFunction("int16",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::INT16>
+                                       : CastFunction<DataType::INT16>,
+                               DataType::INT16);
+        RegisterFilterFunction("int32",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::INT32>
+                                       : CastFunction<DataType::INT32>,
+                               DataType::INT32);
+        RegisterFilterFunction("int64",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::INT64>
+                                       : CastFunction<DataType::INT64>,
+                               DataType::INT64);
+        RegisterFilterFunction("float",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::FLOAT>
+                                       : CastFunction<DataType::FLOAT>,
+                               DataType::FLOAT);
+        RegisterFilterFunction("double",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::DOUBLE>
+                                       : CastFunction<DataType::DOUBLE>,
+                               DataType::DOUBLE);
+        RegisterFilterFunction("varchar",
+                               {source},
+                               is_json ? CastJsonFunction<DataType::VARCHAR>
+                                       : CastFunction<DataType::VARCHAR>,
+                               DataType::VARCHAR);
+    }
+}
+
 void
 FunctionFactory::RegisterFilterFunction(
     std::string func_name,
     std::vector<DataType> func_param_type_list,
-    FilterFunctionPtr func) {
-    filter_function_map_[FilterFunctionRegisterKey{
-        func_name, func_param_type_list}] = func;
+    FilterFunctionPtr func,
+    DataType return_type) {
+    FilterFunctionRegisterKey key{func_name, func_param_type_list};
+    filter_function_map_[key] = func;
+    return_type_map_[key] = return_type;
 }
 
 const FilterFunctionPtr
@@ -78,6 +202,16 @@ FunctionFactory::GetFilterFunction(
     return nullptr;
 }
 
+DataType
+FunctionFactory::GetFilterFunctionReturnType(
+    const FilterFunctionRegisterKey& func_sig) const {
+    auto iter = return_type_map_.find(func_sig);
+    if (iter != return_type_map_.end()) {
+        return iter->second;
+    }
+    return DataType::BOOL;
+}
+
 }  // namespace expression
 }  // namespace exec
 }  // namespace milvus
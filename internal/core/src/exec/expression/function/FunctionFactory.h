@@ -73,14 +73,22 @@ class FunctionFactory {
     void
     Initialize();
 
+    // return_type is the data type of the result vector, functions used as
+    // predicates return BOOL, scalar functions like lower/length return the
+    // type of the computed value.
     void
     RegisterFilterFunction(std::string func_name,
                            std::vector<DataType> func_param_type_list,
-                           FilterFunctionPtr func);
+                           FilterFunctionPtr func,
+                           DataType return_type = DataType::BOOL);
 
     const FilterFunctionPtr
     GetFilterFunction(const FilterFunctionRegisterKey& func_sig) const;
 
+    DataType
+    GetFilterFunctionReturnType(
+        const FilterFunctionRegisterKey& func_sig) const;
+
     size_t
     GetFilterFunctionNum() const {
         return filter_function_map_.size();
@@ -99,10 +107,20 @@ class FunctionFactory {
     void
     RegisterAllFunctions();
 
+    void
+    RegisterCompareFunctions();
+
+    void
+    RegisterCastFunctions();
+
     std::unordered_map<FilterFunctionRegisterKey,
                        FilterFunctionPtr,
                        FilterFunctionRegisterKey::Hash>
         filter_function_map_;
+    std::unordered_map<FilterFunctionRegisterKey,
+                       DataType,
+                       FilterFunctionRegisterKey::Hash>
+        return_type_map_;
     std::once_flag init_flag_;
 };
 
@@ -27,4 +27,54 @@ CheckVarcharOrStringType(std::shared_ptr<SimpleVector>& vec) {
     }
 }
 
+void
+CheckInt64Type(std::shared_ptr<SimpleVector>& vec) {
+    if (vec->type() != DataType::INT64) {
+        ThrowInfo(ExprInvalid,
+                  "invalid argument type, expect INT64, actual {}",
+                  vec->type());
+    }
+}
+
+void
+CheckArgumentCount(const RowVector& args, size_t expected) {
+    if (args.childrens().size() != expected) {
+        ThrowInfo(ExprInvalid,
+                  "invalid argument count, expect {}, actual {}",
+                  expected,
+                  args.childrens().size());
+    }
+}
+
+// a byte starts a UTF-8 character unless it is a continuation byte 10xxxxxx
+static inline bool
+IsUtf8LeadByte(char c) {
+    return (static_cast<unsigned char>(c) & 0xC0) != 0x80;
+}
+
+int64_t
+Utf8Length(const std::string& str) {
+    int64_t length = 0;
+    for (auto c : str) {
+        if (IsUtf8LeadByte(c)) {
+            ++length;
+        }
+    }
+    return length;
+}
+
+size_t
+Utf8Offset(const std::string& str, int64_t n) {
+    int64_t count = 0;
+    for (size_t i = 0; i < str.size(); ++i) {
+        if (IsUtf8LeadByte(str[i])) {
+            if (count == n) {
+                return i;
+            }
+            ++count;
+        }
+    }
+    return str.size();
+}
+
 }  // namespace milvus::exec::expression::function
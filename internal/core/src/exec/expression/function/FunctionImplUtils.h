@@ -15,6 +15,9 @@
 // limitations under the License.
 #pragma once
 
+#include <string>
+
+#include "common/EasyAssert.h"
 #include "common/Vector.h"
 
 namespace milvus::exec::expression::function {
@@ -22,4 +25,52 @@ namespace milvus::exec::expression::function {
 void
 CheckVarcharOrStringType(std::shared_ptr<SimpleVector>& vec);
 
+void
+CheckInt64Type(std::shared_ptr<SimpleVector>& vec);
+
+void
+CheckArgumentCount(const RowVector& args, size_t expected);
+
+// NumericValueAt returns the i-th value of a numeric vector converted to T.
+template <typename T>
+T
+NumericValueAt(const std::shared_ptr<SimpleVector>& vec, size_t i) {
+    switch (vec->type()) {
+        case DataType::BOOL:
+            return static_cast<T>(
+                *reinterpret_cast<bool*>(vec->RawValueAt(i, sizeof(bool))));
+        case DataType::INT8:
+            return static_cast<T>(*reinterpret_cast<int8_t*>(
+                vec->RawValueAt(i, sizeof(int8_t))));
+        case DataType::INT16:
+            return static_cast<T>(*reinterpret_cast<int16_t*>(
+                vec->RawValueAt(i, sizeof(int16_t))));
+        case DataType::INT32:
+            return static_cast<T>(*reinterpret_cast<int32_t*>(
+                vec->RawValueAt(i, sizeof(int32_t))));
+        case DataType::INT64:
+            return static_cast<T>(*reinterpret_cast<int64_t*>(
+                vec->RawValueAt(i, sizeof(int64_t))));
+        case DataType::FLOAT:
+            return static_cast<T>(
+                *reinterpret_cast<float*>(vec->RawValueAt(i, sizeof(float))));
+        case DataType::DOUBLE:
+            return static_cast<T>(*reinterpret_cast<double*>(
+                vec->RawValueAt(i, sizeof(double))));
+        default:
+            ThrowInfo(ExprInvalid,
+                      "invalid argument type, expect numeric, actual {}",
+                      vec->type());
+    }
+}
+
+// Utf8Length returns the number of UTF-8 characters in str.
+int64_t
+Utf8Length(const std::string& str);
+
+// Utf8Offset returns the byte offset of the n-th (0-based) UTF-8 character
+// in str, or the size of str if it has no more than n characters.
+size_t
+Utf8Offset(const std::string& str, int64_t n);
+
 }  // namespace milvus::exec::expression::function
@@ -10,10 +10,14 @@
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
 #include <gtest/gtest.h>
+#include <optional>
+#include <string>
 #include <vector>
 
 #include "common/Types.h"
 #include "common/Vector.h"
+#include "exec/expression/function/impl/CastFunctions.h"
+#include "exec/expression/function/impl/CompareFunctions.h"
 #include "exec/expression/function/impl/StringFunctions.h"
 
 using namespace milvus;
@@ -237,3 +241,326 @@ TEST_F(FunctionTest, StartsWithIncorrectArgs) {
     milvus::RowVector three_args(arg_vec);
     EXPECT_ANY_THROW(StartsWithVarchar(three_args, result));
 }
+
+TEST_F(FunctionTest, EndsWith) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto strs =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::VARCHAR, 4);
+    auto* strs_data = strs->RawAsValues<std::string>();
+    strs_data[0] = "hello";
+    strs_data[1] = "world";
+    strs_data[2] = "lo";
+    strs_data[3] = "";
+    arg_vec.push_back(strs);
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<std::string>>(
+        milvus::DataType::VARCHAR, 4, "lo"));
+    milvus::RowVector args(std::move(arg_vec));
+    VectorPtr result;
+    EndsWithVarchar(args, result);
+
+    auto result_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(result_vec, nullptr);
+    TargetBitmapView bitmap(result_vec->GetRawData(), result_vec->size());
+    std::vector<bool> expected{true, false, true, false};
+    for (int i = 0; i < 4; ++i) {
+        EXPECT_TRUE(result_vec->ValidAt(i)) << "i: " << i;
+        EXPECT_EQ(bitmap[i], expected[i]) << "i: " << i;
+    }
+}
+
+TEST_F(FunctionTest, LowerUpper) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto strs =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::VARCHAR, 3);
+    auto* strs_data = strs->RawAsValues<std::string>();
+    strs_data[0] = "Hello World";
+    strs_data[1] = "MiLvUs 向量";
+    strs_data[2] = "Àé Straße Ωμέγα Привет";
+    arg_vec.push_back(strs);
+    milvus::RowVector args(std::move(arg_vec));
+
+    VectorPtr result;
+    LowerVarchar(args, result);
+    auto lower = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(lower, nullptr);
+    EXPECT_EQ(lower->type(), milvus::DataType::VARCHAR);
+    EXPECT_EQ(lower->RawAsValues<std::string>()[0], "hello world");
+    EXPECT_EQ(lower->RawAsValues<std::string>()[1], "milvus 向量");
+    EXPECT_EQ(lower->RawAsValues<std::string>()[2], "àé straße ωμέγα привет");
+
+    UpperVarchar(args, result);
+    auto upper = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(upper, nullptr);
+    EXPECT_EQ(upper->RawAsValues<std::string>()[0], "HELLO WORLD");
+    EXPECT_EQ(upper->RawAsValues<std::string>()[1], "MILVUS 向量");
+    EXPECT_EQ(upper->RawAsValues<std::string>()[2], "ÀÉ STRAßE ΩΜΈΓΑ ПРИВЕТ");
+}
+
+TEST_F(FunctionTest, LengthNull) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    arg_vec.push_back(std::make_shared<milvus::ColumnVector>(
+        milvus::DataType::VARCHAR, 3, 3));
+    milvus::RowVector args(std::move(arg_vec));
+    VectorPtr result;
+    LengthVarchar(args, result);
+
+    auto result_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(result_vec, nullptr);
+    for (int i = 0; i < 3; ++i) {
+        EXPECT_FALSE(result_vec->ValidAt(i)) << "i: " << i;
+    }
+}
+
+TEST_F(FunctionTest, Length) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto strs =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::VARCHAR, 3);
+    auto* strs_data = strs->RawAsValues<std::string>();
+    strs_data[0] = "";
+    strs_data[1] = "abc";
+    strs_data[2] = "向量db";
+    arg_vec.push_back(strs);
+    milvus::RowVector args(std::move(arg_vec));
+    VectorPtr result;
+    LengthVarchar(args, result);
+
+    auto result_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(result_vec, nullptr);
+    EXPECT_EQ(result_vec->type(), milvus::DataType::INT64);
+    auto* data = result_vec->RawAsValues<int64_t>();
+    EXPECT_EQ(data[0], 0);
+    EXPECT_EQ(data[1], 3);
+    EXPECT_EQ(data[2], 4);
+}
+
+TEST_F(FunctionTest, Substring) {
+    auto check = [](const std::string& str,
+                    int64_t start,
+                    std::optional<int64_t> length,
+                    const std::string& expected) {
+        std::vector<milvus::VectorPtr> arg_vec;
+        arg_vec.push_back(
+            std::make_shared<milvus::ConstantVector<std::string>>(
+                milvus::DataType::VARCHAR, 1, str));
+        arg_vec.push_back(std::make_shared<milvus::ConstantVector<int64_t>>(
+            milvus::DataType::INT64, 1, start));
+        if (length.has_value()) {
+            arg_vec.push_back(
+                std::make_shared<milvus::ConstantVector<int64_t>>(
+                    milvus::DataType::INT64, 1, length.value()));
+        }
+        milvus::RowVector args(std::move(arg_vec));
+        VectorPtr result;
+        SubstringVarchar(args, result);
+        auto result_vec =
+            std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+        ASSERT_NE(result_vec, nullptr);
+        EXPECT_EQ(result_vec->RawAsValues<std::string>()[0], expected)
+            << str << ", " << start;
+    };
+
+    check("milvus", 1, std::nullopt, "milvus");
+    check("milvus", 4, std::nullopt, "vus");
+    check("milvus", 2, 3, "ilv");
+    check("milvus", 0, 2, "m");
+    check("milvus", -3, 2, "");
+    check("milvus", 5, 10, "us");
+    check("milvus", 10, 2, "");
+    check("向量数据库", 2, 2, "量数");
+
+    std::vector<milvus::VectorPtr> arg_vec;
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<std::string>>(
+        milvus::DataType::VARCHAR, 1, "milvus"));
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<int64_t>>(
+        milvus::DataType::INT64, 1, 1));
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<int64_t>>(
+        milvus::DataType::INT64, 1, -1));
+    milvus::RowVector negative_length_args(arg_vec);
+    VectorPtr result;
+    EXPECT_ANY_THROW(SubstringVarchar(negative_length_args, result));
+}
+
+TEST_F(FunctionTest, Compare) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto values =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::INT64, 3);
+    auto* values_data = values->RawAsValues<int64_t>();
+    values_data[0] = 1;
+    values_data[1] = 2;
+    values_data[2] = 3;
+    arg_vec.push_back(values);
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<int64_t>>(
+        milvus::DataType::INT64, 3, 2));
+    milvus::RowVector args(std::move(arg_vec));
+
+    auto check = [&](const milvus::VectorPtr& result,
+                     const std::vector<bool>& expected) {
+        auto result_vec =
+            std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+        ASSERT_NE(result_vec, nullptr);
+        TargetBitmapView bitmap(result_vec->GetRawData(), result_vec->size());
+        for (size_t i = 0; i < expected.size(); ++i) {
+            EXPECT_EQ(bitmap[i], expected[i]) << "i: " << i;
+        }
+    };
+
+    VectorPtr result;
+    CompareFunction<CompareOp::EQ>(args, result);
+    check(result, {false, true, false});
+    CompareFunction<CompareOp::NE>(args, result);
+    check(result, {true, false, true});
+    CompareFunction<CompareOp::LT>(args, result);
+    check(result, {true, false, false});
+    CompareFunction<CompareOp::LE>(args, result);
+    check(result, {true, true, false});
+    CompareFunction<CompareOp::GT>(args, result);
+    check(result, {false, false, true});
+    CompareFunction<CompareOp::GE>(args, result);
+    check(result, {false, true, true});
+
+    // mismatched types
+    std::vector<milvus::VectorPtr> mismatched_vec;
+    mismatched_vec.push_back(values);
+    mismatched_vec.push_back(
+        std::make_shared<milvus::ConstantVector<std::string>>(
+            milvus::DataType::VARCHAR, 3, "2"));
+    milvus::RowVector mismatched_args(mismatched_vec);
+    EXPECT_ANY_THROW(CompareFunction<CompareOp::EQ>(mismatched_args, result));
+}
+
+TEST_F(FunctionTest, CompareNumeric) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto values =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::DOUBLE, 3);
+    auto* values_data = values->RawAsValues<double>();
+    values_data[0] = 1.5;
+    values_data[1] = 2;
+    values_data[2] = 2.5;
+    arg_vec.push_back(values);
+    arg_vec.push_back(std::make_shared<milvus::ConstantVector<int64_t>>(
+        milvus::DataType::INT64, 3, 2));
+    milvus::RowVector args(std::move(arg_vec));
+
+    VectorPtr result;
+    CompareFunction<CompareOp::GT>(args, result);
+    auto result_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(result_vec, nullptr);
+    TargetBitmapView bitmap(result_vec->GetRawData(), result_vec->size());
+    EXPECT_FALSE(bitmap[0]);
+    EXPECT_FALSE(bitmap[1]);
+    EXPECT_TRUE(bitmap[2]);
+}
+
+TEST_F(FunctionTest, Cast) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    auto strs =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::VARCHAR, 4);
+    auto* strs_data = strs->RawAsValues<std::string>();
+    strs_data[0] = "30";
+    strs_data[1] = "1000";
+    strs_data[2] = "abc";
+    strs_data[3] = "2.5";
+    arg_vec.push_back(strs);
+    milvus::RowVector args(std::move(arg_vec));
+
+    VectorPtr result;
+    CastFunction<milvus::DataType::INT64>(args, result);
+    auto int64_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(int64_vec, nullptr);
+    EXPECT_EQ(int64_vec->type(), milvus::DataType::INT64);
+    EXPECT_EQ(int64_vec->RawAsValues<int64_t>()[0], 30);
+    EXPECT_EQ(int64_vec->RawAsValues<int64_t>()[1], 1000);
+    EXPECT_FALSE(int64_vec->ValidAt(2));
+    EXPECT_FALSE(int64_vec->ValidAt(3));
+
+    // out of the range of int8
+    CastFunction<milvus::DataType::INT8>(args, result);
+    auto int8_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(int8_vec, nullptr);
+    EXPECT_EQ(int8_vec->RawAsValues<int8_t>()[0], 30);
+    EXPECT_FALSE(int8_vec->ValidAt(1));
+
+    CastFunction<milvus::DataType::DOUBLE>(args, result);
+    auto double_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(double_vec, nullptr);
+    EXPECT_EQ(double_vec->RawAsValues<double>()[3], 2.5);
+    EXPECT_FALSE(double_vec->ValidAt(2));
+
+    std::vector<milvus::VectorPtr> double_arg_vec;
+    double_arg_vec.push_back(double_vec);
+    milvus::RowVector double_args(std::move(double_arg_vec));
+    CastFunction<milvus::DataType::INT64>(double_args, result);
+    auto truncated = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(truncated, nullptr);
+    EXPECT_EQ(truncated->RawAsValues<int64_t>()[3], 2);
+    CastFunction<milvus::DataType::VARCHAR>(double_args, result);
+    auto varchar_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(varchar_vec, nullptr);
+    EXPECT_EQ(varchar_vec->RawAsValues<std::string>()[0], "30");
+    EXPECT_EQ(varchar_vec->RawAsValues<std::string>()[3], "2.5");
+    EXPECT_FALSE(varchar_vec->ValidAt(2));
+}
+
+TEST_F(FunctionTest, CastJson) {
+    std::vector<milvus::VectorPtr> arg_vec;
+    // the values at a JSON path are evaluated as JSON text
+    auto values =
+        std::make_shared<milvus::ColumnVector>(milvus::DataType::VARCHAR, 6);
+    auto* values_data = values->RawAsValues<std::string>();
+    values_data[0] = "31";
+    values_data[1] = "\"42\"";
+    values_data[2] = "30.9";
+    values_data[3] = "true";
+    values_data[4] = "[1,2]";
+    TargetBitmapView valid_view(values->GetValidRawData(), 6);
+    valid_view[5] = false;
+    arg_vec.push_back(values);
+    milvus::RowVector args(std::move(arg_vec));
+
+    VectorPtr result;
+    CastJsonFunction<milvus::DataType::INT64>(args, result);
+    auto int64_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(int64_vec, nullptr);
+    auto* int64_data = int64_vec->RawAsValues<int64_t>();
+    EXPECT_EQ(int64_data[0], 31);
+    EXPECT_EQ(int64_data[1], 42);
+    EXPECT_EQ(int64_data[2], 30);
+    EXPECT_EQ(int64_data[3], 1);
+    EXPECT_FALSE(int64_vec->ValidAt(4));
+    EXPECT_FALSE(int64_vec->ValidAt(5));
+
+    CastJsonFunction<milvus::DataType::VARCHAR>(args, result);
+    auto varchar_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(varchar_vec, nullptr);
+    auto* varchar_data = varchar_vec->RawAsValues<std::string>();
+    EXPECT_EQ(varchar_data[0], "31");
+    EXPECT_EQ(varchar_data[1], "42");
+    EXPECT_EQ(varchar_data[3], "true");
+    EXPECT_EQ(varchar_data[4], "[1,2]");
+    EXPECT_FALSE(varchar_vec->ValidAt(5));
+
+    // int64(meta["age"]) > 30
+    std::vector<milvus::VectorPtr> compare_vec;
+    CastJsonFunction<milvus::DataType::INT64>(args, result);
+    compare_vec.push_back(result);
+    compare_vec.push_back(std::make_shared<milvus::ConstantVector<int64_t>>(
+        milvus::DataType::INT64, 6, 30));
+    milvus::RowVector compare_args(std::move(compare_vec));
+    CompareFunction<CompareOp::GT>(compare_args, result);
+    auto filter = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(filter, nullptr);
+    TargetBitmapView bitmap(filter->GetRawData(), filter->size());
+    EXPECT_TRUE(bitmap[0]);
+    EXPECT_TRUE(bitmap[1]);
+    EXPECT_FALSE(bitmap[2]);
+    EXPECT_FALSE(bitmap[3]);
+    EXPECT_FALSE(filter->ValidAt(4));
+
+    CastJsonFunction<milvus::DataType::BOOL>(args, result);
+    auto bool_vec = std::dynamic_pointer_cast<milvus::ColumnVector>(result);
+    ASSERT_NE(bool_vec, nullptr);
+    TargetBitmapView bool_bitmap(bool_vec->GetRawData(), bool_vec->size());
+    EXPECT_TRUE(bool_bitmap[0]);
+    EXPECT_FALSE(bool_vec->ValidAt(1));
+    EXPECT_TRUE(bool_bitmap[3]);
+}
@@ -0,0 +1,203 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/StringFunctions.h"
+
+#include <string>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// a code point range [first, last] whose letters are mapped to the other case
+// by adding delta, with step 2 the upper and lower letters alternate
+struct CaseRange {
+    char32_t first;
+    char32_t last;
+    int32_t delta;
+    int32_t step;
+};
+
+// simple case mappings of the Latin, Greek, Cyrillic and Armenian letters and
+// the fullwidth Latin letters, upper to lower
+static const CaseRange kLowerRanges[] = {
+    {0x0041, 0x005A, 32, 1},     {0x00C0, 0x00D6, 32, 1},
+    {0x00D8, 0x00DE, 32, 1},     {0x0100, 0x012E, 1, 2},
+    {0x0130, 0x0130, -199, 1},   {0x0132, 0x0136, 1, 2},
+    {0x0139, 0x0147, 1, 2},      {0x014A, 0x0176, 1, 2},
+    {0x0178, 0x0178, -121, 1},   {0x0179, 0x017D, 1, 2},
+    {0x0386, 0x0386, 38, 1},     {0x0388, 0x038A, 37, 1},
+    {0x038C, 0x038C, 64, 1},     {0x038E, 0x038F, 63, 1},
+    {0x0391, 0x03A1, 32, 1},     {0x03A3, 0x03AB, 32, 1},
+    {0x0400, 0x040F, 80, 1},     {0x0410, 0x042F, 32, 1},
+    {0x0460, 0x0480, 1, 2},      {0x048A, 0x04BE, 1, 2},
+    {0x0531, 0x0556, 48, 1},     {0x1E00, 0x1E94, 1, 2},
+    {0x1EA0, 0x1EFE, 1, 2},      {0xFF21, 0xFF3A, 32, 1},
+};
+
+// lower to upper
+static const CaseRange kUpperRanges[] = {
+    {0x0061, 0x007A, -32, 1},    {0x00E0, 0x00F6, -32, 1},
+    {0x00F8, 0x00FE, -32, 1},    {0x00FF, 0x00FF, 121, 1},
+    {0x0101, 0x012F, -1, 2},     {0x0131, 0x0131, -232, 1},
+    {0x0133, 0x0137, -1, 2},     {0x013A, 0x0148, -1, 2},
+    {0x014B, 0x0177, -1, 2},     {0x017A, 0x017E, -1, 2},
+    {0x03AC, 0x03AC, -38, 1},    {0x03AD, 0x03AF, -37, 1},
+    {0x03B1, 0x03C1, -32, 1},    {0x03C2, 0x03C2, -31, 1},
+    {0x03C3, 0x03CB, -32, 1},    {0x03CC, 0x03CC, -64, 1},
+    {0x03CD, 0x03CE, -63, 1},    {0x0430, 0x044F, -32, 1},
+    {0x0450, 0x045F, -80, 1},    {0x0461, 0x0481, -1, 2},
+    {0x048B, 0x04BF, -1, 2},     {0x0561, 0x0586, -48, 1},
+    {0x1E01, 0x1E95, -1, 2},     {0x1EA1, 0x1EFF, -1, 2},
+    {0xFF41, 0xFF5A, -32, 1},
+};
+
+template <size_t N>
+static char32_t
+MapCase(char32_t c, const CaseRange (&ranges)[N]) {
+    for (const auto& range : ranges) {
+        if (c < range.first) {
+            break;
+        }
+        if (c <= range.last && (c - range.first) % range.step == 0) {
+            return static_cast<char32_t>(static_cast<int32_t>(c) +
+                                         range.delta);
+        }
+    }
+    return c;
+}
+
+// DecodeUtf8 decodes the character at str[pos] and returns its length in
+// bytes, or 0 if the bytes are not a valid UTF-8 character.
+static size_t
+DecodeUtf8(const std::string& str, size_t pos, char32_t& c) {
+    auto lead = static_cast<unsigned char>(str[pos]);
+    size_t length;
+    if (lead < 0x80) {
+        c = lead;
+        return 1;
+    } else if ((lead & 0xE0) == 0xC0) {
+        c = lead & 0x1F;
+        length = 2;
+    } else if ((lead & 0xF0) == 0xE0) {
+        c = lead & 0x0F;
+        length = 3;
+    } else if ((lead & 0xF8) == 0xF0) {
+        c = lead & 0x07;
+        length = 4;
+    } else {
+        return 0;
+    }
+    if (pos + length > str.size()) {
+        return 0;
+    }
+    for (size_t i = 1; i < length; ++i) {
+        auto byte = static_cast<unsigned char>(str[pos + i]);
+        if ((byte & 0xC0) != 0x80) {
+            return 0;
+        }
+        c = (c << 6) | (byte & 0x3F);
+    }
+    return length;
+}
+
+static void
+EncodeUtf8(char32_t c, std::string& out) {
+    if (c < 0x80) {
+        out.push_back(static_cast<char>(c));
+    } else if (c < 0x800) {
+        out.push_back(static_cast<char>(0xC0 | (c >> 6)));
+        out.push_back(static_cast<char>(0x80 | (c & 0x3F)));
+    } else if (c < 0x10000) {
+        out.push_back(static_cast<char>(0xE0 | (c >> 12)));
+        out.push_back(static_cast<char>(0x80 | ((c >> 6) & 0x3F)));
+        out.push_back(static_cast<char>(0x80 | (c & 0x3F)));
+    } else {
+        out.push_back(static_cast<char>(0xF0 | (c >> 18)));
+        out.push_back(static_cast<char>(0x80 | ((c >> 12) & 0x3F)));
+        out.push_back(static_cast<char>(0x80 | ((c >> 6) & 0x3F)));
+        out.push_back(static_cast<char>(0x80 | (c & 0x3F)));
+    }
+}
+
+// ConvertCase maps every character of str by the ranges, the bytes which are
+// not valid UTF-8 are kept as they are.
+template <size_t N>
+static std::string
+ConvertCase(const std::string& str, const CaseRange (&ranges)[N]) {
+    std::string out;
+    out.reserve(str.size());
+    size_t pos = 0;
+    while (pos < str.size()) {
+        char32_t c;
+        auto length = DecodeUtf8(str, pos, c);
+        if (length == 0) {
+            out.push_back(str[pos++]);
+            continue;
+        }
+        if (length == 1) {
+            out.push_back(static_cast<char>(MapCase(c, ranges)));
+        } else {
+            EncodeUtf8(MapCase(c, ranges), out);
+        }
+        pos += length;
+    }
+    return out;
+}
+
+template <size_t N>
+void
+ConvertCaseVarchar(const RowVector& args,
+                   FilterFunctionReturn& result,
+                   const CaseRange (&ranges)[N]) {
+    CheckArgumentCount(args, 1);
+    auto strs = std::dynamic_pointer_cast<SimpleVector>(args.child(0));
+    Assert(strs != nullptr);
+    CheckVarcharOrStringType(strs);
+
+    auto size = strs->size();
+    auto res = std::make_shared<ColumnVector>(DataType::VARCHAR, size);
+    auto* res_data = res->RawAsValues<std::string>();
+    TargetBitmapView valid_view(res->GetValidRawData(), size);
+    for (size_t i = 0; i < size; ++i) {
+        if (!strs->ValidAt(i)) {
+            valid_view[i] = false;
+            continue;
+        }
+        auto* str_ptr = reinterpret_cast<std::string*>(
+            strs->RawValueAt(i, sizeof(std::string)));
+        res_data[i] = ConvertCase(*str_ptr, ranges);
+    }
+    result = res;
+}
+
+void
+LowerVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    ConvertCaseVarchar(args, result, kLowerRanges);
+}
+
+void
+UpperVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    ConvertCaseVarchar(args, result, kUpperRanges);
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
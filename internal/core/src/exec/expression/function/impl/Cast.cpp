@@ -0,0 +1,319 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/CastFunctions.h"
+
+#include <algorithm>
+#include <cctype>
+#include <cerrno>
+#include <charconv>
+#include <cmath>
+#include <cstdlib>
+#include <limits>
+#include <optional>
+#include <string>
+#include <type_traits>
+#include <variant>
+
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "fmt/core.h"
+#include "simdjson.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// JsonText is a JSON array or object, which can only be cast to varchar.
+struct JsonText {
+    std::string text;
+};
+
+using CastValue =
+    std::variant<bool, int64_t, float, double, std::string, JsonText>;
+
+static std::optional<CastValue>
+SourceValueAt(const std::shared_ptr<SimpleVector>& vec, size_t i) {
+    if (!vec->ValidAt(i)) {
+        return std::nullopt;
+    }
+    switch (vec->type()) {
+        case DataType::BOOL:
+            return NumericValueAt<bool>(vec, i);
+        case DataType::INT8:
+        case DataType::INT16:
+        case DataType::INT32:
+        case DataType::INT64:
+            return NumericValueAt<int64_t>(vec, i);
+        case DataType::FLOAT:
+            return NumericValueAt<float>(vec, i);
+        case DataType::DOUBLE:
+            return NumericValueAt<double>(vec, i);
+        case DataType::STRING:
+        case DataType::VARCHAR:
+            return *reinterpret_cast<std::string*>(
+                vec->RawValueAt(i, sizeof(std::string)));
+        default:
+            ThrowInfo(ExprInvalid,
+                      "unsupported argument type for cast function: {}",
+                      vec->type());
+    }
+}
+
+static std::optional<CastValue>
+JsonValueAt(const std::shared_ptr<SimpleVector>& vec,
+            size_t i,
+            simdjson::dom::parser& parser) {
+    if (!vec->ValidAt(i)) {
+        return std::nullopt;
+    }
+    auto& text = *reinterpret_cast<std::string*>(
+        vec->RawValueAt(i, sizeof(std::string)));
+    simdjson::dom::element element;
+    if (parser.parse(text).get(element) != simdjson::SUCCESS) {
+        return std::nullopt;
+    }
+    switch (element.type()) {
+        case simdjson::dom::element_type::BOOL:
+            return element.get_bool().value();
+        case simdjson::dom::element_type::INT64:
+            return element.get_int64().value();
+        case simdjson::dom::element_type::UINT64:
+            return static_cast<double>(element.get_uint64().value());
+        case simdjson::dom::element_type::DOUBLE:
+            return element.get_double().value();
+        case simdjson::dom::element_type::STRING:
+            return std::string(element.get_string().value());
+        case simdjson::dom::element_type::ARRAY:
+        case simdjson::dom::element_type::OBJECT:
+            return JsonText{text};
+        default:
+            return std::nullopt;
+    }
+}
+
+static std::optional<double>
+ParseDouble(const std::string& str) {
+    if (str.empty() || std::isspace(static_cast<unsigned char>(str[0]))) {
+        return std::nullopt;
+    }
+    char* end = nullptr;
+    errno = 0;
+    auto value = std::strtod(str.c_str(), &end);
+    if (end != str.c_str() + str.size() || errno == ERANGE) {
+        return std::nullopt;
+    }
+    return value;
+}
+
+static std::optional<double>
+ToDouble(const CastValue& value) {
+    if (auto v = std::get_if<bool>(&value)) {
+        return *v ? 1 : 0;
+    } else if (auto v = std::get_if<int64_t>(&value)) {
+        return static_cast<double>(*v);
+    } else if (auto v = std::get_if<float>(&value)) {
+        return *v;
+    } else if (auto v = std::get_if<double>(&value)) {
+        return *v;
+    } else if (auto v = std::get_if<std::string>(&value)) {
+        return ParseDouble(*v);
+    }
+    return std::nullopt;
+}
+
+static std::optional<int64_t>
+ToInt64(const CastValue& value) {
+    if (auto v = std::get_if<bool>(&value)) {
+        return *v ? 1 : 0;
+    } else if (auto v = std::get_if<int64_t>(&value)) {
+        return *v;
+    } else if (auto v = std::get_if<std::string>(&value)) {
+        int64_t result;
+        auto end = v->data() + v->size();
+        auto [ptr, ec] = std::from_chars(v->data(), end, result);
+        if (v->empty() || ec != std::errc() || ptr != end) {
+            return std::nullopt;
+        }
+        return result;
+    }
+    auto number = ToDouble(value);
+    // 2^63 is the first double out of the range of int64
+    if (!number.has_value() || !std::isfinite(number.value()) ||
+        number.value() < -9223372036854775808.0 ||
+        number.value() >= 9223372036854775808.0) {
+        return std::nullopt;
+    }
+    return static_cast<int64_t>(number.value());
+}
+
+static std::optional<bool>
+ToBool(const CastValue& value) {
+    if (auto v = std::get_if<bool>(&value)) {
+        return *v;
+    } else if (auto v = std::get_if<std::string>(&value)) {
+        std::string lower(v->size(), '\0');
+        std::transform(
+            v->begin(), v->end(), lower.begin(), [](unsigned char c) {
+                return static_cast<char>(std::tolower(c));
+            });
+        if (lower == "true") {
+            return true;
+        } else if (lower == "false") {
+            return false;
+        }
+        return std::nullopt;
+    } else if (std::holds_alternative<JsonText>(value)) {
+        return std::nullopt;
+    }
+    auto number = ToDouble(value);
+    if (!number.has_value() || std::isnan(number.value())) {
+        return std::nullopt;
+    }
+    return number.value() != 0;
+}
+
+static std::optional<std::string>
+ToVarchar(const CastValue& value) {
+    if (auto v = std::get_if<bool>(&value)) {
+        return *v ? "true" : "false";
+    } else if (auto v = std::get_if<int64_t>(&value)) {
+        return std::to_string(*v);
+    } else if (auto v = std::get_if<float>(&value)) {
+        return fmt::format("{}", *v);
+    } else if (auto v = std::get_if<double>(&value)) {
+        return fmt::format("{}", *v);
+    } else if (auto v = std::get_if<std::string>(&value)) {
+        return *v;
+    }
+    return std::get<JsonText>(value).text;
+}
+
+template <typename T>
+static std::optional<T>
+ConvertTo(const CastValue& value) {
+    if constexpr (std::is_same_v<T, bool>) {
+        return ToBool(value);
+    } else if constexpr (std::is_same_v<T, std::string>) {
+        return ToVarchar(value);
+    } else if constexpr (std::is_integral_v<T>) {
+        auto number = ToInt64(value);
+        if (!number.has_value() ||
+            number.value() < std::numeric_limits<T>::min() ||
+            number.value() > std::numeric_limits<T>::max()) {
+            return std::nullopt;
+        }
+        return static_cast<T>(number.value());
+    } else {
+        auto number = ToDouble(value);
+        if (!number.has_value()) {
+            return std::nullopt;
+        }
+        if (std::isfinite(number.value()) &&
+            std::abs(number.value()) > std::numeric_limits<T>::max()) {
+            return std::nullopt;
+        }
+        return static_cast<T>(number.value());
+    }
+}
+
+template <DataType target, typename GetValue>
+static void
+CastVector(const std::shared_ptr<SimpleVector>& vec,
+           FilterFunctionReturn& result,
+           GetValue get_value) {
+    using T = typename TypeTraits<target>::NativeType;
+    auto size = vec->size();
+    if constexpr (target == DataType::BOOL) {
+        // bool results are filters, which are bitmaps
+        TargetBitmap bitmap(size, false);
+        TargetBitmap valid_bitmap(size, true);
+        for (size_t i = 0; i < size; ++i) {
+            auto value = get_value(i);
+            auto converted = value.has_value()
+                                 ? ConvertTo<bool>(value.value())
+                                 : std::nullopt;
+            if (!converted.has_value()) {
+                valid_bitmap[i] = false;
+                continue;
+            }
+            bitmap[i] = converted.value();
+        }
+        result = std::make_shared<ColumnVector>(std::move(bitmap),
+                                                std::move(valid_bitmap));
+    } else {
+        auto res = std::make_shared<ColumnVector>(target, size);
+        auto* res_data = res->RawAsValues<T>();
+        TargetBitmapView valid_view(res->GetValidRawData(), size);
+        for (size_t i = 0; i < size; ++i) {
+            auto value = get_value(i);
+            auto converted = value.has_value()
+                                 ? ConvertTo<T>(value.value())
+                                 : std::nullopt;
+            if (!converted.has_value()) {
+                valid_view[i] = false;
+                continue;
+            }
+            res_data[i] = std::move(converted.value());
+        }
+        result = res;
+    }
+}
+
+template <DataType target>
+void
+CastFunction(const RowVector& args, FilterFunctionReturn& result) {
+    CheckArgumentCount(args, 1);
+    auto vec = std::dynamic_pointer_cast<SimpleVector>(args.child(0));
+    Assert(vec != nullptr);
+    CastVector<target>(
+        vec, result, [&](size_t i) { return SourceValueAt(vec, i); });
+}
+
+template <DataType target>
+void
+CastJsonFunction(const RowVector& args, FilterFunctionReturn& result) {
+    CheckArgumentCount(args, 1);
+    auto vec = std::dynamic_pointer_cast<SimpleVector>(args.child(0));
+    Assert(vec != nullptr);
+    CheckVarcharOrStringType(vec);
+    simdjson::dom::parser parser;
+    CastVector<target>(
+        vec, result, [&](size_t i) { return JsonValueAt(vec, i, parser); });
+}
+
+#define INSTANTIATE_CAST_FUNCTIONS(target)                               \
+    template void CastFunction<target>(const RowVector& args,            \
+                                       FilterFunctionReturn& result);    \
+    template void CastJsonFunction<target>(const RowVector& args,        \
+                                           FilterFunctionReturn& result);
+
+INSTANTIATE_CAST_FUNCTIONS(DataType::BOOL)
+INSTANTIATE_CAST_FUNCTIONS(DataType::INT8)
+INSTANTIATE_CAST_FUNCTIONS(DataType::INT16)
+INSTANTIATE_CAST_FUNCTIONS(DataType::INT32)
+INSTANTIATE_CAST_FUNCTIONS(DataType::INT64)
+INSTANTIATE_CAST_FUNCTIONS(DataType::FLOAT)
+INSTANTIATE_CAST_FUNCTIONS(DataType::DOUBLE)
+INSTANTIATE_CAST_FUNCTIONS(DataType::VARCHAR)
+
+#undef INSTANTIATE_CAST_FUNCTIONS
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -0,0 +1,45 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+#pragma once
+
+#include "common/Types.h"
+#include "common/Vector.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+// CastFunction casts the values of a scalar vector to the target type, the
+// values which cannot be converted are cast to null, e.g. int8(1000) and
+// int64("abc"). The fraction of the floating numbers is truncated when they
+// are cast to integers.
+template <DataType target>
+void
+CastFunction(const RowVector& args, FilterFunctionReturn& result);
+
+// CastJsonFunction casts the values at a JSON path, which are evaluated as
+// JSON text, to the target type. The JSON strings are converted as varchar,
+// the arrays and objects can only be cast to varchar as their JSON text.
+template <DataType target>
+void
+CastJsonFunction(const RowVector& args, FilterFunctionReturn& result);
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
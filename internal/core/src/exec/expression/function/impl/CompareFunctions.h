@@ -0,0 +1,124 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+#pragma once
+
+#include <algorithm>
+#include <string>
+#include <type_traits>
+
+#include "common/EasyAssert.h"
+#include "common/Vector.h"
+#include "exec/expression/function/FunctionFactory.h"
+#include "exec/expression/function/FunctionImplUtils.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+enum class CompareOp { EQ, NE, LT, LE, GT, GE };
+
+template <CompareOp op, typename T>
+bool
+CompareValue(const T& left, const T& right) {
+    switch (op) {
+        case CompareOp::EQ:
+            return left == right;
+        case CompareOp::NE:
+            return left != right;
+        case CompareOp::LT:
+            return left < right;
+        case CompareOp::LE:
+            return left <= right;
+        case CompareOp::GT:
+            return left > right;
+        case CompareOp::GE:
+            return left >= right;
+    }
+    return false;
+}
+
+template <typename T>
+T
+CompareValueAt(const std::shared_ptr<SimpleVector>& vec, size_t i) {
+    if constexpr (std::is_same_v<T, std::string>) {
+        return *reinterpret_cast<std::string*>(
+            vec->RawValueAt(i, sizeof(std::string)));
+    } else {
+        return NumericValueAt<T>(vec, i);
+    }
+}
+
+template <CompareOp op, typename T>
+void
+CompareVectors(const std::shared_ptr<SimpleVector>& left,
+               const std::shared_ptr<SimpleVector>& right,
+               FilterFunctionReturn& result) {
+    auto size = std::max(left->size(), right->size());
+    TargetBitmap bitmap(size, false);
+    TargetBitmap valid_bitmap(size, true);
+    for (size_t i = 0; i < size; ++i) {
+        if (left->ValidAt(i) && right->ValidAt(i)) {
+            bitmap.set(i,
+                       CompareValue<op, T>(CompareValueAt<T>(left, i),
+                                           CompareValueAt<T>(right, i)));
+        } else {
+            valid_bitmap[i] = false;
+        }
+    }
+    result = std::make_shared<ColumnVector>(std::move(bitmap),
+                                            std::move(valid_bitmap));
+}
+
+// CompareFunction compares two vectors row by row, it is used to filter on the
+// results of scalar functions. The integers are compared as int64, the other
+// numbers are compared as double.
+template <CompareOp op>
+void
+CompareFunction(const RowVector& args, FilterFunctionReturn& result) {
+    if (args.childrens().size() != 2) {
+        ThrowInfo(ExprInvalid,
+                  "invalid argument count, expect 2, actual {}",
+                  args.childrens().size());
+    }
+    auto left = std::dynamic_pointer_cast<SimpleVector>(args.child(0));
+    Assert(left != nullptr);
+    auto right = std::dynamic_pointer_cast<SimpleVector>(args.child(1));
+    Assert(right != nullptr);
+
+    if (IsStringDataType(left->type())) {
+        CheckVarcharOrStringType(right);
+        CompareVectors<op, std::string>(left, right, result);
+    } else if (IsNumericDataType(left->type()) &&
+               IsNumericDataType(right->type())) {
+        if (IsIntegerDataType(left->type()) &&
+            IsIntegerDataType(right->type())) {
+            CompareVectors<op, int64_t>(left, right, result);
+        } else {
+            CompareVectors<op, double>(left, right, result);
+        }
+    } else {
+        ThrowInfo(ExprInvalid,
+                  "unsupported argument types for compare function: {}, {}",
+                  left->type(),
+                  right->type());
+    }
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -0,0 +1,54 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/StringFunctions.h"
+
+#include <string>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+void
+LengthVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    CheckArgumentCount(args, 1);
+    auto strs = std::dynamic_pointer_cast<SimpleVector>(args.child(0));
+    Assert(strs != nullptr);
+    CheckVarcharOrStringType(strs);
+
+    auto size = strs->size();
+    auto res = std::make_shared<ColumnVector>(DataType::INT64, size);
+    auto* res_data = res->RawAsValues<int64_t>();
+    TargetBitmapView valid_view(res->GetValidRawData(), size);
+    for (size_t i = 0; i < size; ++i) {
+        if (!strs->ValidAt(i)) {
+            valid_view[i] = false;
+            continue;
+        }
+        auto* str_ptr = reinterpret_cast<std::string*>(
+            strs->RawValueAt(i, sizeof(std::string)));
+        res_data[i] = Utf8Length(*str_ptr);
+    }
+    result = res;
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
@@ -29,6 +29,26 @@ EmptyVarchar(const RowVector& args, FilterFunctionReturn& result);
 void
 StartsWithVarchar(const RowVector& args, FilterFunctionReturn& result);
 
+void
+EndsWithVarchar(const RowVector& args, FilterFunctionReturn& result);
+
+// lower/upper convert the Latin, Greek, Cyrillic and Armenian letters by the
+// simple case mapping of Unicode, the other characters are kept as they are
+void
+LowerVarchar(const RowVector& args, FilterFunctionReturn& result);
+
+void
+UpperVarchar(const RowVector& args, FilterFunctionReturn& result);
+
+// length returns the number of UTF-8 characters
+void
+LengthVarchar(const RowVector& args, FilterFunctionReturn& result);
+
+// substring(str, start[, length]) follows the SQL semantic, start is 1-based
+// and counted in UTF-8 characters
+void
+SubstringVarchar(const RowVector& args, FilterFunctionReturn& result);
+
 }  // namespace function
 }  // namespace expression
 }  // namespace exec
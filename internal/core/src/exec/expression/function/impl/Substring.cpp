@@ -0,0 +1,94 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+#include "exec/expression/function/FunctionImplUtils.h"
+#include "exec/expression/function/impl/StringFunctions.h"
+
+#include <algorithm>
+#include <limits>
+#include <string>
+#include "common/EasyAssert.h"
+#include "exec/expression/function/FunctionFactory.h"
+
+namespace milvus {
+namespace exec {
+namespace expression {
+namespace function {
+
+void
+SubstringVarchar(const RowVector& args, FilterFunctionReturn& result) {
+    if (args.childrens().size() != 2 && args.childrens().size() != 3) {
+        ThrowInfo(ExprInvalid,
+                  "invalid argument count, expect 2 or 3, actual {}",
+                  args.childrens().size());
+    }
+    auto strs = std::dynamic_pointer_cast<SimpleVector>(args.child(0));
+    Assert(strs != nullptr);
+    CheckVarcharOrStringType(strs);
+    auto starts = std::dynamic_pointer_cast<SimpleVector>(args.child(1));
+    Assert(starts != nullptr);
+    CheckInt64Type(starts);
+    std::shared_ptr<SimpleVector> lengths = nullptr;
+    if (args.childrens().size() == 3) {
+        lengths = std::dynamic_pointer_cast<SimpleVector>(args.child(2));
+        Assert(lengths != nullptr);
+        CheckInt64Type(lengths);
+    }
+
+    auto size = strs->size();
+    auto res = std::make_shared<ColumnVector>(DataType::VARCHAR, size);
+    auto* res_data = res->RawAsValues<std::string>();
+    TargetBitmapView valid_view(res->GetValidRawData(), size);
+    for (size_t i = 0; i < size; ++i) {
+        if (!strs->ValidAt(i) || !starts->ValidAt(i) ||
+            (lengths != nullptr && !lengths->ValidAt(i))) {
+            valid_view[i] = false;
+            continue;
+        }
+        auto* str_ptr = reinterpret_cast<std::string*>(
+            strs->RawValueAt(i, sizeof(std::string)));
+        auto start =
+            *reinterpret_cast<int64_t*>(starts->RawValueAt(i, sizeof(int64_t)));
+        auto end = std::numeric_limits<int64_t>::max();
+        if (lengths != nullptr) {
+            auto length = *reinterpret_cast<int64_t*>(
+                lengths->RawValueAt(i, sizeof(int64_t)));
+            if (length < 0) {
+                ThrowInfo(ExprInvalid,
+                          "negative substring length not allowed: {}",
+                          length);
+            }
+            end = start > std::numeric_limits<int64_t>::max() - length
+                      ? std::numeric_limits<int64_t>::max()
+                      : start + length;
+        }
+        // the window [start, end) is 1-based, clip it to the string
+        start = std::max<int64_t>(start, 1);
+        if (end <= start) {
+            continue;
+        }
+        auto begin_pos = Utf8Offset(*str_ptr, start - 1);
+        auto end_pos = end == std::numeric_limits<int64_t>::max()
+                           ? str_ptr->size()
+                           : Utf8Offset(*str_ptr, end - 1);
+        res_data[i] = str_ptr->substr(begin_pos, end_pos - begin_pos);
+    }
+    result = res;
+}
+
+}  // namespace function
+}  // namespace expression
+}  // namespace exec
+}  // namespace milvus
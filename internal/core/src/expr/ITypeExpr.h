@@ -341,7 +341,8 @@ class ValueExpr : public ITypeExpr {
                 type_ = DataType::INT64;
                 break;
             case proto::plan::GenericValue::ValCase::kFloatVal:
-                type_ = DataType::FLOAT;
+                // float_val is a double, keep its precision
+                type_ = DataType::DOUBLE;
                 break;
             case proto::plan::GenericValue::ValCase::kStringVal:
                 type_ = DataType::VARCHAR;
@@ -691,12 +692,17 @@ class NullExpr : public ITypeFilterExpr {
     NullExprType op_;
 };
 
-class CallExpr : public ITypeFilterExpr {
+// CallExpr is a predicate when the return type is BOOL, otherwise it produces
+// the values of a scalar function which are consumed by other call exprs.
+class CallExpr : public ITypeExpr {
  public:
     CallExpr(const std::string fun_name,
              const std::vector<TypedExprPtr>& parameters,
-             const exec::expression::FilterFunctionPtr function_ptr)
-        : fun_name_(std::move(fun_name)), function_ptr_(function_ptr) {
+             const exec::expression::FilterFunctionPtr function_ptr,
+             DataType return_type = DataType::BOOL)
+        : ITypeExpr(return_type),
+          fun_name_(std::move(fun_name)),
+          function_ptr_(function_ptr) {
         inputs_.insert(inputs_.end(), parameters.begin(), parameters.end());
     }
 
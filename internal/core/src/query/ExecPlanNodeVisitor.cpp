@@ -91,6 +91,7 @@ ExecPlanNodeVisitor::ExecuteTask(
     int64_t processed_num = 0;
     BitsetType bitset_holder;
     for (;;) {
+        query_context->throw_if_cancelled();
         auto result = task->Next();
         if (!result) {
             Assert(processed_num == query_context->get_active_count());
@@ -140,8 +141,13 @@ ExecPlanNodeVisitor::VectorVisitorImpl(VectorPlanNode& node) {
                                                      collection_ttl_timestamp_,
                                                      consystency_level_,
                                                      node.plan_options_);
+    query_context->set_cancellation_token(cancel_token_);
 
-    query_context->set_search_info(node.search_info_);
+    // the vector search runs in one batch, let it check the token per chunk.
+    // the plan node is shared by all segments, so set it on a copy.
+    auto search_info = node.search_info_;
+    search_info.cancel_token_ = cancel_token_;
+    query_context->set_search_info(search_info);
     query_context->set_placeholder_group(placeholder_group_);
 
     // Do plan fragment task work
@@ -198,6 +204,7 @@ ExecPlanNodeVisitor::visit(RetrievePlanNode& node) {
                                                      collection_ttl_timestamp_,
                                                      consystency_level_,
                                                      node.plan_options_);
+    query_context->set_cancellation_token(cancel_token_);
 
     // Do task execution
     auto bitset_holder = ExecuteTask(plan, query_context);
@@ -103,6 +103,11 @@ class ExecPlanNodeVisitor : public PlanNodeVisitor {
         return expr_use_pk_index_;
     }
 
+    void
+    SetCancellationToken(const folly::CancellationToken& cancel_token) {
+        cancel_token_ = cancel_token;
+    }
+
     static BitsetType
     ExecuteTask(plan::PlanFragment& plan,
                 std::shared_ptr<milvus::exec::QueryContext> query_context);
@@ -122,6 +127,7 @@ class ExecPlanNodeVisitor : public PlanNodeVisitor {
     RetrieveResultOpt retrieve_result_opt_;
     bool expr_use_pk_index_ = false;
     int32_t consystency_level_ = 0;
+    folly::CancellationToken cancel_token_;
 };
 
 // for test use only
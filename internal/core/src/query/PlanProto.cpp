@@ -440,7 +440,10 @@ ProtoParser::ParseCallExprs(const proto::plan::CallExpr& expr_pb) {
                   "function " + func_sig.ToString() + " not found. ");
     }
     return std::make_shared<expr::CallExpr>(
-        expr_pb.function_name(), parameters, function);
+        expr_pb.function_name(),
+        parameters,
+        function,
+        factory.GetFilterFunctionReturnType(func_sig));
 }
 
 expr::TypedExprPtr
@@ -15,6 +15,7 @@
 #include "common/Types.h"
 #include "SearchOnGrowing.h"
 #include <cstddef>
+#include <folly/futures/FutureException.h>
 #include "knowhere/comp/index_param.h"
 #include "knowhere/config.h"
 #include "log/Log.h"
@@ -163,6 +164,9 @@ SearchOnGrowing(const segcore::SegmentGrowingImpl& segment,
 
         for (int chunk_id = current_chunk_id; chunk_id < max_chunk;
              ++chunk_id) {
+            if (info.cancel_token_.isCancellationRequested()) {
+                throw folly::FutureCancellation();
+            }
             auto chunk_data = vec_ptr->get_chunk_data(chunk_id);
 
             auto element_begin = chunk_id * vec_size_per_chunk;
@@ -12,6 +12,7 @@
 #include <algorithm>
 #include <cmath>
 #include <string>
+#include <folly/futures/FutureException.h>
 
 #include "bitset/detail/element_wise.h"
 #include "cachinglayer/Utils.h"
@@ -148,6 +149,9 @@ SearchOnSealedColumn(const Schema& schema,
 
     auto offset = 0;
     for (int i = 0; i < num_chunk; ++i) {
+        if (search_info.cancel_token_.isCancellationRequested()) {
+            throw folly::FutureCancellation();
+        }
         auto pw = column->DataOfChunk(i);
         auto vec_data = pw.get();
         auto chunk_size = column->chunk_row_nums(i);
@@ -15,6 +15,8 @@
 // limitations under the License.
 #include "segcore/SegmentChunkReader.h"
 
+#include "common/Json.h"
+
 namespace milvus::segcore {
 template <typename T>
 MultipleChunkDataAccessor
@@ -333,4 +335,106 @@ SegmentChunkReader::GetChunkDataAccessor(
     }
 }
 
+// JsonValueAt returns the JSON text of the value at the pointer, the missing
+// and null values are returned as nullopt.
+static data_access_type
+JsonValueAt(const Json& json, const std::string& pointer) {
+    auto res = json.at_string_any(pointer);
+    if (res.error() != simdjson::SUCCESS) {
+        return std::nullopt;
+    }
+    std::string value = res.value();
+    if (value.empty() || value == "null") {
+        return std::nullopt;
+    }
+    return value;
+}
+
+MultipleChunkDataAccessor
+SegmentChunkReader::GetJsonMultipleChunkDataAccessor(
+    FieldId field_id,
+    int64_t& current_chunk_id,
+    int64_t& current_chunk_pos,
+    const std::string& pointer) const {
+    if (segment_->type() == SegmentType::Growing &&
+        !storage::MmapManager::GetInstance()
+             .GetMmapConfig()
+             .growing_enable_mmap) {
+        auto pw = segment_->chunk_data<Json>(field_id, current_chunk_id);
+        auto current_chunk_size =
+            segment_->chunk_size(field_id, current_chunk_id);
+        return [=,
+                pw = std::move(pw),
+                &current_chunk_id,
+                &current_chunk_pos]() mutable -> const data_access_type {
+            if (current_chunk_pos >= current_chunk_size) {
+                current_chunk_id++;
+                current_chunk_pos = 0;
+                pw = segment_->chunk_data<Json>(field_id, current_chunk_id);
+                current_chunk_size =
+                    segment_->chunk_size(field_id, current_chunk_id);
+            }
+            auto chunk_valid_data = pw.get().valid_data();
+            if (chunk_valid_data && !chunk_valid_data[current_chunk_pos]) {
+                current_chunk_pos++;
+                return std::nullopt;
+            }
+            return JsonValueAt(pw.get().data()[current_chunk_pos++], pointer);
+        };
+    }
+    auto pw = segment_->chunk_view<Json>(field_id, current_chunk_id);
+    auto current_chunk_size = segment_->chunk_size(field_id, current_chunk_id);
+    return [=,
+            pw = std::move(pw),
+            &current_chunk_id,
+            &current_chunk_pos]() mutable -> const data_access_type {
+        if (current_chunk_pos >= current_chunk_size) {
+            current_chunk_id++;
+            current_chunk_pos = 0;
+            pw = segment_->chunk_view<Json>(field_id, current_chunk_id);
+            current_chunk_size =
+                segment_->chunk_size(field_id, current_chunk_id);
+        }
+        auto& chunk_data = pw.get().first;
+        auto& chunk_valid_data = pw.get().second;
+        if (current_chunk_pos < chunk_valid_data.size() &&
+            !chunk_valid_data[current_chunk_pos]) {
+            current_chunk_pos++;
+            return std::nullopt;
+        }
+        return JsonValueAt(chunk_data[current_chunk_pos++], pointer);
+    };
+}
+
+ChunkDataAccessor
+SegmentChunkReader::GetJsonChunkDataAccessor(FieldId field_id,
+                                             int chunk_id,
+                                             const std::string& pointer) const {
+    if (segment_->type() == SegmentType::Growing &&
+        !storage::MmapManager::GetInstance()
+             .GetMmapConfig()
+             .growing_enable_mmap) {
+        auto pw = segment_->chunk_data<Json>(field_id, chunk_id);
+        return [pw = std::move(pw),
+                pointer](int i) mutable -> const data_access_type {
+            auto chunk_data = pw.get().data();
+            auto chunk_valid_data = pw.get().valid_data();
+            if (chunk_valid_data && !chunk_valid_data[i]) {
+                return std::nullopt;
+            }
+            return JsonValueAt(chunk_data[i], pointer);
+        };
+    }
+    auto pw = segment_->chunk_view<Json>(field_id, chunk_id);
+    return [pw = std::move(pw),
+            pointer](int i) mutable -> const data_access_type {
+        auto& chunk_data = pw.get().first;
+        auto& chunk_valid_data = pw.get().second;
+        if (i < chunk_valid_data.size() && !chunk_valid_data[i]) {
+            return std::nullopt;
+        }
+        return JsonValueAt(chunk_data[i], pointer);
+    };
+}
+
 }  // namespace milvus::segcore
@@ -62,6 +62,19 @@ class SegmentChunkReader {
                          const std::vector<PinWrapper<const index::IndexBase*>>&
                              pinned_index) const;
 
+    // the accessors of the values at the JSON pointer, the values are returned
+    // as JSON text, the missing and null values are returned as nullopt
+    MultipleChunkDataAccessor
+    GetJsonMultipleChunkDataAccessor(FieldId field_id,
+                                     int64_t& current_chunk_id,
+                                     int64_t& current_chunk_pos,
+                                     const std::string& pointer) const;
+
+    ChunkDataAccessor
+    GetJsonChunkDataAccessor(FieldId field_id,
+                             int chunk_id,
+                             const std::string& pointer) const;
+
     void
     MoveCursorForMultipleChunk(int64_t& current_chunk_id,
                                int64_t& current_chunk_pos,
@@ -14,6 +14,7 @@
 #include <cstdint>
 
 #include "Utils.h"
+#include "folly/futures/FutureException.h"
 #include "common/EasyAssert.h"
 #include "common/SystemProperty.h"
 #include "common/Tracer.h"
@@ -85,12 +86,14 @@ SegmentInternalInterface::Search(
     const query::PlaceholderGroup* placeholder_group,
     Timestamp timestamp,
     int32_t consistency_level,
-    Timestamp collection_ttl) const {
+    Timestamp collection_ttl,
+    const folly::CancellationToken& cancel_token) const {
     std::shared_lock lck(mutex_);
     milvus::tracer::AddEvent("obtained_segment_lock_mutex");
     check_search(plan);
     query::ExecPlanNodeVisitor visitor(
         *this, timestamp, placeholder_group, consistency_level, collection_ttl);
+    visitor.SetCancellationToken(cancel_token);
     auto results = std::make_unique<SearchResult>();
     *results = visitor.get_moved_result(*plan->plan_node_);
     results->segment_ = (void*)this;
@@ -98,18 +101,21 @@ SegmentInternalInterface::Search(
 }
 
 std::unique_ptr<proto::segcore::RetrieveResults>
-SegmentInternalInterface::Retrieve(tracer::TraceContext* trace_ctx,
-                                   const query::RetrievePlan* plan,
-                                   Timestamp timestamp,
-                                   int64_t limit_size,
-                                   bool ignore_non_pk,
-                                   int32_t consistency_level,
-                                   Timestamp collection_ttl) const {
+SegmentInternalInterface::Retrieve(
+    tracer::TraceContext* trace_ctx,
+    const query::RetrievePlan* plan,
+    Timestamp timestamp,
+    int64_t limit_size,
+    bool ignore_non_pk,
+    int32_t consistency_level,
+    Timestamp collection_ttl,
+    const folly::CancellationToken& cancel_token) const {
     std::shared_lock lck(mutex_);
     tracer::AutoSpan span("Retrieve", tracer::GetRootSpan());
     auto results = std::make_unique<proto::segcore::RetrieveResults>();
     query::ExecPlanNodeVisitor visitor(
         *this, timestamp, consistency_level, collection_ttl);
+    visitor.SetCancellationToken(cancel_token);
     auto retrieve_results = visitor.get_retrieve_result(*plan->plan_node_);
     retrieve_results.segment_ = (void*)this;
     results->set_has_more_result(retrieve_results.has_more_result);
@@ -136,6 +142,11 @@ SegmentInternalInterface::Retrieve(tracer::TraceContext* trace_ctx,
     results->mutable_offset()->Add(retrieve_results.result_offsets_.begin(),
                                    retrieve_results.result_offsets_.end());
 
+    // filling the target entries may load the output fields
+    if (cancel_token.isCancellationRequested()) {
+        throw folly::FutureCancellation();
+    }
+
     std::chrono::high_resolution_clock::time_point get_target_entry_start =
         std::chrono::high_resolution_clock::now();
     FillTargetEntry(trace_ctx,
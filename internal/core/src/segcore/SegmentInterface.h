@@ -31,6 +31,7 @@
 #include "common/BitsetView.h"
 #include "common/QueryResult.h"
 #include "common/QueryInfo.h"
+#include "folly/CancellationToken.h"
 #include "folly/SharedMutex.h"
 #include "common/type_c.h"
 #include "mmap/ChunkedColumnInterface.h"
@@ -74,7 +75,8 @@ class SegmentInterface {
            const query::PlaceholderGroup* placeholder_group,
            Timestamp timestamp,
            int32_t consistency_level = 0,
-           Timestamp collection_ttl = 0) const = 0;
+           Timestamp collection_ttl = 0,
+           const folly::CancellationToken& cancel_token = {}) const = 0;
 
     virtual std::unique_ptr<proto::segcore::RetrieveResults>
     Retrieve(tracer::TraceContext* trace_ctx,
@@ -83,7 +85,8 @@ class SegmentInterface {
              int64_t limit_size,
              bool ignore_non_pk,
              int32_t consistency_level = 0,
-             Timestamp collection_ttl = 0) const = 0;
+             Timestamp collection_ttl = 0,
+             const folly::CancellationToken& cancel_token = {}) const = 0;
 
     virtual std::unique_ptr<proto::segcore::RetrieveResults>
     Retrieve(tracer::TraceContext* trace_ctx,
@@ -279,7 +282,8 @@ class SegmentInternalInterface : public SegmentInterface {
            const query::PlaceholderGroup* placeholder_group,
            Timestamp timestamp,
            int32_t consistency_level = 0,
-           Timestamp collection_ttl = 0) const override;
+           Timestamp collection_ttl = 0,
+           const folly::CancellationToken& cancel_token = {}) const override;
 
     void
     FillPrimaryKeys(const query::Plan* plan,
@@ -296,7 +300,8 @@ class SegmentInternalInterface : public SegmentInterface {
              int64_t limit_size,
              bool ignore_non_pk,
              int32_t consistency_level = 0,
-             Timestamp collection_ttl = 0) const override;
+             Timestamp collection_ttl = 0,
+             const folly::CancellationToken& cancel_token = {}) const override;
 
     std::unique_ptr<proto::segcore::RetrieveResults>
     Retrieve(tracer::TraceContext* trace_ctx,
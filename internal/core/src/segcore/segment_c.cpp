@@ -146,8 +146,12 @@ AsyncSearch(CTraceContext c_trace,
 
             segment->LazyCheckSchema(plan->schema_);
 
-            auto search_result = segment->Search(
-                plan, phg_ptr, timestamp, consistency_level, collection_ttl);
+            auto search_result = segment->Search(plan,
+                                                 phg_ptr,
+                                                 timestamp,
+                                                 consistency_level,
+                                                 collection_ttl,
+                                                 cancel_token);
             if (!milvus::PositivelyRelated(
                     plan->plan_node_->search_info_.metric_type_)) {
                 for (auto& dis : search_result->distances_) {
@@ -223,7 +227,8 @@ AsyncRetrieve(CTraceContext c_trace,
                                                      limit_size,
                                                      ignore_non_pk,
                                                      consistency_level,
-                                                     collection_ttl);
+                                                     collection_ttl,
+                                                     cancel_token);
 
             return CreateLeakedCRetrieveResultFromProto(
                 std::move(retrieve_result));
@@ -251,6 +256,7 @@ AsyncRetrieveByOffsets(CTraceContext c_trace,
             milvus::tracer::AutoSpan span(
                 "SegCoreRetrieveByOffsets", &trace_ctx, true);
 
+            cancel_token.throwIfCancelled();
             auto retrieve_result =
                 segment->Retrieve(&trace_ctx, plan, offsets, len);
 
@@ -9,6 +9,8 @@
 // is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
 // or implied. See the License for the specific language governing permissions and limitations under the License
 
+#include <folly/CancellationToken.h>
+#include <folly/futures/FutureException.h>
 #include <gtest/gtest.h>
 
 #include "pb/schema.pb.h"
@@ -133,6 +135,52 @@ TEST(Query, ExecWithPredicateLoader) {
     ASSERT_EQ(json.dump(2), ref.dump(2));
 }
 
+TEST(Query, ExecCancelled) {
+    auto schema = std::make_shared<Schema>();
+    schema->AddDebugField(
+        "fakevec", DataType::VECTOR_FLOAT, 16, knowhere::metric::L2);
+    auto counter_fid = schema->AddDebugField("counter", DataType::INT64);
+    schema->set_primary_field_id(counter_fid);
+    const char* raw_plan = R"(vector_anns: <
+                                    field_id: 100
+                                    query_info: <
+                                      topk: 5
+                                      round_decimal: 3
+                                      metric_type: "L2"
+                                      search_params: "{\"nprobe\": 10}"
+                                    >
+                                    placeholder_tag: "$0"
+     >)";
+    int64_t N = 1000;
+    auto dataset = DataGen(schema, N);
+    auto segment = CreateGrowingSegment(schema, empty_index_meta);
+    segment->PreInsert(N);
+    segment->Insert(0,
+                    N,
+                    dataset.row_ids_.data(),
+                    dataset.timestamps_.data(),
+                    dataset.raw_);
+
+    auto plan_str = translate_text_plan_to_binary_plan(raw_plan);
+    auto plan =
+        CreateSearchPlanByExpr(schema, plan_str.data(), plan_str.size());
+    auto ph_group_raw = CreatePlaceholderGroup(1, 16, 1024);
+    auto ph_group =
+        ParsePlaceholderGroup(plan.get(), ph_group_raw.SerializeAsString());
+    Timestamp timestamp = 1000000;
+
+    folly::CancellationSource source;
+    auto sr = segment->Search(
+        plan.get(), ph_group.get(), timestamp, 0, 0, source.getToken());
+    ASSERT_EQ(sr->total_nq_, 1);
+
+    source.requestCancellation();
+    ASSERT_THROW(
+        segment->Search(
+            plan.get(), ph_group.get(), timestamp, 0, 0, source.getToken()),
+        folly::FutureCancellation);
+}
+
 TEST(Query, ExecWithPredicateSmallN) {
     auto schema = std::make_shared<Schema>();
     schema->AddDebugField(
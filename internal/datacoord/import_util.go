@@ -737,6 +737,54 @@ func LogResultSegmentsInfo(jobID int64, meta *meta, segmentIDs []int64) {
 		zap.Int64("totalRows", totalRows), zap.Int64("totalSize", totalSize))
 }
 
+// SplitJSONLImportFiles splits the large JSON Lines files into multiple byte ranges,
+// so that one large file can be pre-imported and imported by multiple tasks in parallel.
+// The other files are returned as they are.
+func SplitJSONLImportFiles(ctx context.Context, cm storage.ChunkManager,
+	reqFiles []*internalpb.ImportFile, options []*commonpb.KeyValuePair,
+) ([]*internalpb.ImportFile, error) {
+	splitSize := paramtable.Get().DataCoordCfg.JSONLSplitSizeInMB.GetAsInt64() * 1024 * 1024
+	if splitSize <= 0 || importutilv2.IsBackup(options) || importutilv2.IsL0Import(options) {
+		return reqFiles, nil
+	}
+	resFiles := make([]*internalpb.ImportFile, 0, len(reqFiles))
+	for _, importFile := range reqFiles {
+		fileType, err := importutilv2.GetFileType(importFile)
+		if err != nil || fileType != importutilv2.JSONL ||
+			importFile.GetStartOffset() != 0 || importFile.GetEndOffset() != 0 {
+			resFiles = append(resFiles, importFile)
+			continue
+		}
+		size, err := cm.Size(ctx, importFile.GetPaths()[0])
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("get size of file %s failed, err=%v", importFile.GetPaths()[0], err))
+		}
+		if size <= splitSize {
+			resFiles = append(resFiles, importFile)
+			continue
+		}
+		for start := int64(0); start < size; start += splitSize {
+			end := start + splitSize
+			if end >= size {
+				// read to the end of the file
+				end = 0
+			}
+			resFiles = append(resFiles, &internalpb.ImportFile{
+				Paths:       importFile.GetPaths(),
+				StartOffset: start,
+				EndOffset:   end,
+			})
+		}
+		log.Ctx(ctx).Info("split jsonl file for import", zap.String("path", importFile.GetPaths()[0]),
+			zap.Int64("size", size), zap.Int64("splitSize", splitSize))
+	}
+	if len(resFiles) > paramtable.Get().DataCoordCfg.MaxFilesPerImportReq.GetAsInt() {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("The max number of import files should not exceed %d, but got %d after splitting the JSON Lines files",
+			paramtable.Get().DataCoordCfg.MaxFilesPerImportReq.GetAsInt(), len(resFiles)))
+	}
+	return resFiles, nil
+}
+
 // ValidateBinlogImportRequest validates the binlog import request.
 func ValidateBinlogImportRequest(ctx context.Context, cm storage.ChunkManager,
 	reqFiles []*msgpb.ImportFile, options []*commonpb.KeyValuePair,
@@ -1215,6 +1215,78 @@ func TestImportUtil_ListBinlogImportRequestFiles(t *testing.T) {
 	})
 }
 
+func TestImportUtil_SplitJSONLImportFiles(t *testing.T) {
+	ctx := context.Background()
+	paramtable.Init()
+	pt := paramtable.Get()
+	pt.Save(pt.DataCoordCfg.JSONLSplitSizeInMB.Key, "1")
+	defer pt.Reset(pt.DataCoordCfg.JSONLSplitSizeInMB.Key)
+
+	t.Run("not jsonl files", func(t *testing.T) {
+		reqFiles := []*internalpb.ImportFile{
+			{
+				Paths: []string{"a.json"},
+			},
+			{
+				Paths: []string{"a.parquet"},
+			},
+		}
+		files, err := SplitJSONLImportFiles(ctx, nil, reqFiles, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, reqFiles, files)
+	})
+
+	t.Run("get size failed", func(t *testing.T) {
+		mockCM := mocks2.NewChunkManager(t)
+		mockCM.EXPECT().Size(mock.Anything, "a.jsonl").Return(0, errors.New("mock error"))
+		reqFiles := []*internalpb.ImportFile{
+			{
+				Paths: []string{"a.jsonl"},
+			},
+		}
+		_, err := SplitJSONLImportFiles(ctx, mockCM, reqFiles, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("split", func(t *testing.T) {
+		mockCM := mocks2.NewChunkManager(t)
+		mockCM.EXPECT().Size(mock.Anything, "a.jsonl").Return(2*1024*1024+1, nil)
+		mockCM.EXPECT().Size(mock.Anything, "b.ndjson").Return(1024, nil)
+		reqFiles := []*internalpb.ImportFile{
+			{
+				Paths: []string{"a.jsonl"},
+			},
+			{
+				Paths: []string{"b.ndjson"},
+			},
+		}
+		files, err := SplitJSONLImportFiles(ctx, mockCM, reqFiles, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, 4, len(files))
+		assert.Equal(t, int64(0), files[0].GetStartOffset())
+		assert.Equal(t, int64(1024*1024), files[0].GetEndOffset())
+		assert.Equal(t, int64(1024*1024), files[1].GetStartOffset())
+		assert.Equal(t, int64(2*1024*1024), files[1].GetEndOffset())
+		assert.Equal(t, int64(2*1024*1024), files[2].GetStartOffset())
+		assert.Equal(t, int64(0), files[2].GetEndOffset())
+		assert.Equal(t, []string{"b.ndjson"}, files[3].GetPaths())
+		assert.Equal(t, int64(0), files[3].GetEndOffset())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		pt.Save(pt.DataCoordCfg.JSONLSplitSizeInMB.Key, "0")
+		defer pt.Save(pt.DataCoordCfg.JSONLSplitSizeInMB.Key, "1")
+		reqFiles := []*internalpb.ImportFile{
+			{
+				Paths: []string{"a.jsonl"},
+			},
+		}
+		files, err := SplitJSONLImportFiles(ctx, nil, reqFiles, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, reqFiles, files)
+	})
+}
+
 // TestImportUtil_ValidateMaxImportJobExceed tests validation of maximum import jobs
 func TestImportUtil_ValidateMaxImportJobExceed(t *testing.T) {
 	ctx := context.Background()
@@ -1885,6 +1885,12 @@ func (s *Server) ImportV2(ctx context.Context, in *internalpb.ImportRequestInter
 			resp.Status = merr.Status(err)
 			return resp, nil
 		}
+	} else {
+		files, err = SplitJSONLImportFiles(ctx, s.meta.chunkManager, files, in.GetOptions())
+		if err != nil {
+			resp.Status = merr.Status(err)
+			return resp, nil
+		}
 	}
 
 	// Allocate file ids.
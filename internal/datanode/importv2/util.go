@@ -415,7 +415,8 @@ func RunEmbeddingFunction(task *ImportTask, data *storage.InsertData) error {
 func RunDenseEmbedding(task *ImportTask, data *storage.InsertData) error {
 	schema := task.GetSchema()
 	if embedding.HasNonBM25Functions(schema.Functions, []int64{}) {
-		exec, err := embedding.NewFunctionExecutor(schema)
+		// the db of the collection is unknown in import, the embeddings are identified by the collection and function
+		exec, err := embedding.NewFunctionExecutor("", schema)
 		if err != nil {
 			return err
 		}
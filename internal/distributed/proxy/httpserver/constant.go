@@ -38,6 +38,7 @@ const (
 	ResourceGroupCategory   = "/resource_groups/"
 	SegmentCategory         = "/segments/"
 	QuotaCenterCategory     = "/quotacenter/"
+	ClientCategory          = "/clients/"
 
 	ListAction           = "list"
 	HasAction            = "has"
@@ -77,6 +78,7 @@ const (
 	AddPrivilegesToGroupAction      = "add_privileges_to_group"
 	RemovePrivilegesFromGroupAction = "remove_privileges_from_group"
 	TransferReplicaAction           = "transfer_replica"
+	DisconnectAction                = "disconnect"
 )
 
 const (
@@ -129,6 +131,8 @@ const (
 	HTTPReturnLoadState      = "loadState"
 	HTTPReturnLoadProgress   = "loadProgress"
 	HTTPReturnTopks          = "topks"
+	HTTPReturnExplain        = "explain"
+	HTTPReturnProfile        = "profile"
 
 	HTTPReturnHas = "has"
 
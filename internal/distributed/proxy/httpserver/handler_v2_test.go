@@ -2767,6 +2767,35 @@ func TestGetQuotaMetrics(t *testing.T) {
 	fmt.Println(w.Body.String())
 }
 
+func TestDisconnectClient(t *testing.T) {
+	paramtable.Init()
+
+	mp := mocks.NewMockProxy(t)
+	mp.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.GetMetricsRequest) (*milvuspb.GetMetricsResponse, error) {
+		assert.JSONEq(t, `{"metric_type": "disconnect_client", "identifier": 1001}`, req.GetRequest())
+		return &milvuspb.GetMetricsResponse{Status: &StatusSuccess}, nil
+	}).Once()
+	testEngine := initHTTPServerV2(mp, false)
+
+	for _, testcase := range []requestBodyTestCase{
+		{path: DisconnectAction, requestBody: []byte(`{"identifier": 1001}`)},
+		{path: DisconnectAction, requestBody: []byte(`{}`), errCode: 1802, errMsg: "missing required parameters"},
+	} {
+		bodyReader := bytes.NewReader(testcase.requestBody)
+		req := httptest.NewRequest(http.MethodPost, versionalV2(ClientCategory, testcase.path), bodyReader)
+		w := httptest.NewRecorder()
+		testEngine.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		returnBody := &ReturnErrMsg{}
+		err := json.Unmarshal(w.Body.Bytes(), returnBody)
+		assert.NoError(t, err)
+		assert.Equal(t, testcase.errCode, returnBody.Code, "request body: %s", string(testcase.requestBody))
+		if testcase.errCode != 0 {
+			assert.Contains(t, returnBody.Message, testcase.errMsg)
+		}
+	}
+}
+
 type AddCollectionFieldSuite struct {
 	suite.Suite
 	testEngine *gin.Engine
@@ -241,6 +241,8 @@ type QueryReqV2 struct {
 	Offset           int32                  `json:"offset"`
 	ExprParams       map[string]interface{} `json:"exprParams"`
 	ConsistencyLevel string                 `json:"consistencyLevel"`
+	Explain          bool                   `json:"explain"`
+	Profile          bool                   `json:"profile"`
 }
 
 func (req *QueryReqV2) GetDbName() string { return req.DbName }
@@ -294,6 +296,8 @@ type SearchReqV2 struct {
 	ConsistencyLevel string                 `json:"consistencyLevel"`
 	ExprParams       map[string]interface{} `json:"exprParams"`
 	FunctionScore    FunctionScore          `json:"functionScore"`
+	Explain          bool                   `json:"explain"`
+	Profile          bool                   `json:"profile"`
 	// not use Params any more, just for compatibility
 	Params map[string]float64 `json:"params"`
 }
@@ -331,6 +335,8 @@ type HybridSearchReq struct {
 	OutputFields     []string       `json:"outputFields"`
 	ConsistencyLevel string         `json:"consistencyLevel"`
 	FunctionScore    FunctionScore  `json:"functionScore"`
+	Explain          bool           `json:"explain"`
+	Profile          bool           `json:"profile"`
 }
 
 func (req *HybridSearchReq) GetDbName() string { return req.DbName }
@@ -766,3 +772,7 @@ func (req *GetSegmentsInfoReq) GetSegmentIDs() []int64 {
 }
 
 type GetQuotaMetricsReq struct{}
+
+type DisconnectClientReq struct {
+	Identifier int64 `json:"identifier" binding:"required"`
+}
@@ -19,6 +19,7 @@ package httpserver
 import (
 	"context"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
@@ -26,6 +27,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
 )
 
 func toKVPair(data map[string]string) []*commonpb.KeyValuePair {
@@ -129,9 +131,15 @@ func (h *HandlersV2) describeResourceGroup(ctx context.Context, c *gin.Context,
 	})
 	if err == nil {
 		response := resp.(*milvuspb.DescribeResourceGroupResponse)
-		HTTPReturn(c, http.StatusOK, gin.H{
+		data := gin.H{
 			"resource_group": response.GetResourceGroup(),
-		})
+		}
+		for _, key := range []string{common.ResourceGroupMemCapacityKey, common.ResourceGroupMemQuotaKey, common.ResourceGroupMemUsedKey, common.ResourceGroupCPUNumKey} {
+			if value, ok := response.GetStatus().GetExtraInfo()[key]; ok {
+				data[key], _ = strconv.ParseInt(value, 10, 64)
+			}
+		}
+		HTTPReturn(c, http.StatusOK, data)
 	}
 	return resp, err
 }
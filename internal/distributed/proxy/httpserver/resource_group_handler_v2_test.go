@@ -29,6 +29,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 )
@@ -165,3 +166,36 @@ func TestResourceGroupHandlerV2(t *testing.T) {
 	// verify test case
 	validateRequestBodyTestCases(t, testServer, testCases, false)
 }
+
+func TestDescribeResourceGroupUsage(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key, "false")
+	defer paramtable.Get().Reset(paramtable.Get().QuotaConfig.QuotaAndLimitsEnabled.Key)
+
+	mockProxy := mocks.NewMockProxy(t)
+	status := merr.Success()
+	status.ExtraInfo = map[string]string{
+		common.ResourceGroupMemCapacityKey: "1024",
+		common.ResourceGroupMemQuotaKey:    "921",
+		common.ResourceGroupMemUsedKey:     "512",
+		common.ResourceGroupCPUNumKey:      "4",
+	}
+	mockProxy.EXPECT().DescribeResourceGroup(mock.Anything, mock.Anything).Return(&milvuspb.DescribeResourceGroupResponse{
+		Status:        status,
+		ResourceGroup: &milvuspb.ResourceGroup{Name: "rg"},
+	}, nil)
+	testServer := initHTTPServerV2(mockProxy, false)
+
+	req := httptest.NewRequest(http.MethodPost, versionalV2(ResourceGroupCategory, DescribeAction), bytes.NewReader([]byte(`{"name":"rg"}`)))
+	w := httptest.NewRecorder()
+	testServer.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	returnBody := map[string]interface{}{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &returnBody))
+	assert.NotNil(t, returnBody["resource_group"])
+	assert.EqualValues(t, 1024, returnBody[common.ResourceGroupMemCapacityKey])
+	assert.EqualValues(t, 921, returnBody[common.ResourceGroupMemQuotaKey])
+	assert.EqualValues(t, 512, returnBody[common.ResourceGroupMemUsedKey])
+	assert.EqualValues(t, 4, returnBody[common.ResourceGroupCPUNumKey])
+}
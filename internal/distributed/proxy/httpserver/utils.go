@@ -1600,7 +1600,7 @@ func CheckLimiter(ctx context.Context, req interface{}, pxy types.ProxyComponent
 	if err != nil {
 		return nil, err
 	}
-	err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+	err = proxy.CheckRateLimit(ctx, limiter, dbID, collectionIDToPartIDs, rt, n)
 	nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
 	metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.TotalLabel).Inc()
 	if err != nil {
@@ -2078,6 +2078,29 @@ func generateSearchParams(reqSearchParams map[string]interface{}) ([]*commonpb.K
 	return searchParams, nil
 }
 
+// generateExplainProfileParams converts the explain and profile options of a search or query request into kv params.
+func generateExplainProfileParams(explain bool, profile bool) []*commonpb.KeyValuePair {
+	params := make([]*commonpb.KeyValuePair, 0, 2)
+	if explain {
+		params = append(params, &commonpb.KeyValuePair{Key: common.ExplainKey, Value: strconv.FormatBool(explain)})
+	}
+	if profile {
+		params = append(params, &commonpb.KeyValuePair{Key: common.ProfileKey, Value: strconv.FormatBool(profile)})
+	}
+	return params
+}
+
+// withExplainProfile adds the plan tree and the execution stats returned by proxy into the http response.
+func withExplainProfile(resp gin.H, status *commonpb.Status) gin.H {
+	if value, ok := status.GetExtraInfo()[common.ExplainKey]; ok {
+		resp[HTTPReturnExplain] = json.RawMessage(value)
+	}
+	if value, ok := status.GetExtraInfo()[common.ProfileKey]; ok {
+		resp[HTTPReturnProfile] = json.RawMessage(value)
+	}
+	return resp
+}
+
 func genFunctionSchema(ctx context.Context, function *FunctionSchema) (*schemapb.FunctionSchema, error) {
 	functionTypeValue, ok := schemapb.FunctionType_value[function.FunctionType]
 	if !ok {
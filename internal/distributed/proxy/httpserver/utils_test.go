@@ -2697,3 +2697,27 @@ func TestGenFunctionScore(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestExplainProfile(t *testing.T) {
+	assert.Empty(t, generateExplainProfileParams(false, false))
+
+	params := generateExplainProfileParams(true, true)
+	assert.Equal(t, []*commonpb.KeyValuePair{
+		{Key: common.ExplainKey, Value: "true"},
+		{Key: common.ProfileKey, Value: "true"},
+	}, params)
+
+	resp := withExplainProfile(gin.H{HTTPReturnCode: 0}, &commonpb.Status{})
+	assert.NotContains(t, resp, HTTPReturnExplain)
+	assert.NotContains(t, resp, HTTPReturnProfile)
+
+	status := &commonpb.Status{ExtraInfo: map[string]string{
+		common.ExplainKey: `[{"node_type":"query"}]`,
+		common.ProfileKey: `{"name":"proxy","duration_us":10}`,
+	}}
+	resp = withExplainProfile(gin.H{HTTPReturnCode: 0}, status)
+	bs, err := json.Marshal(resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "query", gjson.GetBytes(bs, "explain.0.node_type").String())
+	assert.Equal(t, "proxy", gjson.GetBytes(bs, "profile.name").String())
+}
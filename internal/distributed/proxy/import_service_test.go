@@ -0,0 +1,75 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package grpcproxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestImportService(t *testing.T) {
+	ctx := context.Background()
+	mockProxy := mocks.NewMockProxy(t)
+	svc := newImportService(mockProxy)
+
+	t.Run("authorization disabled", func(t *testing.T) {
+		mockProxy.EXPECT().ImportV2(mock.Anything, mock.Anything).Return(&internalpb.ImportResponse{
+			Status: merr.Success(),
+			JobID:  "1",
+		}, nil).Once()
+		importResp, err := svc.ImportV2(ctx, &internalpb.ImportRequest{CollectionName: "coll"})
+		assert.NoError(t, merr.CheckRPCCall(importResp, err))
+		assert.Equal(t, "1", importResp.GetJobID())
+
+		mockProxy.EXPECT().GetImportProgress(mock.Anything, mock.Anything).Return(&internalpb.GetImportProgressResponse{
+			Status:   merr.Success(),
+			State:    internalpb.ImportJobState_Completed,
+			Progress: 100,
+		}, nil).Once()
+		progressResp, err := svc.GetImportProgress(ctx, &internalpb.GetImportProgressRequest{JobID: "1"})
+		assert.NoError(t, merr.CheckRPCCall(progressResp, err))
+		assert.Equal(t, internalpb.ImportJobState_Completed, progressResp.GetState())
+
+		mockProxy.EXPECT().ListImports(mock.Anything, mock.Anything).Return(&internalpb.ListImportsResponse{
+			Status: merr.Success(),
+			JobIDs: []string{"1"},
+		}, nil).Once()
+		listResp, err := svc.ListImports(ctx, &internalpb.ListImportsRequest{CollectionName: "coll"})
+		assert.NoError(t, merr.CheckRPCCall(listResp, err))
+		assert.Equal(t, []string{"1"}, listResp.GetJobIDs())
+	})
+
+	t.Run("no auth info", func(t *testing.T) {
+		paramtable.Get().Save(paramtable.Get().CommonCfg.AuthorizationEnabled.Key, "true")
+		defer paramtable.Get().Reset(paramtable.Get().CommonCfg.AuthorizationEnabled.Key)
+
+		importResp, err := svc.ImportV2(ctx, &internalpb.ImportRequest{CollectionName: "coll"})
+		assert.Error(t, merr.CheckRPCCall(importResp, err))
+		progressResp, err := svc.GetImportProgress(ctx, &internalpb.GetImportProgressRequest{JobID: "1"})
+		assert.Error(t, merr.CheckRPCCall(progressResp, err))
+		listResp, err := svc.ListImports(ctx, &internalpb.ListImportsRequest{CollectionName: "coll"})
+		assert.Error(t, merr.CheckRPCCall(listResp, err))
+	})
+}
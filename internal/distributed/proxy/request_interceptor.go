@@ -42,6 +42,7 @@ var (
 func init() {
 	retryableCode = typeutil.NewSet(
 		merr.Code(merr.ErrServiceRateLimit),
+		merr.Code(merr.ErrServiceScopeRateLimit),
 		merr.Code(merr.ErrCollectionSchemaMismatch),
 	)
 
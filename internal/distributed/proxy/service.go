@@ -53,7 +53,9 @@ import (
 	mhttp "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/proxy"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/proxy/slowlog"
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/componentutil"
@@ -242,10 +244,12 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 			proxy.DatabaseInterceptor(),
 			UnaryRequestStatsInterceptor,
 			accesslog.UnaryAccessLogInterceptor,
+			proxy.UnaryServerAuditInterceptor(),
 			proxy.GrpcAuthInterceptor(proxy.AuthenticationInterceptor),
 			proxy.UnaryServerHookInterceptor(),
 			proxy.UnaryServerInterceptor(proxy.PrivilegeInterceptor),
 			logutil.UnaryTraceLoggerInterceptor,
+			proxy.ConnectionLimitInterceptor,
 			proxy.RateLimitInterceptor(limiter),
 			accesslog.UnaryUpdateAccessInfoInterceptor,
 			proxy.TraceLogInterceptor,
@@ -319,6 +323,7 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 	}
 
 	milvuspb.RegisterMilvusServiceServer(s.grpcExternalServer, s)
+	proxypb.RegisterImportServiceServer(s.grpcExternalServer, newImportService(s.proxy))
 	grpc_health_v1.RegisterHealthServer(s.grpcExternalServer, s)
 	errChan <- nil
 
@@ -326,7 +331,8 @@ func (s *Server) startExternalGrpc(errChan chan error) {
 		zap.Any("enforcement policy", kaep),
 		zap.Any("server parameters", kasp))
 
-	if err := s.grpcExternalServer.Serve(s.listenerManager.ExternalGrpcListener()); err != nil && err != cmux.ErrServerClosed {
+	// the connections are tracked to close the ones of the clients disconnected forcibly
+	if err := s.grpcExternalServer.Serve(connection.WrapListener(s.listenerManager.ExternalGrpcListener())); err != nil && err != cmux.ErrServerClosed {
 		log.Error("failed to serve on Proxy's listener", zap.Error(err))
 		errChan <- err
 		return
@@ -432,6 +438,7 @@ func (s *Server) init() error {
 	log.Info("Proxy init http server's parameter table done")
 
 	accesslog.InitAccessLogger(paramtable.Get())
+	auditlog.InitAuditLogger(paramtable.Get())
 	serviceName := fmt.Sprintf("Proxy ip: %s, port: %d", Params.IP, Params.Port.GetAsInt())
 	log.Info("init Proxy's tracer done", zap.String("service name", serviceName))
 
@@ -507,6 +514,8 @@ func (s *Server) init() error {
 		return err
 	}
 	log.Debug("init Proxy done")
+	// the node ID is allocated in the init of proxy
+	slowlog.InitSlowQueryLog(paramtable.Get())
 	// nolint
 	// Intentionally print to stdout, which is usually a sign that Milvus is ready to serve.
 	fmt.Println("---Milvus Proxy successfully initialized and ready to serve!---")
@@ -589,6 +598,7 @@ func (s *Server) Stop() (err error) {
 	s.wg.Wait()
 
 	logger.Info("internal server[proxy] start to stop")
+	slowlog.Close()
 	err = s.proxy.Stop()
 	if err != nil {
 		logger.Error("failed to close proxy", zap.Error(err))
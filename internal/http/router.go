@@ -51,17 +51,21 @@ const (
 	RouteGcPause  = "/management/datacoord/garbage_collection/pause"
 	RouteGcResume = "/management/datacoord/garbage_collection/resume"
 
-	RouteSuspendQueryCoordBalance = "/management/querycoord/balance/suspend"
-	RouteResumeQueryCoordBalance  = "/management/querycoord/balance/resume"
-	RouteQueryCoordBalanceStatus  = "/management/querycoord/balance/status"
-	RouteTransferSegment          = "/management/querycoord/transfer/segment"
-	RouteTransferChannel          = "/management/querycoord/transfer/channel"
+	RouteSuspendQueryCoordBalance  = "/management/querycoord/balance/suspend"
+	RouteResumeQueryCoordBalance   = "/management/querycoord/balance/resume"
+	RouteQueryCoordBalanceStatus   = "/management/querycoord/balance/status"
+	RouteSimulateQueryCoordBalance = "/management/querycoord/balance/simulate"
+	RouteTransferSegment           = "/management/querycoord/transfer/segment"
+	RouteTransferChannel           = "/management/querycoord/transfer/channel"
 
 	RouteSuspendQueryNode           = "/management/querycoord/node/suspend"
 	RouteResumeQueryNode            = "/management/querycoord/node/resume"
 	RouteListQueryNode              = "/management/querycoord/node/list"
 	RouteGetQueryNodeDistribution   = "/management/querycoord/distribution/get"
 	RouteCheckQueryNodeDistribution = "/management/querycoord/distribution/check"
+
+	RouteListProxyClients      = "/management/proxy/client/list"
+	RouteDisconnectProxyClient = "/management/proxy/client/disconnect"
 )
 
 // for WebUI restful api root path
@@ -78,6 +82,8 @@ const (
 	HookConfigsPath = "/_hook/configs"
 	// SlowQueryPath is the path to get slow queries metrics
 	SlowQueryPath = "/_cluster/slow_query"
+	// SlowQueryTopPath is the path to get the top slow query fingerprints within a time window
+	SlowQueryTopPath = "/_cluster/slow_query/top"
 
 	// QCDistPath is the path to get QueryCoord distribution.
 	QCDistPath = "/_qc/dist"
@@ -0,0 +1,121 @@
+package planparserv2
+
+import (
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// ExplainPlan returns a structured, json serializable description of the plan.
+// Field names are resolved through schema when it is not nil.
+func ExplainPlan(schema *typeutil.SchemaHelper, plan *planpb.PlanNode) map[string]interface{} {
+	js := make(map[string]interface{})
+	if plan == nil {
+		return js
+	}
+	visitor := NewShowExprVisitor()
+	explainPredicates := func(expr *planpb.Expr) interface{} {
+		if expr == nil {
+			return nil
+		}
+		return visitor.VisitExpr(expr)
+	}
+
+	switch node := plan.GetNode().(type) {
+	case *planpb.PlanNode_VectorAnns:
+		anns := node.VectorAnns
+		js["node_type"] = "vector_anns"
+		js["field_id"] = anns.GetFieldId()
+		js["field_name"] = explainFieldName(schema, anns.GetFieldId())
+		js["vector_type"] = anns.GetVectorType().String()
+		js["query_info"] = explainQueryInfo(schema, anns.GetQueryInfo())
+		js["predicates"] = explainPredicates(anns.GetPredicates())
+	case *planpb.PlanNode_Query:
+		query := node.Query
+		js["node_type"] = "query"
+		js["is_count"] = query.GetIsCount()
+		js["limit"] = query.GetLimit()
+		js["predicates"] = explainPredicates(query.GetPredicates())
+	case *planpb.PlanNode_Predicates:
+		js["node_type"] = "predicates"
+		js["predicates"] = explainPredicates(node.Predicates)
+	}
+
+	outputFields := make([]interface{}, 0, len(plan.GetOutputFieldIds()))
+	for _, fieldID := range plan.GetOutputFieldIds() {
+		outputFields = append(outputFields, map[string]interface{}{
+			"field_id":   fieldID,
+			"field_name": explainFieldName(schema, fieldID),
+		})
+	}
+	js["output_fields"] = outputFields
+	if len(plan.GetDynamicFields()) > 0 {
+		js["dynamic_fields"] = plan.GetDynamicFields()
+	}
+
+	if len(plan.GetScorers()) > 0 {
+		scorers := make([]interface{}, 0, len(plan.GetScorers()))
+		for _, scorer := range plan.GetScorers() {
+			scorers = append(scorers, map[string]interface{}{
+				"weight": scorer.GetWeight(),
+				"filter": explainPredicates(scorer.GetFilter()),
+			})
+		}
+		js["scorers"] = scorers
+	}
+	if plan.GetPlanOptions() != nil {
+		js["plan_options"] = map[string]interface{}{
+			"expr_use_json_stats": plan.GetPlanOptions().GetExprUseJsonStats(),
+		}
+	}
+	return js
+}
+
+// ExplainPlanString is the json encoded form of ExplainPlan.
+func ExplainPlanString(schema *typeutil.SchemaHelper, plan *planpb.PlanNode) (string, error) {
+	bs, err := json.Marshal(ExplainPlan(schema, plan))
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func explainQueryInfo(schema *typeutil.SchemaHelper, info *planpb.QueryInfo) interface{} {
+	if info == nil {
+		return nil
+	}
+	js := make(map[string]interface{})
+	js["topk"] = info.GetTopk()
+	js["metric_type"] = info.GetMetricType()
+	js["search_params"] = info.GetSearchParams()
+	js["round_decimal"] = info.GetRoundDecimal()
+	if info.GetGroupByFieldId() > 0 {
+		js["group_by_field_id"] = info.GetGroupByFieldId()
+		js["group_by_field_name"] = explainFieldName(schema, info.GetGroupByFieldId())
+		js["group_size"] = info.GetGroupSize()
+		js["strict_group_size"] = info.GetStrictGroupSize()
+	}
+	if info.GetHints() != "" {
+		js["hints"] = info.GetHints()
+	}
+	if info.GetJsonPath() != "" {
+		js["json_path"] = info.GetJsonPath()
+		js["json_type"] = info.GetJsonType().String()
+	}
+	if info.SearchIteratorV2Info != nil {
+		js["search_iterator_v2"] = true
+	}
+	js["materialized_view_involved"] = info.GetMaterializedViewInvolved()
+	return js
+}
+
+func explainFieldName(schema *typeutil.SchemaHelper, fieldID int64) string {
+	if schema == nil {
+		return ""
+	}
+	field, err := schema.GetFieldFromID(fieldID)
+	if err != nil {
+		return ""
+	}
+	return field.GetName()
+}
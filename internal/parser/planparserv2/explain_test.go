@@ -0,0 +1,61 @@
+package planparserv2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+func TestExplainPlan(t *testing.T) {
+	schema := newTestSchema(true)
+	helper, err := typeutil.CreateSchemaHelper(schema)
+	assert.NoError(t, err)
+
+	t.Run("search", func(t *testing.T) {
+		plan, err := CreateSearchPlan(helper, `Int64Field > 10 and exists A`, "FloatVectorField", &planpb.QueryInfo{
+			Topk:       10,
+			MetricType: "L2",
+		}, nil, nil)
+		assert.NoError(t, err)
+
+		js := ExplainPlan(helper, plan)
+		assert.Equal(t, "vector_anns", js["node_type"])
+		assert.Equal(t, "FloatVectorField", js["field_name"])
+		assert.Equal(t, planpb.VectorType_FloatVector.String(), js["vector_type"])
+		queryInfo := js["query_info"].(map[string]interface{})
+		assert.Equal(t, int64(10), queryInfo["topk"])
+		assert.Equal(t, "L2", queryInfo["metric_type"])
+		assert.NotNil(t, js["predicates"])
+
+		str, err := ExplainPlanString(helper, plan)
+		assert.NoError(t, err)
+		decoded := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal([]byte(str), &decoded))
+		assert.Equal(t, "vector_anns", decoded["node_type"])
+	})
+
+	t.Run("query", func(t *testing.T) {
+		plan, err := CreateRetrievePlan(helper, `Int64Field in [1, 2, 3]`, nil)
+		assert.NoError(t, err)
+		plan.GetQuery().Limit = 5
+		plan.OutputFieldIds = []int64{105}
+
+		js := ExplainPlan(helper, plan)
+		assert.Equal(t, "query", js["node_type"])
+		assert.Equal(t, int64(5), js["limit"])
+		assert.Equal(t, false, js["is_count"])
+		outputFields := js["output_fields"].([]interface{})
+		assert.Len(t, outputFields, 1)
+		assert.Equal(t, "Int64Field", outputFields[0].(map[string]interface{})["field_name"])
+		predicates := js["predicates"].(map[string]interface{})
+		assert.Equal(t, "term", predicates["expr"].(map[string]interface{})["expr_type"])
+	})
+
+	t.Run("nil plan", func(t *testing.T) {
+		assert.Empty(t, ExplainPlan(helper, nil))
+	})
+}
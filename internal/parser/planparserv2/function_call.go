@@ -0,0 +1,216 @@
+package planparserv2
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// functionSignature describes one overload of a scalar function.
+type functionSignature struct {
+	params     []schemapb.DataType
+	returnType schemapb.DataType
+}
+
+// scalarFunctions are the functions registered in the FunctionFactory of segcore,
+// the parameters are type-checked against the schema before being sent to segcore.
+// Calls of the other functions are passed through as they are.
+var scalarFunctions = map[string][]functionSignature{
+	"lower": {
+		{params: []schemapb.DataType{schemapb.DataType_VarChar}, returnType: schemapb.DataType_VarChar},
+	},
+	"upper": {
+		{params: []schemapb.DataType{schemapb.DataType_VarChar}, returnType: schemapb.DataType_VarChar},
+	},
+	"length": {
+		{params: []schemapb.DataType{schemapb.DataType_VarChar}, returnType: schemapb.DataType_Int64},
+	},
+	"substring": {
+		{params: []schemapb.DataType{schemapb.DataType_VarChar, schemapb.DataType_Int64}, returnType: schemapb.DataType_VarChar},
+		{params: []schemapb.DataType{schemapb.DataType_VarChar, schemapb.DataType_Int64, schemapb.DataType_Int64}, returnType: schemapb.DataType_VarChar},
+	},
+	"starts_with": {
+		{params: []schemapb.DataType{schemapb.DataType_VarChar, schemapb.DataType_VarChar}, returnType: schemapb.DataType_Bool},
+	},
+	"ends_with": {
+		{params: []schemapb.DataType{schemapb.DataType_VarChar, schemapb.DataType_VarChar}, returnType: schemapb.DataType_Bool},
+	},
+}
+
+// castFunctions are the SQL-style casts written as function calls, e.g. int64(meta["age"]).
+var castFunctions = map[string]schemapb.DataType{
+	"bool":    schemapb.DataType_Bool,
+	"int8":    schemapb.DataType_Int8,
+	"int16":   schemapb.DataType_Int16,
+	"int32":   schemapb.DataType_Int32,
+	"int64":   schemapb.DataType_Int64,
+	"float":   schemapb.DataType_Float,
+	"double":  schemapb.DataType_Double,
+	"varchar": schemapb.DataType_VarChar,
+}
+
+// compareFunctions are the functions registered in segcore to compare the results of scalar functions.
+var compareFunctions = map[planpb.OpType]string{
+	planpb.OpType_Equal:        "equal",
+	planpb.OpType_NotEqual:     "not_equal",
+	planpb.OpType_LessThan:     "less_than",
+	planpb.OpType_LessEqual:    "less_equal",
+	planpb.OpType_GreaterThan:  "greater_than",
+	planpb.OpType_GreaterEqual: "greater_equal",
+}
+
+// isScalarFunctionCall returns true if the expression produces the values of a scalar function,
+// which can only be consumed by comparisons or other functions.
+func isScalarFunctionCall(expr *ExprWithType) bool {
+	return expr.expr.GetCallExpr() != nil && !typeutil.IsBoolType(expr.dataType)
+}
+
+func isJSONOrArrayColumn(expr *ExprWithType) bool {
+	columnInfo := toColumnInfo(expr)
+	return columnInfo != nil && (typeutil.IsJSONType(columnInfo.GetDataType()) || typeutil.IsArrayType(columnInfo.GetDataType()))
+}
+
+// castSourceTypes are the types of the fields which can be cast, JSON fields are cast by the values at the path.
+var castSourceTypes = map[schemapb.DataType]bool{
+	schemapb.DataType_Bool:    true,
+	schemapb.DataType_Int8:    true,
+	schemapb.DataType_Int16:   true,
+	schemapb.DataType_Int32:   true,
+	schemapb.DataType_Int64:   true,
+	schemapb.DataType_Float:   true,
+	schemapb.DataType_Double:  true,
+	schemapb.DataType_String:  true,
+	schemapb.DataType_VarChar: true,
+	schemapb.DataType_JSON:    true,
+}
+
+// handleCast lowers the cast into a call of the cast function, which converts the values of the field
+// in segcore, e.g. int64(meta["age"]) converts the JSON values and strings at the path to int64.
+// The values which cannot be converted are cast to null.
+func handleCast(functionName string, targetType schemapb.DataType, params []*ExprWithType) (*ExprWithType, error) {
+	if len(params) != 1 {
+		return nil, fmt.Errorf("%s() expects 1 parameter, but got %d", functionName, len(params))
+	}
+	param := params[0]
+	columnInfo := toColumnInfo(param)
+	if columnInfo == nil {
+		return nil, fmt.Errorf("%s() can only be applied to a field", functionName)
+	}
+	if !castSourceTypes[columnInfo.GetDataType()] {
+		return nil, fmt.Errorf("%s() on %s field is not supported", functionName, columnInfo.GetDataType().String())
+	}
+
+	return &ExprWithType{
+		expr: &planpb.Expr{
+			Expr: &planpb.Expr_CallExpr{
+				CallExpr: &planpb.CallExpr{
+					FunctionName:       functionName,
+					FunctionParameters: []*planpb.Expr{param.expr},
+				},
+			},
+		},
+		dataType:      targetType,
+		nodeDependent: !typeutil.IsBoolType(targetType),
+	}, nil
+}
+
+func paramMatches(expected schemapb.DataType, param *ExprWithType) bool {
+	if typeutil.IsStringType(expected) {
+		return typeutil.IsStringType(param.dataType)
+	}
+	return expected == param.dataType
+}
+
+// handleScalarFunction type-checks the parameters of a scalar function and lowers it into a call expression.
+func handleScalarFunction(functionName string, signatures []functionSignature, params []*ExprWithType) (*ExprWithType, error) {
+	for _, param := range params {
+		if param.expr.GetIsTemplate() {
+			return nil, fmt.Errorf("placeholder is not supported in the parameters of %s()", functionName)
+		}
+		if isJSONOrArrayColumn(param) {
+			return nil, fmt.Errorf("%s() on JSON or array field is not supported", functionName)
+		}
+	}
+
+	for _, signature := range signatures {
+		if len(signature.params) != len(params) {
+			continue
+		}
+		matched := true
+		for i, expected := range signature.params {
+			if !paramMatches(expected, params[i]) {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		return &ExprWithType{
+			expr: &planpb.Expr{
+				Expr: &planpb.Expr_CallExpr{
+					CallExpr: &planpb.CallExpr{
+						FunctionName: functionName,
+						FunctionParameters: lo.Map(params, func(param *ExprWithType, _ int) *planpb.Expr {
+							return param.expr
+						}),
+					},
+				},
+			},
+			dataType:      signature.returnType,
+			nodeDependent: !typeutil.IsBoolType(signature.returnType),
+		}, nil
+	}
+
+	paramTypes := lo.Map(params, func(param *ExprWithType, _ int) string {
+		return getDataType(param)
+	})
+	return nil, fmt.Errorf("function %s(%s) is not supported", functionName, strings.Join(paramTypes, ", "))
+}
+
+// handleFunctionCompare lowers the comparison on the results of scalar functions into a call of compare function.
+func handleFunctionCompare(op planpb.OpType, left, right *ExprWithType) (*planpb.Expr, error) {
+	functionName, ok := compareFunctions[op]
+	if !ok {
+		return nil, fmt.Errorf("unsupported op type: %s", op)
+	}
+	if left.expr.GetIsTemplate() || right.expr.GetIsTemplate() {
+		return nil, errors.New("placeholder is not supported in comparisons with function results")
+	}
+
+	dataType := left.dataType
+	if !isScalarFunctionCall(left) {
+		dataType = right.dataType
+	}
+
+	params := make([]*planpb.Expr, 0, 2)
+	for _, operand := range []*ExprWithType{left, right} {
+		if valueExpr := operand.expr.GetValueExpr(); valueExpr != nil {
+			value, err := castValue(dataType, valueExpr.GetValue())
+			if err != nil {
+				return nil, err
+			}
+			params = append(params, toValueExpr(value).expr)
+			continue
+		}
+		if isJSONOrArrayColumn(operand) || !paramMatches(dataType, operand) {
+			return nil, fmt.Errorf("comparisons between %s and %s are not supported", getDataType(left), getDataType(right))
+		}
+		params = append(params, operand.expr)
+	}
+
+	return &planpb.Expr{
+		Expr: &planpb.Expr_CallExpr{
+			CallExpr: &planpb.CallExpr{
+				FunctionName:       functionName,
+				FunctionParameters: params,
+			},
+		},
+	}, nil
+}
@@ -721,13 +721,34 @@ func (v *ParserVisitor) getChildColumnInfo(identifier, child antlr.TerminalNode)
 func (v *ParserVisitor) VisitCall(ctx *parser.CallContext) interface{} {
 	functionName := strings.ToLower(ctx.Identifier().GetText())
 	numParams := len(ctx.AllExpr())
-	funcParameters := make([]*planpb.Expr, 0, numParams)
+	params := make([]*ExprWithType, 0, numParams)
 	for _, param := range ctx.AllExpr() {
 		paramExpr := param.Accept(v)
 		if err := getError(paramExpr); err != nil {
 			return err
 		}
-		funcParameters = append(funcParameters, getExpr(param.Accept(v)).expr)
+		params = append(params, getExpr(paramExpr))
+	}
+
+	if targetType, ok := castFunctions[functionName]; ok {
+		expr, err := handleCast(functionName, targetType, params)
+		if err != nil {
+			return err
+		}
+		return expr
+	}
+
+	if signatures, ok := scalarFunctions[functionName]; ok {
+		expr, err := handleScalarFunction(functionName, signatures, params)
+		if err != nil {
+			return err
+		}
+		return expr
+	}
+
+	funcParameters := make([]*planpb.Expr, 0, numParams)
+	for _, param := range params {
+		funcParameters = append(funcParameters, param.expr)
 	}
 	return &ExprWithType{
 		expr: &planpb.Expr{
@@ -162,6 +162,137 @@ func TestExpr_Call(t *testing.T) {
 	assert.Nil(t, expr)
 }
 
+func TestExpr_ScalarFunction(t *testing.T) {
+	helper := newTestSchemaHelper(t)
+
+	exprStrs := []string{
+		`lower(VarCharField) == "milvus"`,
+		`upper(VarCharField) != "MILVUS"`,
+		`"milvus" == lower(VarCharField)`,
+		`length(VarCharField) > 3`,
+		`3 <= length(VarCharField)`,
+		`length(VarCharField) == Int64Field`,
+		`substring(VarCharField, 1, 3) == "mil"`,
+		`substring(VarCharField, 2) >= "a"`,
+		`lower(substring(VarCharField, 1, 3)) == "mil"`,
+		`starts_with(VarCharField, "mil")`,
+		`ends_with(lower(VarCharField), "vus")`,
+		`not ends_with(VarCharField, "vus") and length(VarCharField) < 10`,
+		`LOWER(VarCharField) == "milvus"`,
+	}
+	for _, exprStr := range exprStrs {
+		assertValidExpr(t, helper, exprStr)
+	}
+
+	expr, err := ParseExpr(helper, `length(VarCharField) > 3`, nil)
+	require.NoError(t, err)
+	callExpr := expr.GetCallExpr()
+	assert.Equal(t, "greater_than", callExpr.GetFunctionName())
+	assert.Equal(t, "length", callExpr.GetFunctionParameters()[0].GetCallExpr().GetFunctionName())
+	assert.Equal(t, int64(3), callExpr.GetFunctionParameters()[1].GetValueExpr().GetValue().GetInt64Val())
+
+	expr, err = ParseExpr(helper, `"milvus" < lower(VarCharField)`, nil)
+	require.NoError(t, err)
+	callExpr = expr.GetCallExpr()
+	assert.Equal(t, "less_than", callExpr.GetFunctionName())
+	assert.Equal(t, "milvus", callExpr.GetFunctionParameters()[0].GetValueExpr().GetValue().GetStringVal())
+	assert.Equal(t, "lower", callExpr.GetFunctionParameters()[1].GetCallExpr().GetFunctionName())
+
+	invalidExprStrs := []string{
+		// scalar functions cannot be a predicate
+		`lower(VarCharField)`,
+		`length(VarCharField)`,
+		// type mismatch
+		`lower(Int64Field) == "1"`,
+		`lower(VarCharField) == 1`,
+		`length(VarCharField) > 1.5`,
+		`length(VarCharField) == "3"`,
+		`length(VarCharField) == Int32Field`,
+		`substring(VarCharField, "1")`,
+		`substring(VarCharField) == "a"`,
+		`starts_with(VarCharField, 1)`,
+		// JSON and array fields
+		`lower(JSONField["A"]) == "a"`,
+		`length(StringArrayField) > 1`,
+		// placeholder
+		`lower(VarCharField) == {name}`,
+	}
+	for _, exprStr := range invalidExprStrs {
+		assertInvalidExpr(t, helper, exprStr)
+	}
+}
+
+func TestExpr_Cast(t *testing.T) {
+	helper := newTestSchemaHelper(t)
+
+	exprStrs := []string{
+		`int64(Int8Field) == 1`,
+		`int64(Int32Field) > 10`,
+		`int16(Int16Field) != 1`,
+		`int8(Int64Field) != 1`,
+		`double(FloatField) > 1.5`,
+		`double(DoubleField) > 1`,
+		`float(DoubleField) > 1.5`,
+		`int64(VarCharField) > 1`,
+		`varchar(Int64Field) == "1"`,
+		`varchar(StringField) == "milvus"`,
+		`lower(varchar(JSONField["name"])) == "milvus"`,
+		`int64(JSONField["age"]) > 30`,
+		`double(JSONField["score"]) <= 99.5`,
+		`int64(A) < 10`,
+		`bool(Int8Field)`,
+		`bool(JSONField["flag"]) && Int64Field > 1`,
+	}
+	for _, exprStr := range exprStrs {
+		assertValidExpr(t, helper, exprStr)
+	}
+
+	// the values are converted by the cast function in segcore
+	expr, err := ParseExpr(helper, `int64(JSONField["age"]) > 30`, nil)
+	require.NoError(t, err)
+	compareCall := expr.GetCallExpr()
+	require.NotNil(t, compareCall)
+	assert.Equal(t, "greater_than", compareCall.GetFunctionName())
+	require.Equal(t, 2, len(compareCall.GetFunctionParameters()))
+	castCall := compareCall.GetFunctionParameters()[0].GetCallExpr()
+	require.NotNil(t, castCall)
+	assert.Equal(t, "int64", castCall.GetFunctionName())
+	columnInfo := castCall.GetFunctionParameters()[0].GetColumnExpr().GetInfo()
+	assert.Equal(t, schemapb.DataType_JSON, columnInfo.GetDataType())
+	assert.Equal(t, []string{"age"}, columnInfo.GetNestedPath())
+	assert.Equal(t, int64(30), compareCall.GetFunctionParameters()[1].GetValueExpr().GetValue().GetInt64Val())
+
+	expr, err = ParseExpr(helper, `double(Int8Field) >= 1`, nil)
+	require.NoError(t, err)
+	compareCall = expr.GetCallExpr()
+	require.NotNil(t, compareCall)
+	assert.Equal(t, "double", compareCall.GetFunctionParameters()[0].GetCallExpr().GetFunctionName())
+	assert.Equal(t, float64(1), compareCall.GetFunctionParameters()[1].GetValueExpr().GetValue().GetFloatVal())
+
+	invalidExprStrs := []string{
+		// the operands must be of the cast type
+		`int64(Int8Field) > 1.5`,
+		`int64(Int8Field) == "1"`,
+		`double(FloatField) == "1.5"`,
+		`varchar(VarCharField) == 1`,
+		`int64(JSONField["age"]) > "30"`,
+		// the results of casts can only be compared
+		`varchar(VarCharField) like "mil%"`,
+		`int64(JSONField["age"]) in [1, 2]`,
+		// array and vector fields
+		`int64(StringArrayField) > 1`,
+		`int64(StringArrayField[0]) > 1`,
+		`varchar(FloatVectorField) == "1"`,
+		// not a field
+		`int64(1) > 1`,
+		`int64(Int8Field, Int16Field) > 1`,
+		`int64(length(VarCharField)) > 1`,
+	}
+	for _, exprStr := range invalidExprStrs {
+		assertInvalidExpr(t, helper, exprStr)
+	}
+}
+
 func TestExpr_Compare(t *testing.T) {
 	schema := newTestSchema(true)
 	helper, err := typeutil.CreateSchemaHelper(schema)
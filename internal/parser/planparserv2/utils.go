@@ -483,6 +483,9 @@ func HandleCompare(op int, left, right *ExprWithType) (*planpb.Expr, error) {
 	}
 
 	cmpOp := cmpOpMap[op]
+	if isScalarFunctionCall(left) || isScalarFunctionCall(right) {
+		return handleFunctionCompare(cmpOp, left, right)
+	}
 	if valueExpr := left.expr.GetValueExpr(); valueExpr != nil {
 		op, err := reverseOrder(cmpOp)
 		if err != nil {
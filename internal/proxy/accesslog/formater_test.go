@@ -31,6 +31,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	"github.com/milvus-io/milvus/pkg/v2/tracer"
 	"github.com/milvus-io/milvus/pkg/v2/util"
@@ -181,6 +182,57 @@ func (s *LogFormatterSuite) TestFormatMethodResult() {
 	}
 }
 
+func (s *LogFormatterSuite) TestFormatJSON() {
+	formatter := NewFormatterWithMode("[$time_now] $method_name $method_expr [$method_name]", OutputModeJSON)
+	s.Equal([]string{"$time_now", "$method_name", "$method_expr"}, formatter.keys)
+
+	req := &milvuspb.QueryRequest{
+		DbName:         "test-db",
+		CollectionName: "test-collection",
+		Expr:           `name == "a b" and id in [1, 2]`,
+	}
+	i := info.NewGrpcAccessInfo(s.ctx, s.serverinfo, req)
+	fs := formatter.Format(i)
+	s.True(strings.HasSuffix(fs, "\n"))
+
+	values := make(map[string]string)
+	s.NoError(json.Unmarshal([]byte(fs), &values))
+	s.Len(values, 3)
+	s.Equal(req.Expr, values["method_expr"])
+
+	s.NoError(formatter.SetFields("database_name", "$collection_name"))
+	values = make(map[string]string)
+	s.NoError(json.Unmarshal([]byte(formatter.Format(i)), &values))
+	s.Equal(map[string]string{"database_name": "test-db", "collection_name": "test-collection"}, values)
+
+	s.Error(formatter.SetFields("unknown_field"))
+}
+
+func (s *LogFormatterSuite) TestFormatLogfmt() {
+	formatter := NewFormatterWithMode("$database_name $method_expr $partition_name", OutputModeLogfmt)
+	req := &milvuspb.QueryRequest{
+		DbName: "test-db",
+		Expr:   `name == "a b"`,
+	}
+	i := info.NewGrpcAccessInfo(s.ctx, s.serverinfo, req)
+	s.Equal(`database_name=test-db method_expr="name == \"a b\"" partition_name=[]`+"\n", formatter.Format(i))
+}
+
+func (s *LogFormatterSuite) TestSample() {
+	formatter := NewFormatter("$method_name")
+	s.Error(formatter.SetSampleRatio(1.5))
+	s.NoError(formatter.SetSampleRatio(0))
+
+	i := info.NewGrpcAccessInfo(s.ctx, s.serverinfo, s.reqs[0])
+	i.SetResult(s.resps[0], s.errs[0])
+	s.False(formatter.Sampled(i))
+
+	// failed requests are always logged
+	i = info.NewGrpcAccessInfo(s.ctx, s.serverinfo, s.reqs[1])
+	i.SetResult(s.resps[1], s.errs[1])
+	s.True(formatter.Sampled(i))
+}
+
 func (s *LogFormatterSuite) TestParseConfigKeyFailed() {
 	configKey := ".testf.invalidSub"
 	_, _, err := parseConfigKey(configKey)
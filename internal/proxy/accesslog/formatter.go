@@ -17,36 +17,57 @@
 package accesslog
 
 import (
+	"bytes"
 	"fmt"
+	"math/rand"
+	"regexp"
 	"strings"
 
+	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 )
 
 const (
-	fomaterkey = "format"
-	methodKey  = "methods"
+	fomaterkey     = "format"
+	methodKey      = "methods"
+	fieldsKey      = "fields"
+	sampleRatioKey = "sampleratio" // config keys are lower cased
 )
 
+// output modes of access log
+const (
+	OutputModeText   = "text"
+	OutputModeJSON   = "json"
+	OutputModeLogfmt = "logfmt"
+)
+
+var metricPattern = regexp.MustCompile(`\$[a-z_]+`)
+
 var BaseFormatterKey = "base"
 
 // Formaater manager not concurrent safe
 // make sure init with Add and SetMethod before use Get
 type FormatterManger struct {
+	mode       string
 	formatters map[string]*Formatter
 	methodMap  map[string]string
 }
 
 func NewFormatterManger() *FormatterManger {
+	return NewFormatterMangerWithMode(OutputModeText)
+}
+
+func NewFormatterMangerWithMode(mode string) *FormatterManger {
 	return &FormatterManger{
+		mode:       mode,
 		formatters: make(map[string]*Formatter),
 		methodMap:  make(map[string]string),
 	}
 }
 
 func (m *FormatterManger) Add(name, fmt string) {
-	m.formatters[name] = NewFormatter(fmt)
+	m.formatters[name] = NewFormatterWithMode(fmt, m.mode)
 }
 
 func (m *FormatterManger) SetMethod(name string, methods ...string) {
@@ -72,16 +93,84 @@ type Formatter struct {
 	base   string
 	fmt    string
 	fields []string
+
+	mode string
+	// metrics of json and logfmt output, in order
+	keys []string
+	// ratio of successful requests to be logged, failed ones are always logged
+	sampleRatio float64
 }
 
 func NewFormatter(base string) *Formatter {
+	return NewFormatterWithMode(base, OutputModeText)
+}
+
+// NewFormatterWithMode creates a formatter with the output mode,
+// the structured modes output the metrics of base in order by default.
+func NewFormatterWithMode(base string, mode string) *Formatter {
 	formatter := &Formatter{
-		base: base,
+		base:        base,
+		mode:        mode,
+		sampleRatio: 1,
 	}
 	formatter.build()
+	formatter.keys = validKeys(metricPattern.FindAllString(base, -1))
 	return formatter
 }
 
+// SetFields sets the metrics of json and logfmt output,
+// the metric name could be with or without the "$" prefix.
+func (f *Formatter) SetFields(fields ...string) error {
+	keys := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "$") {
+			field = "$" + field
+		}
+		if _, ok := info.MetricFuncMap[field]; !ok {
+			return merr.WrapErrParameterInvalidMsg("unknown access log field %s", field)
+		}
+		keys = append(keys, field)
+	}
+	f.keys = keys
+	return nil
+}
+
+func (f *Formatter) SetSampleRatio(ratio float64) error {
+	if ratio < 0 || ratio > 1 {
+		return merr.WrapErrParameterInvalidRange(0, 1, ratio, "access log sample ratio out of range")
+	}
+	f.sampleRatio = ratio
+	return nil
+}
+
+// Sampled returns whether the request should be logged.
+func (f *Formatter) Sampled(i info.AccessInfo) bool {
+	if f.sampleRatio >= 1 {
+		return true
+	}
+	if i.MethodStatus() != "Successful" {
+		return true
+	}
+	return rand.Float64() < f.sampleRatio
+}
+
+// validKeys removes the unknown and duplicated metrics.
+func validKeys(keys []string) []string {
+	seen := make(map[string]struct{})
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if _, ok := info.MetricFuncMap[key]; !ok {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		result = append(result, key)
+	}
+	return result
+}
+
 func (f *Formatter) buildMetric(metric string, prefixs []string) ([]string, []string) {
 	newFields := []string{}
 	newPrefixs := []string{}
@@ -120,14 +209,80 @@ func (f *Formatter) build() {
 }
 
 func (f *Formatter) Format(i info.AccessInfo) string {
-	fieldValues := info.Get(i, f.fields...)
-	return fmt.Sprintf(f.fmt, fieldValues...)
+	switch f.mode {
+	case OutputModeJSON:
+		return f.formatJSON(i)
+	case OutputModeLogfmt:
+		return f.formatLogfmt(i)
+	default:
+		fieldValues := info.Get(i, f.fields...)
+		return fmt.Sprintf(f.fmt, fieldValues...)
+	}
+}
+
+func (f *Formatter) formatJSON(i info.AccessInfo) string {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for id, key := range f.keys {
+		if id > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(quote(key[1:]))
+		buf.WriteByte(':')
+		buf.Write(quote(info.MetricFuncMap[key](i)))
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+func (f *Formatter) formatLogfmt(i info.AccessInfo) string {
+	buf := bytes.Buffer{}
+	for id, key := range f.keys {
+		if id > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(key[1:])
+		buf.WriteByte('=')
+		value := info.MetricFuncMap[key](i)
+		if needQuote(value) {
+			buf.Write(quote(value))
+		} else {
+			buf.WriteString(value)
+		}
+	}
+	buf.WriteByte('\n')
+	return buf.String()
+}
+
+// quote returns the json string of s, with quotes and control characters escaped.
+func quote(s string) []byte {
+	bs, err := json.Marshal(s)
+	if err != nil {
+		return []byte(`""`)
+	}
+	return bs
+}
+
+func needQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, c := range s {
+		if c <= ' ' || c == '=' || c == '"' || c == '\\' || c == 0x7f {
+			return true
+		}
+	}
+	return false
 }
 
 func parseConfigKey(k string) (string, string, error) {
 	fields := strings.Split(k, ".")
-	if len(fields) != 2 || (fields[1] != fomaterkey && fields[1] != methodKey) {
-		return "", "", merr.WrapErrParameterInvalid("<FormatterName>.(format|methods)", k, "parse accsslog formatter config key failed")
+	if len(fields) != 2 {
+		return "", "", merr.WrapErrParameterInvalid("<FormatterName>.(format|methods|fields|sampleRatio)", k, "parse accsslog formatter config key failed")
+	}
+	option := strings.ToLower(fields[1])
+	if option != fomaterkey && option != methodKey && option != fieldsKey && option != sampleRatioKey {
+		return "", "", merr.WrapErrParameterInvalid("<FormatterName>.(format|methods|fields|sampleRatio)", k, "parse accsslog formatter config key failed")
 	}
-	return fields[0], fields[1], nil
+	return fields[0], option, nil
 }
@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/proxy/accesslog/info"
 	configEvent "github.com/milvus-io/milvus/pkg/v2/config"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 )
 
@@ -116,6 +117,9 @@ func (l *AccessLogger) Write(info info.AccessInfo) bool {
 	if !ok {
 		return false
 	}
+	if !formatter.Sampled(info) {
+		return false
+	}
 	_, err := l.writer.Write([]byte(formatter.Format(info)))
 	if err != nil {
 		log.Warn("write access log failed", zap.Error(err))
@@ -148,19 +152,38 @@ func InitAccessLogger(params *paramtable.ComponentParam) {
 }
 
 func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error) {
-	formatterManger := NewFormatterManger()
+	mode := logCfg.OutputMode.GetValue()
+	if mode != OutputModeText && mode != OutputModeJSON && mode != OutputModeLogfmt {
+		return nil, merr.WrapErrParameterInvalid("text|json|logfmt", mode, "invalid access log output mode")
+	}
+	formatterManger := NewFormatterMangerWithMode(mode)
 	formatMap := make(map[string]string)   // fommatter name -> formatter format
 	methodMap := make(map[string][]string) // fommatter name -> formatter owner method
+	fieldsMap := make(map[string][]string) // fommatter name -> fields of structured output
+	ratioMap := make(map[string]float64)   // fommatter name -> sample ratio
 	for key, value := range logCfg.Formatter.GetValue() {
 		formatterName, option, err := parseConfigKey(key)
 		if err != nil {
 			return nil, err
 		}
 
-		if option == fomaterkey {
+		switch option {
+		case fomaterkey:
 			formatMap[formatterName] = value
-		} else if option == methodKey {
+		case methodKey:
 			methodMap[formatterName] = paramtable.ParseAsStings(value)
+		case fieldsKey:
+			fieldsMap[formatterName] = paramtable.ParseAsStings(value)
+			// structured formatter could be configured with fields only
+			if _, ok := formatMap[formatterName]; !ok {
+				formatMap[formatterName] = ""
+			}
+		case sampleRatioKey:
+			ratio, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, merr.WrapErrParameterInvalid("float", value, "invalid access log sample ratio")
+			}
+			ratioMap[formatterName] = ratio
 		}
 	}
 
@@ -169,6 +192,16 @@ func initFormatter(logCfg *paramtable.AccessLogConfig) (*FormatterManger, error)
 		if methods, ok := methodMap[name]; ok {
 			formatterManger.SetMethod(name, methods...)
 		}
+		if fields, ok := fieldsMap[name]; ok {
+			if err := formatterManger.formatters[name].SetFields(fields...); err != nil {
+				return nil, err
+			}
+		}
+		if ratio, ok := ratioMap[name]; ok {
+			if err := formatterManger.formatters[name].SetSampleRatio(ratio); err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	return formatterManger, nil
@@ -172,6 +172,32 @@ func TestAccessLogger_Basic(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestInitFormatter_Structured(t *testing.T) {
+	var Params paramtable.ComponentParam
+	Params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	Params.Save(Params.ProxyCfg.AccessLog.OutputMode.Key, OutputModeJSON)
+	Params.SaveGroup(map[string]string{
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "base.format":        "$time_now $method_name",
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "search.fields":      "$method_name, $nq, $search_params",
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "search.methods":     "Search",
+		Params.ProxyCfg.AccessLog.Formatter.KeyPrefix + "search.sampleRatio": "0.1",
+	})
+
+	formatters, err := initFormatter(&Params.ProxyCfg.AccessLog)
+	assert.NoError(t, err)
+	formatter, ok := formatters.GetByMethod("Search")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"$method_name", "$nq", "$search_params"}, formatter.keys)
+	assert.Equal(t, 0.1, formatter.sampleRatio)
+	formatter, ok = formatters.GetByMethod("CreateCollection")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"$time_now", "$method_name"}, formatter.keys)
+
+	Params.Save(Params.ProxyCfg.AccessLog.OutputMode.Key, "xml")
+	_, err = initFormatter(&Params.ProxyCfg.AccessLog)
+	assert.Error(t, err)
+}
+
 func TestAccessLogger_WriteFailed(t *testing.T) {
 	once = sync.Once{}
 	var Params paramtable.ComponentParam
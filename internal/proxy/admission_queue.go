@@ -0,0 +1,329 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"container/heap"
+	"context"
+	"maps"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type admissionWaiter struct {
+	priority int
+	// seq keeps the waiters of the same priority in arrival order
+	seq   uint64
+	check func() error
+	// cancel returns the tokens acquired by a passed check
+	cancel func()
+	// done receives the result of the check which admits or rejects the waiter
+	done  chan error
+	index int
+}
+
+// admissionHeap orders the waiters by priority in descending order, then by arrival.
+type admissionHeap []*admissionWaiter
+
+func (h admissionHeap) Len() int { return len(h) }
+
+func (h admissionHeap) Less(i, j int) bool {
+	if h[i].priority != h[j].priority {
+		return h[i].priority > h[j].priority
+	}
+	return h[i].seq < h[j].seq
+}
+
+func (h admissionHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *admissionHeap) Push(x any) {
+	w := x.(*admissionWaiter)
+	w.index = len(*h)
+	*h = append(*h, w)
+}
+
+func (h *admissionHeap) Pop() any {
+	old := *h
+	n := len(old)
+	w := old[n-1]
+	old[n-1] = nil
+	w.index = -1
+	*h = old[:n-1]
+	return w
+}
+
+// admissionQueue holds the rate limited requests for a while instead of rejecting them immediately.
+// The waiting requests are checked again periodically in the order of priority, and rejected with
+// the original rate limit error if not admitted before the max wait time or their deadline.
+type admissionQueue struct {
+	mu      sync.Mutex
+	waiters admissionHeap
+	seq     uint64
+	// whether the dispatcher is running, it exits once there is no waiter
+	running bool
+}
+
+func newAdmissionQueue() *admissionQueue {
+	return &admissionQueue{
+		waiters: make(admissionHeap, 0),
+	}
+}
+
+func isRateLimitError(err error) bool {
+	return errors.Is(err, merr.ErrServiceRateLimit) || errors.Is(err, merr.ErrServiceScopeRateLimit)
+}
+
+// Admit returns nil once check passes, the request waits in the queue if check fails for rate limit.
+// The request is queued without checking if there are requests waiting already,
+// so it doesn't take the tokens before the waiters of the same or higher priority.
+func (q *admissionQueue) Admit(ctx context.Context, priority int, rt internalpb.RateType, check func() error, cancel func()) error {
+	var err error
+	if q.Len() > 0 {
+		err = merr.WrapErrServiceRateLimit(0, "request is queued for admission, please retry later")
+	} else {
+		err = check()
+		if err == nil || !isRateLimitError(err) {
+			return err
+		}
+	}
+
+	start := time.Now()
+	w, ok := q.push(priority, check, cancel)
+	if !ok {
+		metrics.ProxyAdmissionWaitLatency.WithLabelValues(paramtable.GetStringNodeID(), rt.String(), metrics.RejectedLabel).Observe(0)
+		return err
+	}
+
+	timer := time.NewTimer(Params.ProxyCfg.AdmissionMaxWaitTime.GetAsDuration(time.Millisecond))
+	defer timer.Stop()
+	var result error
+	select {
+	case result = <-w.done:
+	case <-timer.C:
+		result = q.remove(w, err)
+	case <-ctx.Done():
+		result = q.remove(w, err)
+	}
+
+	status := metrics.SuccessLabel
+	if result != nil {
+		status = metrics.RejectedLabel
+	}
+	metrics.ProxyAdmissionWaitLatency.WithLabelValues(paramtable.GetStringNodeID(), rt.String(), status).
+		Observe(float64(time.Since(start).Milliseconds()))
+	return result
+}
+
+// push returns false if the queue is full.
+func (q *admissionQueue) push(priority int, check func() error, cancel func()) (*admissionWaiter, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.waiters.Len() >= Params.ProxyCfg.AdmissionMaxQueueLength.GetAsInt() {
+		return nil, false
+	}
+	q.seq++
+	w := &admissionWaiter{
+		priority: priority,
+		seq:      q.seq,
+		check:    check,
+		cancel:   cancel,
+		done:     make(chan error, 1),
+	}
+	heap.Push(&q.waiters, w)
+	q.updateMetrics()
+	if !q.running {
+		q.running = true
+		go q.dispatch(Params.ProxyCfg.AdmissionCheckInterval.GetAsDuration(time.Millisecond))
+	}
+	return w, true
+}
+
+// remove gives up waiting, it returns the result instead if the waiter has been admitted or rejected.
+func (q *admissionQueue) remove(w *admissionWaiter, err error) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if w.index < 0 {
+		return <-w.done
+	}
+	heap.Remove(&q.waiters, w.index)
+	q.updateMetrics()
+	return err
+}
+
+func (q *admissionQueue) dispatch(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !q.admit() {
+			return
+		}
+	}
+}
+
+// admit checks the waiters in the order of priority, it returns false if there is no waiter left.
+// The waiters of lower priority are still checked after a higher one fails,
+// since they may request the collections or rate types not limited.
+// The checks run without holding the lock, so the requests arriving meanwhile are not blocked.
+func (q *admissionQueue) admit() bool {
+	q.mu.Lock()
+	waiters := make([]*admissionWaiter, q.waiters.Len())
+	copy(waiters, q.waiters)
+	q.mu.Unlock()
+
+	sort.Slice(waiters, func(i, j int) bool {
+		return admissionHeap(waiters).Less(i, j)
+	})
+	for _, w := range waiters {
+		err := w.check()
+		if err != nil && isRateLimitError(err) {
+			continue
+		}
+		q.mu.Lock()
+		if w.index >= 0 {
+			heap.Remove(&q.waiters, w.index)
+			w.done <- err
+		} else if err == nil {
+			// the waiter has given up while checking, return the tokens taken for it
+			w.cancel()
+		}
+		q.mu.Unlock()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.updateMetrics()
+	if q.waiters.Len() == 0 {
+		q.running = false
+		return false
+	}
+	return true
+}
+
+func (q *admissionQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.waiters.Len()
+}
+
+func (q *admissionQueue) updateMetrics() {
+	metrics.ProxyAdmissionQueueLength.WithLabelValues(paramtable.GetStringNodeID()).Set(float64(q.waiters.Len()))
+}
+
+// priorityConfig is the parsed priorities of a priority config group.
+type priorityConfig struct {
+	raw        map[string]string
+	priorities map[string]int
+}
+
+// priorityConfigCache parses a priority config group once it's loaded or changed,
+// so the invalid priorities are reported once instead of on every request.
+type priorityConfigCache struct {
+	name   string
+	config atomic.Pointer[priorityConfig]
+}
+
+func (c *priorityConfigCache) Get(raw map[string]string) map[string]int {
+	if config := c.config.Load(); config != nil && maps.Equal(config.raw, raw) {
+		return config.priorities
+	}
+	priorities := make(map[string]int, len(raw))
+	for key, value := range raw {
+		if value == "" {
+			continue
+		}
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			log.Warn("invalid admission priority, use the default one",
+				zap.String("config", c.name), zap.String("key", key), zap.String("value", value))
+			continue
+		}
+		priorities[strings.ToLower(key)] = priority
+	}
+	c.config.Store(&priorityConfig{raw: raw, priorities: priorities})
+	return priorities
+}
+
+var (
+	admissionTypePriorities = &priorityConfigCache{name: "typePriorities"}
+	admissionRolePriorities = &priorityConfigCache{name: "rolePriorities"}
+)
+
+// admissionPriority returns the highest priority of the request type and the roles of the user,
+// the priority set by the client could only lower it.
+func admissionPriority(ctx context.Context, username string, rt internalpb.RateType) int {
+	typeKey := "dml"
+	if IsDDLRequest(rt) {
+		typeKey = "ddl"
+	} else if rt == internalpb.RateType_DQLSearch || rt == internalpb.RateType_DQLQuery {
+		typeKey = "dql"
+	}
+	priority := admissionTypePriorities.Get(Params.ProxyCfg.AdmissionTypePriorities.GetValue())[typeKey]
+
+	rolePriorities := admissionRolePriorities.Get(Params.ProxyCfg.AdmissionRolePriorities.GetValue())
+	if username != "" && len(rolePriorities) > 0 && globalMetaCache != nil {
+		for _, role := range globalMetaCache.GetUserRole(username) {
+			if value, ok := rolePriorities[strings.ToLower(role)]; ok {
+				priority = max(priority, value)
+			}
+		}
+	}
+
+	if Params.ProxyCfg.AdmissionClientPriorityEnabled.GetAsBool() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(util.HeaderPriority); len(values) > 0 {
+				if clientPriority, err := strconv.Atoi(values[0]); err == nil {
+					priority = min(priority, clientPriority)
+				}
+			}
+		}
+	}
+	return priority
+}
+
+// Admit checks the rate limits like CheckWithCaller, the rate limited requests wait for admission if enabled.
+func (m *SimpleLimiter) Admit(ctx context.Context, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
+	username := GetCurUserFromContextOrDefault(ctx)
+	check := func() error {
+		return m.CheckWithCaller(username, dbID, collectionIDToPartIDs, rt, n)
+	}
+	if !Params.ProxyCfg.AdmissionEnabled.GetAsBool() {
+		return check()
+	}
+	cancel := func() {
+		m.CancelWithCaller(username, dbID, collectionIDToPartIDs, rt, n)
+	}
+	return m.admissionQueue.Admit(ctx, admissionPriority(ctx, username, rt), rt, check, cancel)
+}
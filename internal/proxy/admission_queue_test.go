@@ -0,0 +1,226 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// newTokenCheck returns a check which passes only when there are tokens left.
+func newTokenCheck(tokens *atomic.Int32) func() error {
+	return func() error {
+		if tokens.Dec() >= 0 {
+			return nil
+		}
+		tokens.Inc()
+		return merr.WrapErrServiceRateLimit(1)
+	}
+}
+
+func TestAdmissionQueue(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(Params.ProxyCfg.AdmissionCheckInterval.Key, "1")
+	defer params.Reset(Params.ProxyCfg.AdmissionCheckInterval.Key)
+	ctx := context.Background()
+
+	t.Run("not rate limited", func(t *testing.T) {
+		q := newAdmissionQueue()
+		assert.NoError(t, q.Admit(ctx, 0, internalpb.RateType_DQLSearch, func() error { return nil }, func() {}))
+		err := q.Admit(ctx, 0, internalpb.RateType_DMLInsert, func() error { return merr.ErrServiceQuotaExceeded }, func() {})
+		assert.ErrorIs(t, err, merr.ErrServiceQuotaExceeded)
+		assert.Equal(t, 0, q.Len())
+	})
+
+	t.Run("admitted", func(t *testing.T) {
+		q := newAdmissionQueue()
+		tokens := atomic.NewInt32(0)
+		go func() {
+			assert.Eventually(t, func() bool { return q.Len() == 1 }, time.Second, time.Millisecond)
+			tokens.Store(1)
+		}()
+		assert.NoError(t, q.Admit(ctx, 0, internalpb.RateType_DQLSearch, newTokenCheck(tokens), func() { tokens.Inc() }))
+		assert.Equal(t, 0, q.Len())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		params.Save(Params.ProxyCfg.AdmissionMaxWaitTime.Key, "20")
+		defer params.Reset(Params.ProxyCfg.AdmissionMaxWaitTime.Key)
+		q := newAdmissionQueue()
+		err := q.Admit(ctx, 0, internalpb.RateType_DQLSearch, newTokenCheck(atomic.NewInt32(0)), func() {})
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+		assert.Equal(t, 0, q.Len())
+
+		// the deadline of the request is earlier than the max wait time
+		params.Save(Params.ProxyCfg.AdmissionMaxWaitTime.Key, "10000")
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		err = q.Admit(ctx, 0, internalpb.RateType_DQLSearch, newTokenCheck(atomic.NewInt32(0)), func() {})
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+		assert.Equal(t, 0, q.Len())
+	})
+
+	t.Run("queue full", func(t *testing.T) {
+		params.Save(Params.ProxyCfg.AdmissionMaxQueueLength.Key, "0")
+		defer params.Reset(Params.ProxyCfg.AdmissionMaxQueueLength.Key)
+		q := newAdmissionQueue()
+		start := time.Now()
+		err := q.Admit(ctx, 0, internalpb.RateType_DQLSearch, newTokenCheck(atomic.NewInt32(0)), func() {})
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+		assert.Less(t, time.Since(start), Params.ProxyCfg.AdmissionMaxWaitTime.GetAsDuration(time.Millisecond))
+	})
+
+	t.Run("priority", func(t *testing.T) {
+		q := newAdmissionQueue()
+		tokens := atomic.NewInt32(0)
+		admitted := make(chan int, 3)
+		wg := sync.WaitGroup{}
+		for i, priority := range []int{0, 10, 5} {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, q.Admit(ctx, priority, internalpb.RateType_DQLSearch, newTokenCheck(tokens), func() { tokens.Inc() }))
+				admitted <- priority
+			}()
+			assert.Eventually(t, func() bool { return q.Len() == i+1 }, time.Second, time.Millisecond)
+		}
+
+		for _, expected := range []int{10, 5, 0} {
+			tokens.Store(1)
+			assert.Equal(t, expected, <-admitted)
+		}
+		wg.Wait()
+	})
+
+	t.Run("no queue jumping", func(t *testing.T) {
+		q := newAdmissionQueue()
+		tokens := atomic.NewInt32(0)
+		admitted := make(chan int, 2)
+		go func() {
+			assert.NoError(t, q.Admit(ctx, 0, internalpb.RateType_DQLSearch, newTokenCheck(tokens), func() { tokens.Inc() }))
+			admitted <- 0
+		}()
+		assert.Eventually(t, func() bool { return q.Len() == 1 }, time.Second, time.Millisecond)
+
+		// the tokens are available for the new request, but it's queued after the waiter
+		tokens.Store(1)
+		go func() {
+			assert.NoError(t, q.Admit(ctx, 0, internalpb.RateType_DQLSearch, newTokenCheck(tokens), func() { tokens.Inc() }))
+			admitted <- 1
+		}()
+		assert.Equal(t, 0, <-admitted)
+		tokens.Store(1)
+		assert.Equal(t, 1, <-admitted)
+	})
+
+	t.Run("cancel tokens of waiter given up", func(t *testing.T) {
+		q := newAdmissionQueue()
+		tokens := atomic.NewInt32(0)
+		check := newTokenCheck(tokens)
+		ctx, cancel := context.WithCancel(ctx)
+		checked := make(chan struct{})
+		// the waiter gives up while its check passes
+		err := q.Admit(ctx, 0, internalpb.RateType_DQLSearch, func() error {
+			if q.Len() == 0 {
+				return check()
+			}
+			cancel()
+			<-checked
+			tokens.Store(1)
+			return check()
+		}, func() { tokens.Inc() })
+		assert.ErrorIs(t, err, merr.ErrServiceRateLimit)
+		close(checked)
+		assert.Eventually(t, func() bool { return tokens.Load() == 1 }, time.Second, time.Millisecond)
+	})
+
+	t.Run("check without lock", func(t *testing.T) {
+		q := newAdmissionQueue()
+		tokens := atomic.NewInt32(0)
+		check := newTokenCheck(tokens)
+		go func() {
+			assert.Eventually(t, func() bool { return q.Len() == 1 }, time.Second, time.Millisecond)
+			tokens.Store(1)
+		}()
+		// the check of the waiter accesses the queue, which deadlocks if the lock is held
+		assert.NoError(t, q.Admit(ctx, 0, internalpb.RateType_DQLSearch, func() error {
+			q.Len()
+			return check()
+		}, func() { tokens.Inc() }))
+		assert.Equal(t, 0, q.Len())
+	})
+}
+
+func TestPriorityConfigCache(t *testing.T) {
+	cache := &priorityConfigCache{name: "test"}
+	raw := map[string]string{"admin": "10", "Reader": "5", "bad": "high", "empty": ""}
+	priorities := cache.Get(raw)
+	assert.Equal(t, map[string]int{"admin": 10, "reader": 5}, priorities)
+
+	// parsed once until the config changes
+	config := cache.config.Load()
+	cache.Get(map[string]string{"admin": "10", "Reader": "5", "bad": "high", "empty": ""})
+	assert.Same(t, config, cache.config.Load())
+
+	priorities = cache.Get(map[string]string{"admin": "1"})
+	assert.Equal(t, map[string]int{"admin": 1}, priorities)
+	assert.NotSame(t, config, cache.config.Load())
+}
+
+func TestAdmissionPriority(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.SaveGroup(map[string]string{
+		"proxy.admission.typePriorities.dql":   "5",
+		"proxy.admission.rolePriorities.admin": "10",
+	})
+	defer params.ResetGroup("proxy.admission.typePriorities.dql", "proxy.admission.rolePriorities.admin")
+
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetUserRole("alice").Return([]string{"public", "admin"}).Maybe()
+	mockCache.EXPECT().GetUserRole("bob").Return([]string{"public"}).Maybe()
+	globalMetaCache = mockCache
+
+	ctx := context.Background()
+	assert.Equal(t, 0, admissionPriority(ctx, "", internalpb.RateType_DMLInsert))
+	assert.Equal(t, 5, admissionPriority(ctx, "", internalpb.RateType_DQLQuery))
+	assert.Equal(t, 10, admissionPriority(ctx, "alice", internalpb.RateType_DQLSearch))
+	assert.Equal(t, 5, admissionPriority(ctx, "bob", internalpb.RateType_DQLSearch))
+
+	// the client could only lower the priority
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(util.HeaderPriority, "1"))
+	assert.Equal(t, 5, admissionPriority(ctx, "bob", internalpb.RateType_DQLSearch))
+	params.Save(Params.ProxyCfg.AdmissionClientPriorityEnabled.Key, "true")
+	defer params.Reset(Params.ProxyCfg.AdmissionClientPriorityEnabled.Key)
+	assert.Equal(t, 1, admissionPriority(ctx, "bob", internalpb.RateType_DQLSearch))
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(util.HeaderPriority, "100"))
+	assert.Equal(t, 10, admissionPriority(ctx, "alice", internalpb.RateType_DQLSearch))
+}
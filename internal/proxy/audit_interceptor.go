@@ -0,0 +1,81 @@
+package proxy
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
+	"github.com/milvus-io/milvus/pkg/v2/util/funcutil"
+)
+
+// UnaryServerAuditInterceptor writes audit records for the DDL, RBAC and data-mutating grpc requests.
+// It should be placed before the authentication and privilege interceptors, so that the rejected requests are audited too.
+func UnaryServerAuditInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		address := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			address = p.Addr.String()
+		}
+		return AuditInterceptor(ctx, req, GetCurUserFromContextOrDefault(ctx), address, info.FullMethod, handler)
+	}
+}
+
+func AuditInterceptor(ctx context.Context, req any, userName, address, fullMethod string, handler grpc.UnaryHandler) (interface{}, error) {
+	if !auditlog.Enabled() {
+		return handler(ctx, req)
+	}
+	record := auditlog.NewRecord(fullMethod, req)
+	if record == nil {
+		return handler(ctx, req)
+	}
+
+	record.User = userName
+	record.Address = address
+	if traceID := trace.SpanFromContext(ctx).SpanContext().TraceID(); traceID.IsValid() {
+		record.TraceID = traceID.String()
+	}
+	record.OldValue = auditOldValue(ctx, req)
+
+	resp, err := handler(ctx, req)
+	record.SetResult(resp, err)
+	auditlog.Write(record)
+	return resp, err
+}
+
+// auditOldValue returns the value before the change for the requests altering existing settings.
+func auditOldValue(ctx context.Context, req any) map[string]any {
+	if globalMetaCache == nil {
+		return nil
+	}
+	switch r := req.(type) {
+	case *milvuspb.AlterCollectionRequest:
+		info, err := globalMetaCache.GetCollectionInfo(ctx, r.GetDbName(), r.GetCollectionName(), 0)
+		if err != nil {
+			return nil
+		}
+		return map[string]any{"properties": funcutil.KeyValuePair2Map(info.properties)}
+	case *milvuspb.AlterCollectionFieldRequest:
+		schema, err := globalMetaCache.GetCollectionSchema(ctx, r.GetDbName(), r.GetCollectionName())
+		if err != nil {
+			return nil
+		}
+		for _, field := range schema.GetFields() {
+			if field.GetName() == r.GetFieldName() {
+				return map[string]any{"type_params": funcutil.KeyValuePair2Map(field.GetTypeParams())}
+			}
+		}
+	case *milvuspb.AlterDatabaseRequest:
+		info, err := globalMetaCache.GetDatabaseInfo(ctx, r.GetDbName())
+		if err != nil {
+			return nil
+		}
+		return map[string]any{"properties": funcutil.KeyValuePair2Map(info.properties)}
+	case *milvuspb.OperateUserRoleRequest:
+		return map[string]any{"roles": globalMetaCache.GetUserRole(r.GetUsername())}
+	}
+	return nil
+}
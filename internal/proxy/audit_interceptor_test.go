@@ -0,0 +1,68 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy/auditlog"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestAuditInterceptor(t *testing.T) {
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AuditLog.Enable.Key, "true")
+	params.Save(params.ProxyCfg.AuditLog.LocalPath.Key, t.TempDir())
+	params.Save(params.ProxyCfg.AuditLog.Filename.Key, "audit.log")
+	auditlog.InitAuditLogger(&params)
+	require.True(t, auditlog.Enabled())
+
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetUserRole("alice").Return([]string{"public"})
+	globalMetaCache = mockCache
+
+	called := 0
+	handler := func(ctx context.Context, req any) (interface{}, error) {
+		called++
+		return merr.Status(merr.ErrPrivilegeNotPermitted), nil
+	}
+
+	// not audited
+	_, err := AuditInterceptor(context.Background(), &milvuspb.QueryRequest{}, "alice", "127.0.0.1", "/milvus.proto.milvus.MilvusService/Query", handler)
+	assert.NoError(t, err)
+
+	resp, err := AuditInterceptor(context.Background(), &milvuspb.OperateUserRoleRequest{
+		Username: "alice",
+		RoleName: "admin",
+		Type:     milvuspb.OperateUserRoleType_AddUserToRole,
+	}, "alice", "127.0.0.1", "/milvus.proto.milvus.MilvusService/OperateUserRole", handler)
+	assert.NoError(t, err)
+	assert.Error(t, merr.Error(resp.(*commonpb.Status)))
+	assert.Equal(t, 2, called)
+
+	content, err := os.ReadFile(path.Join(params.ProxyCfg.AuditLog.LocalPath.GetValue(), "audit.log"))
+	require.NoError(t, err)
+	_, err = auditlog.Verify(bytes.NewReader(content), "")
+	assert.NoError(t, err)
+
+	record := &auditlog.Record{}
+	require.NoError(t, json.Unmarshal(content, record))
+	assert.Equal(t, "OperateUserRole", record.Method)
+	assert.Equal(t, "alice", record.User)
+	assert.Equal(t, "127.0.0.1", record.Address)
+	assert.Equal(t, auditlog.StatusFailed, record.Status)
+	assert.Equal(t, []any{"public"}, record.OldValue["roles"])
+	assert.Equal(t, "admin", record.NewValue["role_name"])
+}
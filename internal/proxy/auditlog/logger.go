@@ -0,0 +1,289 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy/accesslog"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+var (
+	_globalL *AuditLogger
+	once     sync.Once
+)
+
+// AuditLogger writes hash chained audit records.
+// Records are written synchronously without any cache, a record is reported as lost
+// in the server log if it could not be written.
+type AuditLogger struct {
+	mu       sync.Mutex
+	writer   io.Writer
+	nodeID   int64
+	seq      uint64
+	prevHash string
+}
+
+func NewAuditLogger(writer io.Writer, nodeID int64) *AuditLogger {
+	return &AuditLogger{
+		writer: writer,
+		nodeID: nodeID,
+	}
+}
+
+// Write seals r into the hash chain and writes it.
+func (l *AuditLogger) Write(r *Record) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r.Seq = l.seq + 1
+	r.NodeID = l.nodeID
+	r.PrevHash = l.prevHash
+	hash, err := hashRecord(r)
+	if err != nil {
+		return err
+	}
+	r.Hash = hash
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := l.writer.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	l.seq = r.Seq
+	l.prevHash = r.Hash
+	return nil
+}
+
+// restore continues the hash chain from the last record of the existing log files.
+func (l *AuditLogger) restore(dir, filename string) error {
+	r, err := lastRecord(dir, filename)
+	if err != nil || r == nil {
+		return err
+	}
+	l.seq = r.Seq
+	l.prevHash = r.Hash
+	return nil
+}
+
+func (l *AuditLogger) Close() {
+	if closer, ok := l.writer.(io.Closer); ok {
+		closer.Close()
+	}
+}
+
+// hashRecord returns the hex encoded sha256 of r encoded with an empty Hash.
+func hashRecord(r *Record) (string, error) {
+	hash := r.Hash
+	r.Hash = ""
+	bs, err := json.Marshal(r)
+	r.Hash = hash
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func decodeRecord(line []byte) (*Record, error) {
+	r := &Record{}
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+	if err := decoder.Decode(r); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Verify checks the hash chain of the records read from reader.
+// prevHash is the hash of the record before the first one, empty if reader starts from the head of the chain.
+// It returns the hash of the last record, or the first broken record as error.
+func Verify(reader io.Reader, prevHash string) (string, error) {
+	br := bufio.NewReader(reader)
+	for {
+		line, readErr := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			r, err := decodeRecord(line)
+			if err != nil {
+				return prevHash, errors.Wrapf(err, "decode audit record failed after hash %s", prevHash)
+			}
+			if r.PrevHash != prevHash {
+				return prevHash, fmt.Errorf("audit record %d is not linked to the previous record, prev_hash: %s, expected: %s", r.Seq, r.PrevHash, prevHash)
+			}
+			hash, err := hashRecord(r)
+			if err != nil {
+				return prevHash, err
+			}
+			if hash != r.Hash {
+				return prevHash, fmt.Errorf("audit record %d was modified, hash: %s, expected: %s", r.Seq, r.Hash, hash)
+			}
+			prevHash = r.Hash
+		}
+		if readErr == io.EOF {
+			return prevHash, nil
+		}
+		if readErr != nil {
+			return prevHash, readErr
+		}
+	}
+}
+
+// lastRecord finds the last record in the current log file,
+// or in the latest sealed file if the current one is empty.
+func lastRecord(dir, filename string) (*Record, error) {
+	ext := path.Ext(filename)
+	prefix := filename[:len(filename)-len(ext)]
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// sealed files are named as prefix.time.ext, so the latest is the last one in order
+	sealed := make([]string, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		if name != filename && strings.HasPrefix(name, prefix) && strings.HasSuffix(name, ext) {
+			sealed = append(sealed, name)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(sealed)))
+
+	for _, name := range append([]string{filename}, sealed...) {
+		r, err := lastRecordOfFile(path.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if r != nil {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func lastRecordOfFile(filename string) (*Record, error) {
+	file, err := os.Open(filename)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var last []byte
+	br := bufio.NewReader(file)
+	for {
+		line, readErr := br.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			last = line
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, readErr
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return decodeRecord(last)
+}
+
+func newAuditLogger(params *paramtable.ComponentParam) (*AuditLogger, error) {
+	logCfg := &params.ProxyCfg.AuditLog
+	if len(logCfg.Filename.GetValue()) == 0 {
+		return NewAuditLogger(os.Stdout, paramtable.GetNodeID()), nil
+	}
+
+	writer, err := accesslog.NewRotateWriterWithConfig(&accesslog.RotateConfig{
+		LocalPath:     logCfg.LocalPath.GetValue(),
+		FileName:      logCfg.Filename.GetValue(),
+		RotatedTime:   logCfg.RotatedTime.GetAsInt64(),
+		MaxSize:       logCfg.MaxSize.GetAsInt(),
+		MaxBackups:    logCfg.MaxBackups.GetAsInt(),
+		MinioEnable:   logCfg.MinioEnable.GetAsBool(),
+		RemotePath:    logCfg.RemotePath.GetValue(),
+		RemoteMaxTime: logCfg.RemoteMaxTime.GetAsInt(),
+	}, &params.MinioCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := NewAuditLogger(writer, paramtable.GetNodeID())
+	if err := logger.restore(logCfg.LocalPath.GetValue(), logCfg.Filename.GetValue()); err != nil {
+		writer.Close()
+		return nil, err
+	}
+	return logger, nil
+}
+
+func InitAuditLogger(params *paramtable.ComponentParam) {
+	once.Do(func() {
+		if !params.ProxyCfg.AuditLog.Enable.GetAsBool() {
+			return
+		}
+		logger, err := newAuditLogger(params)
+		if err != nil {
+			log.Warn("Init audit logger failed", zap.Error(err))
+			return
+		}
+		_globalL = logger
+		log.Info("Init audit logger success", zap.Uint64("seq", logger.seq))
+	})
+}
+
+// Enabled returns whether the audit log is enabled.
+func Enabled() bool {
+	return _globalL != nil
+}
+
+// Write writes r with the global audit logger.
+func Write(r *Record) {
+	if _globalL == nil || r == nil {
+		return
+	}
+	if err := _globalL.Write(r); err != nil {
+		log.Warn("write audit log failed, audit record lost",
+			zap.String("method", r.Method),
+			zap.String("user", r.User),
+			zap.String("object", r.Object),
+			zap.String("status", r.Status),
+			zap.Error(err))
+	}
+}
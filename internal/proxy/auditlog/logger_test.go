@@ -0,0 +1,145 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestMain(m *testing.M) {
+	paramtable.Init()
+	os.Exit(m.Run())
+}
+
+func writeRecords(t *testing.T, logger *AuditLogger, num int) {
+	for i := 0; i < num; i++ {
+		r := NewRecord("DropCollection", &milvuspb.DropCollectionRequest{DbName: "default", CollectionName: "test"})
+		r.User = "root"
+		r.SetResult(merr.Success(), nil)
+		require.NoError(t, logger.Write(r))
+	}
+}
+
+func TestAuditLogger_HashChain(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := NewAuditLogger(buf, 1)
+	writeRecords(t, logger, 3)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	first, err := decodeRecord([]byte(lines[0]))
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), first.Seq)
+	assert.Equal(t, int64(1), first.NodeID)
+	assert.Empty(t, first.PrevHash)
+
+	last, err := Verify(strings.NewReader(buf.String()), "")
+	assert.NoError(t, err)
+	assert.Equal(t, logger.prevHash, last)
+
+	t.Run("modified", func(t *testing.T) {
+		tampered := strings.Replace(buf.String(), `"user":"root"`, `"user":"guest"`, 1)
+		_, err := Verify(strings.NewReader(tampered), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		tampered := lines[0] + "\n" + lines[2] + "\n"
+		_, err := Verify(strings.NewReader(tampered), "")
+		assert.Error(t, err)
+	})
+
+	t.Run("continue", func(t *testing.T) {
+		_, err := Verify(strings.NewReader(lines[2]), first.Hash)
+		assert.Error(t, err)
+		second, err := decodeRecord([]byte(lines[1]))
+		require.NoError(t, err)
+		_, err = Verify(strings.NewReader(lines[2]), second.Hash)
+		assert.NoError(t, err)
+	})
+}
+
+func TestAuditLogger_Restore(t *testing.T) {
+	dir := t.TempDir()
+	filename := "audit.log"
+
+	file, err := os.Create(path.Join(dir, filename))
+	require.NoError(t, err)
+	logger := NewAuditLogger(file, 1)
+	writeRecords(t, logger, 2)
+	require.NoError(t, file.Close())
+
+	// the current file was sealed before restart
+	require.NoError(t, os.Rename(path.Join(dir, filename), path.Join(dir, "audit.2024-01-01T00-00-00.000.log")))
+	require.NoError(t, os.WriteFile(path.Join(dir, filename), nil, 0o644))
+
+	file, err = os.OpenFile(path.Join(dir, filename), os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	restored := NewAuditLogger(file, 1)
+	require.NoError(t, restored.restore(dir, filename))
+	assert.Equal(t, uint64(2), restored.seq)
+	assert.Equal(t, logger.prevHash, restored.prevHash)
+	writeRecords(t, restored, 1)
+	require.NoError(t, file.Close())
+
+	sealed, err := os.ReadFile(path.Join(dir, "audit.2024-01-01T00-00-00.000.log"))
+	require.NoError(t, err)
+	current, err := os.ReadFile(path.Join(dir, filename))
+	require.NoError(t, err)
+	_, err = Verify(bytes.NewReader(append(sealed, current...)), "")
+	assert.NoError(t, err)
+}
+
+func TestAuditLogger_Init(t *testing.T) {
+	once = sync.Once{}
+	defer func() {
+		once = sync.Once{}
+		_globalL = nil
+	}()
+
+	var params paramtable.ComponentParam
+	params.Init(paramtable.NewBaseTable(paramtable.SkipRemote(true)))
+	params.Save(params.ProxyCfg.AuditLog.Enable.Key, "true")
+	params.Save(params.ProxyCfg.AuditLog.LocalPath.Key, t.TempDir())
+	params.Save(params.ProxyCfg.AuditLog.Filename.Key, "audit.log")
+
+	InitAuditLogger(&params)
+	require.True(t, Enabled())
+	defer _globalL.Close()
+
+	r := NewRecord("CreateRole", &milvuspb.CreateRoleRequest{Entity: &milvuspb.RoleEntity{Name: "admin"}})
+	r.SetResult(merr.Success(), nil)
+	Write(r)
+
+	content, err := os.ReadFile(path.Join(params.ProxyCfg.AuditLog.LocalPath.GetValue(), "audit.log"))
+	require.NoError(t, err)
+	_, err = Verify(bytes.NewReader(content), "")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"object":"admin"`)
+}
@@ -0,0 +1,275 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"bytes"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/requestutil"
+)
+
+const (
+	CategoryDDL    = "ddl"
+	CategoryRBAC   = "rbac"
+	CategoryDML    = "dml"
+	CategoryImport = "import"
+
+	StatusSuccess = "success"
+	StatusFailed  = "failed"
+
+	redacted   = "******"
+	timeFormat = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// methodCategories lists the audited methods.
+var methodCategories = map[string]string{
+	"CreateCollection":      CategoryDDL,
+	"DropCollection":        CategoryDDL,
+	"AlterCollection":       CategoryDDL,
+	"AlterCollectionField":  CategoryDDL,
+	"AddCollectionField":    CategoryDDL,
+	"RenameCollection":      CategoryDDL,
+	"LoadCollection":        CategoryDDL,
+	"ReleaseCollection":     CategoryDDL,
+	"CreatePartition":       CategoryDDL,
+	"DropPartition":         CategoryDDL,
+	"LoadPartitions":        CategoryDDL,
+	"ReleasePartitions":     CategoryDDL,
+	"CreateIndex":           CategoryDDL,
+	"AlterIndex":            CategoryDDL,
+	"DropIndex":             CategoryDDL,
+	"CreateAlias":           CategoryDDL,
+	"DropAlias":             CategoryDDL,
+	"AlterAlias":            CategoryDDL,
+	"CreateDatabase":        CategoryDDL,
+	"DropDatabase":          CategoryDDL,
+	"AlterDatabase":         CategoryDDL,
+	"CreateResourceGroup":   CategoryDDL,
+	"DropResourceGroup":     CategoryDDL,
+	"UpdateResourceGroups":  CategoryDDL,
+	"TransferNode":          CategoryDDL,
+	"TransferReplica":       CategoryDDL,
+	"CreateCredential":      CategoryRBAC,
+	"UpdateCredential":      CategoryRBAC,
+	"DeleteCredential":      CategoryRBAC,
+	"CreateRole":            CategoryRBAC,
+	"DropRole":              CategoryRBAC,
+	"OperateUserRole":       CategoryRBAC,
+	"OperatePrivilege":      CategoryRBAC,
+	"OperatePrivilegeV2":    CategoryRBAC,
+	"CreatePrivilegeGroup":  CategoryRBAC,
+	"DropPrivilegeGroup":    CategoryRBAC,
+	"OperatePrivilegeGroup": CategoryRBAC,
+	"RestoreRBAC":           CategoryRBAC,
+	"Delete":                CategoryDML,
+	"Upsert":                CategoryDML,
+	"Import":                CategoryImport,
+	"ImportV2":              CategoryImport,
+}
+
+// Category returns the audit category of the method, empty if the method is not audited.
+// Both the short method name and the grpc full method are accepted.
+func Category(method string) string {
+	return methodCategories[path.Base(method)]
+}
+
+// Record is a single audit entry, it's written as one json line.
+// Hash is the sha256 of the record encoded with an empty Hash,
+// and PrevHash links the record to the one written before it.
+type Record struct {
+	Time      string         `json:"time"`
+	Seq       uint64         `json:"seq"`
+	NodeID    int64          `json:"node_id"`
+	Category  string         `json:"category"`
+	Method    string         `json:"method"`
+	User      string         `json:"user"`
+	Address   string         `json:"address"`
+	Database  string         `json:"database,omitempty"`
+	Object    string         `json:"object,omitempty"`
+	OldValue  map[string]any `json:"old_value,omitempty"`
+	NewValue  map[string]any `json:"new_value,omitempty"`
+	Status    string         `json:"status"`
+	ErrorCode int32          `json:"error_code"`
+	ErrorMsg  string         `json:"error_msg,omitempty"`
+	TraceID   string         `json:"trace_id,omitempty"`
+	PrevHash  string         `json:"prev_hash"`
+	Hash      string         `json:"hash"`
+}
+
+// NewRecord creates the audit record of req, returns nil if the method is not audited.
+func NewRecord(method string, req any) *Record {
+	method = path.Base(method)
+	category := Category(method)
+	if category == "" {
+		return nil
+	}
+
+	r := &Record{
+		Time:     time.Now().Format(timeFormat),
+		Category: category,
+		Method:   method,
+		Object:   objectName(req),
+		NewValue: requestValue(req),
+	}
+	if dbName, ok := requestutil.GetDbNameFromRequest(req); ok {
+		r.Database = dbName.(string)
+	}
+	return r
+}
+
+// SetResult fills the result status of the audited request.
+func (r *Record) SetResult(resp any, err error) {
+	if err == nil {
+		if status, ok := requestutil.GetStatusFromResponse(resp); ok {
+			err = merr.Error(status)
+		}
+	}
+	if err != nil {
+		r.Status = StatusFailed
+		r.ErrorCode = merr.Code(err)
+		r.ErrorMsg = err.Error()
+		return
+	}
+	r.Status = StatusSuccess
+}
+
+// objectName returns the name of the object changed by req.
+func objectName(req any) string {
+	switch r := req.(type) {
+	case *milvuspb.CreateCredentialRequest:
+		return r.GetUsername()
+	case *milvuspb.UpdateCredentialRequest:
+		return r.GetUsername()
+	case *milvuspb.DeleteCredentialRequest:
+		return r.GetUsername()
+	case *milvuspb.CreateRoleRequest:
+		return r.GetEntity().GetName()
+	case *milvuspb.DropRoleRequest:
+		return r.GetRoleName()
+	case *milvuspb.OperateUserRoleRequest:
+		return r.GetUsername()
+	case *milvuspb.OperatePrivilegeRequest:
+		return r.GetEntity().GetRole().GetName()
+	case *milvuspb.OperatePrivilegeV2Request:
+		return r.GetRole().GetName()
+	case *milvuspb.CreatePrivilegeGroupRequest:
+		return r.GetGroupName()
+	case *milvuspb.DropPrivilegeGroupRequest:
+		return r.GetGroupName()
+	case *milvuspb.OperatePrivilegeGroupRequest:
+		return r.GetGroupName()
+	case *milvuspb.CreateResourceGroupRequest:
+		return r.GetResourceGroup()
+	case *milvuspb.DropResourceGroupRequest:
+		return r.GetResourceGroup()
+	case *milvuspb.CreateDatabaseRequest:
+		return r.GetDbName()
+	case *milvuspb.DropDatabaseRequest:
+		return r.GetDbName()
+	case *milvuspb.AlterDatabaseRequest:
+		return r.GetDbName()
+	}
+	if name, ok := requestutil.GetCollectionNameFromRequest(req); ok {
+		return name.(string)
+	}
+	return ""
+}
+
+// requestValue converts req to a json object as the new value of the record.
+// Passwords are redacted and the row data of upsert is dropped, only the row count is kept.
+func requestValue(req any) map[string]any {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return nil
+	}
+	switch r := msg.(type) {
+	case *milvuspb.UpsertRequest:
+		msg = &milvuspb.UpsertRequest{
+			DbName:         r.GetDbName(),
+			CollectionName: r.GetCollectionName(),
+			PartitionName:  r.GetPartitionName(),
+			NumRows:        r.GetNumRows(),
+		}
+	case *milvuspb.CreateCollectionRequest:
+		// the schema is serialized, unfold it to be readable
+		schema := &schemapb.CollectionSchema{}
+		if err := proto.Unmarshal(r.GetSchema(), schema); err == nil {
+			value := protoValue(r)
+			if value != nil {
+				value["schema"] = protoValue(schema)
+			}
+			return redact(value)
+		}
+	}
+	return redact(protoValue(msg))
+}
+
+func protoValue(msg proto.Message) map[string]any {
+	bs, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(msg)
+	if err != nil {
+		return nil
+	}
+	value, err := decode(bs)
+	if err != nil {
+		return nil
+	}
+	// the msg base carries nothing useful for auditing
+	delete(value, "base")
+	return value
+}
+
+// decode unmarshals bs and keeps the numbers as they are,
+// so that encoding the value again gives exactly the same bytes.
+func decode(bs []byte) (map[string]any, error) {
+	value := make(map[string]any)
+	decoder := json.NewDecoder(bytes.NewReader(bs))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func redact(value map[string]any) map[string]any {
+	for k, v := range value {
+		if strings.Contains(strings.ToLower(k), "password") {
+			value[k] = redacted
+			continue
+		}
+		switch v := v.(type) {
+		case map[string]any:
+			redact(v)
+		case []any:
+			for _, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					redact(m)
+				}
+			}
+		}
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auditlog
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+func TestNewRecord(t *testing.T) {
+	t.Run("not audited", func(t *testing.T) {
+		assert.Nil(t, NewRecord("Search", &milvuspb.SearchRequest{}))
+		assert.Empty(t, Category("/milvus.proto.milvus.MilvusService/Query"))
+		assert.Equal(t, CategoryDML, Category("/milvus.proto.milvus.MilvusService/Delete"))
+	})
+
+	t.Run("credential", func(t *testing.T) {
+		r := NewRecord("/milvus.proto.milvus.MilvusService/UpdateCredential", &milvuspb.UpdateCredentialRequest{
+			Username:    "alice",
+			OldPassword: "old",
+			NewPassword: "new",
+		})
+		assert.Equal(t, CategoryRBAC, r.Category)
+		assert.Equal(t, "UpdateCredential", r.Method)
+		assert.Equal(t, "alice", r.Object)
+		assert.Equal(t, redacted, r.NewValue["old_password"])
+		assert.Equal(t, redacted, r.NewValue["new_password"])
+	})
+
+	t.Run("upsert", func(t *testing.T) {
+		r := NewRecord("Upsert", &milvuspb.UpsertRequest{
+			DbName:         "db",
+			CollectionName: "coll",
+			NumRows:        2,
+			FieldsData:     []*schemapb.FieldData{{FieldName: "pk"}},
+			Base:           &commonpb.MsgBase{MsgType: commonpb.MsgType_Upsert},
+		})
+		assert.Equal(t, "db", r.Database)
+		assert.Equal(t, "coll", r.Object)
+		assert.NotContains(t, r.NewValue, "fields_data")
+		assert.NotContains(t, r.NewValue, "base")
+		assert.Contains(t, r.NewValue, "num_rows")
+	})
+
+	t.Run("create collection", func(t *testing.T) {
+		schema, err := proto.Marshal(&schemapb.CollectionSchema{Name: "coll"})
+		assert.NoError(t, err)
+		r := NewRecord("CreateCollection", &milvuspb.CreateCollectionRequest{CollectionName: "coll", Schema: schema})
+		assert.Equal(t, "coll", r.NewValue["schema"].(map[string]any)["name"])
+	})
+}
+
+func TestRecord_SetResult(t *testing.T) {
+	r := &Record{}
+	r.SetResult(merr.Success(), nil)
+	assert.Equal(t, StatusSuccess, r.Status)
+
+	r = &Record{}
+	r.SetResult(merr.Status(merr.ErrPrivilegeNotPermitted), nil)
+	assert.Equal(t, StatusFailed, r.Status)
+	assert.Equal(t, merr.Code(merr.ErrPrivilegeNotPermitted), r.ErrorCode)
+
+	r = &Record{}
+	r.SetResult(nil, errors.New("mock"))
+	assert.Equal(t, StatusFailed, r.Status)
+	assert.Equal(t, "mock", r.ErrorMsg)
+}
@@ -0,0 +1,280 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	rlinternal "github.com/milvus-io/milvus/internal/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/ratelimitutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+const (
+	callerScopeUser = "user"
+	callerScopeRole = "role"
+
+	// the callers throttled within the window are reported in quota states
+	callerThrottledReportWindow = time.Minute
+)
+
+// callerRateTypes maps the lower-case config keys of caller limits to the rate types.
+var callerRateTypes = map[string]internalpb.RateType{
+	"dml.insertrate":     internalpb.RateType_DMLInsert,
+	"dml.upsertrate":     internalpb.RateType_DMLUpsert,
+	"dml.deleterate":     internalpb.RateType_DMLDelete,
+	"dml.bulkloadrate":   internalpb.RateType_DMLBulkLoad,
+	"dql.searchrate":     internalpb.RateType_DQLSearch,
+	"dql.queryrate":      internalpb.RateType_DQLQuery,
+	"ddl.collectionrate": internalpb.RateType_DDLCollection,
+	"ddl.partitionrate":  internalpb.RateType_DDLPartition,
+	"ddl.indexrate":      internalpb.RateType_DDLIndex,
+	"ddl.flushrate":      internalpb.RateType_DDLFlush,
+	"ddl.compactionrate": internalpb.RateType_DDLCompaction,
+	"ddl.dbrate":         internalpb.RateType_DDLDB,
+}
+
+// callerLimiter limits the requests of a user or a role, regardless of the databases and collections requested.
+type callerLimiter struct {
+	scope string
+	node  *rlinternal.RateLimiterNode
+	// the last time the reads or writes were rejected
+	lastRejected *typeutil.ConcurrentMap[milvuspb.QuotaState, time.Time]
+}
+
+func newCallerLimiter(scope, name string) *callerLimiter {
+	return &callerLimiter{
+		scope:        fmt.Sprintf("%s:%s", scope, name),
+		node:         rlinternal.NewRateLimiterNode(internalpb.RateScope_Cluster),
+		lastRejected: typeutil.NewConcurrentMap[milvuspb.QuotaState, time.Time](),
+	}
+}
+
+// setLimits replaces the limits of the caller, the rate types not in limits are unlimited.
+func (l *callerLimiter) setLimits(limits map[internalpb.RateType]float64) {
+	for rt, rate := range limits {
+		if old, ok := l.node.GetLimiters().Get(rt); ok {
+			if old.Limit() != ratelimitutil.Limit(rate) {
+				old.SetLimit(ratelimitutil.Limit(rate))
+			}
+			continue
+		}
+		l.node.GetLimiters().Insert(rt, ratelimitutil.NewLimiter(ratelimitutil.Limit(rate), rate))
+		log.Ctx(context.TODO()).Debug("RateLimiter register for rateType",
+			zap.String("source", l.scope),
+			zap.String("rateType", rt.String()),
+			zap.Float64("rateLimit", rate))
+	}
+	l.node.GetLimiters().Range(func(rt internalpb.RateType, _ *ratelimitutil.Limiter) bool {
+		if _, ok := limits[rt]; !ok {
+			l.node.GetLimiters().Remove(rt)
+		}
+		return true
+	})
+}
+
+func (l *callerLimiter) check(rt internalpb.RateType, n int) error {
+	limited, rate := l.node.Limit(rt, n)
+	if !limited {
+		return nil
+	}
+	state := milvuspb.QuotaState_WriteLimited
+	if rt == internalpb.RateType_DQLSearch || rt == internalpb.RateType_DQLQuery {
+		state = milvuspb.QuotaState_ReadLimited
+	}
+	l.lastRejected.Insert(state, time.Now())
+	return merr.WrapErrServiceScopeRateLimit(l.scope, rate,
+		fmt.Sprintf("request is rejected by the %s rate limit of %s, please retry later", rt.String(), l.scope))
+}
+
+// parseCallerLimits parses the limits configured as <name>.<rate key>: <rate>,
+// the names may contain dots so the known rate keys are matched as suffix.
+func parseCallerLimits(values map[string]string) map[string]map[internalpb.RateType]float64 {
+	limits := make(map[string]map[internalpb.RateType]float64)
+	for key, value := range values {
+		for rateKey, rt := range callerRateTypes {
+			if !strings.HasSuffix(key, "."+rateKey) {
+				continue
+			}
+			name := strings.TrimSuffix(key, "."+rateKey)
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 {
+				log.Warn("invalid caller rate limit, ignore it", zap.String("key", key), zap.String("value", value))
+				break
+			}
+			switch rt {
+			case internalpb.RateType_DMLInsert, internalpb.RateType_DMLUpsert, internalpb.RateType_DMLDelete, internalpb.RateType_DMLBulkLoad:
+				rate = rate * 1024 * 1024
+			}
+			if limits[name] == nil {
+				limits[name] = make(map[internalpb.RateType]float64)
+			}
+			limits[name][rt] = rate
+			break
+		}
+	}
+	return limits
+}
+
+// updateCallerLimiters syncs the limiters with the configured limits, the limiters of unconfigured callers are removed.
+func updateCallerLimiters(scope string, limiters *typeutil.ConcurrentMap[string, *callerLimiter], values map[string]string) {
+	limits := parseCallerLimits(values)
+	for name, rates := range limits {
+		limiter, _ := limiters.GetOrInsert(name, newCallerLimiter(scope, name))
+		limiter.setLimits(rates)
+	}
+	limiters.Range(func(name string, _ *callerLimiter) bool {
+		if _, ok := limits[name]; !ok {
+			limiters.Remove(name)
+		}
+		return true
+	})
+}
+
+func (m *SimpleLimiter) updateCallerLimiters() {
+	updateCallerLimiters(callerScopeUser, m.userLimiters, paramtable.Get().QuotaConfig.UserRateLimits.GetValue())
+	updateCallerLimiters(callerScopeRole, m.roleLimiters, paramtable.Get().QuotaConfig.RoleRateLimits.GetValue())
+}
+
+// CheckWithCaller checks the rate limits of the user and its roles, and then the limits checked by Check.
+func (m *SimpleLimiter) CheckWithCaller(username string, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() || n <= 0 {
+		return nil
+	}
+	doneLimiters, err := m.checkCaller(username, rt, n)
+	if err != nil {
+		return err
+	}
+	if err := m.Check(dbID, collectionIDToPartIDs, rt, n); err != nil {
+		for _, limiter := range doneLimiters {
+			limiter.node.Cancel(rt, n)
+		}
+		return err
+	}
+	return nil
+}
+
+// CancelWithCaller returns the tokens taken by a passed CheckWithCaller.
+func (m *SimpleLimiter) CancelWithCaller(username string, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) {
+	if !Params.QuotaConfig.QuotaAndLimitsEnabled.GetAsBool() || n <= 0 {
+		return
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+
+	for _, limiter := range m.getCallerLimiters(username) {
+		limiter.node.Cancel(rt, n)
+	}
+	m.rateLimiter.GetRootLimiters().Cancel(rt, n)
+	if dbID == util.InvalidDBID {
+		return
+	}
+	if dbRateLimiters := m.rateLimiter.GetDatabaseLimiters(dbID); dbRateLimiters != nil {
+		dbRateLimiters.Cancel(rt, n)
+	}
+	for collectionID, partitionIDs := range collectionIDToPartIDs {
+		if collectionID == 0 {
+			continue
+		}
+		if !isNotCollectionLevelLimitRequest(rt) {
+			if collectionRateLimiters := m.rateLimiter.GetCollectionLimiters(dbID, collectionID); collectionRateLimiters != nil {
+				collectionRateLimiters.Cancel(rt, n)
+			}
+		}
+		for _, partID := range partitionIDs {
+			if partID == 0 {
+				continue
+			}
+			if partitionRateLimiters := m.rateLimiter.GetPartitionLimiters(dbID, collectionID, partID); partitionRateLimiters != nil {
+				partitionRateLimiters.Cancel(rt, n)
+			}
+		}
+	}
+}
+
+// getCallerLimiters returns the limiters of the user and its roles.
+func (m *SimpleLimiter) getCallerLimiters(username string) []*callerLimiter {
+	limiters := make([]*callerLimiter, 0)
+	if username == "" {
+		return limiters
+	}
+	if limiter, ok := m.userLimiters.Get(strings.ToLower(username)); ok {
+		limiters = append(limiters, limiter)
+	}
+	if m.roleLimiters.Len() > 0 && globalMetaCache != nil {
+		for _, role := range globalMetaCache.GetUserRole(username) {
+			if limiter, ok := m.roleLimiters.Get(strings.ToLower(role)); ok {
+				limiters = append(limiters, limiter)
+			}
+		}
+	}
+	return limiters
+}
+
+func (m *SimpleLimiter) checkCaller(username string, rt internalpb.RateType, n int) ([]*callerLimiter, error) {
+	if username == "" {
+		return nil, nil
+	}
+
+	m.quotaStatesMu.RLock()
+	defer m.quotaStatesMu.RUnlock()
+
+	limiters := m.getCallerLimiters(username)
+	doneLimiters := make([]*callerLimiter, 0, len(limiters))
+	for _, limiter := range limiters {
+		if err := limiter.check(rt, n); err != nil {
+			for _, done := range doneLimiters {
+				done.node.Cancel(rt, n)
+			}
+			return nil, err
+		}
+		doneLimiters = append(doneLimiters, limiter)
+	}
+	return doneLimiters, nil
+}
+
+// getCallerQuotaStates returns the states of the callers throttled recently.
+func (m *SimpleLimiter) getCallerQuotaStates() ([]milvuspb.QuotaState, []string) {
+	states := make([]milvuspb.QuotaState, 0)
+	reasons := make([]string, 0)
+	collect := func(_ string, limiter *callerLimiter) bool {
+		limiter.lastRejected.Range(func(state milvuspb.QuotaState, last time.Time) bool {
+			if time.Since(last) <= callerThrottledReportWindow {
+				states = append(states, state)
+				reasons = append(reasons, fmt.Sprintf("rate limit of %s exceeded", limiter.scope))
+			}
+			return true
+		})
+		return true
+	}
+	m.userLimiters.Range(collect)
+	m.roleLimiters.Range(collect)
+	return states, reasons
+}
@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
+	"github.com/milvus-io/milvus/pkg/v2/util"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestParseCallerLimits(t *testing.T) {
+	limits := parseCallerLimits(map[string]string{
+		"alice.dml.insertrate":      "2",
+		"alice.dql.searchrate":      "100",
+		"svc.reader.dql.queryrate":  "10",
+		"bob.dql.queryrate":         "invalid",
+		"carol.dql.unknownrate":     "1",
+		"dave.ddl.collectionrate":   "-1",
+		"erin.ddl.dbrate":           "0",
+		"frank.dml.bulkloadrate.xx": "1",
+	})
+	assert.Equal(t, map[string]map[internalpb.RateType]float64{
+		"alice": {
+			internalpb.RateType_DMLInsert: 2 * 1024 * 1024,
+			internalpb.RateType_DQLSearch: 100,
+		},
+		"svc.reader": {internalpb.RateType_DQLQuery: 10},
+		"erin":       {internalpb.RateType_DDLDB: 0},
+	}, limits)
+}
+
+func TestSimpleLimiter_CheckWithCaller(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+	defer params.Reset(Params.QuotaConfig.QuotaAndLimitsEnabled.Key)
+	params.SaveGroup(map[string]string{
+		"quotaAndLimits.users.alice.dql.queryRate":   "10",
+		"quotaAndLimits.roles.reader.dql.searchRate": "10",
+	})
+	defer params.ResetGroup("quotaAndLimits.users.alice.dql.queryRate", "quotaAndLimits.roles.reader.dql.searchRate")
+
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetUserRole("alice").Return(nil).Maybe()
+	mockCache.EXPECT().GetUserRole("Alice").Return(nil).Maybe()
+	mockCache.EXPECT().GetUserRole("bob").Return([]string{"reader"}).Maybe()
+	mockCache.EXPECT().GetUserRole("carol").Return([]string{"reader"}).Maybe()
+	mockCache.EXPECT().GetUserRole("dave").Return(nil).Maybe()
+	globalMetaCache = mockCache
+
+	limiter := NewSimpleLimiter(0, 0)
+
+	t.Run("user", func(t *testing.T) {
+		// the tokens start from the burst, which is the same as the rate
+		assert.NoError(t, limiter.CheckWithCaller("Alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 11))
+		err := limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1)
+		assert.ErrorIs(t, err, merr.ErrServiceScopeRateLimit)
+		assert.Contains(t, err.Error(), "user:alice")
+
+		// other rate types and users are not limited
+		assert.NoError(t, limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DMLInsert, 1024))
+		assert.NoError(t, limiter.CheckWithCaller("dave", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1))
+		assert.NoError(t, limiter.CheckWithCaller("", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1))
+	})
+
+	t.Run("role", func(t *testing.T) {
+		assert.NoError(t, limiter.CheckWithCaller("bob", util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 11))
+		// the limit is shared by the users of the role
+		err := limiter.CheckWithCaller("carol", util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 1)
+		assert.ErrorIs(t, err, merr.ErrServiceScopeRateLimit)
+		assert.Contains(t, err.Error(), "role:reader")
+		assert.NoError(t, limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLSearch, 1))
+	})
+
+	t.Run("quota states", func(t *testing.T) {
+		states, reasons := limiter.GetQuotaStates()
+		assert.ElementsMatch(t, []milvuspb.QuotaState{milvuspb.QuotaState_ReadLimited, milvuspb.QuotaState_ReadLimited}, states)
+		assert.ElementsMatch(t, []string{"rate limit of user:alice exceeded", "rate limit of role:reader exceeded"}, reasons)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		params.SaveGroup(map[string]string{"quotaAndLimits.users.alice.dql.queryRate": "0"})
+		limiter.updateCallerLimiters()
+		err := limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1)
+		assert.ErrorIs(t, err, merr.ErrServiceScopeRateLimit)
+
+		params.ResetGroup("quotaAndLimits.users.alice.dql.queryRate", "quotaAndLimits.roles.reader.dql.searchRate")
+		limiter.updateCallerLimiters()
+		assert.Equal(t, 0, limiter.userLimiters.Len())
+		assert.Equal(t, 0, limiter.roleLimiters.Len())
+		assert.NoError(t, limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1))
+	})
+}
+
+func TestSimpleLimiter_CancelWithCaller(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(Params.QuotaConfig.QuotaAndLimitsEnabled.Key, "true")
+	defer params.Reset(Params.QuotaConfig.QuotaAndLimitsEnabled.Key)
+	params.SaveGroup(map[string]string{"quotaAndLimits.users.alice.dql.queryRate": "10"})
+	defer params.ResetGroup("quotaAndLimits.users.alice.dql.queryRate")
+
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	globalMetaCache = nil
+
+	limiter := NewSimpleLimiter(0, 0)
+	assert.NoError(t, limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 11))
+	assert.ErrorIs(t, limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1), merr.ErrServiceScopeRateLimit)
+
+	// the tokens are returned
+	limiter.CancelWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 11)
+	assert.NoError(t, limiter.CheckWithCaller("alice", util.InvalidDBID, nil, internalpb.RateType_DQLQuery, 1))
+}
@@ -3,6 +3,7 @@ package connection
 import (
 	"time"
 
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
@@ -12,6 +13,13 @@ type clientInfo struct {
 	*commonpb.ClientInfo
 	identifier     int64
 	lastActiveTime time.Time
+	// the user and database the client connected with
+	user   string
+	dbName string
+	// the remote address of the connection the client sends requests through
+	addr string
+	// the number of the in-flight requests of the client
+	inflight *atomic.Int64
 }
 
 func (c *clientInfo) GetLogger() []zap.Field {
@@ -19,6 +27,9 @@ func (c *clientInfo) GetLogger() []zap.Field {
 	fields = append(fields,
 		zap.Int64("identifier", c.identifier),
 		zap.Time("last_active_time", c.lastActiveTime),
+		zap.String("connected_user", c.user),
+		zap.String("db_name", c.dbName),
+		zap.String("addr", c.addr),
 	)
 	return fields
 }
@@ -0,0 +1,46 @@
+package connection
+
+import (
+	"net"
+	"sync"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// WrapListener tracks the connections accepted by lis,
+// so that the connection of a client could be closed once it's disconnected forcibly.
+func WrapListener(lis net.Listener) net.Listener {
+	return GetManager().wrapListener(lis)
+}
+
+func (s *connectionManager) wrapListener(lis net.Listener) net.Listener {
+	return &trackedListener{Listener: lis, conns: s.conns}
+}
+
+type trackedListener struct {
+	net.Listener
+	conns *typeutil.ConcurrentMap[string, net.Conn]
+}
+
+func (l *trackedListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	tracked := &trackedConn{Conn: conn, conns: l.conns}
+	l.conns.Insert(conn.RemoteAddr().String(), tracked)
+	return tracked, nil
+}
+
+type trackedConn struct {
+	net.Conn
+	conns     *typeutil.ConcurrentMap[string, net.Conn]
+	closeOnce sync.Once
+}
+
+func (c *trackedConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.conns.Remove(c.RemoteAddr().String())
+	})
+	return c.Conn.Close()
+}
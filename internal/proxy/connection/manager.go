@@ -3,14 +3,20 @@ package connection
 import (
 	"container/heap"
 	"context"
+	"fmt"
+	"net"
 	"strconv"
 	"sync"
 	"time"
 
+	"go.uber.org/atomic"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/peer"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
@@ -23,6 +29,19 @@ type connectionManager struct {
 	wg          sync.WaitGroup
 
 	clientInfos *typeutil.ConcurrentMap[int64, clientInfo]
+	// registerMu makes the connection limit check and the registration atomic
+	registerMu sync.Mutex
+
+	// the in-flight requests of each user and database, only counted if limited,
+	// the users and databases without in-flight requests are removed
+	inflightMu   sync.Mutex
+	userInflight map[string]int64
+	dbInflight   map[string]int64
+	// the clients disconnected forcibly and the time they are disconnected,
+	// their requests are rejected until they connect again
+	disconnected *typeutil.ConcurrentMap[int64, time.Time]
+	// the connections accepted by the listeners wrapped by WrapListener, keyed by the remote address
+	conns *typeutil.ConcurrentMap[string, net.Conn]
 }
 
 func (s *connectionManager) init() {
@@ -52,6 +71,7 @@ func (s *connectionManager) checkLoop() {
 			return
 		case <-t.C:
 			s.removeLongInactiveClients()
+			s.evictIdleClients()
 			// not sure if we should purge them periodically.
 			s.purgeIfNumOfClientsExceed()
 			t.Reset(paramtable.Get().ProxyCfg.ConnectionCheckIntervalSeconds.GetAsDuration(time.Second))
@@ -95,15 +115,190 @@ func (s *connectionManager) purgeIfNumOfClientsExceed() {
 		zap.Int64("num after purge", int64(s.clientInfos.Len())))
 }
 
-func (s *connectionManager) Register(ctx context.Context, identifier int64, info *commonpb.ClientInfo) {
-	cli := clientInfo{
+func (s *connectionManager) newClientInfo(ctx context.Context, identifier int64, info *commonpb.ClientInfo) clientInfo {
+	user, _ := contextutil.GetCurUserFromContext(ctx)
+	if user == "" {
+		user = info.GetUser()
+	}
+	return clientInfo{
 		ClientInfo:     info,
 		identifier:     identifier,
 		lastActiveTime: time.Now(),
+		user:           user,
+		dbName:         getDBNameFromContext(ctx),
+		addr:           getPeerAddr(ctx),
+		inflight:       atomic.NewInt64(0),
+	}
+}
+
+func getPeerAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return p.Addr.String()
 	}
+	return ""
+}
+
+// Register registers the client connected with the user and database in ctx,
+// it fails if the client exceeds the connection limits.
+func (s *connectionManager) Register(ctx context.Context, identifier int64, info *commonpb.ClientInfo) error {
+	cli := s.newClientInfo(ctx, identifier, info)
 
+	s.registerMu.Lock()
+	defer s.registerMu.Unlock()
+	if err := s.checkConnectionLimits(cli); err != nil {
+		log.Ctx(ctx).Warn("client register rejected", append(cli.GetLogger(), zap.Error(err))...)
+		return err
+	}
 	s.clientInfos.Insert(identifier, cli)
+	s.disconnected.Remove(identifier)
 	log.Ctx(ctx).Info("client register", cli.GetLogger()...)
+	return nil
+}
+
+// reregister registers the client which has connected but is unknown to the manager,
+// i.e. it has been evicted for idle or the proxy restarted.
+// It fails if the connection limits are exceeded, the evicted client doesn't keep its slot.
+func (s *connectionManager) reregister(ctx context.Context, identifier int64, info *commonpb.ClientInfo) (clientInfo, error) {
+	cli := s.newClientInfo(ctx, identifier, info)
+
+	s.registerMu.Lock()
+	defer s.registerMu.Unlock()
+	if existing, ok := s.clientInfos.Get(identifier); ok {
+		// registered by another request concurrently
+		return existing, nil
+	}
+	if err := s.checkConnectionLimits(cli); err != nil {
+		log.Ctx(ctx).Warn("client register again rejected", append(cli.GetLogger(), zap.Error(err))...)
+		return clientInfo{}, err
+	}
+	s.clientInfos.Insert(identifier, cli)
+	log.Ctx(ctx).Info("client register again", cli.GetLogger()...)
+	return cli, nil
+}
+
+func (s *connectionManager) checkConnectionLimits(cli clientInfo) error {
+	userLimit := paramtable.Get().ProxyCfg.MaxConnectionNumPerUser.GetAsInt64()
+	dbLimit := paramtable.Get().ProxyCfg.MaxConnectionNumPerDatabase.GetAsInt64()
+	if userLimit <= 0 && dbLimit <= 0 {
+		return nil
+	}
+
+	var userNum, dbNum int64
+	s.clientInfos.Range(func(identifier int64, info clientInfo) bool {
+		// the client connects again
+		if identifier == cli.identifier {
+			return true
+		}
+		if cli.user != "" && info.user == cli.user {
+			userNum++
+		}
+		if info.dbName == cli.dbName {
+			dbNum++
+		}
+		return true
+	})
+	if userLimit > 0 && cli.user != "" && userNum >= userLimit {
+		return merr.WrapErrServiceConnectionLimit("user:"+cli.user, userLimit,
+			fmt.Sprintf("user %s has connected %d clients, please close the idle ones", cli.user, userNum))
+	}
+	if dbLimit > 0 && dbNum >= dbLimit {
+		return merr.WrapErrServiceConnectionLimit("database:"+cli.dbName, dbLimit,
+			fmt.Sprintf("database %s has been connected by %d clients, please close the idle ones", cli.dbName, dbNum))
+	}
+	return nil
+}
+
+// Acquire counts an in-flight request of the client in ctx, the user and the database,
+// it fails if the client has been disconnected or any in-flight request limit is exceeded.
+// The returned release func must be called once the request is done.
+func (s *connectionManager) Acquire(ctx context.Context, user, dbName string) (func(), error) {
+	userLimit := paramtable.Get().ProxyCfg.MaxInflightRequestsPerUser.GetAsInt64()
+	dbLimit := paramtable.Get().ProxyCfg.MaxInflightRequestsPerDatabase.GetAsInt64()
+	countUser := userLimit > 0 && user != ""
+	countDB := dbLimit > 0
+
+	var client *atomic.Int64
+	if identifier, err := GetIdentifierFromContext(ctx); err == nil {
+		if _, ok := s.disconnected.Get(identifier); ok {
+			return nil, merr.WrapErrServiceClientDisconnected(identifier, "the client has been disconnected, please connect again")
+		}
+		info, ok := s.clientInfos.Get(identifier)
+		if !ok {
+			info, err = s.reregister(ctx, identifier, &commonpb.ClientInfo{User: user})
+			if err != nil {
+				return nil, err
+			}
+		}
+		if addr := getPeerAddr(ctx); addr != "" && addr != info.addr {
+			// the client reconnected
+			s.updateAddr(identifier, addr)
+		}
+		client = info.inflight
+	}
+
+	if countUser || countDB {
+		s.inflightMu.Lock()
+		if countUser && s.userInflight[user] >= userLimit {
+			s.inflightMu.Unlock()
+			return nil, merr.WrapErrTooManyRequests(int32(userLimit), fmt.Sprintf("too many in-flight requests of user %s", user))
+		}
+		if countDB && s.dbInflight[dbName] >= dbLimit {
+			s.inflightMu.Unlock()
+			return nil, merr.WrapErrTooManyRequests(int32(dbLimit), fmt.Sprintf("too many in-flight requests to database %s", dbName))
+		}
+		if countUser {
+			s.userInflight[user]++
+		}
+		if countDB {
+			s.dbInflight[dbName]++
+		}
+		s.inflightMu.Unlock()
+	}
+	if client != nil {
+		client.Inc()
+	}
+
+	return func() {
+		if client != nil {
+			client.Dec()
+		}
+		if countUser || countDB {
+			s.inflightMu.Lock()
+			defer s.inflightMu.Unlock()
+			if countUser {
+				decInflight(s.userInflight, user)
+			}
+			if countDB {
+				decInflight(s.dbInflight, dbName)
+			}
+		}
+	}, nil
+}
+
+// decInflight decreases the in-flight requests of key, and removes it once there is none.
+func decInflight(inflight map[string]int64, key string) {
+	if inflight[key] <= 1 {
+		delete(inflight, key)
+		return
+	}
+	inflight[key]--
+}
+
+// Disconnect removes the client forcibly and closes its connection,
+// the following requests of the client are rejected until it connects again.
+func (s *connectionManager) Disconnect(ctx context.Context, identifier int64) error {
+	info, ok := s.clientInfos.GetAndRemove(identifier)
+	if !ok {
+		return merr.WrapErrParameterInvalidMsg("client %d not found", identifier)
+	}
+	s.disconnected.Insert(identifier, time.Now())
+	if conn, ok := s.conns.Get(info.addr); ok && info.addr != "" {
+		if err := conn.Close(); err != nil {
+			log.Ctx(ctx).Warn("failed to close the connection of client", append(info.GetLogger(), zap.Error(err))...)
+		}
+	}
+	log.Ctx(ctx).Info("client disconnected", info.GetLogger()...)
+	return nil
 }
 
 func (s *connectionManager) KeepActive(identifier int64) {
@@ -121,6 +316,8 @@ func (s *connectionManager) List() []*commonpb.ClientInfo {
 			}
 			client.Reserved["identifier"] = string(strconv.AppendInt(nil, identifier, 10))
 			client.Reserved["last_active_time"] = info.lastActiveTime.String()
+			client.Reserved["db_name"] = info.dbName
+			client.Reserved["inflight_requests"] = strconv.FormatInt(info.inflight.Load(), 10)
 
 			clients = append(clients, client)
 		}
@@ -143,6 +340,14 @@ func (s *connectionManager) Get(ctx context.Context) *commonpb.ClientInfo {
 	return cli.ClientInfo
 }
 
+func (s *connectionManager) updateAddr(identifier int64, addr string) {
+	info, ok := s.clientInfos.Get(identifier)
+	if ok {
+		info.addr = addr
+		s.clientInfos.Insert(identifier, info)
+	}
+}
+
 func (s *connectionManager) Update(identifier int64) {
 	info, ok := s.clientInfos.Get(identifier)
 	if ok {
@@ -154,18 +359,43 @@ func (s *connectionManager) Update(identifier int64) {
 func (s *connectionManager) removeLongInactiveClients() {
 	ttl := paramtable.Get().ProxyCfg.ConnectionClientInfoTTLSeconds.GetAsDuration(time.Second)
 	s.clientInfos.Range(func(candidate int64, info clientInfo) bool {
-		if time.Since(info.lastActiveTime) > ttl {
+		if time.Since(info.lastActiveTime) > ttl && info.inflight.Load() == 0 {
 			log.Info("client deregister", info.GetLogger()...)
 			s.clientInfos.Remove(candidate)
 		}
 		return true
 	})
+	s.disconnected.Range(func(identifier int64, disconnectedTime time.Time) bool {
+		if time.Since(disconnectedTime) > ttl {
+			s.disconnected.Remove(identifier)
+		}
+		return true
+	})
+}
+
+// evictIdleClients evicts the clients idle for a while, so that they no longer count towards the connection limits.
+func (s *connectionManager) evictIdleClients() {
+	timeout := paramtable.Get().ProxyCfg.ConnectionIdleTimeoutSeconds.GetAsDuration(time.Second)
+	if timeout <= 0 {
+		return
+	}
+	s.clientInfos.Range(func(candidate int64, info clientInfo) bool {
+		if time.Since(info.lastActiveTime) > timeout && info.inflight.Load() == 0 {
+			log.Info("evict idle client", info.GetLogger()...)
+			s.clientInfos.Remove(candidate)
+		}
+		return true
+	})
 }
 
 func newConnectionManager() *connectionManager {
 	s := &connectionManager{
-		closeSignal: make(chan struct{}, 1),
-		clientInfos: typeutil.NewConcurrentMap[int64, clientInfo](),
+		closeSignal:  make(chan struct{}, 1),
+		clientInfos:  typeutil.NewConcurrentMap[int64, clientInfo](),
+		userInflight: make(map[string]int64),
+		dbInflight:   make(map[string]int64),
+		disconnected: typeutil.NewConcurrentMap[int64, time.Time](),
+		conns:        typeutil.NewConcurrentMap[string, net.Conn](),
 	}
 	s.init()
 
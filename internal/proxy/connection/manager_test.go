@@ -2,12 +2,21 @@ package connection
 
 import (
 	"context"
+	"io"
+	"net"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/peer"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/v2/util"
+	"github.com/milvus-io/milvus/pkg/v2/util/contextutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/crypto"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 )
 
@@ -65,3 +74,204 @@ func TestConnectionManager_Purge(t *testing.T) {
 		return s.clientInfos.Len() <= 2
 	}, time.Second*5, time.Second)
 }
+
+func newClientContext(user string, dbName string, identifier int64) context.Context {
+	return contextutil.AppendToIncomingContext(context.Background(),
+		strings.ToLower(util.HeaderAuthorize), crypto.Base64Encode(user+util.CredentialSeperator+"password"),
+		strings.ToLower(util.HeaderDBName), dbName,
+		util.IdentifierKey, strconv.FormatInt(identifier, 10))
+}
+
+func TestConnectionManager_ConnectionLimits(t *testing.T) {
+	paramtable.Init()
+
+	pt := paramtable.Get()
+	pt.Save(pt.ProxyCfg.MaxConnectionNumPerUser.Key, "2")
+	pt.Save(pt.ProxyCfg.MaxConnectionNumPerDatabase.Key, "3")
+	defer pt.Reset(pt.ProxyCfg.MaxConnectionNumPerUser.Key)
+	defer pt.Reset(pt.ProxyCfg.MaxConnectionNumPerDatabase.Key)
+	s := newConnectionManager()
+	defer s.Stop()
+
+	assert.NoError(t, s.Register(newClientContext("alice", "db1", 1), 1, &commonpb.ClientInfo{}))
+	assert.NoError(t, s.Register(newClientContext("alice", "db2", 2), 2, &commonpb.ClientInfo{}))
+	// connect again
+	assert.NoError(t, s.Register(newClientContext("alice", "db2", 2), 2, &commonpb.ClientInfo{}))
+	err := s.Register(newClientContext("alice", "db1", 3), 3, &commonpb.ClientInfo{})
+	assert.ErrorIs(t, err, merr.ErrServiceConnectionLimit)
+	assert.Contains(t, err.Error(), "user:alice")
+
+	assert.NoError(t, s.Register(newClientContext("bob", "db1", 4), 4, &commonpb.ClientInfo{}))
+	assert.NoError(t, s.Register(newClientContext("carol", "db1", 5), 5, &commonpb.ClientInfo{}))
+	err = s.Register(newClientContext("dave", "db1", 6), 6, &commonpb.ClientInfo{})
+	assert.ErrorIs(t, err, merr.ErrServiceConnectionLimit)
+	assert.Contains(t, err.Error(), "database:db1")
+	assert.Equal(t, 4, len(s.List()))
+}
+
+func TestConnectionManager_InflightLimits(t *testing.T) {
+	paramtable.Init()
+
+	pt := paramtable.Get()
+	pt.Save(pt.ProxyCfg.MaxInflightRequestsPerUser.Key, "2")
+	pt.Save(pt.ProxyCfg.MaxInflightRequestsPerDatabase.Key, "3")
+	defer pt.Reset(pt.ProxyCfg.MaxInflightRequestsPerUser.Key)
+	defer pt.Reset(pt.ProxyCfg.MaxInflightRequestsPerDatabase.Key)
+	s := newConnectionManager()
+	defer s.Stop()
+
+	ctx := newClientContext("alice", "db1", 1)
+	assert.NoError(t, s.Register(ctx, 1, &commonpb.ClientInfo{}))
+
+	release1, err := s.Acquire(ctx, "alice", "db1")
+	assert.NoError(t, err)
+	release2, err := s.Acquire(ctx, "alice", "db2")
+	assert.NoError(t, err)
+	_, err = s.Acquire(ctx, "alice", "db1")
+	assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+	assert.Equal(t, "2", s.List()[0].GetReserved()["inflight_requests"])
+
+	release3, err := s.Acquire(context.Background(), "bob", "db1")
+	assert.NoError(t, err)
+	release4, err := s.Acquire(context.Background(), "carol", "db1")
+	assert.NoError(t, err)
+	_, err = s.Acquire(context.Background(), "dave", "db1")
+	assert.ErrorIs(t, err, merr.ErrServiceTooManyRequests)
+
+	for _, release := range []func(){release1, release2, release3, release4} {
+		release()
+	}
+	assert.Equal(t, "0", s.List()[0].GetReserved()["inflight_requests"])
+	// the users and databases without in-flight requests are removed
+	assert.Empty(t, s.userInflight)
+	assert.Empty(t, s.dbInflight)
+	release, err := s.Acquire(ctx, "alice", "db1")
+	assert.NoError(t, err)
+	release()
+}
+
+func TestConnectionManager_Disconnect(t *testing.T) {
+	paramtable.Init()
+	s := newConnectionManager()
+	defer s.Stop()
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	assert.NoError(t, err)
+	lis = s.wrapListener(lis)
+	defer lis.Close()
+	go func() {
+		conn, err := lis.Accept()
+		if err == nil {
+			// serve nothing until the connection is closed
+			io.Copy(io.Discard, conn)
+			conn.Close()
+		}
+	}()
+	conn, err := net.Dial("tcp", lis.Addr().String())
+	assert.NoError(t, err)
+	defer conn.Close()
+	assert.Eventually(t, func() bool { return s.conns.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	ctx := peer.NewContext(newClientContext("alice", "db1", 1), &peer.Peer{Addr: conn.LocalAddr()})
+	assert.Error(t, s.Disconnect(ctx, 1))
+	assert.NoError(t, s.Register(ctx, 1, &commonpb.ClientInfo{}))
+	assert.NoError(t, s.Disconnect(ctx, 1))
+	assert.Equal(t, 0, len(s.List()))
+
+	// the connection is closed by the proxy
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	_, err = conn.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Eventually(t, func() bool { return s.conns.Len() == 0 }, time.Second, 10*time.Millisecond)
+
+	_, err = s.Acquire(ctx, "alice", "db1")
+	assert.ErrorIs(t, err, merr.ErrServiceClientDisconnected)
+
+	// connect again
+	assert.NoError(t, s.Register(ctx, 1, &commonpb.ClientInfo{}))
+	release, err := s.Acquire(ctx, "alice", "db1")
+	assert.NoError(t, err)
+	release()
+}
+
+func TestConnectionManager_EvictIdleClients(t *testing.T) {
+	paramtable.Init()
+
+	pt := paramtable.Get()
+	pt.Save(pt.ProxyCfg.ConnectionIdleTimeoutSeconds.Key, "1")
+	defer pt.Reset(pt.ProxyCfg.ConnectionIdleTimeoutSeconds.Key)
+	s := newConnectionManager()
+	defer s.Stop()
+
+	ctx1 := newClientContext("alice", "db1", 1)
+	ctx2 := newClientContext("alice", "db1", 2)
+	assert.NoError(t, s.Register(ctx1, 1, &commonpb.ClientInfo{}))
+	assert.NoError(t, s.Register(ctx2, 2, &commonpb.ClientInfo{}))
+	release, err := s.Acquire(ctx1, "alice", "db1")
+	assert.NoError(t, err)
+
+	time.Sleep(1100 * time.Millisecond)
+	s.evictIdleClients()
+	// the client with in-flight requests is kept
+	_, ok := s.clientInfos.Get(1)
+	assert.True(t, ok)
+	_, ok = s.clientInfos.Get(2)
+	assert.False(t, ok)
+	release()
+
+	// the evicted client is registered again by its requests
+	release, err = s.Acquire(ctx2, "alice", "db1")
+	assert.NoError(t, err)
+	release()
+	_, ok = s.clientInfos.Get(2)
+	assert.True(t, ok)
+}
+
+func TestConnectionManager_Reregister(t *testing.T) {
+	paramtable.Init()
+
+	pt := paramtable.Get()
+	pt.Save(pt.ProxyCfg.MaxConnectionNumPerUser.Key, "1")
+	pt.Save(pt.ProxyCfg.ConnectionIdleTimeoutSeconds.Key, "1")
+	defer pt.Reset(pt.ProxyCfg.MaxConnectionNumPerUser.Key)
+	defer pt.Reset(pt.ProxyCfg.ConnectionIdleTimeoutSeconds.Key)
+
+	t.Run("restart", func(t *testing.T) {
+		// the proxy restarted, the clients connected before are unknown to the new manager
+		s := newConnectionManager()
+		defer s.Stop()
+
+		ctx1 := newClientContext("alice", "db1", 1)
+		ctx2 := newClientContext("alice", "db1", 2)
+		release1, err := s.Acquire(ctx1, "alice", "db1")
+		assert.NoError(t, err)
+		// the connection limits are enforced on the clients connected before as well
+		_, err = s.Acquire(ctx2, "alice", "db1")
+		assert.ErrorIs(t, err, merr.ErrServiceConnectionLimit)
+		assert.Equal(t, 1, len(s.List()))
+		release1()
+
+		// and they're counted for the new connections
+		err = s.Register(newClientContext("alice", "db1", 3), 3, &commonpb.ClientInfo{})
+		assert.ErrorIs(t, err, merr.ErrServiceConnectionLimit)
+	})
+
+	t.Run("evicted", func(t *testing.T) {
+		s := newConnectionManager()
+		defer s.Stop()
+
+		ctx1 := newClientContext("alice", "db1", 1)
+		ctx2 := newClientContext("alice", "db1", 2)
+		assert.NoError(t, s.Register(ctx1, 1, &commonpb.ClientInfo{}))
+		time.Sleep(1100 * time.Millisecond)
+		s.evictIdleClients()
+		assert.Equal(t, 0, len(s.List()))
+
+		// another client of the user connects after the eviction
+		assert.NoError(t, s.Register(ctx2, 2, &commonpb.ClientInfo{}))
+		// the evicted client doesn't keep its slot
+		_, err := s.Acquire(ctx1, "alice", "db1")
+		assert.ErrorIs(t, err, merr.ErrServiceConnectionLimit)
+		assert.Equal(t, 1, len(s.List()))
+	})
+}
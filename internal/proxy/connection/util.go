@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
@@ -47,6 +48,18 @@ func GetIdentifierFromContext(ctx context.Context) (int64, error) {
 	return identifier, nil
 }
 
+func getDBNameFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return util.DefaultDBName
+	}
+	dbNames := md[strings.ToLower(util.HeaderDBName)]
+	if len(dbNames) < 1 || dbNames[0] == "" {
+		return util.DefaultDBName
+	}
+	return dbNames[0]
+}
+
 func KeepActiveInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 	// We shouldn't block the normal rpc. though this may be not very accurate enough.
 	// On the other hand, too many goroutines will also influence the rpc.
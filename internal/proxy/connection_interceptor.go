@@ -0,0 +1,57 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/requestutil"
+)
+
+// ConnectionLimitInterceptor rejects the requests of the disconnected clients
+// and the requests exceeding the in-flight request limits of the user or database.
+func ConnectionLimitInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	// the client registers itself by connect
+	if _, ok := req.(*milvuspb.ConnectRequest); ok {
+		return handler(ctx, req)
+	}
+
+	release, err := connection.GetManager().Acquire(ctx, GetCurUserFromContextOrDefault(ctx), GetRequestDBName(ctx, req))
+	if err != nil {
+		log.Ctx(ctx).Warn("request rejected by connection limits", zap.String("method", info.FullMethod), zap.Error(err))
+		if rsp := GetFailedResponse(req, err); rsp != nil {
+			return rsp, nil
+		}
+		return nil, err
+	}
+	defer release()
+	return handler(ctx, req)
+}
+
+// GetRequestDBName returns the database of the request, or the one the client connected with if not set.
+func GetRequestDBName(ctx context.Context, req any) string {
+	if dbName, ok := requestutil.GetDbNameFromRequest(req); ok && dbName.(string) != "" {
+		return dbName.(string)
+	}
+	return GetCurDBNameFromContextOrDefault(ctx)
+}
@@ -0,0 +1,76 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// getMaxExecutionTime returns the max execution time of the search and query requests on the collection,
+// the collection property takes precedence over the database one, and then the proxy config.
+func getMaxExecutionTime(ctx context.Context, dbName, collectionName string) time.Duration {
+	if globalMetaCache != nil {
+		if dbName == "" {
+			dbName = GetCurDBNameFromContextOrDefault(ctx)
+		}
+		collectionInfo, err := globalMetaCache.GetCollectionInfo(ctx, dbName, collectionName, 0)
+		if err == nil {
+			if timeout, ok := common.GetMaxExecutionTime(collectionInfo.properties...); ok {
+				return timeout
+			}
+		}
+		dbInfo, err := globalMetaCache.GetDatabaseInfo(ctx, dbName)
+		if err == nil {
+			if timeout, ok := common.GetMaxExecutionTime(dbInfo.properties...); ok {
+				return timeout
+			}
+		}
+	}
+	return paramtable.Get().ProxyCfg.MaxExecutionTime.GetAsDuration(time.Millisecond)
+}
+
+// withMaxExecutionTime bounds the request by the max execution time, the deadline is propagated to the
+// query nodes and cancels the running segcore tasks once exceeded.
+// The deadline set by the client is never extended, the returned max execution time is 0 if it is kept.
+func withMaxExecutionTime(ctx context.Context, dbName, collectionName string) (context.Context, context.CancelFunc, time.Duration) {
+	timeout := getMaxExecutionTime(ctx, dbName, collectionName)
+	if timeout <= 0 {
+		return ctx, func() {}, 0
+	}
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= timeout {
+		return ctx, func() {}, 0
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, timeout
+}
+
+// maxExecutionTimeStatus replaces the failure caused by exceeding the max execution time with
+// ErrServiceRequestTimeout, so that the client could tell it from the other failures.
+func maxExecutionTimeStatus(ctx context.Context, status *commonpb.Status, maxExecutionTime time.Duration, method string) *commonpb.Status {
+	if maxExecutionTime <= 0 || merr.Ok(status) || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return status
+	}
+	return merr.Status(merr.WrapErrServiceRequestTimeout(maxExecutionTime, method, status.GetReason()))
+}
@@ -0,0 +1,92 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestMaxExecutionTime(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.MaxExecutionTime.Key, "1000")
+	defer params.Reset(params.ProxyCfg.MaxExecutionTime.Key)
+
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, "coll", mock.Anything).Return(&collectionInfo{
+		properties: []*commonpb.KeyValuePair{{Key: common.MaxExecutionTimeKey, Value: "100"}},
+	}, nil).Maybe()
+	mockCache.EXPECT().GetCollectionInfo(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, merr.ErrCollectionNotFound).Maybe()
+	mockCache.EXPECT().GetDatabaseInfo(mock.Anything, "db").Return(&databaseInfo{
+		properties: []*commonpb.KeyValuePair{{Key: common.MaxExecutionTimeKey, Value: "200"}},
+	}, nil).Maybe()
+	mockCache.EXPECT().GetDatabaseInfo(mock.Anything, mock.Anything).Return(&databaseInfo{}, nil).Maybe()
+	globalMetaCache = mockCache
+
+	ctx := context.Background()
+	assert.Equal(t, 100*time.Millisecond, getMaxExecutionTime(ctx, "db", "coll"))
+	assert.Equal(t, 200*time.Millisecond, getMaxExecutionTime(ctx, "db", "other"))
+	assert.Equal(t, time.Second, getMaxExecutionTime(ctx, "default", "other"))
+
+	t.Run("deadline", func(t *testing.T) {
+		execCtx, cancel, timeout := withMaxExecutionTime(ctx, "db", "coll")
+		defer cancel()
+		assert.Equal(t, 100*time.Millisecond, timeout)
+		_, ok := execCtx.Deadline()
+		assert.True(t, ok)
+
+		// the shorter deadline of the client is kept
+		clientCtx, clientCancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer clientCancel()
+		execCtx, cancel, timeout = withMaxExecutionTime(clientCtx, "db", "coll")
+		defer cancel()
+		assert.Zero(t, timeout)
+		assert.Equal(t, clientCtx, execCtx)
+
+		params.Save(params.ProxyCfg.MaxExecutionTime.Key, "0")
+		execCtx, cancel, timeout = withMaxExecutionTime(ctx, "default", "other")
+		defer cancel()
+		assert.Zero(t, timeout)
+		assert.Equal(t, ctx, execCtx)
+	})
+
+	t.Run("status", func(t *testing.T) {
+		failed := merr.Status(errors.New("segcore cancelled"))
+		assert.Equal(t, failed, maxExecutionTimeStatus(ctx, failed, time.Second, "Search"))
+
+		execCtx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+		<-execCtx.Done()
+		assert.True(t, merr.Ok(maxExecutionTimeStatus(execCtx, merr.Success(), time.Millisecond, "Search")))
+		assert.Equal(t, failed, maxExecutionTimeStatus(execCtx, failed, 0, "Search"))
+		status := maxExecutionTimeStatus(execCtx, failed, time.Millisecond, "Search")
+		assert.ErrorIs(t, merr.Error(status), merr.ErrServiceRequestTimeout)
+	})
+}
@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/samber/lo"
@@ -29,6 +30,7 @@ import (
 	mhttp "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/json"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/proxy/slowlog"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
@@ -172,6 +174,49 @@ func getSlowQuery(node *Proxy) gin.HandlerFunc {
 	}
 }
 
+// getSlowQueryTop returns the top slow query fingerprints within the window, the query parameters are
+// window (duration, default 1h), limit (default 10) and sort_by (total, count or p99, default total).
+func getSlowQueryTop(c *gin.Context) {
+	window := time.Hour
+	if value := c.Query("window"); value != "" {
+		var err error
+		window, err = time.ParseDuration(value)
+		if err != nil || window <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				mhttp.HTTPReturnMessage: "invalid window: " + value,
+			})
+			return
+		}
+	}
+	limit := 10
+	if value := c.Query("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				mhttp.HTTPReturnMessage: "invalid limit: " + value,
+			})
+			return
+		}
+	}
+	sortBy := c.DefaultQuery("sort_by", slowlog.SortByTotal)
+	if sortBy != slowlog.SortByTotal && sortBy != slowlog.SortByCount && sortBy != slowlog.SortByP99 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			mhttp.HTTPReturnMessage: "invalid sort_by: " + sortBy,
+		})
+		return
+	}
+
+	ret, err := json.Marshal(slowlog.Top(window, limit, sortBy))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			mhttp.HTTPReturnMessage: err.Error(),
+		})
+		return
+	}
+	c.Data(http.StatusOK, contentType, ret)
+}
+
 // buildReqParams fetch all parameters from query parameter of URL, add them into a map data structure.
 // put key and value from query parameter into map, concatenate values with separator if values size is greater than 1
 func buildReqParams(c *gin.Context, metricsType string, customParams ...*commonpb.KeyValuePair) map[string]interface{} {
@@ -121,6 +121,23 @@ func TestGetDependencies(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "metastore")
 }
 
+func TestGetSlowQueryTop(t *testing.T) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/_cluster/slow_query/top?window=30m&limit=5&sort_by=count", nil)
+	getSlowQueryTop(c)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String())
+
+	for _, query := range []string{"window=abc", "limit=-1", "sort_by=unknown"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "/_cluster/slow_query/top?"+query, nil)
+		getSlowQueryTop(c)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	}
+}
+
 func TestBuildReqParams(t *testing.T) {
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -42,6 +42,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/proxy/slowlog"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/analyzer"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
@@ -189,6 +190,21 @@ func (node *Proxy) InvalidateCollectionMetaCache(ctx context.Context, request *p
 		}
 	}
 
+	// the ingestion connector property may be altered
+	if node.ingestionMgr != nil && msgType == commonpb.MsgType_AlterCollection && collectionID != UniqueID(0) {
+		node.ingestionMgr.InvalidateCollection(collectionID)
+	}
+
+	if node.resultCache != nil {
+		switch {
+		case collectionID != UniqueID(0):
+			node.resultCache.Invalidate(collectionID)
+		case msgType == commonpb.MsgType_DropDatabase, msgType == commonpb.MsgType_AlterDatabase, collectionName != "":
+			// the collection is unknown without id
+			node.resultCache.Clear()
+		}
+	}
+
 	if msgType == commonpb.MsgType_DropCollection {
 		// no need to handle error, since this Proxy may not create dml stream for the collection.
 		node.chMgr.removeDMLStream(request.GetCollectionID())
@@ -2649,6 +2665,8 @@ func GetCollectionRateSubLabel(req any) string {
 
 // Search searches the most similar records of requests.
 func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest) (*milvuspb.SearchResults, error) {
+	ctx, cancel, maxExecutionTime := withMaxExecutionTime(ctx, request.GetDbName(), request.GetCollectionName())
+	defer cancel()
 	var err error
 	rsp := &milvuspb.SearchResults{
 		Status: merr.Success(),
@@ -2711,6 +2729,7 @@ func (node *Proxy) Search(ctx context.Context, request *milvuspb.SearchRequest)
 	if err != nil {
 		rsp.Status = merr.Status(err)
 	}
+	rsp.Status = maxExecutionTimeStatus(ctx, rsp.GetStatus(), maxExecutionTime, "Search")
 	return rsp, nil
 }
 
@@ -2740,6 +2759,17 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Search")
 	defer sp.End()
 
+	// the ground truth search for recall evaluation is never cached
+	var cacheLookup *resultCacheLookup
+	if !isRecallEvaluation {
+		cacheLookup = node.newResultCacheLookup(ctx, metrics.SearchLabel, request)
+	}
+	if cacheLookup != nil {
+		if cached, ok := cacheLookup.Get(node.resultCache); ok {
+			return cached.(*milvuspb.SearchResults), false, false, false, nil
+		}
+	}
+
 	if request.SearchByPrimaryKeys {
 		placeholderGroupBytes, err := node.getVectorPlaceholderGroupForSearchByPks(ctx, request)
 		if err != nil {
@@ -2800,9 +2830,11 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 			if sp != nil {
 				traceID = sp.SpanContext().TraceID().String()
 			}
+			slowQuery := metricsinfo.NewSlowQueryWithSearchRequest(request, user, span, traceID)
 			if node.slowQueries != nil {
-				node.slowQueries.Add(qt.BeginTs(), metricsinfo.NewSlowQueryWithSearchRequest(request, user, span, traceID))
+				node.slowQueries.Add(qt.BeginTs(), slowQuery)
 			}
+			slowlog.RecordSearch(request, span, slowQuery)
 		}
 		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
 			metrics.ProxySlowQueryCount.WithLabelValues(
@@ -2874,6 +2906,10 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 		collectionName,
 	).Observe(float64(searchDur))
 
+	if cacheLookup != nil && merr.Ok(qt.result.GetStatus()) && !qt.resultSizeInsufficient && !qt.isRecallEvaluation {
+		cacheLookup.Put(node.resultCache, minChannelTs(qt.queryChannelsTs, qt.SearchRequest.GetGuaranteeTimestamp()), qt.result)
+	}
+
 	if qt.result != nil {
 		username := GetCurUserFromContextOrDefault(ctx)
 		sentSize := proto.Size(qt.result)
@@ -2894,6 +2930,8 @@ func (node *Proxy) search(ctx context.Context, request *milvuspb.SearchRequest,
 }
 
 func (node *Proxy) HybridSearch(ctx context.Context, request *milvuspb.HybridSearchRequest) (*milvuspb.SearchResults, error) {
+	ctx, cancel, maxExecutionTime := withMaxExecutionTime(ctx, request.GetDbName(), request.GetCollectionName())
+	defer cancel()
 	var err error
 	rsp := &milvuspb.SearchResults{
 		Status: merr.Success(),
@@ -2931,6 +2969,7 @@ func (node *Proxy) HybridSearch(ctx context.Context, request *milvuspb.HybridSea
 	if err2 != nil {
 		rsp.Status = merr.Status(err2)
 	}
+	rsp.Status = maxExecutionTimeStatus(ctx, rsp.GetStatus(), maxExecutionTime, "HybridSearch")
 	return rsp, err
 }
 
@@ -3008,9 +3047,11 @@ func (node *Proxy) hybridSearch(ctx context.Context, request *milvuspb.HybridSea
 			if sp != nil {
 				traceID = sp.SpanContext().TraceID().String()
 			}
+			slowQuery := metricsinfo.NewSlowQueryWithSearchRequest(newSearchReq, user, span, traceID)
 			if node.slowQueries != nil {
-				node.slowQueries.Add(qt.BeginTs(), metricsinfo.NewSlowQueryWithSearchRequest(newSearchReq, user, span, traceID))
+				node.slowQueries.Add(qt.BeginTs(), slowQuery)
 			}
+			slowlog.RecordSearch(newSearchReq, span, slowQuery)
 		}
 		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
 			metrics.ProxySlowQueryCount.WithLabelValues(
@@ -3265,9 +3306,11 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 				traceID = sp.SpanContext().TraceID().String()
 			}
 
+			slowQuery := metricsinfo.NewSlowQueryWithQueryRequest(request, user, span, traceID)
 			if node.slowQueries != nil {
-				node.slowQueries.Add(qt.BeginTs(), metricsinfo.NewSlowQueryWithQueryRequest(request, user, span, traceID))
+				node.slowQueries.Add(qt.BeginTs(), slowQuery)
 			}
+			slowlog.RecordQuery(request, span, slowQuery)
 		}
 		if span >= paramtable.Get().ProxyCfg.SlowQuerySpanInSeconds.GetAsDuration(time.Second) {
 			metrics.ProxySlowQueryCount.WithLabelValues(
@@ -3328,6 +3371,8 @@ func (node *Proxy) query(ctx context.Context, qt *queryTask, sp trace.Span) (*mi
 
 // Query get the records by primary keys.
 func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*milvuspb.QueryResults, error) {
+	ctx, cancel, maxExecutionTime := withMaxExecutionTime(ctx, request.GetDbName(), request.GetCollectionName())
+	defer cancel()
 	qt := &queryTask{
 		ctx:       ctx,
 		Condition: NewTaskCondition(ctx),
@@ -3370,11 +3415,26 @@ func (node *Proxy) Query(ctx context.Context, request *milvuspb.QueryRequest) (*
 	defer sp.End()
 	method := "Query"
 
+	cacheLookup := node.newResultCacheLookup(ctx, metrics.QueryLabel, request)
+	if cacheLookup != nil {
+		if cached, ok := cacheLookup.Get(node.resultCache); ok {
+			return cached.(*milvuspb.QueryResults), nil
+		}
+	}
+
 	res, err := node.query(ctx, qt, sp)
+	if res != nil {
+		res.Status = maxExecutionTimeStatus(ctx, res.GetStatus(), maxExecutionTime, method)
+	}
 	if err != nil || !merr.Ok(res.Status) {
 		return res, err
 	}
 
+	if cacheLookup != nil {
+		// query results carry no mvcc timestamp of the channels, the guarantee timestamp is the lower bound of it
+		cacheLookup.Put(node.resultCache, qt.GetGuaranteeTimestamp(), res)
+	}
+
 	log.Ctx(ctx).Debug(rpcDone(method))
 
 	username := GetCurUserFromContextOrDefault(ctx)
@@ -4133,6 +4193,17 @@ func (node *Proxy) GetMetrics(ctx context.Context, req *milvuspb.GetMetricsReque
 		return metrics, nil
 	}
 
+	if metricType == metricsinfo.DisconnectClientKey {
+		// the clients could only be disconnected by the admins
+		if err := checkAdmin(ctx); err != nil {
+			log.Warn("Proxy.GetMetrics failed to disconnect client", zap.Error(err))
+			return &milvuspb.GetMetricsResponse{
+				Status: merr.Status(err),
+			}, nil
+		}
+		return disconnectClient(ctx, ret), nil
+	}
+
 	log.RatedWarn(60, "Proxy.GetMetrics failed, request metric type is not implemented yet",
 		zap.Int64("nodeID", paramtable.GetNodeID()),
 		zap.String("req", req.Request),
@@ -4195,6 +4266,10 @@ func (node *Proxy) GetProxyMetrics(ctx context.Context, req *milvuspb.GetMetrics
 		return proxyMetrics, nil
 	}
 
+	if metricType == metricsinfo.DisconnectClientKey {
+		return disconnectClient(ctx, ret), nil
+	}
+
 	log.Warn("Proxy.GetProxyMetrics failed, request metric type is not implemented yet",
 		zap.String("metricType", metricType))
 
@@ -4203,6 +4278,23 @@ func (node *Proxy) GetProxyMetrics(ctx context.Context, req *milvuspb.GetMetrics
 	}, nil
 }
 
+// disconnectClient disconnects the client connected to this proxy forcibly.
+func disconnectClient(ctx context.Context, req gjson.Result) *milvuspb.GetMetricsResponse {
+	identifier := req.Get(metricsinfo.MetricRequestParamIdentifierKey).Int()
+	if err := connection.GetManager().Disconnect(ctx, identifier); err != nil {
+		log.Ctx(ctx).Warn("failed to disconnect client",
+			zap.Int64("identifier", identifier),
+			zap.Error(err))
+		return &milvuspb.GetMetricsResponse{
+			Status: merr.Status(err),
+		}
+	}
+	return &milvuspb.GetMetricsResponse{
+		Status:        merr.Success(),
+		ComponentName: metricsinfo.ConstructComponentName(typeutil.ProxyRole, paramtable.GetNodeID()),
+	}
+}
+
 // LoadBalance would do a load balancing operation between query nodes
 func (node *Proxy) LoadBalance(ctx context.Context, req *milvuspb.LoadBalanceRequest) (*commonpb.Status, error) {
 	ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-LoadBalance")
@@ -5865,7 +5957,12 @@ func (node *Proxy) Connect(ctx context.Context, request *milvuspb.ConnectRequest
 		Reserved:   make(map[string]string),
 	}
 
-	connection.GetManager().Register(ctx, int64(ts), request.GetClientInfo())
+	if err := connection.GetManager().Register(ctx, int64(ts), request.GetClientInfo()); err != nil {
+		log.Info("connect failed, failed to register client", zap.Error(err))
+		return &milvuspb.ConnectResponse{
+			Status: merr.Status(err),
+		}, nil
+	}
 
 	return &milvuspb.ConnectResponse{
 		Status:     merr.Success(),
@@ -6063,6 +6160,7 @@ func (node *Proxy) RegisterRestRouter(router gin.IRouter) {
 
 	// Slow query request that executed by proxy
 	router.GET(http.SlowQueryPath, getSlowQuery(node))
+	router.GET(http.SlowQueryTopPath, getSlowQueryTop)
 
 	// QueryCoord requests that are forwarded from proxy
 	router.GET(http.QCTargetPath, getQueryComponentMetrics(node, metricsinfo.TargetKey))
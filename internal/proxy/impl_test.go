@@ -40,6 +40,7 @@ import (
 	grpcmixcoordclient "github.com/milvus-io/milvus/internal/distributed/mixcoord/client"
 	mhttp "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/sessionutil"
 	"github.com/milvus-io/milvus/pkg/v2/common"
@@ -48,7 +49,9 @@ import (
 	"github.com/milvus-io/milvus/pkg/v2/proto/proxypb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/rootcoordpb"
+	"github.com/milvus-io/milvus/pkg/v2/util"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/ratelimitutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
@@ -1940,3 +1943,40 @@ func TestProxy_ListFileResources(t *testing.T) {
 		assert.Error(t, merr.Error(resp.GetStatus()))
 	})
 }
+
+func TestProxy_DisconnectClient(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(Params.CommonCfg.AuthorizationEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.CommonCfg.AuthorizationEnabled.Key)
+
+	cacheBak := globalMetaCache
+	defer func() { globalMetaCache = cacheBak }()
+	cache := NewMockCache(t)
+	cache.EXPECT().GetUserRole("alice").Return([]string{util.RoleAdmin}).Maybe()
+	cache.EXPECT().GetUserRole("bob").Return([]string{util.RolePublic}).Maybe()
+	globalMetaCache = cache
+
+	node := &Proxy{}
+	node.UpdateStateCode(commonpb.StateCode_Healthy)
+	identifier := int64(1001)
+	assert.NoError(t, connection.GetManager().Register(context.Background(), identifier, &commonpb.ClientInfo{}))
+	req, err := metricsinfo.ConstructGetMetricsRequest(map[string]interface{}{
+		metricsinfo.MetricTypeKey:                   metricsinfo.DisconnectClientKey,
+		metricsinfo.MetricRequestParamIdentifierKey: identifier,
+	})
+	assert.NoError(t, err)
+
+	// only the admins could disconnect clients
+	resp, err := node.GetMetrics(GetContext(context.Background(), "bob:123456"), req)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrPrivilegeNotPermitted)
+
+	resp, err = node.GetMetrics(GetContext(context.Background(), "alice:123456"), req)
+	assert.NoError(t, err)
+	assert.True(t, merr.Ok(resp.GetStatus()))
+
+	// the client has been disconnected
+	resp, err = node.GetMetrics(GetContext(context.Background(), "root:123456"), req)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrParameterInvalid)
+}
@@ -0,0 +1,166 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+const (
+	FormatJSON = "json"
+	FormatAvro = "avro"
+
+	defaultDBName          = "default"
+	defaultBatchSize       = 1000
+	defaultFlushIntervalMs = 1000
+)
+
+// ConnectorConfig is the spec of an ingestion connector, which consumes the records
+// of a kafka topic and inserts them into a collection.
+type ConnectorConfig struct {
+	Name string `json:"-"`
+
+	DBName         string `json:"dbName"`
+	CollectionName string `json:"collection"`
+	PartitionName  string `json:"partition"`
+
+	Brokers string `json:"brokers"`
+	Topic   string `json:"topic"`
+	GroupID string `json:"groupID"`
+	// Properties are passed to the kafka consumer as they are, e.g. the security settings.
+	Properties map[string]string `json:"properties"`
+
+	// Format is the encoding of record values, json or avro.
+	Format string `json:"format"`
+	// AvroSchema is the writer schema of avro records, which are not framed by a schema registry.
+	AvroSchema string `json:"avroSchema"`
+	// Mapping maps the field name to the dot-separated path of the value in record,
+	// the whole record is taken as the row if no mapping is provided.
+	Mapping map[string]string `json:"mapping"`
+
+	// BatchSize is the max number of records in one insert request.
+	BatchSize int `json:"batchSize"`
+	// FlushIntervalMs is the max time to wait before the records are inserted.
+	FlushIntervalMs int64 `json:"flushIntervalMs"`
+}
+
+func (c *ConnectorConfig) FlushInterval() time.Duration {
+	return time.Duration(c.FlushIntervalMs) * time.Millisecond
+}
+
+func (c *ConnectorConfig) fillDefaults() {
+	if c.DBName == "" {
+		c.DBName = defaultDBName
+	}
+	if c.GroupID == "" {
+		c.GroupID = fmt.Sprintf("milvus-ingestion-%s", c.Name)
+	}
+	if c.Format == "" {
+		c.Format = FormatJSON
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = defaultBatchSize
+	}
+	if c.FlushIntervalMs == 0 {
+		c.FlushIntervalMs = defaultFlushIntervalMs
+	}
+}
+
+func (c *ConnectorConfig) validate() error {
+	if c.CollectionName == "" {
+		return merr.WrapErrParameterInvalidMsg("collection of ingestion connector %s is required", c.Name)
+	}
+	if c.Brokers == "" {
+		return merr.WrapErrParameterInvalidMsg("brokers of ingestion connector %s is required", c.Name)
+	}
+	if c.Topic == "" {
+		return merr.WrapErrParameterInvalidMsg("topic of ingestion connector %s is required", c.Name)
+	}
+	switch c.Format {
+	case FormatJSON:
+	case FormatAvro:
+		if c.AvroSchema == "" {
+			return merr.WrapErrParameterInvalidMsg("avroSchema of ingestion connector %s is required", c.Name)
+		}
+	default:
+		return merr.WrapErrParameterInvalidMsg("unsupported format %s of ingestion connector %s", c.Format, c.Name)
+	}
+	if c.BatchSize < 0 {
+		return merr.WrapErrParameterInvalidMsg("invalid batchSize %d of ingestion connector %s", c.BatchSize, c.Name)
+	}
+	if c.FlushIntervalMs < 0 {
+		return merr.WrapErrParameterInvalidMsg("invalid flushIntervalMs %d of ingestion connector %s", c.FlushIntervalMs, c.Name)
+	}
+	return nil
+}
+
+// ParseConnectorConfig parses the JSON spec of the connector.
+func ParseConnectorConfig(name string, value string) (*ConnectorConfig, error) {
+	config := &ConnectorConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid spec of ingestion connector %s: %s", name, err.Error())
+	}
+	config.Name = name
+	config.fillDefaults()
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ParseCollectionConnectorConfig parses the connector spec set by the collection property `collection.ingestion.connector`,
+// the connector is named after the collection and always ingests into the collection itself.
+func ParseCollectionConnectorConfig(dbName string, collectionName string, value string) (*ConnectorConfig, error) {
+	name := fmt.Sprintf("%s.%s", dbName, collectionName)
+	config := &ConnectorConfig{}
+	if err := json.Unmarshal([]byte(value), config); err != nil {
+		return nil, merr.WrapErrParameterInvalidMsg("invalid spec of ingestion connector %s: %s", name, err.Error())
+	}
+	config.Name = name
+	config.DBName = dbName
+	config.CollectionName = collectionName
+	config.fillDefaults()
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// ParseConnectorConfigs parses the connector specs keyed by connector name,
+// which are configured by `proxy.ingestion.connectors`.
+// The invalid specs are skipped, the valid configs are returned along with the combined error of the invalid ones.
+func ParseConnectorConfigs(values map[string]string) ([]*ConnectorConfig, error) {
+	configs := make([]*ConnectorConfig, 0, len(values))
+	errs := make([]error, 0)
+	for name, value := range values {
+		config, err := ParseConnectorConfig(name, value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		configs = append(configs, config)
+	}
+	sort.Slice(configs, func(i, j int) bool {
+		return configs[i].Name < configs[j].Name
+	})
+	return configs, merr.Combine(errs...)
+}
@@ -0,0 +1,87 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseConnectorConfigs(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		configs, err := ParseConnectorConfigs(map[string]string{
+			"orders": `{"collection": "orders", "brokers": "localhost:9092", "topic": "orders"}`,
+			"events": `{"collection": "events", "dbName": "db1", "brokers": "localhost:9092", "topic": "events",
+				"format": "avro", "avroSchema": "{\"type\": \"record\", \"name\": \"r\", \"fields\": []}",
+				"mapping": {"id": "meta.id"}, "batchSize": 10, "flushIntervalMs": 200}`,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, configs, 2)
+
+		assert.Equal(t, "events", configs[0].Name)
+		assert.Equal(t, "db1", configs[0].DBName)
+		assert.Equal(t, FormatAvro, configs[0].Format)
+		assert.Equal(t, map[string]string{"id": "meta.id"}, configs[0].Mapping)
+		assert.Equal(t, 10, configs[0].BatchSize)
+		assert.Equal(t, int64(200), configs[0].FlushIntervalMs)
+
+		assert.Equal(t, "orders", configs[1].Name)
+		assert.Equal(t, defaultDBName, configs[1].DBName)
+		assert.Equal(t, "milvus-ingestion-orders", configs[1].GroupID)
+		assert.Equal(t, FormatJSON, configs[1].Format)
+		assert.Equal(t, defaultBatchSize, configs[1].BatchSize)
+		assert.Equal(t, int64(defaultFlushIntervalMs), configs[1].FlushIntervalMs)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		specs := []string{
+			`not a json`,
+			`{"brokers": "localhost:9092", "topic": "t"}`,
+			`{"collection": "c", "topic": "t"}`,
+			`{"collection": "c", "brokers": "localhost:9092"}`,
+			`{"collection": "c", "brokers": "localhost:9092", "topic": "t", "format": "csv"}`,
+			`{"collection": "c", "brokers": "localhost:9092", "topic": "t", "format": "avro"}`,
+			`{"collection": "c", "brokers": "localhost:9092", "topic": "t", "batchSize": -1}`,
+		}
+		for _, spec := range specs {
+			_, err := ParseConnectorConfigs(map[string]string{"c": spec})
+			assert.Error(t, err, spec)
+		}
+
+		// the invalid specs don't affect the valid ones
+		configs, err := ParseConnectorConfigs(map[string]string{
+			"a": `{"collection": "c", "brokers": "localhost:9092", "topic": "t"}`,
+			"b": `not a json`,
+		})
+		assert.Error(t, err)
+		assert.Len(t, configs, 1)
+		assert.Equal(t, "a", configs[0].Name)
+	})
+}
+
+func TestParseCollectionConnectorConfig(t *testing.T) {
+	config, err := ParseCollectionConnectorConfig("db1", "events", `{"collection": "other", "dbName": "db2", "brokers": "localhost:9092", "topic": "events"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "db1.events", config.Name)
+	assert.Equal(t, "db1", config.DBName)
+	assert.Equal(t, "events", config.CollectionName)
+	assert.Equal(t, "milvus-ingestion-db1.events", config.GroupID)
+
+	_, err = ParseCollectionConnectorConfig("db1", "events", `{"topic": "events"}`)
+	assert.Error(t, err)
+}
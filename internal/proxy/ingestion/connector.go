@@ -0,0 +1,234 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+const (
+	pollTimeout     = 100 * time.Millisecond
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
+// Proxy is the subset of proxy apis used by ingestion.
+type Proxy interface {
+	ListDatabases(ctx context.Context, request *milvuspb.ListDatabasesRequest) (*milvuspb.ListDatabasesResponse, error)
+	ShowCollections(ctx context.Context, request *milvuspb.ShowCollectionsRequest) (*milvuspb.ShowCollectionsResponse, error)
+	DescribeCollection(ctx context.Context, request *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error)
+	Insert(ctx context.Context, request *milvuspb.InsertRequest) (*milvuspb.MutationResult, error)
+}
+
+// connector consumes the records from source and inserts them into the collection in batches.
+// The offsets are committed after the insertion succeeds, so the records may be inserted
+// more than once if the proxy crashes between the insertion and the commit.
+type connector struct {
+	config  *ConnectorConfig
+	proxy   Proxy
+	source  Source
+	decoder Decoder
+	mapper  *rowMapper
+	// backoff of the next poll retry, reset once a poll succeeds
+	pollBackoff time.Duration
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newConnector(config *ConnectorConfig, proxy Proxy, source Source) (*connector, error) {
+	decoder, err := NewDecoder(config)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &connector{
+		config:      config,
+		proxy:       proxy,
+		source:      source,
+		decoder:     decoder,
+		pollBackoff: minRetryBackoff,
+		ctx:         ctx,
+		cancel:      cancel,
+	}, nil
+}
+
+func (c *connector) start() {
+	c.wg.Add(1)
+	go c.run()
+}
+
+func (c *connector) stop() {
+	c.cancel()
+	c.wg.Wait()
+	c.source.Close()
+}
+
+func (c *connector) logger() *log.MLogger {
+	return log.Ctx(c.ctx).With(
+		zap.String("connector", c.config.Name),
+		zap.String("topic", c.config.Topic),
+		zap.String("collection", c.config.CollectionName))
+}
+
+func (c *connector) run() {
+	defer c.wg.Done()
+	c.logger().Info("ingestion connector started")
+	defer c.logger().Info("ingestion connector stopped")
+	for {
+		records := c.poll()
+		if c.ctx.Err() != nil {
+			return
+		}
+		if len(records) == 0 {
+			continue
+		}
+		if err := c.flush(records); err != nil {
+			// only happens when the connector is stopping, the records will be consumed again
+			return
+		}
+	}
+}
+
+// poll collects the records until the batch is full or the flush interval elapses.
+func (c *connector) poll() []*Record {
+	deadline := time.Now().Add(c.config.FlushInterval())
+	records := make([]*Record, 0, c.config.BatchSize)
+	for len(records) < c.config.BatchSize && c.ctx.Err() == nil {
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			break
+		}
+		if timeout > pollTimeout {
+			timeout = pollTimeout
+		}
+		record, err := c.source.Poll(c.ctx, timeout)
+		if err != nil {
+			// e.g. the brokers are unreachable, back off to avoid spinning on the errors
+			c.logger().Warn("failed to poll record, will retry", zap.Duration("backoff", c.pollBackoff), zap.Error(err))
+			if c.sleep(&c.pollBackoff) != nil {
+				break
+			}
+			continue
+		}
+		c.pollBackoff = minRetryBackoff
+		if record != nil {
+			records = append(records, record)
+		}
+	}
+	return records
+}
+
+// flush inserts the records and commits the offsets, it retries until succeed or the connector is stopped.
+func (c *connector) flush(records []*Record) error {
+	backoff := minRetryBackoff
+	for {
+		err := c.insert(records)
+		if err == nil {
+			err = c.source.Commit(c.ctx, records)
+			if err == nil {
+				return nil
+			}
+			// the records have been inserted, retry the commit only
+			c.logger().Warn("failed to commit offsets", zap.Error(err))
+			for err != nil {
+				if err = c.sleep(&backoff); err != nil {
+					return err
+				}
+				err = c.source.Commit(c.ctx, records)
+			}
+			return nil
+		}
+		c.logger().Warn("failed to insert records, will retry", zap.Int("records", len(records)), zap.Error(err))
+		// the schema may be changed, describe the collection again
+		c.mapper = nil
+		if err := c.sleep(&backoff); err != nil {
+			return err
+		}
+	}
+}
+
+func (c *connector) sleep(backoff *time.Duration) error {
+	select {
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	case <-time.After(*backoff):
+	}
+	*backoff *= 2
+	if *backoff > maxRetryBackoff {
+		*backoff = maxRetryBackoff
+	}
+	return nil
+}
+
+func (c *connector) insert(records []*Record) error {
+	if c.mapper == nil {
+		resp, err := c.proxy.DescribeCollection(c.ctx, &milvuspb.DescribeCollectionRequest{
+			DbName:         c.config.DBName,
+			CollectionName: c.config.CollectionName,
+		})
+		if err := merr.CheckRPCCall(resp, err); err != nil {
+			return err
+		}
+		mapper, err := newRowMapper(c.config, resp.GetSchema())
+		if err != nil {
+			return err
+		}
+		c.mapper = mapper
+	}
+
+	data, err := c.mapper.newInsertData()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		row, err := c.decoder.Decode(record.Value)
+		if err == nil {
+			err = c.mapper.appendRecord(data, row)
+		}
+		if err != nil {
+			// bad records are skipped, otherwise the connector gets stuck forever
+			c.logger().Warn("skip invalid record",
+				zap.Int32("partition", record.Partition),
+				zap.Int64("offset", record.Offset),
+				zap.Error(err))
+		}
+	}
+	if data.GetRowNum() == 0 {
+		return nil
+	}
+
+	request, err := c.mapper.buildInsertRequest(data)
+	if err != nil {
+		return err
+	}
+	resp, err := c.proxy.Insert(c.ctx, request)
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return err
+	}
+	c.logger().Debug("records ingested", zap.Uint32("rows", request.GetNumRows()))
+	return nil
+}
@@ -0,0 +1,502 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type fakeSource struct {
+	mu        sync.Mutex
+	records   []*Record
+	committed map[topicPartition]int64
+	closed    bool
+	// the number of polls to fail
+	pollFailTimes int
+	polls         int
+}
+
+func newFakeSource(values ...string) *fakeSource {
+	s := &fakeSource{committed: make(map[topicPartition]int64)}
+	for i, value := range values {
+		s.records = append(s.records, &Record{Topic: "t", Partition: 0, Offset: int64(i), Value: []byte(value)})
+	}
+	return s
+}
+
+func (s *fakeSource) Poll(ctx context.Context, timeout time.Duration) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.polls++
+	if s.pollFailTimes > 0 {
+		s.pollFailTimes--
+		return nil, errors.New("mock")
+	}
+	if len(s.records) == 0 {
+		return nil, nil
+	}
+	record := s.records[0]
+	s.records = s.records[1:]
+	return record, nil
+}
+
+func (s *fakeSource) Commit(ctx context.Context, records []*Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for tp, offset := range nextOffsets(records) {
+		s.committed[tp] = offset
+	}
+	return nil
+}
+
+func (s *fakeSource) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+}
+
+func (s *fakeSource) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *fakeSource) pollTimes() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.polls
+}
+
+func (s *fakeSource) committedOffset() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.committed[topicPartition{topic: "t", partition: 0}]
+}
+
+type fakeProxy struct {
+	mu        sync.Mutex
+	schema    *schemapb.CollectionSchema
+	failTimes int
+	requests  []*milvuspb.InsertRequest
+	// the ingestion connector specs of collections, db name -> collection name -> spec
+	connectors map[string]map[string]string
+	listFailed bool
+	// collection ids and describe times of collections, "db.collection" -> value
+	collectionIDs map[string]int64
+	describes     map[string]int
+}
+
+func (p *fakeProxy) collectionID(dbName, collectionName string) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.getCollectionID(dbName, collectionName)
+}
+
+func (p *fakeProxy) getCollectionID(dbName, collectionName string) int64 {
+	if p.collectionIDs == nil {
+		p.collectionIDs = make(map[string]int64)
+	}
+	key := dbName + "." + collectionName
+	if _, ok := p.collectionIDs[key]; !ok {
+		p.collectionIDs[key] = int64(len(p.collectionIDs) + 1)
+	}
+	return p.collectionIDs[key]
+}
+
+func (p *fakeProxy) describeTimes(dbName, collectionName string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.describes[dbName+"."+collectionName]
+}
+
+func (p *fakeProxy) setConnectors(connectors map[string]map[string]string, listFailed bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.connectors = connectors
+	p.listFailed = listFailed
+}
+
+func (p *fakeProxy) ListDatabases(ctx context.Context, request *milvuspb.ListDatabasesRequest) (*milvuspb.ListDatabasesResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.listFailed {
+		return &milvuspb.ListDatabasesResponse{Status: merr.Status(merr.WrapErrServiceUnavailable("mock"))}, nil
+	}
+	dbNames := []string{defaultDBName}
+	for dbName := range p.connectors {
+		if dbName != defaultDBName {
+			dbNames = append(dbNames, dbName)
+		}
+	}
+	return &milvuspb.ListDatabasesResponse{Status: merr.Success(), DbNames: dbNames}, nil
+}
+
+func (p *fakeProxy) ShowCollections(ctx context.Context, request *milvuspb.ShowCollectionsRequest) (*milvuspb.ShowCollectionsResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	collectionNames := make([]string, 0)
+	collectionIDs := make([]int64, 0)
+	for collectionName := range p.connectors[request.GetDbName()] {
+		collectionNames = append(collectionNames, collectionName)
+		collectionIDs = append(collectionIDs, p.getCollectionID(request.GetDbName(), collectionName))
+	}
+	return &milvuspb.ShowCollectionsResponse{Status: merr.Success(), CollectionNames: collectionNames, CollectionIds: collectionIDs}, nil
+}
+
+func (p *fakeProxy) DescribeCollection(ctx context.Context, request *milvuspb.DescribeCollectionRequest) (*milvuspb.DescribeCollectionResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.describes == nil {
+		p.describes = make(map[string]int)
+	}
+	p.describes[request.GetDbName()+"."+request.GetCollectionName()]++
+	resp := &milvuspb.DescribeCollectionResponse{Status: merr.Success(), Schema: p.schema}
+	if spec, ok := p.connectors[request.GetDbName()][request.GetCollectionName()]; ok && spec != "" {
+		resp.Properties = []*commonpb.KeyValuePair{{Key: common.CollectionIngestionConnectorKey, Value: spec}}
+	}
+	return resp, nil
+}
+
+func (p *fakeProxy) Insert(ctx context.Context, request *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failTimes > 0 {
+		p.failTimes--
+		return &milvuspb.MutationResult{Status: merr.Status(merr.WrapErrServiceUnavailable("mock"))}, nil
+	}
+	p.requests = append(p.requests, request)
+	return &milvuspb.MutationResult{Status: merr.Success()}, nil
+}
+
+func (p *fakeProxy) insertedRows() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rows := 0
+	for _, request := range p.requests {
+		rows += int(request.GetNumRows())
+	}
+	return rows
+}
+
+type ConnectorSuite struct {
+	suite.Suite
+
+	schema *schemapb.CollectionSchema
+	config *ConnectorConfig
+}
+
+func (s *ConnectorSuite) SetupTest() {
+	s.schema = &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "id", DataType: schemapb.DataType_Int64, IsPrimaryKey: true, AutoID: true},
+			{FieldID: 101, Name: "user", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: "max_length", Value: "64"}}},
+			{FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+		},
+	}
+	s.config = &ConnectorConfig{
+		Name:           "test",
+		CollectionName: "test",
+		Brokers:        "localhost:9092",
+		Topic:          "t",
+		Mapping:        map[string]string{"user": "meta.user", "vector": "embedding"},
+		BatchSize:      2,
+	}
+	s.config.fillDefaults()
+	s.config.FlushIntervalMs = 50
+}
+
+func (s *ConnectorSuite) record(user string) string {
+	return fmt.Sprintf(`{"meta": {"user": "%s"}, "embedding": [0.1, 0.2], "ignored": 1}`, user)
+}
+
+func (s *ConnectorSuite) TestIngest() {
+	source := newFakeSource(s.record("a"), s.record("b"), s.record("c"))
+	proxy := &fakeProxy{schema: s.schema}
+	c, err := newConnector(s.config, proxy, source)
+	s.Require().NoError(err)
+	c.start()
+	defer c.stop()
+
+	s.Eventually(func() bool {
+		return proxy.insertedRows() == 3 && source.committedOffset() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	request := proxy.requests[0]
+	s.Equal("default", request.GetDbName())
+	s.Equal("test", request.GetCollectionName())
+	s.EqualValues(2, request.GetNumRows())
+	// auto-generated primary key is not provided
+	s.Len(request.GetFieldsData(), 2)
+	for _, fieldData := range request.GetFieldsData() {
+		switch fieldData.GetFieldName() {
+		case "user":
+			s.Equal([]string{"a", "b"}, fieldData.GetScalars().GetStringData().GetData())
+		case "vector":
+			s.Equal([]float32{0.1, 0.2, 0.1, 0.2}, fieldData.GetVectors().GetFloatVector().GetData())
+		default:
+			s.Fail("unexpected field", fieldData.GetFieldName())
+		}
+	}
+}
+
+func (s *ConnectorSuite) TestSkipInvalidRecord() {
+	source := newFakeSource(s.record("a"), `not a json`, `{"meta": {"user": "b"}}`)
+	proxy := &fakeProxy{schema: s.schema}
+	c, err := newConnector(s.config, proxy, source)
+	s.Require().NoError(err)
+	c.start()
+	defer c.stop()
+
+	s.Eventually(func() bool {
+		return source.committedOffset() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Equal(1, proxy.insertedRows())
+}
+
+func (s *ConnectorSuite) TestRetryInsert() {
+	source := newFakeSource(s.record("a"))
+	proxy := &fakeProxy{schema: s.schema, failTimes: 2}
+	c, err := newConnector(s.config, proxy, source)
+	s.Require().NoError(err)
+	c.start()
+	defer c.stop()
+
+	s.Eventually(func() bool {
+		return proxy.insertedRows() == 1 && source.committedOffset() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *ConnectorSuite) TestPollBackoff() {
+	source := newFakeSource(s.record("a"))
+	source.pollFailTimes = 3
+	proxy := &fakeProxy{schema: s.schema}
+	c, err := newConnector(s.config, proxy, source)
+	s.Require().NoError(err)
+	c.start()
+	defer c.stop()
+
+	// backs off 100ms, 200ms and 400ms before the record is polled
+	time.Sleep(250 * time.Millisecond)
+	s.LessOrEqual(source.pollTimes(), 3)
+	s.Eventually(func() bool {
+		return proxy.insertedRows() == 1 && source.committedOffset() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *ConnectorSuite) TestStopWhileRetrying() {
+	source := newFakeSource(s.record("a"))
+	proxy := &fakeProxy{schema: s.schema, failTimes: 1000}
+	c, err := newConnector(s.config, proxy, source)
+	s.Require().NoError(err)
+	c.start()
+
+	time.Sleep(200 * time.Millisecond)
+	c.stop()
+	s.True(source.closed)
+	s.Equal(int64(0), source.committedOffset())
+}
+
+func TestConnector(t *testing.T) {
+	suite.Run(t, new(ConnectorSuite))
+}
+
+type ManagerSuite struct {
+	suite.Suite
+}
+
+func (s *ManagerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *ManagerSuite) SetupTest() {
+	params := paramtable.Get()
+	params.Save(params.ProxyCfg.IngestionRefreshInterval.Key, "0.05")
+}
+
+func (s *ManagerSuite) TearDownTest() {
+	params := paramtable.Get()
+	params.Reset(params.ProxyCfg.IngestionRefreshInterval.Key)
+}
+
+// fakeSources records the sources created by manager
+type fakeSources struct {
+	mu      sync.Mutex
+	sources map[string][]*fakeSource
+	// the number of failures to create the source of connector
+	failTimes map[string]int
+}
+
+func newFakeSources() *fakeSources {
+	return &fakeSources{
+		sources:   make(map[string][]*fakeSource),
+		failTimes: make(map[string]int),
+	}
+}
+
+func (f *fakeSources) newSource(config *ConnectorConfig) (Source, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failTimes[config.Name] > 0 {
+		f.failTimes[config.Name]--
+		return nil, errors.New("mock")
+	}
+	source := newFakeSource()
+	f.sources[config.Name] = append(f.sources[config.Name], source)
+	return source, nil
+}
+
+// running returns the names of connectors whose latest source is not closed
+func (f *fakeSources) running() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0)
+	for name, sources := range f.sources {
+		if !sources[len(sources)-1].isClosed() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *fakeSources) created(name string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.sources[name])
+}
+
+func (s *ManagerSuite) TestStaticConnectors() {
+	configs := []*ConnectorConfig{
+		{Name: "a", Format: FormatJSON, BatchSize: 1, FlushIntervalMs: 10},
+		{Name: "b", Format: FormatJSON, BatchSize: 1, FlushIntervalMs: 10},
+	}
+	sources := newFakeSources()
+	m := NewManager(&fakeProxy{}, configs, sources.newSource)
+	m.Start()
+	s.Eventually(func() bool {
+		return reflect.DeepEqual([]string{"a", "b"}, sources.running())
+	}, 5*time.Second, 10*time.Millisecond)
+	m.Stop()
+	s.Empty(sources.running())
+	s.Equal(1, sources.created("a"))
+	s.Equal(1, sources.created("b"))
+}
+
+func (s *ManagerSuite) TestRetryFailedConnector() {
+	configs := []*ConnectorConfig{
+		{Name: "a", Format: FormatJSON, BatchSize: 1, FlushIntervalMs: 10},
+		{Name: "b", Format: FormatJSON, BatchSize: 1, FlushIntervalMs: 10},
+	}
+	sources := newFakeSources()
+	sources.failTimes["b"] = 2
+	m := NewManager(&fakeProxy{}, configs, sources.newSource)
+	m.Start()
+	defer m.Stop()
+
+	// the failed connector doesn't affect the others, and it's started once the source is available
+	s.Eventually(func() bool {
+		return reflect.DeepEqual([]string{"a", "b"}, sources.running())
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Equal(1, sources.created("a"))
+	s.Equal(1, sources.created("b"))
+}
+
+func (s *ManagerSuite) TestCollectionConnectors() {
+	spec := `{"brokers": "localhost:9092", "topic": "t", "flushIntervalMs": 10}`
+	proxy := &fakeProxy{}
+	proxy.setConnectors(map[string]map[string]string{
+		defaultDBName: {"c1": spec, "c2": ""},
+		"db1":         {"c1": spec, "c3": `not a json`},
+	}, false)
+	configs := []*ConnectorConfig{
+		{Name: "a", Format: FormatJSON, BatchSize: 1, FlushIntervalMs: 10},
+	}
+	sources := newFakeSources()
+	m := NewManager(proxy, configs, sources.newSource)
+	m.Start()
+	defer m.Stop()
+
+	s.Eventually(func() bool {
+		return reflect.DeepEqual([]string{"a", "db1.c1", "default.c1"}, sources.running())
+	}, 5*time.Second, 10*time.Millisecond)
+	m.mu.Lock()
+	config := m.connectors["db1.c1"].config
+	m.mu.Unlock()
+	s.Equal("db1", config.DBName)
+	s.Equal("c1", config.CollectionName)
+
+	// the collections without connectors are described only once until they are altered
+	s.Eventually(func() bool {
+		return proxy.describeTimes(defaultDBName, "c1") > 2
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Equal(1, proxy.describeTimes(defaultDBName, "c2"))
+	s.Equal(1, proxy.describeTimes("db1", "c3"))
+	m.InvalidateCollection(proxy.collectionID(defaultDBName, "c2"))
+	s.Eventually(func() bool {
+		return proxy.describeTimes(defaultDBName, "c2") == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Equal(1, proxy.describeTimes("db1", "c3"))
+
+	// the connectors are kept if failed to list the collections
+	proxy.setConnectors(nil, true)
+	time.Sleep(200 * time.Millisecond)
+	s.Equal([]string{"a", "db1.c1", "default.c1"}, sources.running())
+
+	// the changed connector is restarted, and the removed one is stopped
+	proxy.setConnectors(map[string]map[string]string{
+		defaultDBName: {"c1": `{"brokers": "localhost:9092", "topic": "t2"}`},
+	}, false)
+	s.Eventually(func() bool {
+		return reflect.DeepEqual([]string{"a", "default.c1"}, sources.running())
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Equal(2, sources.created("default.c1"))
+	s.Equal(1, sources.created("a"))
+}
+
+func TestManager(t *testing.T) {
+	suite.Run(t, new(ManagerSuite))
+}
+
+func TestNextOffsets(t *testing.T) {
+	offsets := nextOffsets([]*Record{
+		{Topic: "t", Partition: 0, Offset: 3},
+		{Topic: "t", Partition: 1, Offset: 5},
+		{Topic: "t", Partition: 0, Offset: 1},
+	})
+	assert.Equal(t, map[topicPartition]int64{
+		{topic: "t", partition: 0}: 4,
+		{topic: "t", partition: 1}: 6,
+	}, offsets)
+}
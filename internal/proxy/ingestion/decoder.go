@@ -0,0 +1,81 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+
+	avroutil "github.com/milvus-io/milvus/internal/util/importutilv2/avro"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+// Decoder decodes the value of record into a generic row. The numbers of json records are json.Number,
+// which is what the json row parser of import expects, while the avro records keep the native values,
+// they are normalized by the row mapper according to the collection schema.
+type Decoder interface {
+	Decode(value []byte) (map[string]any, error)
+}
+
+func NewDecoder(config *ConnectorConfig) (Decoder, error) {
+	switch config.Format {
+	case FormatJSON:
+		return &jsonDecoder{}, nil
+	case FormatAvro:
+		schema, err := avro.Parse(config.AvroSchema)
+		if err != nil {
+			return nil, merr.WrapErrParameterInvalidMsg("invalid avro schema of ingestion connector %s: %s", config.Name, err.Error())
+		}
+		return &avroDecoder{schema: schema}, nil
+	default:
+		return nil, merr.WrapErrParameterInvalidMsg("unsupported format %s", config.Format)
+	}
+}
+
+type jsonDecoder struct{}
+
+func (d *jsonDecoder) Decode(value []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	var row map[string]any
+	if err := dec.Decode(&row); err != nil {
+		return nil, fmt.Errorf("failed to decode json record: %w", err)
+	}
+	if row == nil {
+		return nil, fmt.Errorf("json record should be an object")
+	}
+	return row, nil
+}
+
+type avroDecoder struct {
+	schema avro.Schema
+}
+
+func (d *avroDecoder) Decode(value []byte) (map[string]any, error) {
+	var v any
+	if err := avro.Unmarshal(d.schema, value, &v); err != nil {
+		return nil, fmt.Errorf("failed to decode avro record: %w", err)
+	}
+	row, ok := avroutil.UnwrapUnion(d.schema, v).(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("avro record should be a record")
+	}
+	return row, nil
+}
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hamba/avro/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+)
+
+func TestJSONDecoder(t *testing.T) {
+	decoder, err := NewDecoder(&ConnectorConfig{Format: FormatJSON})
+	assert.NoError(t, err)
+
+	row, err := decoder.Decode([]byte(`{"id": 1, "vector": [0.1, 0.2], "meta": {"tag": "a"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("1"), row["id"])
+	assert.Equal(t, []any{json.Number("0.1"), json.Number("0.2")}, row["vector"])
+	assert.Equal(t, map[string]any{"tag": "a"}, row["meta"])
+
+	_, err = decoder.Decode([]byte(`[1, 2]`))
+	assert.Error(t, err)
+	_, err = decoder.Decode([]byte(`null`))
+	assert.Error(t, err)
+}
+
+func TestAvroDecoder(t *testing.T) {
+	schema := `{
+		"type": "record",
+		"name": "row",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "vector", "type": {"type": "array", "items": "float"}},
+			{"name": "tag", "type": ["null", "string"]}
+		]
+	}`
+	_, err := NewDecoder(&ConnectorConfig{Format: FormatAvro, AvroSchema: "invalid"})
+	assert.Error(t, err)
+
+	decoder, err := NewDecoder(&ConnectorConfig{Format: FormatAvro, AvroSchema: schema})
+	assert.NoError(t, err)
+
+	value, err := avro.Marshal(avro.MustParse(schema), map[string]any{
+		"id":     int64(7),
+		"vector": []float32{0.5, 1},
+		"tag":    "a",
+	})
+	assert.NoError(t, err)
+
+	// the native values are kept, and the union is unwrapped
+	row, err := decoder.Decode(value)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), row["id"])
+	assert.Equal(t, []any{float32(0.5), float32(1)}, row["vector"])
+	assert.Equal(t, "a", row["tag"])
+
+	_, err = decoder.Decode([]byte{0xff})
+	assert.Error(t, err)
+
+	// the native values are converted according to the collection schema
+	mapper, err := newRowMapper(&ConnectorConfig{Format: FormatAvro}, &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "id", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "vector", DataType: schemapb.DataType_FloatVector, TypeParams: []*commonpb.KeyValuePair{{Key: "dim", Value: "2"}}},
+			{FieldID: 102, Name: "tag", DataType: schemapb.DataType_VarChar, TypeParams: []*commonpb.KeyValuePair{{Key: "max_length", Value: "8"}}},
+		},
+	})
+	assert.NoError(t, err)
+	data, err := mapper.newInsertData()
+	assert.NoError(t, err)
+	assert.NoError(t, mapper.appendRecord(data, row))
+	assert.Equal(t, 1, data.GetRowNum())
+	assert.Equal(t, []int64{7}, data.Data[100].GetDataRows())
+	assert.Equal(t, []float32{0.5, 1}, data.Data[101].GetDataRows())
+	assert.Equal(t, []string{"a"}, data.Data[102].GetDataRows())
+}
@@ -0,0 +1,270 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// Manager manages the lifetime of ingestion connectors in proxy.
+// The connectors come from the static configs and the collection property `collection.ingestion.connector`,
+// they are refreshed periodically, so the connectors of collections are started or stopped as the property changes,
+// and the connectors failed to start are retried in the next round.
+type Manager struct {
+	proxy     Proxy
+	configs   []*ConnectorConfig
+	newSource SourceFactory
+
+	mu         sync.Mutex
+	connectors map[string]*connector
+	// the collections altered since the last refresh, they are described again
+	invalidated typeutil.UniqueSet
+
+	// the described collections, collection id -> connectors of collection,
+	// only accessed by the refresh loop
+	described map[int64]*describedCollection
+
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+}
+
+func NewManager(proxy Proxy, configs []*ConnectorConfig, newSource SourceFactory) *Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		proxy:       proxy,
+		configs:     configs,
+		newSource:   newSource,
+		connectors:  make(map[string]*connector),
+		invalidated: typeutil.NewUniqueSet(),
+		described:   make(map[int64]*describedCollection),
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start starts refreshing the connectors in background, it never fails the caller.
+func (m *Manager) Start() {
+	m.startOnce.Do(func() {
+		m.wg.Add(1)
+		go m.loop()
+	})
+}
+
+func (m *Manager) Stop() {
+	m.stopOnce.Do(func() {
+		m.cancel()
+		m.wg.Wait()
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for name, c := range m.connectors {
+			c.stop()
+			delete(m.connectors, name)
+		}
+	})
+}
+
+// InvalidateCollection marks the collection as altered,
+// so its connector property is read again in the next refresh.
+func (m *Manager) InvalidateCollection(collectionID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.invalidated.Insert(collectionID)
+}
+
+func (m *Manager) loop() {
+	defer m.wg.Done()
+	m.refresh()
+	ticker := time.NewTicker(paramtable.Get().ProxyCfg.IngestionRefreshInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			m.refresh()
+		}
+	}
+}
+
+// refresh starts the connectors not running yet, restarts the ones whose spec changed,
+// and stops the ones no longer configured.
+func (m *Manager) refresh() {
+	configs := make(map[string]*ConnectorConfig, len(m.configs))
+	collectionConfigs, err := m.listCollectionConnectors()
+	if err != nil {
+		if m.ctx.Err() != nil {
+			return
+		}
+		// keep the running connectors of collections as they are
+		log.Warn("failed to list ingestion connectors of collections", zap.Error(err))
+		m.mu.Lock()
+		for name, c := range m.connectors {
+			configs[name] = c.config
+		}
+		m.mu.Unlock()
+	}
+	for _, config := range collectionConfigs {
+		configs[config.Name] = config
+	}
+	// the static configs take precedence
+	for _, config := range m.configs {
+		configs[config.Name] = config
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.ctx.Err() != nil {
+		return
+	}
+	for name, c := range m.connectors {
+		if config, ok := configs[name]; !ok || !reflect.DeepEqual(config, c.config) {
+			log.Info("stop ingestion connector since its spec is changed or removed", zap.String("connector", name))
+			c.stop()
+			delete(m.connectors, name)
+		}
+	}
+	for name, config := range configs {
+		if _, ok := m.connectors[name]; ok {
+			continue
+		}
+		c, err := m.startConnector(config)
+		if err != nil {
+			log.Warn("failed to start ingestion connector, will retry", zap.String("connector", name), zap.Error(err))
+			continue
+		}
+		m.connectors[name] = c
+	}
+}
+
+func (m *Manager) startConnector(config *ConnectorConfig) (*connector, error) {
+	source, err := m.newSource(config)
+	if err != nil {
+		return nil, err
+	}
+	c, err := newConnector(config, m.proxy, source)
+	if err != nil {
+		source.Close()
+		return nil, err
+	}
+	c.start()
+	return c, nil
+}
+
+// describedCollection is the connectors of a described collection.
+type describedCollection struct {
+	dbName         string
+	collectionName string
+	configs        []*ConnectorConfig
+}
+
+// listCollectionConnectors lists the connectors configured by the collection property of all collections.
+// Only the collections with connectors, the new ones and the altered ones are described,
+// the others are known to have no connector.
+func (m *Manager) listCollectionConnectors() ([]*ConnectorConfig, error) {
+	m.mu.Lock()
+	for _, collectionID := range m.invalidated.Collect() {
+		delete(m.described, collectionID)
+	}
+	m.invalidated = typeutil.NewUniqueSet()
+	m.mu.Unlock()
+
+	dbs, err := m.proxy.ListDatabases(m.ctx, &milvuspb.ListDatabasesRequest{})
+	if err := merr.CheckRPCCall(dbs, err); err != nil {
+		return nil, err
+	}
+	listed := typeutil.NewUniqueSet()
+	configs := make([]*ConnectorConfig, 0)
+	for _, dbName := range dbs.GetDbNames() {
+		collections, err := m.proxy.ShowCollections(m.ctx, &milvuspb.ShowCollectionsRequest{DbName: dbName})
+		if err := merr.CheckRPCCall(collections, err); err != nil {
+			return nil, err
+		}
+		collectionIDs := collections.GetCollectionIds()
+		for i, collectionName := range collections.GetCollectionNames() {
+			var collectionID int64
+			if i < len(collectionIDs) {
+				collectionID = collectionIDs[i]
+			}
+			listed.Insert(collectionID)
+			cached, ok := m.described[collectionID]
+			if ok && len(cached.configs) == 0 &&
+				cached.dbName == dbName && cached.collectionName == collectionName {
+				continue
+			}
+			described, err := m.describeCollection(dbName, collectionName)
+			if err != nil {
+				if errors.Is(err, merr.ErrCollectionNotFound) {
+					// dropped after listed
+					continue
+				}
+				return nil, err
+			}
+			m.described[collectionID] = described
+			configs = append(configs, described.configs...)
+		}
+	}
+	// forget the dropped collections
+	for collectionID := range m.described {
+		if !listed.Contain(collectionID) {
+			delete(m.described, collectionID)
+		}
+	}
+	return configs, nil
+}
+
+func (m *Manager) describeCollection(dbName, collectionName string) (*describedCollection, error) {
+	resp, err := m.proxy.DescribeCollection(m.ctx, &milvuspb.DescribeCollectionRequest{
+		DbName:         dbName,
+		CollectionName: collectionName,
+	})
+	if err := merr.CheckRPCCall(resp, err); err != nil {
+		return nil, err
+	}
+	described := &describedCollection{
+		dbName:         dbName,
+		collectionName: collectionName,
+	}
+	for _, kv := range resp.GetProperties() {
+		if kv.GetKey() != common.CollectionIngestionConnectorKey {
+			continue
+		}
+		config, err := ParseCollectionConnectorConfig(dbName, collectionName, kv.GetValue())
+		if err != nil {
+			log.RatedWarn(60, "skip invalid ingestion connector of collection",
+				zap.String("db", dbName), zap.String("collection", collectionName), zap.Error(err))
+			continue
+		}
+		described.configs = append(described.configs, config)
+	}
+	return described, nil
+}
@@ -0,0 +1,138 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/json"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// rowMapper converts the decoded records into the insert request of the collection.
+type rowMapper struct {
+	config *ConnectorConfig
+	schema *schemapb.CollectionSchema
+	parser json.RowParser
+}
+
+func newRowMapper(config *ConnectorConfig, schema *schemapb.CollectionSchema) (*rowMapper, error) {
+	parser, err := json.NewRowParser(schema)
+	if err != nil {
+		return nil, err
+	}
+	return &rowMapper{
+		config: config,
+		schema: schema,
+		parser: parser,
+	}, nil
+}
+
+// mapRow picks the fields from record according to the mapping,
+// the whole record is the row if there is no mapping.
+func (m *rowMapper) mapRow(record map[string]any) (map[string]any, error) {
+	if len(m.config.Mapping) == 0 {
+		return record, nil
+	}
+	row := make(map[string]any, len(m.config.Mapping))
+	for field, path := range m.config.Mapping {
+		value, ok := lookupPath(record, path)
+		if !ok {
+			// let the row parser decide whether the field could be absent
+			continue
+		}
+		row[field] = value
+	}
+	return row, nil
+}
+
+func lookupPath(record map[string]any, path string) (any, bool) {
+	var current any = record
+	for _, key := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		current, ok = obj[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// newInsertData creates an empty buffer of rows.
+func (m *rowMapper) newInsertData() (*storage.InsertData, error) {
+	return storage.NewInsertData(m.schema)
+}
+
+// appendRecord maps and parses the record, then appends it to the buffer.
+func (m *rowMapper) appendRecord(data *storage.InsertData, record map[string]any) error {
+	row, err := m.mapRow(record)
+	if err != nil {
+		return err
+	}
+	if m.config.Format == FormatAvro {
+		row, err = json.NormalizeNativeRow(m.schema, row)
+		if err != nil {
+			return err
+		}
+	}
+	parsed, err := m.parser.Parse(row)
+	if err != nil {
+		return err
+	}
+	return data.Append(parsed)
+}
+
+// buildInsertRequest converts the buffered rows into the insert request.
+func (m *rowMapper) buildInsertRequest(data *storage.InsertData) (*milvuspb.InsertRequest, error) {
+	record, err := storage.TransferInsertDataToInsertRecord(data)
+	if err != nil {
+		return nil, err
+	}
+	fields := lo.KeyBy(m.schema.GetFields(), func(field *schemapb.FieldSchema) int64 {
+		return field.GetFieldID()
+	})
+	fieldsData := make([]*schemapb.FieldData, 0, len(record.GetFieldsData()))
+	for _, fieldData := range record.GetFieldsData() {
+		field, ok := fields[fieldData.GetFieldId()]
+		if !ok {
+			return nil, fmt.Errorf("field %d not found in schema", fieldData.GetFieldId())
+		}
+		// auto-generated primary key is assigned by proxy
+		if typeutil.IsAutoPKField(field) {
+			continue
+		}
+		fieldData.FieldName = field.GetName()
+		fieldData.IsDynamic = field.GetIsDynamic()
+		fieldsData = append(fieldsData, fieldData)
+	}
+	return &milvuspb.InsertRequest{
+		DbName:         m.config.DBName,
+		CollectionName: m.config.CollectionName,
+		PartitionName:  m.config.PartitionName,
+		FieldsData:     fieldsData,
+		NumRows:        uint32(data.GetRowNum()),
+	}, nil
+}
@@ -0,0 +1,134 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ingestion
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
+)
+
+// Record is a message consumed from the source.
+type Record struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Value     []byte
+}
+
+// Source is the upstream of an ingestion connector.
+type Source interface {
+	// Poll returns the next record, or nil if no record arrives before timeout.
+	Poll(ctx context.Context, timeout time.Duration) (*Record, error)
+	// Commit marks the records as consumed, they will not be delivered again after restart.
+	Commit(ctx context.Context, records []*Record) error
+	Close()
+}
+
+// SourceFactory creates the source of the connector.
+type SourceFactory func(config *ConnectorConfig) (Source, error)
+
+type kafkaSource struct {
+	consumer *kafka.Consumer
+}
+
+// NewKafkaSource creates a source consuming the topic of the connector.
+// The offsets are committed manually after the records are inserted,
+// so the records are delivered at least once.
+func NewKafkaSource(config *ConnectorConfig) (Source, error) {
+	configMap := &kafka.ConfigMap{
+		"bootstrap.servers":  config.Brokers,
+		"group.id":           config.GroupID,
+		"enable.auto.commit": false,
+		"auto.offset.reset":  "earliest",
+	}
+	for k, v := range config.Properties {
+		if err := configMap.SetKey(k, v); err != nil {
+			return nil, errors.Wrapf(err, "invalid kafka property %s", k)
+		}
+	}
+	consumer, err := kafka.NewConsumer(configMap)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create kafka consumer")
+	}
+	if err := consumer.SubscribeTopics([]string{config.Topic}, nil); err != nil {
+		consumer.Close()
+		return nil, errors.Wrapf(err, "failed to subscribe kafka topic %s", config.Topic)
+	}
+	return &kafkaSource{consumer: consumer}, nil
+}
+
+func (s *kafkaSource) Poll(ctx context.Context, timeout time.Duration) (*Record, error) {
+	msg, err := s.consumer.ReadMessage(timeout)
+	if err != nil {
+		var kafkaErr kafka.Error
+		if errors.As(err, &kafkaErr) && kafkaErr.Code() == kafka.ErrTimedOut {
+			return nil, nil
+		}
+		return nil, err
+	}
+	record := &Record{
+		Partition: msg.TopicPartition.Partition,
+		Offset:    int64(msg.TopicPartition.Offset),
+		Value:     msg.Value,
+	}
+	if msg.TopicPartition.Topic != nil {
+		record.Topic = *msg.TopicPartition.Topic
+	}
+	return record, nil
+}
+
+func (s *kafkaSource) Commit(ctx context.Context, records []*Record) error {
+	offsets := nextOffsets(records)
+	if len(offsets) == 0 {
+		return nil
+	}
+	partitions := make([]kafka.TopicPartition, 0, len(offsets))
+	for tp, offset := range offsets {
+		topic := tp.topic
+		partitions = append(partitions, kafka.TopicPartition{
+			Topic:     &topic,
+			Partition: tp.partition,
+			Offset:    kafka.Offset(offset),
+		})
+	}
+	_, err := s.consumer.CommitOffsets(partitions)
+	return err
+}
+
+func (s *kafkaSource) Close() {
+	s.consumer.Close()
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+// nextOffsets returns the offset of the next record to consume for each partition.
+func nextOffsets(records []*Record) map[topicPartition]int64 {
+	offsets := make(map[topicPartition]int64)
+	for _, record := range records {
+		tp := topicPartition{topic: record.Topic, partition: record.Partition}
+		if offset, ok := offsets[tp]; !ok || record.Offset+1 > offset {
+			offsets[tp] = record.Offset + 1
+		}
+	}
+	return offsets
+}
@@ -0,0 +1,68 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"google.golang.org/grpc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/proxy/ingestion"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+// ingestionProxy is the proxy used by the ingestion connectors. The inserts of connectors go through
+// the audit, hook, rate limit and trace log interceptors as the inserts of the external grpc server,
+// while the authentication and privilege interceptors are skipped since the connectors are configured by the administrators.
+type ingestionProxy struct {
+	*Proxy
+	interceptor grpc.UnaryServerInterceptor
+}
+
+var _ ingestion.Proxy = (*ingestionProxy)(nil)
+
+func newIngestionProxy(node *Proxy) *ingestionProxy {
+	return &ingestionProxy{
+		Proxy: node,
+		interceptor: grpc_middleware.ChainUnaryServer(
+			UnaryServerAuditInterceptor(),
+			UnaryServerHookInterceptor(),
+			RateLimitInterceptor(node.simpleLimiter),
+			TraceLogInterceptor,
+		),
+	}
+}
+
+func (p *ingestionProxy) Insert(ctx context.Context, request *milvuspb.InsertRequest) (*milvuspb.MutationResult, error) {
+	info := &grpc.UnaryServerInfo{
+		Server:     p.Proxy,
+		FullMethod: milvuspb.MilvusService_Insert_FullMethodName,
+	}
+	resp, err := p.interceptor(ctx, request, info, func(ctx context.Context, req any) (any, error) {
+		return p.Proxy.Insert(ctx, req.(*milvuspb.InsertRequest))
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, ok := resp.(*milvuspb.MutationResult)
+	if !ok {
+		return nil, merr.WrapErrServiceInternal("unexpected response of insert")
+	}
+	return result, nil
+}
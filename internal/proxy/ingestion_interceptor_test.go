@@ -0,0 +1,65 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/util/hookutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+func TestIngestionProxy_Insert(t *testing.T) {
+	node := &Proxy{simpleLimiter: NewSimpleLimiter(0, 0)}
+	node.UpdateStateCode(commonpb.StateCode_Abnormal)
+	p := newIngestionProxy(node)
+	request := &milvuspb.InsertRequest{DbName: "default", CollectionName: "test"}
+
+	hookutil.InitOnceHook()
+	defer hookutil.SetTestHook(hookutil.DefaultHook{})
+
+	// the insert goes through the hook interceptor
+	mocked := &milvuspb.MutationResult{Status: merr.Success(), InsertCnt: 1}
+	hookutil.SetTestHook(mockHook{mockRes: mocked})
+	resp, err := p.Insert(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, mocked, resp)
+
+	hookutil.SetTestHook(mockHook{mockRes: "unexpected"})
+	_, err = p.Insert(context.Background(), request)
+	assert.Error(t, err)
+
+	// then reaches the proxy after the rate limit check
+	originCache := globalMetaCache
+	defer func() {
+		globalMetaCache = originCache
+	}()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetDatabaseInfo(mock.Anything, mock.Anything).Return(nil, errors.New("mock"))
+	globalMetaCache = mockCache
+	hookutil.SetTestHook(hookutil.DefaultHook{})
+	resp, err = p.Insert(context.Background(), request)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, merr.Error(resp.GetStatus()), merr.ErrServiceNotReady)
+}
@@ -0,0 +1,264 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/types"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+const (
+	// the number of the recent shard request latencies kept for each collection
+	hedgeLatencyWindowSize = 256
+	// no hedged request is sent before enough latencies are collected
+	hedgeMinLatencySamples = 32
+	// the hedge budget accumulated when the shard requests are fast
+	hedgeBudgetMaxTokens = 10
+)
+
+// shardLatencyWindow keeps the recent latencies of the shard requests of a collection.
+type shardLatencyWindow struct {
+	mu      sync.Mutex
+	samples []time.Duration
+	next    int
+}
+
+func newShardLatencyWindow() *shardLatencyWindow {
+	return &shardLatencyWindow{
+		samples: make([]time.Duration, 0, hedgeLatencyWindowSize),
+	}
+}
+
+func (w *shardLatencyWindow) Record(latency time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.samples) < hedgeLatencyWindowSize {
+		w.samples = append(w.samples, latency)
+		return
+	}
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % hedgeLatencyWindowSize
+}
+
+// HedgeDelay returns the time to wait before hedging, false if there are not enough samples.
+func (w *shardLatencyWindow) HedgeDelay() (time.Duration, bool) {
+	w.mu.Lock()
+	samples := make([]time.Duration, len(w.samples))
+	copy(samples, w.samples)
+	w.mu.Unlock()
+
+	if len(samples) < hedgeMinLatencySamples {
+		return 0, false
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	percentile := Params.ProxyCfg.HedgedRequestPercentile.GetAsFloat()
+	index := int(math.Ceil(percentile*float64(len(samples)))) - 1
+	index = min(max(index, 0), len(samples)-1)
+	return max(samples[index], Params.ProxyCfg.HedgedRequestMinDelay.GetAsDuration(time.Millisecond)), true
+}
+
+// hedgeBudget bounds the ratio of hedged requests, each shard request deposits the ratio of a token
+// and each hedged request withdraws a whole one.
+type hedgeBudget struct {
+	mu     sync.Mutex
+	tokens float64
+}
+
+func (b *hedgeBudget) Deposit() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.tokens+Params.ProxyCfg.HedgedRequestBudgetRatio.GetAsFloat(), hedgeBudgetMaxTokens)
+}
+
+func (b *hedgeBudget) Withdraw() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+type shardResultClaimKey struct{}
+
+// withShardResultClaim makes the executions of a shard request with the returned context
+// share a claim, so that only the first finished one takes the result.
+func withShardResultClaim(ctx context.Context) context.Context {
+	return context.WithValue(ctx, shardResultClaimKey{}, atomic.NewBool(false))
+}
+
+// claimShardResult returns true if the execution should take its result,
+// it is always true for the shard requests not hedged.
+func claimShardResult(ctx context.Context) bool {
+	claimed, ok := ctx.Value(shardResultClaimKey{}).(*atomic.Bool)
+	if !ok {
+		return true
+	}
+	return claimed.CompareAndSwap(false, true)
+}
+
+// shardResultClaimed returns true if another execution of the shard request has taken the result,
+// the execution could be cancelled and its failure should be ignored.
+func shardResultClaimed(ctx context.Context) bool {
+	claimed, ok := ctx.Value(shardResultClaimKey{}).(*atomic.Bool)
+	return ok && claimed.Load()
+}
+
+// isHedgeEnabled returns whether the workload could be hedged, the collection property takes precedence
+// over the database one.
+func (lb *LBPolicyImpl) isHedgeEnabled(ctx context.Context, workload CollectionWorkLoad) bool {
+	if !workload.hedgeable || globalMetaCache == nil {
+		return false
+	}
+	collectionInfo, err := globalMetaCache.GetCollectionInfo(ctx, workload.db, workload.collectionName, workload.collectionID)
+	if err == nil {
+		if enabled, ok := common.IsHedgedRequestEnabled(collectionInfo.properties...); ok {
+			return enabled
+		}
+	}
+	dbInfo, err := globalMetaCache.GetDatabaseInfo(ctx, workload.db)
+	if err != nil {
+		return false
+	}
+	enabled, _ := common.IsHedgedRequestEnabled(dbInfo.properties...)
+	return enabled
+}
+
+type hedgeResult struct {
+	nodeID int64
+	err    error
+}
+
+// executeWithHedge executes the workload on the target node, and sends a duplicate to another replica
+// if it does not return within the hedge delay. The first successful one is taken and the other is cancelled.
+func (lb *LBPolicyImpl) executeWithHedge(ctx context.Context, balancer LBBalancer, workload ChannelWorkload,
+	targetNode nodeInfo, client types.QueryNodeClient, excludeNodes *typeutil.UniqueSet,
+) error {
+	if !workload.hedge {
+		return workload.exec(ctx, targetNode.nodeID, client, workload.channel)
+	}
+
+	window, _ := lb.shardLatencies.GetOrInsert(workload.collectionID, newShardLatencyWindow())
+	start := time.Now()
+	delay, ok := window.HedgeDelay()
+	if !ok {
+		err := workload.exec(ctx, targetNode.nodeID, client, workload.channel)
+		if err == nil {
+			window.Record(time.Since(start))
+		}
+		return err
+	}
+	lb.hedgeBudget.Deposit()
+
+	ctx = withShardResultClaim(ctx)
+	primaryCtx, cancelPrimary := context.WithCancel(ctx)
+	defer cancelPrimary()
+	results := make(chan hedgeResult, 2)
+	go func() {
+		results <- hedgeResult{nodeID: targetNode.nodeID, err: workload.exec(primaryCtx, targetNode.nodeID, client, workload.channel)}
+	}()
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case result := <-results:
+		if result.err == nil {
+			window.Record(time.Since(start))
+		}
+		return result.err
+	case <-timer.C:
+	}
+
+	pending := 1
+	if cancelHedge, ok := lb.startHedge(ctx, balancer, workload, targetNode, excludeNodes, results); ok {
+		defer cancelHedge()
+		pending++
+	}
+	var err error
+	for ; pending > 0; pending-- {
+		result := <-results
+		if result.err == nil {
+			window.Record(time.Since(start))
+			if result.nodeID != targetNode.nodeID {
+				metrics.ProxyHedgedRequestCount.WithLabelValues(paramtable.GetStringNodeID(), metrics.SuccessLabel).Inc()
+			}
+			return nil
+		}
+		// return the error of the target node, so that it is excluded on retry
+		if err == nil || result.nodeID == targetNode.nodeID {
+			err = result.err
+		}
+	}
+	return err
+}
+
+// startHedge sends the hedged request to another replica, the results are sent to results.
+func (lb *LBPolicyImpl) startHedge(ctx context.Context, balancer LBBalancer, workload ChannelWorkload,
+	targetNode nodeInfo, excludeNodes *typeutil.UniqueSet, results chan<- hedgeResult,
+) (context.CancelFunc, bool) {
+	shardLeaders, err := lb.GetShard(ctx, workload.db, workload.collectionName, workload.collectionID, workload.channel, true)
+	if err != nil || len(shardLeaders) <= 1 {
+		return nil, false
+	}
+	if !lb.hedgeBudget.Withdraw() {
+		metrics.ProxyHedgedRequestCount.WithLabelValues(paramtable.GetStringNodeID(), metrics.RejectedLabel).Inc()
+		return nil, false
+	}
+
+	hedgeExcludeNodes := typeutil.NewUniqueSet(excludeNodes.Collect()...)
+	hedgeExcludeNodes.Insert(targetNode.nodeID)
+	hedgeNode, err := lb.selectNode(ctx, balancer, workload, &hedgeExcludeNodes)
+	if err != nil {
+		return nil, false
+	}
+	if hedgeNode.nodeID == targetNode.nodeID {
+		balancer.CancelWorkload(hedgeNode.nodeID, workload.nq)
+		return nil, false
+	}
+	client, err := lb.clientMgr.GetClient(ctx, hedgeNode)
+	if err != nil {
+		balancer.CancelWorkload(hedgeNode.nodeID, workload.nq)
+		return nil, false
+	}
+
+	log.Ctx(ctx).Debug("shard request is slow, send hedged request to another replica",
+		zap.Int64("collectionID", workload.collectionID),
+		zap.String("channelName", workload.channel),
+		zap.Int64("nodeID", targetNode.nodeID),
+		zap.Int64("hedgeNodeID", hedgeNode.nodeID))
+	metrics.ProxyHedgedRequestCount.WithLabelValues(paramtable.GetStringNodeID(), metrics.TotalLabel).Inc()
+	hedgeCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer balancer.CancelWorkload(hedgeNode.nodeID, workload.nq)
+		results <- hedgeResult{nodeID: hedgeNode.nodeID, err: workload.exec(hedgeCtx, hedgeNode.nodeID, client, workload.channel)}
+	}()
+	return cancel, true
+}
@@ -0,0 +1,95 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestShardLatencyWindow(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(Params.ProxyCfg.HedgedRequestMinDelay.Key, "5")
+	defer params.Reset(Params.ProxyCfg.HedgedRequestMinDelay.Key)
+
+	window := newShardLatencyWindow()
+	_, ok := window.HedgeDelay()
+	assert.False(t, ok)
+
+	for i := 1; i <= 100; i++ {
+		window.Record(time.Duration(i) * time.Millisecond)
+	}
+	delay, ok := window.HedgeDelay()
+	assert.True(t, ok)
+	assert.Equal(t, 95*time.Millisecond, delay)
+
+	// not shorter than the min delay
+	params.Save(Params.ProxyCfg.HedgedRequestPercentile.Key, "0.01")
+	defer params.Reset(Params.ProxyCfg.HedgedRequestPercentile.Key)
+	delay, _ = window.HedgeDelay()
+	assert.Equal(t, 5*time.Millisecond, delay)
+
+	// the old latencies are replaced
+	for i := 0; i < hedgeLatencyWindowSize; i++ {
+		window.Record(time.Second)
+	}
+	delay, _ = window.HedgeDelay()
+	assert.Equal(t, time.Second, delay)
+	assert.Len(t, window.samples, hedgeLatencyWindowSize)
+}
+
+func TestHedgeBudget(t *testing.T) {
+	paramtable.Init()
+	params := paramtable.Get()
+	params.Save(Params.ProxyCfg.HedgedRequestBudgetRatio.Key, "0.5")
+	defer params.Reset(Params.ProxyCfg.HedgedRequestBudgetRatio.Key)
+
+	budget := &hedgeBudget{}
+	assert.False(t, budget.Withdraw())
+	budget.Deposit()
+	assert.False(t, budget.Withdraw())
+	budget.Deposit()
+	assert.True(t, budget.Withdraw())
+	assert.False(t, budget.Withdraw())
+
+	for i := 0; i < 100; i++ {
+		budget.Deposit()
+	}
+	for i := 0; i < hedgeBudgetMaxTokens; i++ {
+		assert.True(t, budget.Withdraw())
+	}
+	assert.False(t, budget.Withdraw())
+}
+
+func TestShardResultClaim(t *testing.T) {
+	ctx := context.Background()
+	assert.True(t, claimShardResult(ctx))
+	assert.True(t, claimShardResult(ctx))
+	assert.False(t, shardResultClaimed(ctx))
+
+	ctx = withShardResultClaim(ctx)
+	assert.False(t, shardResultClaimed(ctx))
+	assert.True(t, claimShardResult(ctx))
+	assert.True(t, shardResultClaimed(ctx))
+	assert.False(t, claimShardResult(ctx))
+}
@@ -43,6 +43,8 @@ type ChannelWorkload struct {
 	channel        string
 	nq             int64
 	exec           executeFunc
+	// whether to send hedged requests to another replica if the shard request is slow
+	hedge bool
 }
 
 type CollectionWorkLoad struct {
@@ -51,6 +53,8 @@ type CollectionWorkLoad struct {
 	collectionID   int64
 	nq             int64
 	exec           executeFunc
+	// whether exec supports hedged requests, see claimShardResult
+	hedgeable bool
 }
 
 type LBPolicy interface {
@@ -72,6 +76,10 @@ type LBPolicyImpl struct {
 	clientMgr      shardClientMgr
 	balancerMap    map[string]LBBalancer
 	retryOnReplica int
+
+	// recent shard request latencies of the collections with hedged requests enabled
+	shardLatencies *typeutil.ConcurrentMap[int64, *shardLatencyWindow]
+	hedgeBudget    *hedgeBudget
 }
 
 func NewLBPolicyImpl(clientMgr shardClientMgr) *LBPolicyImpl {
@@ -94,6 +102,8 @@ func NewLBPolicyImpl(clientMgr shardClientMgr) *LBPolicyImpl {
 		clientMgr:      clientMgr,
 		balancerMap:    balancerMap,
 		retryOnReplica: retryOnReplica,
+		shardLatencies: typeutil.NewConcurrentMap[int64, *shardLatencyWindow](),
+		hedgeBudget:    &hedgeBudget{},
 	}
 }
 
@@ -261,7 +271,7 @@ func (lb *LBPolicyImpl) ExecuteWithRetry(ctx context.Context, workload ChannelWo
 			return true, lastErr
 		}
 
-		err = workload.exec(ctx, targetNode.nodeID, client, workload.channel)
+		err = lb.executeWithHedge(ctx, balancer, workload, targetNode, client, &excludeNodes)
 		if err != nil {
 			log.Warn("search/query channel failed",
 				zap.Int64("nodeID", targetNode.nodeID),
@@ -306,6 +316,7 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 		return merr.WrapErrCollectionNotLoaded(workload.collectionID)
 	}
 
+	hedge := lb.isHedgeEnabled(ctx, workload)
 	wg, _ := errgroup.WithContext(ctx)
 	// Launch a goroutine for each channel
 	for _, channel := range channelList {
@@ -317,6 +328,7 @@ func (lb *LBPolicyImpl) Execute(ctx context.Context, workload CollectionWorkLoad
 				channel:        channel,
 				nq:             workload.nq,
 				exec:           workload.exec,
+				hedge:          hedge,
 			})
 		})
 	}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/mock"
@@ -469,6 +470,58 @@ func (s *LBPolicySuite) TestExecute() {
 	s.ErrorIs(err, mockErr)
 }
 
+func (s *LBPolicySuite) TestExecuteWithHedge() {
+	ctx := context.Background()
+	window, _ := s.lbPolicy.shardLatencies.GetOrInsert(s.collectionID, newShardLatencyWindow())
+	for i := 0; i < hedgeMinLatencySamples; i++ {
+		window.Record(time.Millisecond)
+	}
+
+	s.mgr.EXPECT().GetClient(mock.Anything, mock.Anything).Return(s.qn, nil)
+	s.lbBalancer.EXPECT().RegisterNodeInfo(mock.Anything)
+	s.lbBalancer.EXPECT().CancelWorkload(mock.Anything, mock.Anything).Maybe()
+	taken := atomic.NewInt64(0)
+	workload := ChannelWorkload{
+		db:             dbName,
+		collectionName: s.collectionName,
+		collectionID:   s.collectionID,
+		channel:        s.channels[0],
+		nq:             1,
+		// node 1 is slow
+		exec: func(ctx context.Context, nodeID UniqueID, qn types.QueryNodeClient, channel string) error {
+			if nodeID == 1 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(100 * time.Millisecond):
+				}
+			}
+			if shardResultClaimed(ctx) {
+				return nil
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if claimShardResult(ctx) {
+				taken.Store(nodeID)
+			}
+			return nil
+		},
+		hedge: true,
+	}
+
+	// the result of the hedged request is taken
+	s.lbPolicy.hedgeBudget.tokens = 1
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Once()
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).Return(2, nil).Once()
+	s.NoError(s.lbPolicy.ExecuteWithRetry(ctx, workload))
+	s.Equal(int64(2), taken.Load())
+
+	// no hedged request without budget
+	s.lbBalancer.EXPECT().SelectNode(mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Once()
+	s.NoError(s.lbPolicy.ExecuteWithRetry(ctx, workload))
+	s.Equal(int64(1), taken.Load())
+}
+
 func (s *LBPolicySuite) TestUpdateCostMetrics() {
 	s.lbBalancer.EXPECT().UpdateCostMetrics(mock.Anything, mock.Anything)
 	s.lbPolicy.UpdateCostMetrics(1, &internalpb.CostAggregation{})
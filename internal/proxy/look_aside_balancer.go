@@ -216,6 +216,9 @@ func (b *LookAsideBalancer) calculateScore(node int64, cost *internalpb.CostAggr
 
 	// workload := math.Pow(float64(1+cost.GetTotalNQ()+executingNQ), 3.0) * float64(cost.ServiceTime)
 	workload := pow3(1+cost.GetTotalNQ()+executingNQ) * cost.ServiceTime
+	// the delegator reports the workload of the channel, requests on a channel with hot segments
+	// wait behind the inflight ones and the tasks in the read scheduler, which the node level cost misses.
+	workload += cost.GetChannelLatency() * (cost.GetChannelInflight() + cost.GetWaitingTaskNum() + cost.GetExecutingTaskNum())
 	if workload < 0 {
 		return math.MaxInt64
 	}
@@ -112,6 +112,18 @@ func (suite *LookAsideBalancerSuite) TestCalculateScore() {
 	suite.Equal(int64(352), score7)
 	suite.Equal(int64(220), score8)
 
+	// the workload of the hot channel raises the score
+	hotChannelMetrics := &internalpb.CostAggregation{
+		ResponseTime:     5,
+		ServiceTime:      1,
+		TotalNQ:          1,
+		ChannelInflight:  3,
+		ChannelLatency:   10,
+		WaitingTaskNum:   1,
+		ExecutingTaskNum: 1,
+	}
+	suite.Equal(int64(62), suite.balancer.calculateScore(-1, hotChannelMetrics, 0))
+
 	// test score overflow
 	costMetrics5 := &internalpb.CostAggregation{
 		ResponseTime: 5,
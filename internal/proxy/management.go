@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/samber/lo"
@@ -27,10 +28,13 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	management "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/proxy/connection"
 	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v2/util/commonpbutil"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
 // this file contains proxy management restful API handler
@@ -86,6 +90,18 @@ func RegisterMgrRoute(proxy *Proxy) {
 			Path:        management.RouteQueryCoordBalanceStatus,
 			HandlerFunc: proxy.CheckQueryCoordBalanceStatus,
 		})
+		management.Register(&management.Handler{
+			Path:        management.RouteSimulateQueryCoordBalance,
+			HandlerFunc: proxy.SimulateQueryCoordBalance,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteListProxyClients,
+			HandlerFunc: proxy.ListClients,
+		})
+		management.Register(&management.Handler{
+			Path:        management.RouteDisconnectProxyClient,
+			HandlerFunc: proxy.DisconnectClient,
+		})
 	})
 }
 
@@ -160,6 +176,40 @@ func (node *Proxy) ListQueryNode(w http.ResponseWriter, req *http.Request) {
 	w.Write(bytes)
 }
 
+func (node *Proxy) ListClients(w http.ResponseWriter, req *http.Request) {
+	bytes, err := json.Marshal(connection.GetManager().List())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to list clients, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(bytes)
+}
+
+func (node *Proxy) DisconnectClient(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to disconnect client, %s"}`, err.Error())))
+		return
+	}
+
+	identifier, err := strconv.ParseInt(req.FormValue("identifier"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to disconnect client, %s"}`, err.Error())))
+		return
+	}
+	if err := connection.GetManager().Disconnect(req.Context(), identifier); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to disconnect client, %s"}`, err.Error())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(`{"msg": "OK"}`))
+}
+
 func (node *Proxy) GetQueryNodeDistribution(w http.ResponseWriter, req *http.Request) {
 	err := req.ParseForm()
 	if err != nil {
@@ -276,6 +326,71 @@ func (node *Proxy) CheckQueryCoordBalanceStatus(w http.ResponseWriter, req *http
 	w.Write([]byte(fmt.Sprintf(`{"msg": "OK", "status": "%v"}`, balanceStatus)))
 }
 
+// SimulateQueryCoordBalance returns the balance plan generated on a snapshot of the distribution, nothing is moved.
+func (node *Proxy) SimulateQueryCoordBalance(w http.ResponseWriter, req *http.Request) {
+	err := req.ParseForm()
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to simulate balance, %s"}`, err.Error())))
+		return
+	}
+
+	params := map[string]interface{}{
+		metricsinfo.MetricTypeKey:                 metricsinfo.BalanceSimulationKey,
+		metricsinfo.MetricRequestProcessInRoleKey: typeutil.QueryCoordRole,
+	}
+	for _, key := range []string{
+		metricsinfo.MetricRequestParamBalancerKey,
+		metricsinfo.MetricRequestParamCollectionIDKey,
+		metricsinfo.MetricRequestParamAddNodesKey,
+		metricsinfo.MetricRequestParamRemoveNodesKey,
+		metricsinfo.MetricRequestParamMaxRoundsKey,
+	} {
+		if values := req.Form[key]; len(values) > 0 {
+			params[key] = strings.Join(values, metricsinfo.MetricRequestParamsSeparator)
+		}
+	}
+	if v, ok := params[metricsinfo.MetricRequestParamCollectionIDKey]; ok {
+		collectionID, err := strconv.ParseInt(v.(string), 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to simulate balance, %s"}`, err.Error())))
+			return
+		}
+		params[metricsinfo.MetricRequestParamCollectionIDKey] = collectionID
+	}
+	if v, ok := params[metricsinfo.MetricRequestParamMaxRoundsKey]; ok {
+		maxRounds, err := strconv.Atoi(v.(string))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf(`{"msg": "failed to simulate balance, %s"}`, err.Error())))
+			return
+		}
+		params[metricsinfo.MetricRequestParamMaxRoundsKey] = maxRounds
+	}
+
+	metricsReq, err := metricsinfo.ConstructGetMetricsRequest(params)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to simulate balance, %s"}`, err.Error())))
+		return
+	}
+	resp, err := node.mixCoord.GetMetrics(req.Context(), metricsReq)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to simulate balance, %s"}`, err.Error())))
+		return
+	}
+
+	if !merr.Ok(resp.GetStatus()) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(fmt.Sprintf(`{"msg": "failed to simulate balance, %s"}`, resp.GetStatus().GetReason())))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(resp.GetResponse()))
+}
+
 func (node *Proxy) SuspendQueryNode(w http.ResponseWriter, req *http.Request) {
 	err := req.ParseForm()
 	if err != nil {
@@ -26,14 +26,18 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"github.com/tidwall/gjson"
 	"google.golang.org/grpc"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	management "github.com/milvus-io/milvus/internal/http"
 	"github.com/milvus-io/milvus/internal/mocks"
 	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
 	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
 type ProxyManagementSuite struct {
@@ -430,6 +434,78 @@ func (s *ProxyManagementSuite) TestCheckBalanceStatus() {
 	})
 }
 
+func (s *ProxyManagementSuite) TestSimulateQueryCoordBalance() {
+	s.Run("normal", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, req *milvuspb.GetMetricsRequest, opts ...grpc.CallOption) (*milvuspb.GetMetricsResponse, error) {
+			jsonReq := gjson.Parse(req.GetRequest())
+			s.Equal(metricsinfo.BalanceSimulationKey, jsonReq.Get(metricsinfo.MetricTypeKey).String())
+			s.Equal(typeutil.QueryCoordRole, jsonReq.Get(metricsinfo.MetricRequestProcessInRoleKey).String())
+			s.Equal(int64(1), jsonReq.Get(metricsinfo.MetricRequestParamCollectionIDKey).Int())
+			s.Equal("rg1:2,rg2:1", jsonReq.Get(metricsinfo.MetricRequestParamAddNodesKey).String())
+			s.Equal("3", jsonReq.Get(metricsinfo.MetricRequestParamRemoveNodesKey).String())
+			s.False(jsonReq.Get(metricsinfo.MetricRequestParamMaxRoundsKey).Exists())
+			return &milvuspb.GetMetricsResponse{
+				Status:   merr.Success(),
+				Response: `{"converged": true}`,
+			}, nil
+		})
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteSimulateQueryCoordBalance, strings.NewReader("collection_id=1&add_nodes=rg1:2&add_nodes=rg2:1&remove_nodes=3"))
+		s.Require().NoError(err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		recorder := httptest.NewRecorder()
+		s.proxy.SimulateQueryCoordBalance(recorder, req)
+		s.Equal(http.StatusOK, recorder.Code)
+		s.Equal(`{"converged": true}`, recorder.Body.String())
+	})
+
+	s.Run("invalid_params", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteSimulateQueryCoordBalance+"?max_rounds=x", nil)
+		s.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+		s.proxy.SimulateQueryCoordBalance(recorder, req)
+		s.Equal(http.StatusBadRequest, recorder.Code)
+	})
+
+	s.Run("return_error", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).Return(nil, errors.New("mocked error"))
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteSimulateQueryCoordBalance, nil)
+		s.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+		s.proxy.SimulateQueryCoordBalance(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+
+	s.Run("return_failure", func() {
+		s.SetupTest()
+		defer s.TearDownTest()
+
+		s.mixcoord.EXPECT().GetMetrics(mock.Anything, mock.Anything).Return(&milvuspb.GetMetricsResponse{
+			Status: merr.Status(merr.WrapErrParameterInvalid("balancer name", "unknown")),
+		}, nil)
+
+		req, err := http.NewRequest(http.MethodPost, management.RouteSimulateQueryCoordBalance+"?balancer=unknown", nil)
+		s.Require().NoError(err)
+
+		recorder := httptest.NewRecorder()
+		s.proxy.SimulateQueryCoordBalance(recorder, req)
+		s.Equal(http.StatusInternalServerError, recorder.Code)
+	})
+}
+
 func (s *ProxyManagementSuite) TestSuspendQueryNode() {
 	s.Run("normal", func() {
 		s.SetupTest()
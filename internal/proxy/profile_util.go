@@ -0,0 +1,100 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"strconv"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// explainProfile carries the explain and profile options of a search or query request.
+// The plan tree and the execution stats are returned in the ExtraInfo of the result status.
+type explainProfile struct {
+	explain bool
+	plans   []map[string]interface{}
+	stage   *profile.Stage
+}
+
+// init parses the options from params, and marks base to be profiled by the query nodes if required.
+func (e *explainProfile) init(params []*commonpb.KeyValuePair, base *commonpb.MsgBase) error {
+	var err error
+	if e.explain, err = parseBoolParam(params, common.ExplainKey); err != nil {
+		return err
+	}
+	enableProfile, err := parseBoolParam(params, common.ProfileKey)
+	if err != nil {
+		return err
+	}
+	if enableProfile {
+		profile.Enable(base)
+		e.stage = profile.NewStage(profile.ProxyStage, paramtable.GetNodeID())
+	}
+	return nil
+}
+
+func (e *explainProfile) enabled() bool {
+	return e.explain || e.stage != nil
+}
+
+func (e *explainProfile) addPlan(schema *typeutil.SchemaHelper, plan *planpb.PlanNode) {
+	if !e.explain {
+		return
+	}
+	e.plans = append(e.plans, planparserv2.ExplainPlan(schema, plan))
+}
+
+// addShard records the stats returned by a shard leader.
+func (e *explainProfile) addShard(status *commonpb.Status) {
+	e.stage.AddChild(profile.Extract(status))
+}
+
+func (e *explainProfile) attach(status *commonpb.Status) {
+	if status == nil {
+		return
+	}
+	if e.explain {
+		if bs, err := json.Marshal(e.plans); err == nil {
+			if status.ExtraInfo == nil {
+				status.ExtraInfo = make(map[string]string)
+			}
+			status.ExtraInfo[common.ExplainKey] = string(bs)
+		}
+	}
+	profile.Attach(status, e.stage)
+}
+
+func parseBoolParam(params []*commonpb.KeyValuePair, key string) (bool, error) {
+	for _, kv := range params {
+		if kv.GetKey() == key {
+			value, err := strconv.ParseBool(kv.GetValue())
+			if err != nil {
+				return false, merr.WrapErrParameterInvalidMsg("parse %s failed, invalid value: %s", key, kv.GetValue())
+			}
+			return value, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,74 @@
+package proxy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/parser/planparserv2"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+func TestExplainProfile(t *testing.T) {
+	paramtable.Init()
+
+	t.Run("disabled", func(t *testing.T) {
+		base := &commonpb.MsgBase{}
+		e := explainProfile{}
+		assert.NoError(t, e.init(nil, base))
+		assert.False(t, e.enabled())
+		assert.False(t, profile.IsEnabled(base))
+
+		status := &commonpb.Status{}
+		e.addShard(status)
+		e.attach(status)
+		assert.Empty(t, status.GetExtraInfo())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		e := explainProfile{}
+		err := e.init([]*commonpb.KeyValuePair{{Key: common.ProfileKey, Value: "yes please"}}, &commonpb.MsgBase{})
+		assert.Error(t, err)
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		base := &commonpb.MsgBase{}
+		e := explainProfile{}
+		assert.NoError(t, e.init([]*commonpb.KeyValuePair{
+			{Key: common.ExplainKey, Value: "true"},
+			{Key: common.ProfileKey, Value: "true"},
+		}, base))
+		assert.True(t, e.enabled())
+		assert.True(t, profile.IsEnabled(base))
+
+		schema := constructCollectionSchema(testInt64Field, testFloatVecField, testVecDim, "test")
+		helper, err := typeutil.CreateSchemaHelper(schema)
+		assert.NoError(t, err)
+		plan, err := planparserv2.CreateRetrievePlan(helper, testInt64Field+" > 10", nil)
+		assert.NoError(t, err)
+		e.addPlan(helper, plan)
+
+		shardStatus := &commonpb.Status{}
+		profile.Attach(shardStatus, profile.NewStage(profile.DelegatorStage, 1))
+		e.addShard(shardStatus)
+
+		status := &commonpb.Status{}
+		e.attach(status)
+
+		plans := make([]map[string]interface{}, 0)
+		assert.NoError(t, json.Unmarshal([]byte(status.GetExtraInfo()[common.ExplainKey]), &plans))
+		assert.Len(t, plans, 1)
+		assert.Equal(t, "query", plans[0]["node_type"])
+
+		stage := profile.Extract(status)
+		assert.NotNil(t, stage)
+		assert.Equal(t, profile.ProxyStage, stage.Name)
+		assert.Len(t, stage.Children, 1)
+		assert.Equal(t, profile.DelegatorStage, stage.Children[0].Name)
+	})
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/allocator"
 	"github.com/milvus-io/milvus/internal/proxy/connection"
+	"github.com/milvus-io/milvus/internal/proxy/ingestion"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/dependency"
 	"github.com/milvus-io/milvus/internal/util/hookutil"
@@ -118,6 +119,12 @@ type Proxy struct {
 	enableComplexDeleteLimit bool
 
 	slowQueries *expirable.LRU[Timestamp, *metricsinfo.SlowQuery]
+
+	// search and query result cache
+	resultCache *resultCache
+
+	// kafka ingestion connectors
+	ingestionMgr *ingestion.Manager
 }
 
 // NewProxy returns a Proxy struct.
@@ -138,6 +145,7 @@ func NewProxy(ctx context.Context, _ dependency.Factory) (*Proxy, error) {
 		lbPolicy:        lbPolicy,
 		resourceManager: resourceManager,
 		slowQueries:     expirable.NewLRU[Timestamp, *metricsinfo.SlowQuery](20, nil, time.Minute*15),
+		resultCache:     newResultCache(Params.ProxyCfg.ResultCacheMaxSize.GetAsInt64() * 1024 * 1024),
 	}
 	node.UpdateStateCode(commonpb.StateCode_Abnormal)
 	expr.Register("proxy", node)
@@ -306,12 +314,34 @@ func (node *Proxy) Start() error {
 	// register devops api
 	RegisterMgrRoute(node)
 
+	node.startIngestion()
+
 	return nil
 }
 
+// startIngestion starts the ingestion connectors in background, the connectors failed to start are retried
+// by the manager, and the invalid specs are skipped, none of them fails the proxy.
+func (node *Proxy) startIngestion() {
+	if !Params.ProxyCfg.IngestionEnabled.GetAsBool() {
+		return
+	}
+	configs, err := ingestion.ParseConnectorConfigs(Params.ProxyCfg.IngestionConnectors.GetValue())
+	if err != nil {
+		log.Ctx(node.ctx).Warn("skip invalid ingestion connectors", zap.String("role", typeutil.ProxyRole), zap.Error(err))
+	}
+	node.ingestionMgr = ingestion.NewManager(newIngestionProxy(node), configs, ingestion.NewKafkaSource)
+	node.ingestionMgr.Start()
+	log.Ctx(node.ctx).Info("start ingestion connectors done", zap.Int("connectors", len(configs)))
+}
+
 // Stop stops a proxy node.
 func (node *Proxy) Stop() error {
 	log := log.Ctx(node.ctx)
+	if node.ingestionMgr != nil {
+		node.ingestionMgr.Stop()
+		log.Info("stop ingestion connectors", zap.String("role", typeutil.ProxyRole))
+	}
+
 	if node.rowIDAllocator != nil {
 		node.rowIDAllocator.Close()
 		log.Info("close id allocator", zap.String("role", typeutil.ProxyRole))
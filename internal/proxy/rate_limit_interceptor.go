@@ -55,7 +55,7 @@ func RateLimitInterceptor(limiter types.Limiter) grpc.UnaryServerInterceptor {
 				}
 			}
 		}
-		err = limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+		err = CheckRateLimit(ctx, limiter, dbID, collectionIDToPartIDs, rt, n)
 		nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
 		metrics.ProxyRateLimitReqCount.WithLabelValues(nodeID, rt.String(), metrics.TotalLabel).Inc()
 		if err != nil {
@@ -72,6 +72,17 @@ func RateLimitInterceptor(limiter types.Limiter) grpc.UnaryServerInterceptor {
 	}
 }
 
+// CheckRateLimit checks the rate limits of the request, the limits of the current user are checked as well
+// and the rate limited request waits for admission if the limiter supports it.
+func CheckRateLimit(ctx context.Context, limiter types.Limiter, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error {
+	if admissionLimiter, ok := limiter.(interface {
+		Admit(ctx context.Context, dbID int64, collectionIDToPartIDs map[int64][]int64, rt internalpb.RateType, n int) error
+	}); ok {
+		return admissionLimiter.Admit(ctx, dbID, collectionIDToPartIDs, rt, n)
+	}
+	return limiter.Check(dbID, collectionIDToPartIDs, rt, n)
+}
+
 type reqPartName interface {
 	requestutil.DBNameGetter
 	requestutil.CollectionNameGetter
@@ -0,0 +1,301 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/funcutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+	"github.com/milvus-io/milvus/pkg/v2/util/tsoutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+type resultCacheEntry struct {
+	collectionID int64
+	// ts is the mvcc timestamp of the result, all the data written before ts are visible to it
+	ts       Timestamp
+	expireAt time.Time
+	size     int64
+	result   proto.Message
+}
+
+// resultCache caches the search and query results of the identical requests.
+// An entry is only served to the requests whose guarantee timestamp is not greater than
+// the mvcc timestamp of the entry, so the consistency level of the request still holds.
+// The entries of a collection are dropped when its meta cache is invalidated.
+type resultCache struct {
+	mu      sync.Mutex
+	entries *simplelru.LRU[string, *resultCacheEntry]
+	// keys of the entries of each collection
+	collections map[int64]typeutil.Set[string]
+	size        int64
+	maxSize     int64
+}
+
+func newResultCache(maxSize int64) *resultCache {
+	c := &resultCache{
+		collections: make(map[int64]typeutil.Set[string]),
+		maxSize:     maxSize,
+	}
+	// entries are evicted by size rather than by count
+	c.entries, _ = simplelru.NewLRU[string, *resultCacheEntry](math.MaxInt32, c.onEvict)
+	return c
+}
+
+func (c *resultCache) onEvict(key string, entry *resultCacheEntry) {
+	c.size -= entry.size
+	if keys, ok := c.collections[entry.collectionID]; ok {
+		keys.Remove(key)
+		if keys.Len() == 0 {
+			delete(c.collections, entry.collectionID)
+		}
+	}
+	metrics.ProxyResultCacheSize.WithLabelValues(paramtable.GetStringNodeID()).Set(float64(c.size))
+}
+
+// Get returns a copy of the cached result of key if it covers all the data written before guaranteeTs.
+func (c *resultCache) Get(key string, guaranteeTs Timestamp) (proto.Message, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expireAt) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	if entry.ts < guaranteeTs {
+		return nil, false
+	}
+	return proto.Clone(entry.result), true
+}
+
+// Put caches a copy of result, the result is visible to the requests with guarantee timestamp up to ts.
+func (c *resultCache) Put(key string, collectionID int64, ts Timestamp, result proto.Message) {
+	size := int64(proto.Size(result) + len(key))
+	if size > c.maxSize {
+		return
+	}
+	entry := &resultCacheEntry{
+		collectionID: collectionID,
+		ts:           ts,
+		expireAt:     time.Now().Add(Params.ProxyCfg.ResultCacheTTL.GetAsDuration(time.Second)),
+		size:         size,
+		result:       proto.Clone(result),
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// keep the fresher result if another request has cached a newer one
+	if old, ok := c.entries.Peek(key); ok && old.ts > ts {
+		return
+	}
+	c.entries.Remove(key)
+	c.entries.Add(key, entry)
+	c.size += size
+	if _, ok := c.collections[collectionID]; !ok {
+		c.collections[collectionID] = typeutil.NewSet[string]()
+	}
+	c.collections[collectionID].Insert(key)
+	for c.size > c.maxSize {
+		c.entries.RemoveOldest()
+	}
+	metrics.ProxyResultCacheSize.WithLabelValues(paramtable.GetStringNodeID()).Set(float64(c.size))
+}
+
+// Invalidate drops all the cached results of the collection.
+func (c *resultCache) Invalidate(collectionID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys, ok := c.collections[collectionID]
+	if !ok {
+		return
+	}
+	for _, key := range keys.Collect() {
+		c.entries.Remove(key)
+	}
+}
+
+// Clear drops all the cached results.
+func (c *resultCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Purge()
+}
+
+// resultCacheLookup is how a search or query request uses the result cache.
+type resultCacheLookup struct {
+	queryType      string
+	dbName         string
+	collectionName string
+	collectionID   int64
+	key            string
+	// guaranteeTs is the guarantee timestamp of the request, zero if the request
+	// requires strong consistency and could never be served by the cache.
+	guaranteeTs Timestamp
+}
+
+// newResultCacheLookup returns nil if the result of the request should not be cached.
+func (node *Proxy) newResultCacheLookup(ctx context.Context, queryType string, req proto.Message) *resultCacheLookup {
+	if node.resultCache == nil || !Params.ProxyCfg.ResultCacheEnabled.GetAsBool() || globalMetaCache == nil {
+		return nil
+	}
+
+	var params []*commonpb.KeyValuePair
+	switch req := req.(type) {
+	case *milvuspb.SearchRequest:
+		params = req.GetSearchParams()
+	case *milvuspb.QueryRequest:
+		params = req.GetQueryParams()
+	default:
+		return nil
+	}
+	r := req.(interface {
+		GetDbName() string
+		GetCollectionName() string
+		GetGuaranteeTimestamp() uint64
+		GetConsistencyLevel() commonpb.ConsistencyLevel
+		GetUseDefaultConsistency() bool
+	})
+	// iterators are paged by the timestamp of the first page, explain and profile report the execution
+	for _, key := range []string{IteratorField, common.ExplainKey, common.ProfileKey} {
+		if value, err := funcutil.GetAttrByKeyFromRepeatedKV(key, params); err == nil {
+			if enabled, _ := strconv.ParseBool(value); enabled {
+				return nil
+			}
+		}
+	}
+
+	collectionInfo, err := globalMetaCache.GetCollectionInfo(ctx, r.GetDbName(), r.GetCollectionName(), 0)
+	if err != nil || !common.IsResultCacheEnabled(collectionInfo.properties...) {
+		return nil
+	}
+	key, err := resultCacheKey(collectionInfo.collID, queryType, req)
+	if err != nil {
+		return nil
+	}
+
+	lookup := &resultCacheLookup{
+		queryType:      queryType,
+		dbName:         r.GetDbName(),
+		collectionName: r.GetCollectionName(),
+		collectionID:   collectionInfo.collID,
+		key:            key,
+	}
+
+	// same as the guarantee timestamp parsed by the task, with now as the begin timestamp
+	tMax := tsoutil.ComposeTSByTime(time.Now(), 0)
+	guaranteeTs := r.GetGuaranteeTimestamp()
+	if r.GetUseDefaultConsistency() {
+		guaranteeTs = parseGuaranteeTsFromConsistency(guaranteeTs, tMax, collectionInfo.consistencyLevel)
+	} else if r.GetConsistencyLevel() == 0 && guaranteeTs > 0 {
+		guaranteeTs = parseGuaranteeTs(guaranteeTs, tMax)
+	} else {
+		guaranteeTs = parseGuaranteeTsFromConsistency(guaranteeTs, tMax, r.GetConsistencyLevel())
+	}
+	if collectionInfo.updateTimestamp > guaranteeTs {
+		guaranteeTs = collectionInfo.updateTimestamp
+	}
+	if guaranteeTs < tMax {
+		lookup.guaranteeTs = guaranteeTs
+	}
+	return lookup
+}
+
+// Get returns the cached result if the request could be served by the cache.
+func (l *resultCacheLookup) Get(cache *resultCache) (proto.Message, bool) {
+	if l.guaranteeTs == 0 {
+		return nil, false
+	}
+	result, ok := cache.Get(l.key, l.guaranteeTs)
+	state := metrics.CacheMissLabel
+	if ok {
+		state = metrics.CacheHitLabel
+	}
+	metrics.ProxyResultCacheCounter.WithLabelValues(paramtable.GetStringNodeID(), l.queryType, l.dbName, l.collectionName, state).Inc()
+	return result, ok
+}
+
+func (l *resultCacheLookup) Put(cache *resultCache, ts Timestamp, result proto.Message) {
+	cache.Put(l.key, l.collectionID, ts, result)
+}
+
+// resultCacheKey returns the digest of the request without the fields irrelevant to the result.
+func resultCacheKey(collectionID int64, queryType string, req proto.Message) (string, error) {
+	switch r := proto.Clone(req).(type) {
+	case *milvuspb.SearchRequest:
+		r.Base, r.DbName, r.CollectionName = nil, "", ""
+		r.GuaranteeTimestamp, r.TravelTimestamp = 0, 0
+		r.ConsistencyLevel, r.UseDefaultConsistency = 0, false
+		sort.Strings(r.PartitionNames)
+		sortCacheKeyParams(r.SearchParams)
+		req = r
+	case *milvuspb.QueryRequest:
+		r.Base, r.DbName, r.CollectionName = nil, "", ""
+		r.GuaranteeTimestamp, r.TravelTimestamp = 0, 0
+		r.ConsistencyLevel, r.UseDefaultConsistency = 0, false
+		sort.Strings(r.PartitionNames)
+		sortCacheKeyParams(r.QueryParams)
+		req = r
+	default:
+		return "", fmt.Errorf("unsupported request type %T for result cache", req)
+	}
+
+	bs, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d/%s/%x", collectionID, queryType, sha256.Sum256(bs)), nil
+}
+
+func sortCacheKeyParams(kvs []*commonpb.KeyValuePair) {
+	// stable so that the first one of the duplicated keys is still taken
+	sort.SliceStable(kvs, func(i, j int) bool {
+		return kvs[i].GetKey() < kvs[j].GetKey()
+	})
+}
+
+// minChannelTs returns the minimum of the channel timestamps, but not less than defaultTs.
+func minChannelTs(channelsTs map[string]Timestamp, defaultTs Timestamp) Timestamp {
+	minTs := Timestamp(math.MaxUint64)
+	for _, ts := range channelsTs {
+		if ts < minTs {
+			minTs = ts
+		}
+	}
+	if minTs == math.MaxUint64 || minTs < defaultTs {
+		return defaultTs
+	}
+	return minTs
+}
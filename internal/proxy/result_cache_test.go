@@ -0,0 +1,219 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func newCachedQueryResult(count int64) *milvuspb.QueryResults {
+	return &milvuspb.QueryResults{
+		Status:           merr.Success(),
+		CollectionName:   "coll",
+		OutputFields:     []string{"count(*)"},
+		PrimaryFieldName: "pk",
+		SessionTs:        uint64(count),
+	}
+}
+
+func TestResultCache_GetPut(t *testing.T) {
+	paramtable.Init()
+	cache := newResultCache(1024 * 1024)
+
+	_, ok := cache.Get("key", 1)
+	assert.False(t, ok)
+
+	cache.Put("key", 1, 100, newCachedQueryResult(1))
+	result, ok := cache.Get("key", 100)
+	require.True(t, ok)
+	assert.True(t, proto.Equal(newCachedQueryResult(1), result))
+
+	// the result does not cover the data written after its mvcc timestamp
+	_, ok = cache.Get("key", 101)
+	assert.False(t, ok)
+
+	// an older result does not replace the fresher one
+	cache.Put("key", 1, 50, newCachedQueryResult(2))
+	result, ok = cache.Get("key", 100)
+	require.True(t, ok)
+	assert.Equal(t, uint64(1), result.(*milvuspb.QueryResults).GetSessionTs())
+
+	// the cached result is not changed by the caller
+	result.(*milvuspb.QueryResults).CollectionName = "modified"
+	result, _ = cache.Get("key", 100)
+	assert.Equal(t, "coll", result.(*milvuspb.QueryResults).GetCollectionName())
+
+	t.Run("expired", func(t *testing.T) {
+		paramtable.Get().Save(Params.ProxyCfg.ResultCacheTTL.Key, "0")
+		defer paramtable.Get().Reset(Params.ProxyCfg.ResultCacheTTL.Key)
+		cache.Put("expired", 1, 100, newCachedQueryResult(1))
+		_, ok := cache.Get("expired", 1)
+		assert.False(t, ok)
+	})
+}
+
+func TestResultCache_Evict(t *testing.T) {
+	paramtable.Init()
+	entrySize := int64(proto.Size(newCachedQueryResult(1)) + len("key1"))
+	cache := newResultCache(entrySize * 2)
+
+	cache.Put("key1", 1, 100, newCachedQueryResult(1))
+	cache.Put("key2", 1, 100, newCachedQueryResult(1))
+	_, ok := cache.Get("key1", 1)
+	assert.True(t, ok)
+	cache.Put("key3", 2, 100, newCachedQueryResult(1))
+
+	// key2 is the least recently used one
+	_, ok = cache.Get("key2", 1)
+	assert.False(t, ok)
+	assert.Equal(t, entrySize*2, cache.size)
+
+	cache.Invalidate(1)
+	_, ok = cache.Get("key1", 1)
+	assert.False(t, ok)
+	_, ok = cache.Get("key3", 1)
+	assert.True(t, ok)
+	assert.NotContains(t, cache.collections, int64(1))
+
+	cache.Clear()
+	_, ok = cache.Get("key3", 1)
+	assert.False(t, ok)
+	assert.Equal(t, int64(0), cache.size)
+	assert.Empty(t, cache.collections)
+}
+
+func TestResultCacheKey(t *testing.T) {
+	req := &milvuspb.SearchRequest{
+		DbName:           "db",
+		CollectionName:   "coll",
+		PartitionNames:   []string{"p1", "p2"},
+		Dsl:              "id > 0",
+		PlaceholderGroup: []byte{1, 2, 3},
+		SearchParams: []*commonpb.KeyValuePair{
+			{Key: "topk", Value: "10"},
+			{Key: "anns_field", Value: "vec"},
+		},
+		ConsistencyLevel: commonpb.ConsistencyLevel_Bounded,
+	}
+	key, err := resultCacheKey(1, metrics.SearchLabel, req)
+	require.NoError(t, err)
+
+	same := proto.Clone(req).(*milvuspb.SearchRequest)
+	same.PartitionNames = []string{"p2", "p1"}
+	same.SearchParams[0], same.SearchParams[1] = same.SearchParams[1], same.SearchParams[0]
+	same.ConsistencyLevel = commonpb.ConsistencyLevel_Eventually
+	same.Base = &commonpb.MsgBase{MsgID: 100}
+	sameKey, err := resultCacheKey(1, metrics.SearchLabel, same)
+	require.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+	// the request is not modified
+	assert.Equal(t, "topk", req.GetSearchParams()[0].GetKey())
+
+	other := proto.Clone(req).(*milvuspb.SearchRequest)
+	other.Dsl = "id > 1"
+	otherKey, err := resultCacheKey(1, metrics.SearchLabel, other)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+
+	otherKey, err = resultCacheKey(2, metrics.SearchLabel, req)
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherKey)
+
+	_, err = resultCacheKey(1, metrics.SearchLabel, &milvuspb.HybridSearchRequest{})
+	assert.Error(t, err)
+}
+
+func TestProxy_NewResultCacheLookup(t *testing.T) {
+	paramtable.Init()
+	paramtable.Get().Save(Params.ProxyCfg.ResultCacheEnabled.Key, "true")
+	defer paramtable.Get().Reset(Params.ProxyCfg.ResultCacheEnabled.Key)
+
+	cache := globalMetaCache
+	defer func() { globalMetaCache = cache }()
+	mockCache := NewMockCache(t)
+	mockCache.EXPECT().GetCollectionInfo(mock.Anything, "db", "enabled", mock.Anything).Return(&collectionInfo{
+		collID:           1,
+		consistencyLevel: commonpb.ConsistencyLevel_Eventually,
+		properties:       []*commonpb.KeyValuePair{{Key: common.ResultCacheEnabledKey, Value: "true"}},
+	}, nil).Maybe()
+	mockCache.EXPECT().GetCollectionInfo(mock.Anything, "db", "disabled", mock.Anything).Return(&collectionInfo{
+		collID: 2,
+	}, nil).Maybe()
+	globalMetaCache = mockCache
+
+	node := &Proxy{resultCache: newResultCache(1024 * 1024)}
+	ctx := context.Background()
+
+	lookup := node.newResultCacheLookup(ctx, metrics.QueryLabel, &milvuspb.QueryRequest{DbName: "db", CollectionName: "disabled"})
+	assert.Nil(t, lookup)
+
+	lookup = node.newResultCacheLookup(ctx, metrics.QueryLabel, &milvuspb.QueryRequest{
+		DbName:         "db",
+		CollectionName: "enabled",
+		QueryParams:    []*commonpb.KeyValuePair{{Key: IteratorField, Value: "True"}},
+	})
+	assert.Nil(t, lookup)
+
+	// strong consistency requests are cached but never served from the cache
+	lookup = node.newResultCacheLookup(ctx, metrics.QueryLabel, &milvuspb.QueryRequest{
+		DbName:           "db",
+		CollectionName:   "enabled",
+		ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+	})
+	require.NotNil(t, lookup)
+	assert.Equal(t, Timestamp(0), lookup.guaranteeTs)
+	lookup.Put(node.resultCache, 100, newCachedQueryResult(1))
+	_, ok := lookup.Get(node.resultCache)
+	assert.False(t, ok)
+
+	lookup = node.newResultCacheLookup(ctx, metrics.QueryLabel, &milvuspb.QueryRequest{
+		DbName:                "db",
+		CollectionName:        "enabled",
+		UseDefaultConsistency: true,
+	})
+	require.NotNil(t, lookup)
+	assert.Equal(t, Timestamp(1), lookup.guaranteeTs)
+	result, ok := lookup.Get(node.resultCache)
+	assert.True(t, ok)
+	assert.True(t, proto.Equal(newCachedQueryResult(1), result))
+
+	node.resultCache.Invalidate(1)
+	_, ok = lookup.Get(node.resultCache)
+	assert.False(t, ok)
+
+	paramtable.Get().Save(Params.ProxyCfg.ResultCacheEnabled.Key, "false")
+	assert.Nil(t, node.newResultCacheLookup(ctx, metrics.QueryLabel, &milvuspb.QueryRequest{DbName: "db", CollectionName: "enabled"}))
+}
+
+func TestMinChannelTs(t *testing.T) {
+	assert.Equal(t, Timestamp(10), minChannelTs(nil, 10))
+	assert.Equal(t, Timestamp(20), minChannelTs(map[string]Timestamp{"ch1": 30, "ch2": 20}, 10))
+	assert.Equal(t, Timestamp(10), minChannelTs(map[string]Timestamp{"ch1": 5}, 10))
+}
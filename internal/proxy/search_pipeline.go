@@ -32,6 +32,7 @@ import (
 	"github.com/milvus-io/milvus/internal/parser/planparserv2"
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/function/rerank"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
@@ -636,10 +637,13 @@ func (p *pipeline) Run(ctx context.Context, span trace.Span, toReduceResults []*
 	log.Ctx(ctx).Debug("SearchPipeline run", zap.String("pipeline", p.String()))
 	msg := opMsg{}
 	msg["input"] = toReduceResults
+	stage := profile.FromContext(ctx)
 	for _, node := range p.nodes {
 		var err error
 		log.Ctx(ctx).Debug("SearchPipeline run node", zap.String("node", node.name))
+		nodeStage := stage.NewChild(node.name, paramtable.GetNodeID())
 		msg, err = node.Run(ctx, span, msg)
+		nodeStage.Done()
 		if err != nil {
 			log.Ctx(ctx).Error("Run node failed: ", zap.String("err", err.Error()))
 			return nil, err
@@ -45,6 +45,13 @@ type SimpleLimiter struct {
 	quotaStatesMu sync.RWMutex
 	rateLimiter   *rlinternal.RateLimiterTree
 
+	// limiters of the users and roles, keyed by the lower-case name
+	userLimiters *typeutil.ConcurrentMap[string, *callerLimiter]
+	roleLimiters *typeutil.ConcurrentMap[string, *callerLimiter]
+
+	// the rate limited requests waiting for admission
+	admissionQueue *admissionQueue
+
 	// for alloc
 	allocWaitInterval time.Duration
 	allocRetryTimes   uint
@@ -53,7 +60,15 @@ type SimpleLimiter struct {
 // NewSimpleLimiter returns a new SimpleLimiter.
 func NewSimpleLimiter(allocWaitInterval time.Duration, allocRetryTimes uint) *SimpleLimiter {
 	rootRateLimiter := newClusterLimiter()
-	m := &SimpleLimiter{rateLimiter: rlinternal.NewRateLimiterTree(rootRateLimiter), allocWaitInterval: allocWaitInterval, allocRetryTimes: allocRetryTimes}
+	m := &SimpleLimiter{
+		rateLimiter:       rlinternal.NewRateLimiterTree(rootRateLimiter),
+		userLimiters:      typeutil.NewConcurrentMap[string, *callerLimiter](),
+		roleLimiters:      typeutil.NewConcurrentMap[string, *callerLimiter](),
+		admissionQueue:    newAdmissionQueue(),
+		allocWaitInterval: allocWaitInterval,
+		allocRetryTimes:   allocRetryTimes,
+	}
+	m.updateCallerLimiters()
 	return m
 }
 
@@ -182,6 +197,9 @@ func (m *SimpleLimiter) GetQuotaStates() ([]milvuspb.QuotaState, []string) {
 		}
 	}
 
+	callerStates, callerReasons := m.getCallerQuotaStates()
+	states = append(states, callerStates...)
+	reasons = append(reasons, callerReasons...)
 	return states, reasons
 }
 
@@ -211,6 +229,8 @@ func (m *SimpleLimiter) SetRates(rootLimiter *proxypb.LimiterNode) error {
 		return true
 	})
 
+	m.updateCallerLimiters()
+
 	if err := m.updateRateLimiter(rootLimiter); err != nil {
 		return err
 	}
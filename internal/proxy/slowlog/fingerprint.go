@@ -0,0 +1,303 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slowlog
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+)
+
+const (
+	searchType       = "Search"
+	hybridSearchType = "HybridSearch"
+	queryType        = "Query"
+
+	annsFieldKey    = "anns_field"
+	limitKey        = "limit"
+	groupByFieldKey = "group_by_field"
+
+	// the separator of the sub requests of the hybrid search
+	subRequestSeparator = " | "
+)
+
+// Fingerprint identifies the requests of the same shape, the literals of the filter expression
+// are replaced so that the requests only different in values share the fingerprint.
+type Fingerprint struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Database    string `json:"database,omitempty"`
+	Collection  string `json:"collection,omitempty"`
+	Expr        string `json:"expr,omitempty"`
+	TopKBucket  string `json:"topk_bucket,omitempty"`
+	IndexParams string `json:"index_params,omitempty"`
+}
+
+func newFingerprint(fp *Fingerprint) *Fingerprint {
+	h := sha256.Sum256([]byte(strings.Join([]string{
+		fp.Type, fp.Database, fp.Collection, fp.Expr, fp.TopKBucket, fp.IndexParams,
+	}, "\x00")))
+	fp.ID = hex.EncodeToString(h[:8])
+	return fp
+}
+
+// NewFingerprintWithSearchRequest returns the fingerprint of the search or hybrid search request.
+func NewFingerprintWithSearchRequest(request *milvuspb.SearchRequest) *Fingerprint {
+	if len(request.GetSubReqs()) == 0 {
+		return newFingerprint(&Fingerprint{
+			Type:        searchType,
+			Database:    request.GetDbName(),
+			Collection:  request.GetCollectionName(),
+			Expr:        NormalizeExpr(request.GetDsl()),
+			TopKBucket:  topKBucket(request.GetSearchParams()),
+			IndexParams: indexParams(request.GetSearchParams()),
+		})
+	}
+
+	exprs := make([]string, 0, len(request.GetSubReqs()))
+	params := make([]string, 0, len(request.GetSubReqs()))
+	for _, subReq := range request.GetSubReqs() {
+		exprs = append(exprs, NormalizeExpr(subReq.GetDsl()))
+		params = append(params, indexParams(subReq.GetSearchParams()))
+	}
+	return newFingerprint(&Fingerprint{
+		Type:        hybridSearchType,
+		Database:    request.GetDbName(),
+		Collection:  request.GetCollectionName(),
+		Expr:        strings.Join(exprs, subRequestSeparator),
+		TopKBucket:  topKBucket(request.GetSearchParams()),
+		IndexParams: strings.Join(params, subRequestSeparator),
+	})
+}
+
+// NewFingerprintWithQueryRequest returns the fingerprint of the query request.
+func NewFingerprintWithQueryRequest(request *milvuspb.QueryRequest) *Fingerprint {
+	return newFingerprint(&Fingerprint{
+		Type:       queryType,
+		Database:   request.GetDbName(),
+		Collection: request.GetCollectionName(),
+		Expr:       NormalizeExpr(request.GetExpr()),
+		TopKBucket: topKBucket(request.GetQueryParams()),
+	})
+}
+
+func getParam(params []*commonpb.KeyValuePair, key string) string {
+	for _, kv := range params {
+		if kv.GetKey() == key {
+			return kv.GetValue()
+		}
+	}
+	return ""
+}
+
+// topKBucket returns the power of 10 bounding the topk or limit of the request.
+func topKBucket(params []*commonpb.KeyValuePair) string {
+	value := getParam(params, common.TopKKey)
+	if value == "" {
+		value = getParam(params, limitKey)
+	}
+	topk, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || topk <= 0 {
+		return ""
+	}
+	bound := int64(1)
+	for bound < topk {
+		bound *= 10
+	}
+	return fmt.Sprintf("<=%d", bound)
+}
+
+// indexParams returns the search params affecting the cost of the vector search in a stable order.
+func indexParams(params []*commonpb.KeyValuePair) string {
+	var pairs []string
+	for _, key := range []string{annsFieldKey, common.MetricTypeKey, groupByFieldKey} {
+		if value := getParam(params, key); value != "" {
+			pairs = append(pairs, key+"="+value)
+		}
+	}
+
+	var nested map[string]any
+	if value := getParam(params, common.ParamsKey); value != "" && json.Unmarshal([]byte(value), &nested) == nil {
+		keys := make([]string, 0, len(nested))
+		for key := range nested {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			pairs = append(pairs, fmt.Sprintf("%s=%v", key, nested[key]))
+		}
+	}
+	return strings.Join(pairs, ",")
+}
+
+var exprKeywords = map[string]struct{}{
+	"and": {}, "or": {}, "not": {}, "in": {}, "like": {}, "exists": {},
+}
+
+// NormalizeExpr replaces the literals of the filter expression with "?", collapses the lists of literals
+// into "[?]" and normalizes the spaces and the case of the keywords. The keys of the JSON paths and the
+// indexes of the arrays are kept, since the requests on different keys are of different shapes.
+func NormalizeExpr(expr string) string {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			j := i + 1
+			for j < len(runes) && runes[j] != r {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if isPathKey(tokens) {
+				tokens = append(tokens, string(runes[i:min(j+1, len(runes))]))
+			} else {
+				tokens = append(tokens, "?")
+			}
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i + 1
+			for j < len(runes) && isNumberPart(runes, j) {
+				j++
+			}
+			if isPathKey(tokens) {
+				tokens = append(tokens, string(runes[i:j]))
+			} else {
+				tokens = appendLiteral(tokens)
+			}
+			i = j
+		case unicode.IsLetter(r) || r == '_' || r == '$':
+			j := i + 1
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_') {
+				j++
+			}
+			word := string(runes[i:j])
+			lower := strings.ToLower(word)
+			if lower == "true" || lower == "false" {
+				tokens = append(tokens, "?")
+			} else if _, ok := exprKeywords[lower]; ok {
+				tokens = append(tokens, lower)
+			} else {
+				tokens = append(tokens, word)
+			}
+			i = j
+		default:
+			j := i + 1
+			if j < len(runes) && isOperatorPair(r, runes[j]) {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		}
+	}
+	return joinTokens(collapseLists(tokens))
+}
+
+// isPathKey returns true if the next literal is the key of a JSON path or the index of an array,
+// i.e. it follows the "[" after a field or another path key.
+func isPathKey(tokens []string) bool {
+	n := len(tokens)
+	return n >= 2 && tokens[n-1] == "[" && tokens[n-2] != "?" && isOperand(tokens[n-2])
+}
+
+func isNumberPart(runes []rune, i int) bool {
+	r := runes[i]
+	if unicode.IsDigit(r) || unicode.IsLetter(r) || r == '.' || r == '_' {
+		return true
+	}
+	// the sign of the exponent
+	return (r == '+' || r == '-') && (runes[i-1] == 'e' || runes[i-1] == 'E')
+}
+
+func isOperatorPair(a, b rune) bool {
+	switch string([]rune{a, b}) {
+	case ">=", "<=", "==", "!=", "&&", "||", "**":
+		return true
+	}
+	return false
+}
+
+// appendLiteral appends a number literal, the unary minus before it is taken as part of the literal.
+func appendLiteral(tokens []string) []string {
+	n := len(tokens)
+	if n > 0 && tokens[n-1] == "-" && (n == 1 || !isOperand(tokens[n-2])) {
+		tokens[n-1] = "?"
+		return tokens
+	}
+	return append(tokens, "?")
+}
+
+func isOperand(token string) bool {
+	if token == "?" || token == ")" || token == "]" {
+		return true
+	}
+	if _, ok := exprKeywords[token]; ok {
+		return false
+	}
+	r := []rune(token)[0]
+	return unicode.IsLetter(r) || r == '_' || r == '$'
+}
+
+// collapseLists replaces the lists of literals with a single literal, so that the lists of different
+// lengths share the fingerprint.
+func collapseLists(tokens []string) []string {
+	ret := make([]string, 0, len(tokens))
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i] == "[" {
+			j := i + 1
+			for j+1 < len(tokens) && tokens[j] == "?" && tokens[j+1] == "," {
+				j += 2
+			}
+			if j+1 < len(tokens) && tokens[j] == "?" && tokens[j+1] == "]" {
+				ret = append(ret, "[", "?", "]")
+				i = j + 1
+				continue
+			}
+		}
+		ret = append(ret, tokens[i])
+	}
+	return ret
+}
+
+func joinTokens(tokens []string) string {
+	var sb strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			prev := tokens[i-1]
+			// no space between the function and the call, or the field and the json path
+			call := (token == "(" || token == "[") && isOperand(prev) && prev != "?"
+			if !call && prev != "(" && prev != "[" && token != ")" && token != "]" && token != "," {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteString(token)
+	}
+	return sb.String()
+}
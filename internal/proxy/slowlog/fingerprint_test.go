@@ -0,0 +1,117 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slowlog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+)
+
+func TestNormalizeExpr(t *testing.T) {
+	cases := []struct {
+		expr     string
+		expected string
+	}{
+		{"", ""},
+		{`age > 18 and name == "alice"`, "age > ? and name == ?"},
+		{`age>20 AND name=='bob'`, "age > ? and name == ?"},
+		{"id IN [1, 2, 3]", "id in [?]"},
+		{"id in [4,5]", "id in [?]"},
+		{"field1 > 2", "field1 > ?"},
+		{"price<=-1.5e-3 && flag==true", "price <= ? && flag == ?"},
+		{"a - 1 > 0", "a - ? > ?"},
+		{`json_contains(tags, "x") or meta["key"] like "a%"`, `json_contains(tags, ?) or meta["key"] like ?`},
+		{`$meta["a"]["b"] > 1 and arr[0] in [1, 2]`, `$meta["a"]["b"] > ? and arr[0] in [?]`},
+		{`meta["other"] > 2`, `meta["other"] > ?`},
+		{"not (a > 1)", "not (a > ?)"},
+		{`s == "quoted \" literal"`, "s == ?"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, NormalizeExpr(c.expr), c.expr)
+	}
+}
+
+func newSearchRequest(expr string, topk string, params string) *milvuspb.SearchRequest {
+	return &milvuspb.SearchRequest{
+		DbName:         "default",
+		CollectionName: "test",
+		Dsl:            expr,
+		SearchParams: []*commonpb.KeyValuePair{
+			{Key: "anns_field", Value: "vec"},
+			{Key: "metric_type", Value: "L2"},
+			{Key: "topk", Value: topk},
+			{Key: "params", Value: params},
+		},
+	}
+}
+
+func TestFingerprintWithSearchRequest(t *testing.T) {
+	fp := NewFingerprintWithSearchRequest(newSearchRequest("age > 18", "20", `{"nprobe": 10}`))
+	assert.Equal(t, "Search", fp.Type)
+	assert.Equal(t, "age > ?", fp.Expr)
+	assert.Equal(t, "<=100", fp.TopKBucket)
+	assert.Equal(t, "anns_field=vec,metric_type=L2,nprobe=10", fp.IndexParams)
+	assert.Len(t, fp.ID, 16)
+
+	// the same shape
+	other := NewFingerprintWithSearchRequest(newSearchRequest("age > 30", "100", `{"nprobe": 10}`))
+	assert.Equal(t, fp.ID, other.ID)
+
+	// different topk bucket or index params
+	other = NewFingerprintWithSearchRequest(newSearchRequest("age > 18", "10", `{"nprobe": 10}`))
+	assert.Equal(t, "<=10", other.TopKBucket)
+	assert.NotEqual(t, fp.ID, other.ID)
+	other = NewFingerprintWithSearchRequest(newSearchRequest("age > 18", "20", `{"nprobe": 64}`))
+	assert.NotEqual(t, fp.ID, other.ID)
+
+	// the requests on different JSON keys are of different shapes
+	jsonFp := NewFingerprintWithSearchRequest(newSearchRequest(`meta["age"] > 18`, "20", `{"nprobe": 10}`))
+	other = NewFingerprintWithSearchRequest(newSearchRequest(`meta["score"] > 18`, "20", `{"nprobe": 10}`))
+	assert.NotEqual(t, jsonFp.ID, other.ID)
+
+	hybrid := NewFingerprintWithSearchRequest(&milvuspb.SearchRequest{
+		CollectionName: "test",
+		SubReqs: []*milvuspb.SubSearchRequest{
+			{Dsl: "a > 1", SearchParams: newSearchRequest("", "10", `{"ef": 64}`).GetSearchParams()},
+			{Dsl: "b in [1, 2]", SearchParams: newSearchRequest("", "10", "").GetSearchParams()},
+		},
+		SearchParams: []*commonpb.KeyValuePair{{Key: "limit", Value: "5"}},
+	})
+	assert.Equal(t, "HybridSearch", hybrid.Type)
+	assert.Equal(t, "a > ? | b in [?]", hybrid.Expr)
+	assert.Equal(t, "<=10", hybrid.TopKBucket)
+	assert.Equal(t, "anns_field=vec,metric_type=L2,ef=64 | anns_field=vec,metric_type=L2", hybrid.IndexParams)
+}
+
+func TestFingerprintWithQueryRequest(t *testing.T) {
+	fp := NewFingerprintWithQueryRequest(&milvuspb.QueryRequest{
+		CollectionName: "test",
+		Expr:           `name like "a%"`,
+		QueryParams:    []*commonpb.KeyValuePair{{Key: "limit", Value: "1000"}},
+	})
+	assert.Equal(t, "Query", fp.Type)
+	assert.Equal(t, "name like ?", fp.Expr)
+	assert.Equal(t, "<=1000", fp.TopKBucket)
+	assert.Empty(t, fp.IndexParams)
+
+	other := NewFingerprintWithSearchRequest(&milvuspb.SearchRequest{CollectionName: "test", Dsl: `name like "a%"`})
+	assert.NotEqual(t, fp.ID, other.ID)
+}
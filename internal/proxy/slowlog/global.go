@@ -0,0 +1,136 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slowlog
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+var (
+	_globalR  *Recorder
+	once      sync.Once
+	closeCh   = make(chan struct{})
+	closeWg   sync.WaitGroup
+	closeOnce sync.Once
+)
+
+func newStore(params *paramtable.ComponentParam) (store, error) {
+	logCfg := &params.ProxyCfg.SlowQueryLog
+	if !logCfg.MinioEnable.GetAsBool() {
+		return newLocalStore(logCfg.LocalPath.GetValue(), logCfg.Filename.GetValue()), nil
+	}
+	cm, err := storage.NewChunkManagerFactoryWithParam(params).NewPersistentStorageChunkManager(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return newRemoteStore(cm, logCfg.RemotePath.GetValue(), paramtable.GetNodeID(), logCfg.Filename.GetValue()), nil
+}
+
+// InitSlowQueryLog restores the slow query aggregations and starts to persist them periodically.
+// It must be called after the node ID of the proxy is allocated, which is a part of the remote path.
+func InitSlowQueryLog(params *paramtable.ComponentParam) {
+	once.Do(func() {
+		logCfg := &params.ProxyCfg.SlowQueryLog
+		if !logCfg.Enable.GetAsBool() {
+			return
+		}
+		s, err := newStore(params)
+		if err != nil {
+			log.Warn("Init slow query log failed", zap.Error(err))
+			return
+		}
+		recorder := NewRecorder(s, logCfg.Retention.GetAsDuration(time.Hour), logCfg.MaxFingerprints.GetAsInt())
+		if err := recorder.Load(context.Background()); err != nil {
+			log.Warn("restore slow query log failed, start with empty aggregations", zap.Error(err))
+		}
+		_globalR = recorder
+
+		closeWg.Add(1)
+		go flushLoop(recorder, logCfg.FlushInterval.GetAsDuration(time.Second))
+		log.Info("Init slow query log success", zap.Int("fingerprints", len(recorder.aggregates)))
+	})
+}
+
+func flushLoop(recorder *Recorder, interval time.Duration) {
+	defer closeWg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-closeCh:
+			return
+		case <-ticker.C:
+			recorder.expire(time.Now())
+			if err := recorder.Flush(context.Background()); err != nil {
+				log.Warn("flush slow query log failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+// Enabled returns whether the slow query log is enabled.
+func Enabled() bool {
+	return _globalR != nil
+}
+
+// RecordSearch adds a slow search or hybrid search request to the global slow query log.
+func RecordSearch(request *milvuspb.SearchRequest, latency time.Duration, sample *metricsinfo.SlowQuery) {
+	if _globalR == nil {
+		return
+	}
+	_globalR.Record(NewFingerprintWithSearchRequest(request), latency, sample)
+}
+
+// RecordQuery adds a slow query request to the global slow query log.
+func RecordQuery(request *milvuspb.QueryRequest, latency time.Duration, sample *metricsinfo.SlowQuery) {
+	if _globalR == nil {
+		return
+	}
+	_globalR.Record(NewFingerprintWithQueryRequest(request), latency, sample)
+}
+
+// Top returns the top slow query fingerprints of the global slow query log.
+func Top(window time.Duration, limit int, sortBy string) []*Stats {
+	if _globalR == nil {
+		return []*Stats{}
+	}
+	return _globalR.Top(window, limit, sortBy)
+}
+
+// Close stops persisting periodically and flushes the aggregations.
+func Close() {
+	closeOnce.Do(func() {
+		close(closeCh)
+		closeWg.Wait()
+		if _globalR == nil {
+			return
+		}
+		if err := _globalR.Flush(context.Background()); err != nil {
+			log.Warn("flush slow query log failed", zap.Error(err))
+		}
+	})
+}
@@ -0,0 +1,284 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slowlog
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
+)
+
+const (
+	// the slow queries are aggregated into the buckets of the span, which is the granularity of the time window
+	bucketSpan = 10 * time.Minute
+	// the number of the latencies sampled in each bucket for the percentiles
+	maxBucketSamples = 32
+
+	SortByTotal = "total"
+	SortByCount = "count"
+	SortByP99   = "p99"
+)
+
+// bucket aggregates the slow queries of a fingerprint within a bucket span.
+type bucket struct {
+	Start          int64   `json:"start"`
+	Count          int64   `json:"count"`
+	TotalLatencyMs int64   `json:"total_latency_ms"`
+	LatenciesMs    []int64 `json:"latencies_ms"`
+}
+
+func (b *bucket) add(latencyMs int64) {
+	b.Count++
+	b.TotalLatencyMs += latencyMs
+	if len(b.LatenciesMs) < maxBucketSamples {
+		b.LatenciesMs = append(b.LatenciesMs, latencyMs)
+		return
+	}
+	// reservoir sampling keeps each latency with the same probability
+	if i := rand.Int63n(b.Count); i < maxBucketSamples {
+		b.LatenciesMs[i] = latencyMs
+	}
+}
+
+type aggregate struct {
+	Fingerprint *Fingerprint `json:"fingerprint"`
+	// buckets in the order of start time
+	Buckets  []*bucket              `json:"buckets"`
+	LastSeen int64                  `json:"last_seen"`
+	Sample   *metricsinfo.SlowQuery `json:"sample,omitempty"`
+}
+
+// Stats is the aggregation of the slow queries of a fingerprint within a time window.
+type Stats struct {
+	Fingerprint    *Fingerprint           `json:"fingerprint"`
+	Count          int64                  `json:"count"`
+	TotalLatencyMs int64                  `json:"total_latency_ms"`
+	P50LatencyMs   int64                  `json:"p50_latency_ms"`
+	P99LatencyMs   int64                  `json:"p99_latency_ms"`
+	LastSeen       string                 `json:"last_seen"`
+	Sample         *metricsinfo.SlowQuery `json:"sample,omitempty"`
+}
+
+type snapshot struct {
+	Aggregates []*aggregate `json:"aggregates"`
+}
+
+// Recorder aggregates the slow queries by fingerprint, and persists the aggregations to the store.
+type Recorder struct {
+	mu         sync.Mutex
+	aggregates map[string]*aggregate
+	dirty      bool
+
+	store           store
+	retention       time.Duration
+	maxFingerprints int
+}
+
+func NewRecorder(store store, retention time.Duration, maxFingerprints int) *Recorder {
+	return &Recorder{
+		aggregates:      make(map[string]*aggregate),
+		store:           store,
+		retention:       retention,
+		maxFingerprints: maxFingerprints,
+	}
+}
+
+// Record adds a slow query of the fingerprint, sample is kept as the latest example of the fingerprint.
+func (r *Recorder) Record(fp *Fingerprint, latency time.Duration, sample *metricsinfo.SlowQuery) {
+	r.record(time.Now(), fp, latency, sample)
+}
+
+func (r *Recorder) record(now time.Time, fp *Fingerprint, latency time.Duration, sample *metricsinfo.SlowQuery) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	agg, ok := r.aggregates[fp.ID]
+	if !ok {
+		if len(r.aggregates) >= r.maxFingerprints {
+			r.evictOldest()
+		}
+		agg = &aggregate{Fingerprint: fp}
+		r.aggregates[fp.ID] = agg
+	}
+
+	// the slow queries finished out of order fall into the latest bucket
+	start := now.Truncate(bucketSpan).Unix()
+	if n := len(agg.Buckets); n == 0 || agg.Buckets[n-1].Start < start {
+		agg.Buckets = append(agg.Buckets, &bucket{Start: start})
+	}
+	agg.Buckets[len(agg.Buckets)-1].add(latency.Milliseconds())
+	agg.LastSeen = now.Unix()
+	agg.Sample = sample
+	r.dirty = true
+}
+
+// evictOldest removes the fingerprint seen least recently to make room for a new one.
+func (r *Recorder) evictOldest() {
+	var oldest *aggregate
+	for _, agg := range r.aggregates {
+		if oldest == nil || agg.LastSeen < oldest.LastSeen {
+			oldest = agg
+		}
+	}
+	if oldest != nil {
+		delete(r.aggregates, oldest.Fingerprint.ID)
+	}
+}
+
+// expire drops the buckets out of the retention and the fingerprints without any bucket left.
+func (r *Recorder) expire(now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadline := now.Add(-r.retention).Unix()
+	for id, agg := range r.aggregates {
+		i := sort.Search(len(agg.Buckets), func(i int) bool {
+			return agg.Buckets[i].Start+int64(bucketSpan/time.Second) > deadline
+		})
+		if i == 0 {
+			continue
+		}
+		agg.Buckets = agg.Buckets[i:]
+		if len(agg.Buckets) == 0 {
+			delete(r.aggregates, id)
+		}
+		r.dirty = true
+	}
+}
+
+// Top returns at most limit fingerprints with the highest total latency, count or p99 latency
+// of the slow queries within the window.
+func (r *Recorder) Top(window time.Duration, limit int, sortBy string) []*Stats {
+	return r.top(time.Now(), window, limit, sortBy)
+}
+
+func (r *Recorder) top(now time.Time, window time.Duration, limit int, sortBy string) []*Stats {
+	r.mu.Lock()
+	since := now.Add(-window).Truncate(bucketSpan).Unix()
+	ret := make([]*Stats, 0)
+	for _, agg := range r.aggregates {
+		if stats := agg.stats(since); stats != nil {
+			ret = append(ret, stats)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(ret, func(i, j int) bool {
+		switch sortBy {
+		case SortByCount:
+			if ret[i].Count != ret[j].Count {
+				return ret[i].Count > ret[j].Count
+			}
+		case SortByP99:
+			if ret[i].P99LatencyMs != ret[j].P99LatencyMs {
+				return ret[i].P99LatencyMs > ret[j].P99LatencyMs
+			}
+		}
+		return ret[i].TotalLatencyMs > ret[j].TotalLatencyMs
+	})
+	if limit > 0 && len(ret) > limit {
+		ret = ret[:limit]
+	}
+	return ret
+}
+
+// stats returns nil if there is no slow query since the time.
+func (agg *aggregate) stats(since int64) *Stats {
+	stats := &Stats{
+		Fingerprint: agg.Fingerprint,
+		LastSeen:    time.Unix(agg.LastSeen, 0).Format(time.DateTime),
+		Sample:      agg.Sample,
+	}
+	var latencies []int64
+	for _, b := range agg.Buckets {
+		if b.Start < since {
+			continue
+		}
+		stats.Count += b.Count
+		stats.TotalLatencyMs += b.TotalLatencyMs
+		latencies = append(latencies, b.LatenciesMs...)
+	}
+	if stats.Count == 0 {
+		return nil
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	stats.P50LatencyMs = percentile(latencies, 0.5)
+	stats.P99LatencyMs = percentile(latencies, 0.99)
+	return stats
+}
+
+func percentile(sorted []int64, p float64) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[min(max(index, 0), len(sorted)-1)]
+}
+
+// Load restores the aggregations persisted in the store.
+func (r *Recorder) Load(ctx context.Context) error {
+	data, err := r.store.Load(ctx)
+	if err != nil || len(data) == 0 {
+		return err
+	}
+	s := &snapshot{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, agg := range s.Aggregates {
+		if agg.Fingerprint == nil || agg.Fingerprint.ID == "" {
+			continue
+		}
+		r.aggregates[agg.Fingerprint.ID] = agg
+	}
+	return nil
+}
+
+// Flush persists the aggregations to the store if changed since the last flush.
+func (r *Recorder) Flush(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.dirty {
+		r.mu.Unlock()
+		return nil
+	}
+	s := &snapshot{Aggregates: make([]*aggregate, 0, len(r.aggregates))}
+	for _, agg := range r.aggregates {
+		s.Aggregates = append(s.Aggregates, agg)
+	}
+	data, err := json.Marshal(s)
+	r.dirty = false
+	r.mu.Unlock()
+
+	if err == nil {
+		err = r.store.Save(ctx, data)
+	}
+	if err != nil {
+		r.mu.Lock()
+		r.dirty = true
+		r.mu.Unlock()
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slowlog
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	now := time.Date(2025, 1, 1, 12, 5, 0, 0, time.UTC)
+	fp1 := &Fingerprint{ID: "fp1", Type: queryType, Collection: "c1", Expr: "a > ?"}
+	fp2 := &Fingerprint{ID: "fp2", Type: searchType, Collection: "c2"}
+
+	recorder := NewRecorder(newLocalStore(dir, "slow_query.json"), 24*time.Hour, 2)
+	for _, latency := range []time.Duration{100, 200, 300} {
+		recorder.record(now, fp1, latency*time.Millisecond, &metricsinfo.SlowQuery{TraceID: "t1"})
+	}
+	recorder.record(now.Add(-2*time.Hour), fp2, time.Second, nil)
+
+	stats := recorder.top(now, time.Hour, 10, SortByTotal)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "fp1", stats[0].Fingerprint.ID)
+	assert.Equal(t, int64(3), stats[0].Count)
+	assert.Equal(t, int64(600), stats[0].TotalLatencyMs)
+	assert.Equal(t, int64(200), stats[0].P50LatencyMs)
+	assert.Equal(t, int64(300), stats[0].P99LatencyMs)
+	assert.Equal(t, "t1", stats[0].Sample.TraceID)
+
+	stats = recorder.top(now, 3*time.Hour, 10, SortByTotal)
+	require.Len(t, stats, 2)
+	assert.Equal(t, "fp2", stats[0].Fingerprint.ID)
+	stats = recorder.top(now, 3*time.Hour, 10, SortByCount)
+	assert.Equal(t, "fp1", stats[0].Fingerprint.ID)
+	stats = recorder.top(now, 3*time.Hour, 1, SortByP99)
+	require.Len(t, stats, 1)
+	assert.Equal(t, "fp2", stats[0].Fingerprint.ID)
+
+	t.Run("persist", func(t *testing.T) {
+		require.NoError(t, recorder.Flush(ctx))
+		assert.False(t, recorder.dirty)
+
+		restored := NewRecorder(newLocalStore(dir, "slow_query.json"), 24*time.Hour, 2)
+		require.NoError(t, restored.Load(ctx))
+		assert.Equal(t, recorder.top(now, 3*time.Hour, 10, SortByTotal), restored.top(now, 3*time.Hour, 10, SortByTotal))
+
+		// nothing persisted
+		empty := NewRecorder(newLocalStore(t.TempDir(), "slow_query.json"), 24*time.Hour, 2)
+		require.NoError(t, empty.Load(ctx))
+		assert.Empty(t, empty.aggregates)
+	})
+
+	t.Run("evict", func(t *testing.T) {
+		recorder.record(now, &Fingerprint{ID: "fp3"}, time.Second, nil)
+		assert.Len(t, recorder.aggregates, 2)
+		assert.NotContains(t, recorder.aggregates, "fp2")
+	})
+
+	t.Run("expire", func(t *testing.T) {
+		recorder.retention = time.Hour
+		recorder.expire(now)
+		assert.Len(t, recorder.aggregates, 2)
+
+		recorder.record(now.Add(90*time.Minute), fp1, time.Second, nil)
+		assert.Len(t, recorder.aggregates["fp1"].Buckets, 2)
+		recorder.expire(now.Add(2 * time.Hour))
+		assert.Len(t, recorder.aggregates, 1)
+		assert.Len(t, recorder.aggregates["fp1"].Buckets, 1)
+
+		recorder.expire(now.Add(4 * time.Hour))
+		assert.Empty(t, recorder.aggregates)
+	})
+}
+
+func TestBucketSampling(t *testing.T) {
+	b := &bucket{}
+	for i := 0; i < 1000; i++ {
+		b.add(int64(i))
+	}
+	assert.Equal(t, int64(1000), b.Count)
+	assert.Equal(t, int64(999*1000/2), b.TotalLatencyMs)
+	assert.Len(t, b.LatenciesMs, maxBucketSamples)
+}
+
+func TestRemoteStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cm := storage.NewLocalChunkManager(objectstorage.RootPath(dir))
+
+	// the proxies sharing the remote storage keep their own snapshots
+	store1 := newRemoteStore(cm, dir, 1, "slow_query.json")
+	store2 := newRemoteStore(cm, dir, 2, "slow_query.json")
+	assert.Equal(t, path.Join(dir, "1", "slow_query.json"), store1.filePath)
+
+	data, err := store1.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, store1.Save(ctx, []byte("snapshot1")))
+	require.NoError(t, store2.Save(ctx, []byte("snapshot2")))
+	data, err = store1.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot1", string(data))
+	data, err = store2.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "snapshot2", string(data))
+}
@@ -0,0 +1,86 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slowlog
+
+import (
+	"context"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/milvus-io/milvus/internal/storage"
+)
+
+// store persists the snapshot of the slow query aggregations.
+type store interface {
+	// Load returns nil if nothing persisted.
+	Load(ctx context.Context) ([]byte, error)
+	Save(ctx context.Context, data []byte) error
+}
+
+type localStore struct {
+	dir      string
+	filename string
+}
+
+func newLocalStore(dir, filename string) *localStore {
+	return &localStore{dir: dir, filename: filename}
+}
+
+func (s *localStore) Load(ctx context.Context) ([]byte, error) {
+	data, err := os.ReadFile(path.Join(s.dir, s.filename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Save writes a temporary file and renames it, so that the snapshot is never partially written.
+func (s *localStore) Save(ctx context.Context, data []byte) error {
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return err
+	}
+	filePath := path.Join(s.dir, s.filename)
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
+// remoteStore persists the snapshot of each proxy under {rootPath}/{nodeID}/{filename},
+// so that the proxies sharing the remote storage don't overwrite the snapshots of each other.
+type remoteStore struct {
+	cm       storage.ChunkManager
+	filePath string
+}
+
+func newRemoteStore(cm storage.ChunkManager, rootPath string, nodeID int64, filename string) *remoteStore {
+	return &remoteStore{cm: cm, filePath: path.Join(rootPath, strconv.FormatInt(nodeID, 10), filename)}
+}
+
+func (s *remoteStore) Load(ctx context.Context) ([]byte, error) {
+	exist, err := s.cm.Exist(ctx, s.filePath)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return s.cm.Read(ctx, s.filePath)
+}
+
+func (s *remoteStore) Save(ctx context.Context, data []byte) error {
+	return s.cm.Write(ctx, s.filePath, data)
+}
@@ -2766,8 +2766,18 @@ func (t *DescribeResourceGroupTask) Execute(ctx context.Context) error {
 			return err
 		}
 
+		// milvuspb.ResourceGroup has no usage fields, return them in the status extra info
+		status := resp.GetStatus()
+		if status.ExtraInfo == nil {
+			status.ExtraInfo = make(map[string]string)
+		}
+		status.ExtraInfo[common.ResourceGroupMemCapacityKey] = strconv.FormatInt(rgInfo.GetMemCapacity(), 10)
+		status.ExtraInfo[common.ResourceGroupMemQuotaKey] = strconv.FormatInt(rgInfo.GetMemQuota(), 10)
+		status.ExtraInfo[common.ResourceGroupMemUsedKey] = strconv.FormatInt(rgInfo.GetMemUsed(), 10)
+		status.ExtraInfo[common.ResourceGroupCPUNumKey] = strconv.FormatInt(rgInfo.GetCpuNum(), 10)
+
 		t.result = &milvuspb.DescribeResourceGroupResponse{
-			Status: resp.Status,
+			Status: status,
 			ResourceGroup: &milvuspb.ResourceGroup{
 				Name:             rgInfo.GetName(),
 				Capacity:         rgInfo.GetCapacity(),
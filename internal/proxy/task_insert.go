@@ -168,7 +168,7 @@ func (it *insertTask) PreExecute(ctx context.Context) error {
 	if embedding.HasNonBM25Functions(schema.CollectionSchema.Functions, []int64{}) {
 		ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Insert-call-function-udf")
 		defer sp.End()
-		exec, err := embedding.NewFunctionExecutor(schema.CollectionSchema)
+		exec, err := embedding.NewFunctionExecutor(it.insertMsg.GetDbName(), schema.CollectionSchema)
 		if err != nil {
 			return err
 		}
@@ -19,6 +19,7 @@ import (
 	"github.com/milvus-io/milvus/internal/types"
 	"github.com/milvus-io/milvus/internal/util/exprutil"
 	"github.com/milvus-io/milvus/internal/util/reduce"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	typeutil2 "github.com/milvus-io/milvus/internal/util/typeutil"
 	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/log"
@@ -75,6 +76,9 @@ type queryTask struct {
 	allQueryCnt          int64
 	totalRelatedDataSize int64
 	mustUsePartitionKey  bool
+
+	// explain and profile options, see explainProfile
+	explainProfile explainProfile
 }
 
 type queryParams struct {
@@ -385,6 +389,10 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err = t.explainProfile.init(t.request.GetQueryParams(), t.RetrieveRequest.GetBase()); err != nil {
+		return err
+	}
+
 	queryParams, err := parseQueryParams(t.request.GetQueryParams())
 	if err != nil {
 		return err
@@ -459,6 +467,7 @@ func (t *queryTask) PreExecute(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	t.explainProfile.addPlan(t.schema.schemaHelper, t.plan)
 
 	// Set username for this query request,
 	if username, _ := GetCurUserFromContext(ctx); username != "" {
@@ -543,6 +552,7 @@ func (t *queryTask) Execute(ctx context.Context) error {
 		collectionName: t.collectionName,
 		nq:             1,
 		exec:           t.queryShard,
+		hedgeable:      true,
 	})
 	if err != nil {
 		log.Warn("fail to execute query", zap.Error(err))
@@ -644,7 +654,9 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 
 	reducer := createMilvusReducer(ctx, t.queryParams, t.RetrieveRequest, t.schema.CollectionSchema, t.plan, t.collectionName)
 
+	reduceStage := t.explainProfile.stage.NewChild(profile.ReduceStage, paramtable.GetNodeID())
 	t.result, err = reducer.Reduce(toReduceResults)
+	reduceStage.Done()
 	if err != nil {
 		log.Warn("fail to reduce query result", zap.Error(err))
 		return err
@@ -666,6 +678,10 @@ func (t *queryTask) PostExecute(ctx context.Context) error {
 		// first page for iteration, need to set up sessionTs for iterator
 		t.result.SessionTs = getMaxMvccTsFromChannels(t.channelsMvcc, t.BeginTs())
 	}
+	if t.explainProfile.enabled() && t.result.Status == nil {
+		t.result.Status = merr.Success()
+	}
+	t.explainProfile.attach(t.result.Status)
 	log.Debug("Query PostExecute done")
 	return nil
 }
@@ -704,6 +720,10 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 		zap.String("channel", channel))
 
 	result, err := qn.Query(ctx, req)
+	if shardResultClaimed(ctx) {
+		// the hedged request of the channel has returned
+		return nil
+	}
 	if err != nil {
 		log.Warn("QueryNode query return error", zap.Error(err))
 		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
@@ -720,7 +740,11 @@ func (t *queryTask) queryShard(ctx context.Context, nodeID int64, qn types.Query
 	}
 
 	log.Debug("get query result")
+	if !claimShardResult(ctx) {
+		return nil
+	}
 	t.resultBuf.Insert(result)
+	t.explainProfile.addShard(result.GetStatus())
 	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
 	return nil
 }
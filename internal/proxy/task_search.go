@@ -21,6 +21,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/exprutil"
 	"github.com/milvus-io/milvus/internal/util/function/embedding"
 	"github.com/milvus-io/milvus/internal/util/function/rerank"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
@@ -91,6 +92,8 @@ type searchTask struct {
 	rankParams    *rankParams
 
 	isIterator bool
+	// explain and profile options, see explainProfile
+	explainProfile explainProfile
 	// we always remove pk field from output fields, as search result already contains pk field.
 	// if the user explicitly set pk field in output fields, we add it back to the result.
 	userRequestedPkFieldExplicitly bool
@@ -195,6 +198,10 @@ func (t *searchTask) PreExecute(ctx context.Context) error {
 		return err
 	}
 
+	if err = t.explainProfile.init(t.request.GetSearchParams(), t.SearchRequest.GetBase()); err != nil {
+		return err
+	}
+
 	outputFieldIDs, err := getOutputFieldIDs(t.schema, t.translatedOutputFields)
 	if err != nil {
 		log.Info("fail to get output field ids", zap.Error(err))
@@ -456,6 +463,7 @@ func (t *searchTask) initAdvancedSearchRequest(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
+		t.explainProfile.addPlan(t.schema.schemaHelper, plan)
 		if typeutil.IsFieldSparseFloatVector(t.schema.CollectionSchema, internalSubReq.FieldId) {
 			metrics.ProxySearchSparseNumNonZeros.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), t.collectionName, metrics.HybridSearchLabel, strconv.FormatInt(internalSubReq.FieldId, 10)).Observe(float64(typeutil.EstimateSparseVectorNNZFromPlaceholderGroup(internalSubReq.PlaceholderGroup, int(internalSubReq.GetNq()))))
 		}
@@ -469,7 +477,7 @@ func (t *searchTask) initAdvancedSearchRequest(ctx context.Context) error {
 	if embedding.HasNonBM25Functions(t.schema.CollectionSchema.Functions, queryFieldIDs) {
 		ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-AdvancedSearch-call-function-udf")
 		defer sp.End()
-		exec, err := embedding.NewFunctionExecutor(t.schema.CollectionSchema)
+		exec, err := embedding.NewFunctionExecutor(t.request.GetDbName(), t.schema.CollectionSchema)
 		if err != nil {
 			return err
 		}
@@ -567,6 +575,7 @@ func (t *searchTask) initSearchRequest(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	t.explainProfile.addPlan(t.schema.schemaHelper, plan)
 	if typeutil.IsFieldSparseFloatVector(t.schema.CollectionSchema, t.SearchRequest.FieldId) {
 		metrics.ProxySearchSparseNumNonZeros.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), t.collectionName, metrics.SearchLabel, strconv.FormatInt(t.SearchRequest.FieldId, 10)).Observe(float64(typeutil.EstimateSparseVectorNNZFromPlaceholderGroup(t.request.PlaceholderGroup, int(t.request.GetNq()))))
 	}
@@ -588,7 +597,7 @@ func (t *searchTask) initSearchRequest(ctx context.Context) error {
 	if embedding.HasNonBM25Functions(t.schema.CollectionSchema.Functions, []int64{queryInfo.GetQueryFieldId()}) {
 		ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Search-call-function-udf")
 		defer sp.End()
-		exec, err := embedding.NewFunctionExecutor(t.schema.CollectionSchema)
+		exec, err := embedding.NewFunctionExecutor(t.request.GetDbName(), t.schema.CollectionSchema)
 		if err != nil {
 			return err
 		}
@@ -689,6 +698,7 @@ func (t *searchTask) Execute(ctx context.Context) error {
 		collectionName: t.collectionName,
 		nq:             t.Nq,
 		exec:           t.searchShard,
+		hedgeable:      true,
 	})
 	if err != nil {
 		log.Warn("search execute failed", zap.Error(err))
@@ -761,7 +771,7 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 		log.Warn("Faild to create post process pipeline")
 		return err
 	}
-	if t.result, err = pipeline.Run(ctx, sp, toReduceResults); err != nil {
+	if t.result, err = pipeline.Run(profile.NewContext(ctx, t.explainProfile.stage), sp, toReduceResults); err != nil {
 		return err
 	}
 	t.fillResult()
@@ -812,6 +822,7 @@ func (t *searchTask) PostExecute(ctx context.Context) error {
 	}
 
 	metrics.ProxyReduceResultLatency.WithLabelValues(strconv.FormatInt(paramtable.GetNodeID(), 10), metrics.SearchLabel).Observe(float64(tr.RecordSpan().Milliseconds()))
+	t.explainProfile.attach(t.result.GetStatus())
 
 	log.Debug("Search post execute done",
 		zap.Int64("collection", t.GetCollectionID()),
@@ -838,6 +849,10 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 	var err error
 
 	result, err = qn.Search(ctx, req)
+	if shardResultClaimed(ctx) {
+		// the hedged request of the channel has returned
+		return nil
+	}
 	if err != nil {
 		log.Warn("QueryNode search return error", zap.Error(err))
 		globalMetaCache.DeprecateShardCache(t.request.GetDbName(), t.collectionName)
@@ -853,9 +868,13 @@ func (t *searchTask) searchShard(ctx context.Context, nodeID int64, qn types.Que
 			zap.String("reason", result.GetStatus().GetReason()))
 		return errors.Wrapf(merr.Error(result.GetStatus()), "fail to search on QueryNode %d", nodeID)
 	}
+	if !claimShardResult(ctx) {
+		return nil
+	}
 	if t.resultBuf != nil {
 		t.resultBuf.Insert(result)
 	}
+	t.explainProfile.addShard(result.GetStatus())
 	t.lb.UpdateCostMetrics(nodeID, result.CostAggregation)
 
 	return nil
@@ -3417,6 +3417,10 @@ func TestDescribeResourceGroupTask(t *testing.T) {
 				NumAvailableNode: 1,
 				NumOutgoingNode:  map[int64]int32{1: 1},
 				NumIncomingNode:  map[int64]int32{2: 2},
+				MemCapacity:      1024,
+				MemQuota:         921,
+				MemUsed:          512,
+				CpuNum:           4,
 			},
 		}, nil
 	}
@@ -3460,6 +3464,11 @@ func TestDescribeResourceGroupTask(t *testing.T) {
 	incomingNodeNum := groupInfo.GetNumIncomingNode()
 	assert.NotNil(t, outgoingNodeNum["collection1"])
 	assert.NotNil(t, incomingNodeNum["collection2"])
+	extraInfo := task.result.GetStatus().GetExtraInfo()
+	assert.Equal(t, "1024", extraInfo[common.ResourceGroupMemCapacityKey])
+	assert.Equal(t, "921", extraInfo[common.ResourceGroupMemQuotaKey])
+	assert.Equal(t, "512", extraInfo[common.ResourceGroupMemUsedKey])
+	assert.Equal(t, "4", extraInfo[common.ResourceGroupCPUNumKey])
 }
 
 func TestDescribeResourceGroupTaskFailed(t *testing.T) {
@@ -754,7 +754,7 @@ func (it *upsertTask) insertPreExecute(ctx context.Context) error {
 		}
 		ctx, sp := otel.Tracer(typeutil.ProxyRole).Start(ctx, "Proxy-Proxy-Upsert-insertPreExecute-call-function-udf")
 		defer sp.End()
-		exec, err := embedding.NewFunctionExecutor(it.schema.CollectionSchema)
+		exec, err := embedding.NewFunctionExecutor(it.req.GetDbName(), it.schema.CollectionSchema)
 		if err != nil {
 			return err
 		}
@@ -1411,6 +1411,28 @@ func GetRole(username string) ([]string, error) {
 	return globalMetaCache.GetUserRole(username), nil
 }
 
+// checkAdmin checks if the current user is the root or granted the admin role, if authorization is enabled.
+func checkAdmin(ctx context.Context) error {
+	if !Params.CommonCfg.AuthorizationEnabled.GetAsBool() {
+		return nil
+	}
+	username, err := GetCurUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if username == util.UserRoot && !Params.CommonCfg.RootShouldBindRole.GetAsBool() {
+		return nil
+	}
+	roles, err := GetRole(username)
+	if err != nil {
+		return err
+	}
+	if !lo.Contains(roles, util.RoleAdmin) {
+		return merr.WrapErrPrivilegeNotPermitted("user %s is not granted the %s role", username, util.RoleAdmin)
+	}
+	return nil
+}
+
 func PasswordVerify(ctx context.Context, username, rawPwd string) bool {
 	return passwordVerify(ctx, username, rawPwd, globalMetaCache)
 }
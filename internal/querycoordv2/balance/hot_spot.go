@@ -0,0 +1,267 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// HotSpotPlanner plans the temporary extra copies of the segments of the hot channels on the idle nodes of the replica,
+// and retires the copies after the channels cool down.
+// A channel is hot if the read workload reported by its delegator reaches the threshold,
+// and it cools down after the workload drops below the half of the threshold.
+type HotSpotPlanner struct {
+	nodeMgr *session.NodeManager
+	dist    *meta.DistributionManager
+}
+
+func NewHotSpotPlanner(nodeMgr *session.NodeManager, dist *meta.DistributionManager) *HotSpotPlanner {
+	return &HotSpotPlanner{
+		nodeMgr: nodeMgr,
+		dist:    dist,
+	}
+}
+
+// heat returns whether the channel is hot, or cool enough to retire its hot copies.
+func (p *HotSpotPlanner) heat(workload session.ChannelWorkload) (hot bool, cool bool) {
+	params := paramtable.Get()
+	inflightThreshold := params.QueryCoordCfg.HotSpotInflightThreshold.GetAsInt64()
+	latencyThreshold := params.QueryCoordCfg.HotSpotLatencyThreshold.GetAsDuration(time.Millisecond)
+
+	hot = (inflightThreshold > 0 && workload.Inflight >= inflightThreshold) ||
+		(latencyThreshold > 0 && workload.Latency >= latencyThreshold)
+	cool = workload.Inflight < inflightThreshold/2 && workload.Latency < latencyThreshold/2
+	return hot, cool
+}
+
+// isIdle returns whether the node is idle enough to hold the hot copies.
+func (p *HotSpotPlanner) isIdle(node *session.NodeInfo) bool {
+	inflightThreshold := paramtable.Get().QueryCoordCfg.HotSpotInflightThreshold.GetAsInt64()
+	return node != nil && !node.IsStoppingState() && node.ReadTaskNum() < inflightThreshold/2
+}
+
+// Plan returns the plans to add the hot copies of the segments of the hot channels in the replica,
+// and the plans to release the hot copies of the cooled channels.
+// The stale records of the copies which never appear in the distribution are dropped as well.
+func (p *HotSpotPlanner) Plan(ctx context.Context, replica *meta.Replica) (addPlans []SegmentAssignPlan, retirePlans []SegmentAssignPlan) {
+	log := log.Ctx(ctx).With(
+		zap.Int64("collectionID", replica.GetCollectionID()),
+		zap.Int64("replicaID", replica.GetID()),
+	)
+
+	channels := lo.Uniq(lo.Map(p.dist.ChannelDistManager.GetByFilter(meta.WithReplica2Channel(replica)), func(ch *meta.DmChannel, _ int) string {
+		return ch.GetChannelName()
+	}))
+	sort.Strings(channels)
+
+	channelCopies := lo.GroupBy(p.dist.HotCopyManager.GetByReplica(replica.GetID()), func(c *meta.HotCopy) string {
+		return c.Channel
+	})
+
+	for _, channel := range channels {
+		leader := p.dist.ChannelDistManager.GetShardLeader(channel, replica)
+		if leader == nil || leader.View == nil {
+			continue
+		}
+		leaderNode := p.nodeMgr.Get(leader.Node)
+		if leaderNode == nil {
+			continue
+		}
+
+		dist := p.dist.SegmentDistManager.GetByFilter(meta.WithChannel(channel), meta.WithReplica(replica))
+		copies := p.cleanStaleCopies(ctx, leader.View, dist, channelCopies[channel])
+
+		workload := leaderNode.ChannelWorkload(channel)
+		hot, cool := p.heat(workload)
+		switch {
+		case hot:
+			plans := p.planAdd(replica, leader, dist, copies)
+			if len(plans) > 0 {
+				log.Info("channel is hot, add hot copies of its segments",
+					zap.String("channel", channel),
+					zap.Int64("inflight", workload.Inflight),
+					zap.Duration("latency", workload.Latency),
+					zap.Int("copyNum", len(plans)))
+			}
+			addPlans = append(addPlans, plans...)
+		case cool:
+			plans := p.planRetire(replica, dist, copies)
+			if len(plans) > 0 {
+				log.Info("channel cooled down, retire hot copies of its segments",
+					zap.String("channel", channel),
+					zap.Int64("inflight", workload.Inflight),
+					zap.Duration("latency", workload.Latency),
+					zap.Int("copyNum", len(plans)))
+			}
+			retirePlans = append(retirePlans, plans...)
+		}
+	}
+	return addPlans, retirePlans
+}
+
+// cleanStaleCopies forgets the copies which have been released, or promoted to serve the segment on the delegator,
+// and returns the remaining copies of the channel.
+func (p *HotSpotPlanner) cleanStaleCopies(ctx context.Context, view *meta.LeaderView, dist []*meta.Segment, copies []*meta.HotCopy) []*meta.HotCopy {
+	// the copy not loaded in time is treated as released
+	gracePeriod := 2 * paramtable.Get().QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond)
+
+	ret := make([]*meta.HotCopy, 0, len(copies))
+	for _, c := range copies {
+		loaded := lo.ContainsBy(dist, func(s *meta.Segment) bool {
+			return s.GetID() == c.SegmentID && s.Node == c.NodeID
+		})
+		version, ok := view.Segments[c.SegmentID]
+		serving := ok && version.GetNodeID() == c.NodeID
+		if serving || (!loaded && time.Since(c.CreatedAt) > gracePeriod) {
+			log.Ctx(ctx).Info("forget hot copy",
+				zap.Int64("replicaID", c.ReplicaID),
+				zap.String("channel", c.Channel),
+				zap.Int64("segmentID", c.SegmentID),
+				zap.Int64("nodeID", c.NodeID),
+				zap.Bool("serving", serving))
+			p.dist.HotCopyManager.Remove(c.ReplicaID, c.SegmentID, c.NodeID)
+			continue
+		}
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+func (p *HotSpotPlanner) planAdd(replica *meta.Replica, leader *meta.DmChannel, dist []*meta.Segment, copies []*meta.HotCopy) []SegmentAssignPlan {
+	params := paramtable.Get()
+	maxCopiesPerSegment := params.QueryCoordCfg.HotSpotMaxCopiesPerSegment.GetAsInt()
+	maxCopiesPerChannel := params.QueryCoordCfg.HotSpotMaxCopiesPerChannel.GetAsInt()
+	if maxCopiesPerSegment <= 0 || maxCopiesPerChannel <= 0 {
+		return nil
+	}
+
+	rwNodes := replica.GetChannelRWNodes(leader.GetChannelName())
+	if len(rwNodes) == 0 {
+		rwNodes = replica.GetRWNodes()
+	}
+	candidates := make([]*session.NodeInfo, 0, len(rwNodes))
+	for _, nodeID := range rwNodes {
+		node := p.nodeMgr.Get(nodeID)
+		if nodeID != leader.Node && p.isIdle(node) {
+			candidates = append(candidates, node)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	holders := make(map[int64][]int64)
+	for _, s := range dist {
+		holders[s.GetID()] = append(holders[s.GetID()], s.Node)
+	}
+	copyNum := make(map[int64]int)
+	for _, c := range copies {
+		copyNum[c.SegmentID]++
+	}
+
+	// the serving copies of the larger segments are copied first
+	serving := make([]*meta.Segment, 0, len(leader.View.Segments))
+	for _, s := range dist {
+		if version, ok := leader.View.Segments[s.GetID()]; ok && version.GetNodeID() == s.Node && copyNum[s.GetID()] < maxCopiesPerSegment {
+			serving = append(serving, s)
+		}
+	}
+	sort.Slice(serving, func(i, j int) bool {
+		if serving[i].GetNumOfRows() != serving[j].GetNumOfRows() {
+			return serving[i].GetNumOfRows() > serving[j].GetNumOfRows()
+		}
+		return serving[i].GetID() < serving[j].GetID()
+	})
+
+	planned := make(map[int64]int)
+	plans := make([]SegmentAssignPlan, 0)
+	for _, s := range serving {
+		if len(plans) >= maxCopiesPerChannel {
+			break
+		}
+		nodes := lo.Filter(candidates, func(node *session.NodeInfo, _ int) bool {
+			return !lo.Contains(holders[s.GetID()], node.ID())
+		})
+		if len(nodes) == 0 {
+			continue
+		}
+		// spread the copies among the idle nodes, the less busy first
+		target := lo.MinBy(nodes, func(a, b *session.NodeInfo) bool {
+			if planned[a.ID()] != planned[b.ID()] {
+				return planned[a.ID()] < planned[b.ID()]
+			}
+			if a.ReadTaskNum() != b.ReadTaskNum() {
+				return a.ReadTaskNum() < b.ReadTaskNum()
+			}
+			return a.ID() < b.ID()
+		})
+		planned[target.ID()]++
+		plans = append(plans, SegmentAssignPlan{
+			Segment: s,
+			Replica: replica,
+			From:    -1,
+			To:      target.ID(),
+		})
+	}
+	return plans
+}
+
+func (p *HotSpotPlanner) planRetire(replica *meta.Replica, dist []*meta.Segment, copies []*meta.HotCopy) []SegmentAssignPlan {
+	plans := make([]SegmentAssignPlan, 0, len(copies))
+	for _, c := range copies {
+		segment, ok := lo.Find(dist, func(s *meta.Segment) bool {
+			return s.GetID() == c.SegmentID && s.Node == c.NodeID
+		})
+		if !ok {
+			continue
+		}
+		plans = append(plans, SegmentAssignPlan{
+			Segment: segment,
+			Replica: replica,
+			From:    c.NodeID,
+			To:      -1,
+		})
+	}
+	return plans
+}
+
+// RetireAll returns the plans to release all the hot copies of the replica, no matter whether the channels are hot,
+// it's used after the hot spot check is disabled.
+func (p *HotSpotPlanner) RetireAll(ctx context.Context, replica *meta.Replica) []SegmentAssignPlan {
+	plans := make([]SegmentAssignPlan, 0)
+	channelCopies := lo.GroupBy(p.dist.HotCopyManager.GetByReplica(replica.GetID()), func(c *meta.HotCopy) string {
+		return c.Channel
+	})
+	for channel, copies := range channelCopies {
+		dist := p.dist.SegmentDistManager.GetByFilter(meta.WithChannel(channel), meta.WithReplica(replica))
+		if leader := p.dist.ChannelDistManager.GetShardLeader(channel, replica); leader != nil && leader.View != nil {
+			copies = p.cleanStaleCopies(ctx, leader.View, dist, copies)
+		}
+		plans = append(plans, p.planRetire(replica, dist, copies)...)
+	}
+	return plans
+}
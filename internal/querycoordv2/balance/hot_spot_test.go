@@ -0,0 +1,183 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type HotSpotPlannerTestSuite struct {
+	suite.Suite
+	nodeMgr *session.NodeManager
+	dist    *meta.DistributionManager
+	replica *meta.Replica
+	view    *meta.LeaderView
+	planner *HotSpotPlanner
+}
+
+func (suite *HotSpotPlannerTestSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *HotSpotPlannerTestSuite) SetupTest() {
+	suite.nodeMgr = session.NewNodeManager()
+	suite.dist = meta.NewDistributionManager(suite.nodeMgr)
+	suite.planner = NewHotSpotPlanner(suite.nodeMgr, suite.dist)
+	suite.replica = utils.CreateTestReplica(1, 1, []int64{1, 2, 3, 4})
+
+	// node 1 is the leader, node 2 is busy with its own reads, node 3 is idle, node 4 is stopping
+	for _, nodeID := range []int64{1, 2, 3, 4} {
+		node := session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "localhost",
+			Hostname: "localhost",
+		})
+		suite.nodeMgr.Add(node)
+	}
+	suite.nodeMgr.Get(2).UpdateStats(session.WithReadTaskNum(20))
+	suite.nodeMgr.Get(4).SetState(session.NodeStateStopping)
+
+	suite.dist.SegmentDistManager.Update(1, suite.segment(1, 1, 100), suite.segment(2, 1, 50))
+	suite.dist.SegmentDistManager.Update(2, suite.segment(3, 2, 10))
+	suite.view = utils.CreateTestLeaderView(1, 1, "v1", map[int64]int64{1: 1, 2: 1, 3: 2}, nil)
+	channel := utils.CreateTestChannel(1, 1, 1, "v1")
+	channel.View = suite.view
+	suite.dist.ChannelDistManager.Update(1, channel)
+}
+
+func (suite *HotSpotPlannerTestSuite) segment(segmentID, nodeID, rows int64) *meta.Segment {
+	segment := utils.CreateTestSegment(1, 1, segmentID, nodeID, 1, "v1")
+	segment.NumOfRows = rows
+	return segment
+}
+
+func (suite *HotSpotPlannerTestSuite) setWorkload(inflight int64) {
+	suite.nodeMgr.Get(1).UpdateStats(session.WithChannelWorkloads(map[string]session.ChannelWorkload{
+		"v1": {Inflight: inflight},
+	}))
+}
+
+func (suite *HotSpotPlannerTestSuite) addCopies(plans []SegmentAssignPlan) {
+	for _, p := range plans {
+		suite.dist.HotCopyManager.Add(&meta.HotCopy{
+			CollectionID: 1,
+			ReplicaID:    suite.replica.GetID(),
+			Channel:      "v1",
+			SegmentID:    p.Segment.GetID(),
+			NodeID:       p.To,
+			CreatedAt:    time.Now(),
+		})
+	}
+}
+
+func (suite *HotSpotPlannerTestSuite) TestPlan() {
+	ctx := context.Background()
+	params := paramtable.Get()
+	params.Save(params.QueryCoordCfg.HotSpotMaxCopiesPerChannel.Key, "2")
+	defer params.Reset(params.QueryCoordCfg.HotSpotMaxCopiesPerChannel.Key)
+
+	// the channel is not hot
+	addPlans, retirePlans := suite.planner.Plan(ctx, suite.replica)
+	suite.Empty(addPlans)
+	suite.Empty(retirePlans)
+
+	// the larger segments are copied to the idle node first
+	suite.setWorkload(40)
+	addPlans, retirePlans = suite.planner.Plan(ctx, suite.replica)
+	suite.Empty(retirePlans)
+	suite.Len(addPlans, 2)
+	suite.Equal([]int64{1, 2}, lo.Map(addPlans, func(p SegmentAssignPlan, _ int) int64 { return p.Segment.GetID() }))
+	for _, p := range addPlans {
+		suite.Equal(int64(-1), p.From)
+		suite.Equal(int64(3), p.To)
+	}
+	suite.addCopies(addPlans)
+
+	// the segments which have enough copies are skipped
+	suite.dist.SegmentDistManager.Update(3, suite.segment(1, 3, 100))
+	addPlans, _ = suite.planner.Plan(ctx, suite.replica)
+	suite.Len(addPlans, 1)
+	suite.Equal(int64(3), addPlans[0].Segment.GetID())
+	suite.Equal(int64(3), addPlans[0].To)
+
+	// the channel cools down, the loaded copy is released
+	suite.setWorkload(1)
+	addPlans, retirePlans = suite.planner.Plan(ctx, suite.replica)
+	suite.Empty(addPlans)
+	suite.Len(retirePlans, 1)
+	suite.Equal(int64(1), retirePlans[0].Segment.GetID())
+	suite.Equal(int64(3), retirePlans[0].From)
+	suite.Equal(int64(-1), retirePlans[0].To)
+
+	// between the thresholds, the copies are kept
+	suite.setWorkload(20)
+	addPlans, retirePlans = suite.planner.Plan(ctx, suite.replica)
+	suite.Empty(addPlans)
+	suite.Empty(retirePlans)
+	suite.Len(suite.dist.HotCopyManager.GetByReplica(suite.replica.GetID()), 2)
+}
+
+func (suite *HotSpotPlannerTestSuite) TestCleanStaleCopies() {
+	ctx := context.Background()
+	suite.setWorkload(20)
+	suite.dist.SegmentDistManager.Update(3, suite.segment(1, 3, 100))
+	suite.dist.HotCopyManager.Add(
+		&meta.HotCopy{ReplicaID: 1, Channel: "v1", SegmentID: 1, NodeID: 3, CreatedAt: time.Now()},
+		// never loaded
+		&meta.HotCopy{ReplicaID: 1, Channel: "v1", SegmentID: 2, NodeID: 3, CreatedAt: time.Now().Add(-time.Hour)},
+	)
+	suite.planner.Plan(ctx, suite.replica)
+	suite.True(suite.dist.HotCopyManager.Contains(1, 1, 3))
+	suite.False(suite.dist.HotCopyManager.Contains(1, 2, 3))
+
+	// the copy serves the segment on the delegator after the primary one is gone
+	suite.view.Segments[1].NodeID = 3
+	suite.planner.Plan(ctx, suite.replica)
+	suite.False(suite.dist.HotCopyManager.Contains(1, 1, 3))
+}
+
+func (suite *HotSpotPlannerTestSuite) TestRetireAll() {
+	ctx := context.Background()
+	suite.setWorkload(40)
+	suite.dist.SegmentDistManager.Update(3, suite.segment(1, 3, 100), suite.segment(2, 3, 50))
+	suite.dist.HotCopyManager.Add(
+		&meta.HotCopy{ReplicaID: 1, Channel: "v1", SegmentID: 1, NodeID: 3, CreatedAt: time.Now()},
+		&meta.HotCopy{ReplicaID: 1, Channel: "v1", SegmentID: 2, NodeID: 3, CreatedAt: time.Now()},
+	)
+
+	plans := suite.planner.RetireAll(ctx, suite.replica)
+	suite.Len(plans, 2)
+	suite.ElementsMatch([]int64{1, 2}, lo.Map(plans, func(p SegmentAssignPlan, _ int) int64 { return p.Segment.GetID() }))
+	for _, p := range plans {
+		suite.Equal(int64(3), p.From)
+		suite.Equal(int64(-1), p.To)
+	}
+}
+
+func TestHotSpotPlanner(t *testing.T) {
+	suite.Run(t, new(HotSpotPlannerTestSuite))
+}
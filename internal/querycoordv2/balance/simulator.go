@@ -0,0 +1,469 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"context"
+	"sort"
+
+	"github.com/blang/semver/v4"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// the balance stops simulating if it doesn't converge in the rounds
+const defaultSimulationMaxRounds = 100
+
+// SimulatedNodes describes the hypothetical query nodes added to the resource group.
+type SimulatedNodes struct {
+	ResourceGroup string
+	Num           int
+	// memory capacity of each node in MB, the average of the nodes in the resource group if 0
+	MemCapacity float64
+}
+
+// SimulationRequest describes the what-if scenario to simulate.
+type SimulationRequest struct {
+	// the balancer to simulate, the configured one if empty
+	Balancer string
+	// only simulate the replicas of the collection if not 0
+	CollectionID int64
+	AddNodes     []SimulatedNodes
+	RemoveNodes  []int64
+	MaxRounds    int
+}
+
+type SimulatedSegmentMove struct {
+	Round        int   `json:"round"`
+	CollectionID int64 `json:"collection_id"`
+	ReplicaID    int64 `json:"replica_id"`
+	SegmentID    int64 `json:"segment_id"`
+	From         int64 `json:"from"`
+	To           int64 `json:"to"`
+	Rows         int64 `json:"rows"`
+	Bytes        int64 `json:"bytes"`
+}
+
+type SimulatedChannelMove struct {
+	Round        int    `json:"round"`
+	CollectionID int64  `json:"collection_id"`
+	ReplicaID    int64  `json:"replica_id"`
+	Channel      string `json:"channel"`
+	From         int64  `json:"from"`
+	To           int64  `json:"to"`
+}
+
+type SimulatedNodeLoad struct {
+	NodeID       int64   `json:"node_id"`
+	Hypothetical bool    `json:"hypothetical,omitempty"`
+	Removed      bool    `json:"removed,omitempty"`
+	MemCapacity  float64 `json:"mem_capacity_mb"`
+	SegmentsFrom int     `json:"segments_before"`
+	Segments     int     `json:"segments_after"`
+	ChannelsFrom int     `json:"channels_before"`
+	Channels     int     `json:"channels_after"`
+	RowsFrom     int64   `json:"rows_before"`
+	Rows         int64   `json:"rows_after"`
+	MemoryFrom   int64   `json:"memory_bytes_before"`
+	Memory       int64   `json:"memory_bytes_after"`
+}
+
+// SimulationReport is the result of the balance simulation.
+type SimulationReport struct {
+	Balancer        string                  `json:"balancer"`
+	Rounds          int                     `json:"rounds"`
+	Converged       bool                    `json:"converged"`
+	SegmentMoves    []*SimulatedSegmentMove `json:"segment_moves"`
+	ChannelMoves    []*SimulatedChannelMove `json:"channel_moves"`
+	Nodes           []*SimulatedNodeLoad    `json:"nodes"`
+	BytesToTransfer int64                   `json:"bytes_to_transfer"`
+}
+
+// Simulator runs the balancer on a snapshot of the distribution to predict the segment and channel movements,
+// nothing is changed in the cluster.
+type Simulator struct {
+	nodeMgr   *session.NodeManager
+	dist      *meta.DistributionManager
+	meta      *meta.Meta
+	targetMgr meta.TargetManagerInterface
+}
+
+func NewSimulator(nodeMgr *session.NodeManager, dist *meta.DistributionManager, meta *meta.Meta, targetMgr meta.TargetManagerInterface) *Simulator {
+	return &Simulator{
+		nodeMgr:   nodeMgr,
+		dist:      dist,
+		meta:      meta,
+		targetMgr: targetMgr,
+	}
+}
+
+// simulation is the in-memory state of a single simulation.
+type simulation struct {
+	nodeMgr  *session.NodeManager
+	dist     *meta.DistributionManager
+	replicas []*meta.Replica
+
+	segments map[int64][]*meta.Segment
+	channels map[int64][]*meta.DmChannel
+
+	hypothetical map[int64]bool
+	removed      map[int64]bool
+}
+
+func (s *Simulator) Simulate(ctx context.Context, req *SimulationRequest) (*SimulationReport, error) {
+	balancerName := req.Balancer
+	if balancerName == "" {
+		balancerName = paramtable.Get().QueryCoordCfg.Balancer.GetValue()
+	}
+	sim, err := s.snapshot(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	balancer, err := newSimulatedBalancer(balancerName, sim.nodeMgr, sim.dist, s.meta, s.targetMgr)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SimulationReport{
+		Balancer:     balancerName,
+		SegmentMoves: make([]*SimulatedSegmentMove, 0),
+		ChannelMoves: make([]*SimulatedChannelMove, 0),
+	}
+	before := sim.nodeLoads()
+
+	maxRounds := req.MaxRounds
+	if maxRounds <= 0 {
+		maxRounds = defaultSimulationMaxRounds
+	}
+	for report.Rounds < maxRounds {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		report.Rounds++
+		moved := false
+		for _, replica := range sim.replicas {
+			segmentPlans, channelPlans := balancer.BalanceReplica(ctx, replica)
+			for _, plan := range channelPlans {
+				if sim.moveChannel(plan) {
+					report.ChannelMoves = append(report.ChannelMoves, &SimulatedChannelMove{
+						Round:        report.Rounds,
+						CollectionID: plan.Channel.GetCollectionID(),
+						ReplicaID:    replica.GetID(),
+						Channel:      plan.Channel.GetChannelName(),
+						From:         plan.From,
+						To:           plan.To,
+					})
+					moved = true
+				}
+			}
+			for _, plan := range segmentPlans {
+				if sim.moveSegment(plan) {
+					bytes := segmentTransferSize(plan.Segment)
+					report.SegmentMoves = append(report.SegmentMoves, &SimulatedSegmentMove{
+						Round:        report.Rounds,
+						CollectionID: plan.Segment.GetCollectionID(),
+						ReplicaID:    replica.GetID(),
+						SegmentID:    plan.Segment.GetID(),
+						From:         plan.From,
+						To:           plan.To,
+						Rows:         plan.Segment.GetNumOfRows(),
+						Bytes:        bytes,
+					})
+					report.BytesToTransfer += bytes
+					moved = true
+				}
+			}
+		}
+		if !moved {
+			report.Converged = true
+			break
+		}
+	}
+
+	after := sim.nodeLoads()
+	for nodeID, load := range after {
+		if old, ok := before[nodeID]; ok {
+			load.SegmentsFrom, load.ChannelsFrom = old.Segments, old.Channels
+			load.RowsFrom, load.MemoryFrom = old.Rows, old.Memory
+		}
+		report.Nodes = append(report.Nodes, load)
+	}
+	sort.Slice(report.Nodes, func(i, j int) bool { return report.Nodes[i].NodeID < report.Nodes[j].NodeID })
+	return report, nil
+}
+
+// snapshot copies the nodes, distribution and replicas, and applies the hypothetical changes.
+func (s *Simulator) snapshot(ctx context.Context, req *SimulationRequest) (*simulation, error) {
+	sim := &simulation{
+		nodeMgr:      session.NewNodeManager(),
+		segments:     make(map[int64][]*meta.Segment),
+		channels:     make(map[int64][]*meta.DmChannel),
+		hypothetical: make(map[int64]bool),
+		removed:      make(map[int64]bool),
+	}
+
+	var maxNodeID int64
+	var version semver.Version
+	for _, node := range s.nodeMgr.GetAll() {
+		copied := session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   node.ID(),
+			Address:  node.Addr(),
+			Hostname: node.Hostname(),
+			Version:  node.Version(),
+			Labels:   node.Labels(),
+		})
+		copied.SetState(node.GetState())
+		copied.UpdateStats(
+			session.WithSegmentCnt(node.SegmentCnt()),
+			session.WithChannelCnt(node.ChannelCnt()),
+			session.WithMemCapacity(node.MemCapacity()),
+			session.WithCPUNum(node.CPUNum()),
+		)
+		sim.nodeMgr.Add(copied)
+		maxNodeID = max(maxNodeID, node.ID())
+		if node.Version().GT(version) {
+			version = node.Version()
+		}
+	}
+
+	replicas := make(map[int64]*meta.Replica)
+	for _, collectionID := range s.meta.CollectionManager.GetAll(ctx) {
+		if req.CollectionID != 0 && collectionID != req.CollectionID {
+			continue
+		}
+		for _, replica := range s.meta.ReplicaManager.GetByCollection(ctx, collectionID) {
+			replicas[replica.GetID()] = replica
+		}
+	}
+	if req.CollectionID != 0 && len(replicas) == 0 {
+		return nil, merr.WrapErrCollectionNotLoaded(req.CollectionID)
+	}
+
+	// the removed nodes turn into read only, the balancer moves everything out of them
+	for _, nodeID := range req.RemoveNodes {
+		if sim.nodeMgr.Get(nodeID) == nil {
+			return nil, merr.WrapErrNodeNotFound(nodeID)
+		}
+		sim.removed[nodeID] = true
+		for id, replica := range replicas {
+			if !replica.ContainRWNode(nodeID) && !replica.ContainRWSQNode(nodeID) {
+				continue
+			}
+			mutable := replica.CopyForWrite()
+			if replica.ContainRWNode(nodeID) {
+				mutable.AddRONode(nodeID)
+			} else {
+				mutable.AddROSQNode(nodeID)
+			}
+			replicas[id] = mutable.IntoReplica()
+		}
+	}
+
+	// the added nodes are assigned to the replicas of each collection in the resource group in turn
+	for _, spec := range req.AddNodes {
+		if !s.meta.ResourceManager.ContainResourceGroup(ctx, spec.ResourceGroup) {
+			return nil, merr.WrapErrResourceGroupNotFound(spec.ResourceGroup)
+		}
+		memCapacity := spec.MemCapacity
+		if memCapacity <= 0 {
+			memCapacity = s.averageMemCapacity(ctx, spec.ResourceGroup)
+		}
+		rgReplicas := lo.GroupBy(lo.Filter(lo.Values(replicas), func(replica *meta.Replica, _ int) bool {
+			return replica.GetResourceGroup() == spec.ResourceGroup
+		}), func(replica *meta.Replica) int64 { return replica.GetCollectionID() })
+		for _, collectionReplicas := range rgReplicas {
+			sort.Slice(collectionReplicas, func(i, j int) bool { return collectionReplicas[i].GetID() < collectionReplicas[j].GetID() })
+		}
+		for i := 0; i < spec.Num; i++ {
+			maxNodeID++
+			node := session.NewNodeInfo(session.ImmutableNodeInfo{
+				NodeID:  maxNodeID,
+				Version: version,
+			})
+			node.UpdateStats(session.WithMemCapacity(memCapacity))
+			sim.nodeMgr.Add(node)
+			sim.hypothetical[maxNodeID] = true
+			for _, collectionReplicas := range rgReplicas {
+				replica := collectionReplicas[i%len(collectionReplicas)]
+				mutable := replicas[replica.GetID()].CopyForWrite()
+				mutable.AddRWNode(maxNodeID)
+				replicas[replica.GetID()] = mutable.IntoReplica()
+			}
+		}
+	}
+	sim.replicas = lo.Values(replicas)
+	sort.Slice(sim.replicas, func(i, j int) bool { return sim.replicas[i].GetID() < sim.replicas[j].GetID() })
+
+	sim.dist = meta.NewDistributionManager(sim.nodeMgr)
+	for _, segment := range s.dist.SegmentDistManager.GetByFilter() {
+		copied := *segment
+		sim.segments[segment.Node] = append(sim.segments[segment.Node], &copied)
+	}
+	for nodeID, segments := range sim.segments {
+		sim.dist.SegmentDistManager.Update(nodeID, segments...)
+	}
+	for _, channel := range s.dist.ChannelDistManager.GetByFilter() {
+		copied := *channel
+		if channel.View != nil {
+			copied.View = channel.View.Clone()
+		}
+		sim.channels[channel.Node] = append(sim.channels[channel.Node], &copied)
+	}
+	for nodeID, channels := range sim.channels {
+		sim.dist.ChannelDistManager.Update(nodeID, channels...)
+	}
+	return sim, nil
+}
+
+func (s *Simulator) averageMemCapacity(ctx context.Context, rgName string) float64 {
+	nodes, err := s.meta.ResourceManager.GetNodes(ctx, rgName)
+	if err != nil {
+		return 0
+	}
+	var total float64
+	var count int
+	for _, nodeID := range nodes {
+		if node := s.nodeMgr.Get(nodeID); node != nil && node.MemCapacity() > 0 {
+			total += node.MemCapacity()
+			count++
+		}
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / float64(count)
+}
+
+func (sim *simulation) moveSegment(plan SegmentAssignPlan) bool {
+	if plan.Segment == nil || plan.From == plan.To || plan.To < 0 {
+		return false
+	}
+	source := sim.segments[plan.From]
+	index := lo.IndexOf(lo.Map(source, func(segment *meta.Segment, _ int) int64 { return segment.GetID() }), plan.Segment.GetID())
+	if index < 0 {
+		return false
+	}
+	moved := *source[index]
+	sim.segments[plan.From] = append(source[:index:index], source[index+1:]...)
+	sim.segments[plan.To] = append(sim.segments[plan.To], &moved)
+	sim.dist.SegmentDistManager.Update(plan.From, sim.segments[plan.From]...)
+	sim.dist.SegmentDistManager.Update(plan.To, sim.segments[plan.To]...)
+	return true
+}
+
+func (sim *simulation) moveChannel(plan ChannelAssignPlan) bool {
+	if plan.Channel == nil || plan.From == plan.To || plan.To < 0 {
+		return false
+	}
+	source := sim.channels[plan.From]
+	index := lo.IndexOf(lo.Map(source, func(channel *meta.DmChannel, _ int) string { return channel.GetChannelName() }), plan.Channel.GetChannelName())
+	if index < 0 {
+		return false
+	}
+	moved := *source[index]
+	if moved.View != nil {
+		moved.View = moved.View.Clone()
+		moved.View.ID = plan.To
+	}
+	sim.channels[plan.From] = append(source[:index:index], source[index+1:]...)
+	sim.channels[plan.To] = append(sim.channels[plan.To], &moved)
+	sim.dist.ChannelDistManager.Update(plan.From, sim.channels[plan.From]...)
+	sim.dist.ChannelDistManager.Update(plan.To, sim.channels[plan.To]...)
+	return true
+}
+
+func (sim *simulation) nodeLoads() map[int64]*SimulatedNodeLoad {
+	loads := make(map[int64]*SimulatedNodeLoad)
+	for _, node := range sim.nodeMgr.GetAll() {
+		load := &SimulatedNodeLoad{
+			NodeID:       node.ID(),
+			Hypothetical: sim.hypothetical[node.ID()],
+			Removed:      sim.removed[node.ID()],
+			MemCapacity:  node.MemCapacity(),
+			Segments:     len(sim.segments[node.ID()]),
+			Channels:     len(sim.channels[node.ID()]),
+		}
+		for _, segment := range sim.segments[node.ID()] {
+			load.Rows += segment.GetNumOfRows()
+			load.Memory += utils.SegmentMemorySize(segment.SegmentInfo)
+		}
+		loads[node.ID()] = load
+	}
+	return loads
+}
+
+// segmentTransferSize estimates the bytes read from the object storage to load the segment.
+func segmentTransferSize(segment *meta.Segment) int64 {
+	var size int64
+	for _, fieldBinlogs := range [][]*datapb.FieldBinlog{segment.GetBinlogs(), segment.GetStatslogs(), segment.GetDeltalogs()} {
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				size += binlog.GetLogSize()
+			}
+		}
+	}
+	return size
+}
+
+// simulatedScheduler is a scheduler without any task, the balancer sees no pending movement.
+type simulatedScheduler struct{}
+
+var _ task.Scheduler = simulatedScheduler{}
+
+func (simulatedScheduler) Start()                                                   {}
+func (simulatedScheduler) Stop()                                                    {}
+func (simulatedScheduler) AddExecutor(nodeID int64)                                 {}
+func (simulatedScheduler) RemoveExecutor(nodeID int64)                              {}
+func (simulatedScheduler) Add(t task.Task) error                                    { return nil }
+func (simulatedScheduler) Dispatch(node int64)                                      {}
+func (simulatedScheduler) RemoveByNode(node int64)                                  {}
+func (simulatedScheduler) GetChannelTaskNum(filters ...task.TaskFilter) int         { return 0 }
+func (simulatedScheduler) GetSegmentTaskNum(filters ...task.TaskFilter) int         { return 0 }
+func (simulatedScheduler) GetTasksJSON() string                                     { return "" }
+func (simulatedScheduler) GetSegmentTaskDelta(nodeID int64, collectionID int64) int { return 0 }
+func (simulatedScheduler) GetChannelTaskDelta(nodeID int64, collectionID int64) int { return 0 }
+
+func (simulatedScheduler) GetExecutedFlag(nodeID int64) <-chan struct{} {
+	ch := make(chan struct{})
+	close(ch)
+	return ch
+}
+
+func newSimulatedBalancer(name string, nodeMgr *session.NodeManager, dist *meta.DistributionManager, m *meta.Meta, targetMgr meta.TargetManagerInterface) (Balance, error) {
+	scheduler := simulatedScheduler{}
+	switch name {
+	case meta.RoundRobinBalancerName:
+		return NewRoundRobinBalancer(scheduler, nodeMgr), nil
+	case meta.RowCountBasedBalancerName:
+		return NewRowCountBasedBalancer(scheduler, nodeMgr, dist, m, targetMgr), nil
+	case meta.ScoreBasedBalancerName:
+		return NewScoreBasedBalancer(scheduler, nodeMgr, dist, m, targetMgr), nil
+	case meta.MultiTargetBalancerName:
+		return NewMultiTargetBalancer(scheduler, nodeMgr, dist, m, targetMgr), nil
+	case meta.ChannelLevelScoreBalancerName:
+		return NewChannelLevelScoreBalancer(scheduler, nodeMgr, dist, m, targetMgr), nil
+	default:
+		return nil, merr.WrapErrParameterInvalid("balancer name", name)
+	}
+}
@@ -0,0 +1,215 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package balance
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v2/kv"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v2/util/etcd"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type SimulatorTestSuite struct {
+	suite.Suite
+	kv        kv.MetaKv
+	broker    *meta.MockBroker
+	nodeMgr   *session.NodeManager
+	dist      *meta.DistributionManager
+	meta      *meta.Meta
+	targetMgr *meta.TargetManager
+	simulator *Simulator
+}
+
+func (suite *SimulatorTestSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *SimulatorTestSuite) SetupTest() {
+	ctx := context.Background()
+	config := GenerateEtcdConfig()
+	cli, err := etcd.GetEtcdClient(
+		config.UseEmbedEtcd.GetAsBool(),
+		config.EtcdUseSSL.GetAsBool(),
+		config.Endpoints.GetAsStrings(),
+		config.EtcdTLSCert.GetValue(),
+		config.EtcdTLSKey.GetValue(),
+		config.EtcdTLSCACert.GetValue(),
+		config.EtcdTLSMinVersion.GetValue())
+	suite.Require().NoError(err)
+	suite.kv = etcdkv.NewEtcdKV(cli, config.MetaRootPath.GetValue())
+	suite.broker = meta.NewMockBroker(suite.T())
+
+	suite.nodeMgr = session.NewNodeManager()
+	suite.meta = meta.NewMeta(RandomIncrementIDAllocator(), querycoord.NewCatalog(suite.kv), suite.nodeMgr)
+	suite.targetMgr = meta.NewTargetManager(suite.broker, suite.meta)
+	suite.dist = meta.NewDistributionManager(suite.nodeMgr)
+	suite.simulator = NewSimulator(suite.nodeMgr, suite.dist, suite.meta, suite.targetMgr)
+
+	// node 1 serves 10 rows, node 2 serves 50 rows
+	collectionID, replicaID := int64(1), int64(1)
+	segments := []*datapb.SegmentInfo{
+		{ID: 1, PartitionID: 1}, {ID: 2, PartitionID: 1}, {ID: 3, PartitionID: 1},
+	}
+	suite.broker.EXPECT().GetRecoveryInfoV2(mock.Anything, collectionID).Return(nil, segments, nil)
+	suite.broker.EXPECT().GetPartitions(mock.Anything, collectionID).Return([]int64{collectionID}, nil).Maybe()
+	collection := utils.CreateTestCollection(collectionID, int32(replicaID))
+	collection.LoadPercentage = 100
+	collection.Status = querypb.LoadStatus_Loaded
+	suite.meta.CollectionManager.PutCollection(ctx, collection)
+	suite.meta.CollectionManager.PutPartition(ctx, utils.CreateTestPartition(collectionID, collectionID))
+	suite.meta.ReplicaManager.Put(ctx, utils.CreateTestReplica(replicaID, collectionID, []int64{1, 2}))
+	suite.targetMgr.UpdateCollectionNextTarget(ctx, collectionID)
+	suite.targetMgr.UpdateCollectionCurrentTarget(ctx, collectionID)
+
+	suite.dist.SegmentDistManager.Update(1, newSimulatedSegment(1, 1, 10))
+	suite.dist.SegmentDistManager.Update(2, newSimulatedSegment(2, 2, 20), newSimulatedSegment(3, 2, 30))
+	for _, nodeID := range []int64{1, 2} {
+		nodeInfo := session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "127.0.0.1:0",
+			Hostname: "localhost",
+		})
+		nodeInfo.UpdateStats(session.WithMemCapacity(1024))
+		nodeInfo.SetState(session.NodeStateNormal)
+		suite.nodeMgr.Add(nodeInfo)
+		suite.meta.ResourceManager.HandleNodeUp(ctx, nodeID)
+	}
+	utils.RecoverAllCollection(suite.meta)
+}
+
+func (suite *SimulatorTestSuite) TearDownTest() {
+	suite.kv.Close()
+}
+
+func newSimulatedSegment(segmentID int64, nodeID int64, rows int64) *meta.Segment {
+	return &meta.Segment{
+		SegmentInfo: &datapb.SegmentInfo{
+			ID:           segmentID,
+			CollectionID: 1,
+			PartitionID:  1,
+			NumOfRows:    rows,
+			Binlogs: []*datapb.FieldBinlog{
+				{FieldID: 100, Binlogs: []*datapb.Binlog{{LogSize: rows * 10, MemorySize: rows * 20}}},
+			},
+		},
+		Node: nodeID,
+	}
+}
+
+func (suite *SimulatorTestSuite) nodeLoad(report *SimulationReport, nodeID int64) *SimulatedNodeLoad {
+	load, ok := lo.Find(report.Nodes, func(load *SimulatedNodeLoad) bool { return load.NodeID == nodeID })
+	suite.Require().True(ok)
+	return load
+}
+
+func (suite *SimulatorTestSuite) assertDistributionUnchanged() {
+	suite.Len(suite.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(1)), 1)
+	suite.Len(suite.dist.SegmentDistManager.GetByFilter(meta.WithNodeID(2)), 2)
+	suite.Nil(suite.nodeMgr.Get(3))
+	replica := suite.meta.ReplicaManager.Get(context.Background(), 1)
+	suite.ElementsMatch([]int64{1, 2}, replica.GetRWNodes())
+}
+
+func (suite *SimulatorTestSuite) TestSimulateBalance() {
+	report, err := suite.simulator.Simulate(context.Background(), &SimulationRequest{Balancer: meta.ScoreBasedBalancerName})
+	suite.NoError(err)
+	suite.Equal(meta.ScoreBasedBalancerName, report.Balancer)
+	suite.True(report.Converged)
+	suite.NotEmpty(report.SegmentMoves)
+	suite.Len(report.Nodes, 2)
+
+	var bytes int64
+	for _, move := range report.SegmentMoves {
+		bytes += move.Bytes
+	}
+	suite.Equal(bytes, report.BytesToTransfer)
+
+	node1, node2 := suite.nodeLoad(report, 1), suite.nodeLoad(report, 2)
+	suite.Equal(int64(10), node1.RowsFrom)
+	suite.Equal(int64(50), node2.RowsFrom)
+	suite.Equal(int64(60), node1.Rows+node2.Rows)
+	suite.Greater(node1.Rows, node1.RowsFrom)
+	suite.Equal(node1.Rows*20, node1.Memory)
+	suite.assertDistributionUnchanged()
+}
+
+func (suite *SimulatorTestSuite) TestSimulateRemoveNode() {
+	report, err := suite.simulator.Simulate(context.Background(), &SimulationRequest{
+		Balancer:    meta.ScoreBasedBalancerName,
+		RemoveNodes: []int64{2},
+	})
+	suite.NoError(err)
+	suite.True(report.Converged)
+
+	node1, node2 := suite.nodeLoad(report, 1), suite.nodeLoad(report, 2)
+	suite.True(node2.Removed)
+	suite.Equal(0, node2.Segments)
+	suite.Equal(int64(60), node1.Rows)
+	suite.Equal(int64(500), report.BytesToTransfer)
+	suite.assertDistributionUnchanged()
+}
+
+func (suite *SimulatorTestSuite) TestSimulateAddNode() {
+	report, err := suite.simulator.Simulate(context.Background(), &SimulationRequest{
+		Balancer: meta.ScoreBasedBalancerName,
+		AddNodes: []SimulatedNodes{{ResourceGroup: meta.DefaultResourceGroupName, Num: 1}},
+	})
+	suite.NoError(err)
+	suite.True(report.Converged)
+	suite.Len(report.Nodes, 3)
+
+	node3 := suite.nodeLoad(report, 3)
+	suite.True(node3.Hypothetical)
+	suite.Equal(float64(1024), node3.MemCapacity)
+	suite.Equal(0, node3.SegmentsFrom)
+	suite.Greater(node3.Segments, 0)
+	suite.assertDistributionUnchanged()
+}
+
+func (suite *SimulatorTestSuite) TestSimulateFailed() {
+	ctx := context.Background()
+	_, err := suite.simulator.Simulate(ctx, &SimulationRequest{Balancer: "unknown"})
+	suite.ErrorIs(err, merr.ErrParameterInvalid)
+
+	_, err = suite.simulator.Simulate(ctx, &SimulationRequest{RemoveNodes: []int64{100}})
+	suite.ErrorIs(err, merr.ErrNodeNotFound)
+
+	_, err = suite.simulator.Simulate(ctx, &SimulationRequest{AddNodes: []SimulatedNodes{{ResourceGroup: "rg1", Num: 1}}})
+	suite.ErrorIs(err, merr.ErrResourceGroupNotFound)
+
+	_, err = suite.simulator.Simulate(ctx, &SimulationRequest{CollectionID: 100})
+	suite.ErrorIs(err, merr.ErrCollectionNotLoaded)
+}
+
+func TestSimulator(t *testing.T) {
+	suite.Run(t, new(SimulatorTestSuite))
+}
@@ -72,7 +72,8 @@ func NewCheckerController(
 		utils.IndexChecker:   NewIndexChecker(meta, dist, broker, nodeMgr, targetMgr),
 		// todo temporary work around must fix
 		// utils.LeaderChecker:  NewLeaderChecker(meta, dist, targetMgr, nodeMgr, true),
-		utils.LeaderChecker: NewLeaderChecker(meta, dist, targetMgr, nodeMgr),
+		utils.LeaderChecker:  NewLeaderChecker(meta, dist, targetMgr, nodeMgr),
+		utils.HotSpotChecker: NewHotSpotChecker(meta, dist, nodeMgr),
 	}
 
 	manualCheckChs := map[utils.CheckerType]chan struct{}{
@@ -117,6 +118,8 @@ func getCheckerInterval(checker utils.CheckerType) time.Duration {
 		return Params.QueryCoordCfg.IndexCheckInterval.GetAsDuration(time.Millisecond)
 	case utils.LeaderChecker:
 		return Params.QueryCoordCfg.LeaderViewUpdateInterval.GetAsDuration(time.Second)
+	case utils.HotSpotChecker:
+		return Params.QueryCoordCfg.HotSpotCheckInterval.GetAsDuration(time.Millisecond)
 	default:
 		return Params.QueryCoordCfg.CheckInterval.GetAsDuration(time.Millisecond)
 	}
@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkers
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+)
+
+var _ Checker = (*HotSpotChecker)(nil)
+
+// HotSpotChecker adds temporary extra copies of the segments of the hot channels on the idle nodes,
+// and retires them when the read workload of the channels drops.
+type HotSpotChecker struct {
+	*checkerActivation
+	meta    *meta.Meta
+	dist    *meta.DistributionManager
+	planner *balance.HotSpotPlanner
+}
+
+func NewHotSpotChecker(
+	meta *meta.Meta,
+	dist *meta.DistributionManager,
+	nodeMgr *session.NodeManager,
+) *HotSpotChecker {
+	return &HotSpotChecker{
+		checkerActivation: newCheckerActivation(),
+		meta:              meta,
+		dist:              dist,
+		planner:           balance.NewHotSpotPlanner(nodeMgr, dist),
+	}
+}
+
+func (c *HotSpotChecker) ID() utils.CheckerType {
+	return utils.HotSpotChecker
+}
+
+func (c *HotSpotChecker) Description() string {
+	return "HotSpotChecker checks the read workload of channels, and adds or retires the extra segment copies of hot channels"
+}
+
+func (c *HotSpotChecker) Check(ctx context.Context) []task.Task {
+	if !c.IsActive() {
+		return nil
+	}
+
+	// forget the copies of the released replicas
+	for _, replicaID := range c.dist.HotCopyManager.GetReplicas() {
+		if c.meta.ReplicaManager.Get(ctx, replicaID) == nil {
+			c.dist.HotCopyManager.RemoveReplica(replicaID)
+		}
+	}
+
+	enabled := Params.QueryCoordCfg.HotSpotCheckEnabled.GetAsBool()
+	tasks := make([]task.Task, 0)
+	for _, collectionID := range c.meta.CollectionManager.GetAll(ctx) {
+		for _, replica := range c.meta.ReplicaManager.GetByCollection(ctx, collectionID) {
+			if !enabled {
+				tasks = append(tasks, c.createTasks(ctx, c.planner.RetireAll(ctx, replica))...)
+				continue
+			}
+
+			addPlans, retirePlans := c.planner.Plan(ctx, replica)
+			tasks = append(tasks, c.createTasks(ctx, retirePlans)...)
+			for _, p := range addPlans {
+				c.dist.HotCopyManager.Add(&meta.HotCopy{
+					CollectionID: replica.GetCollectionID(),
+					ReplicaID:    replica.GetID(),
+					Channel:      p.Segment.GetInsertChannel(),
+					SegmentID:    p.Segment.GetID(),
+					NodeID:       p.To,
+					CreatedAt:    time.Now(),
+				})
+			}
+			tasks = append(tasks, c.createTasks(ctx, addPlans)...)
+		}
+	}
+	return tasks
+}
+
+func (c *HotSpotChecker) createTasks(ctx context.Context, plans []balance.SegmentAssignPlan) []task.Task {
+	if len(plans) == 0 {
+		return nil
+	}
+	tasks := balance.CreateSegmentTasksFromPlans(ctx, c.ID(), Params.QueryCoordCfg.SegmentTaskTimeout.GetAsDuration(time.Millisecond), plans)
+	for _, t := range tasks {
+		// the hot copies are optional, which shouldn't delay the other segment tasks
+		t.SetPriority(task.TaskPriorityLow)
+		t.SetReason("hot spot")
+	}
+	if len(tasks) > 0 {
+		log.Ctx(ctx).Info("hot spot checker created tasks", zap.Int("taskNum", len(tasks)))
+	}
+	return tasks
+}
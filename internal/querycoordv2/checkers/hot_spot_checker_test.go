@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	etcdkv "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/internal/querycoordv2/task"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
+	"github.com/milvus-io/milvus/pkg/v2/kv"
+	"github.com/milvus-io/milvus/pkg/v2/util/etcd"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type HotSpotCheckerTestSuite struct {
+	suite.Suite
+	checker *HotSpotChecker
+	kv      kv.MetaKv
+
+	meta    *meta.Meta
+	dist    *meta.DistributionManager
+	nodeMgr *session.NodeManager
+}
+
+func (suite *HotSpotCheckerTestSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (suite *HotSpotCheckerTestSuite) SetupTest() {
+	ctx := context.Background()
+	config := GenerateEtcdConfig()
+	cli, err := etcd.GetEtcdClient(
+		config.UseEmbedEtcd.GetAsBool(),
+		config.EtcdUseSSL.GetAsBool(),
+		config.Endpoints.GetAsStrings(),
+		config.EtcdTLSCert.GetValue(),
+		config.EtcdTLSKey.GetValue(),
+		config.EtcdTLSCACert.GetValue(),
+		config.EtcdTLSMinVersion.GetValue())
+	suite.Require().NoError(err)
+	suite.kv = etcdkv.NewEtcdKV(cli, config.MetaRootPath.GetValue())
+
+	suite.nodeMgr = session.NewNodeManager()
+	suite.meta = meta.NewMeta(RandomIncrementIDAllocator(), querycoord.NewCatalog(suite.kv), suite.nodeMgr)
+	suite.dist = meta.NewDistributionManager(suite.nodeMgr)
+	suite.checker = NewHotSpotChecker(suite.meta, suite.dist, suite.nodeMgr)
+
+	suite.meta.CollectionManager.PutCollection(ctx, utils.CreateTestCollection(1, 1))
+	suite.meta.ReplicaManager.Put(ctx, utils.CreateTestReplica(1, 1, []int64{1, 2}))
+	for _, nodeID := range []int64{1, 2} {
+		suite.nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "localhost",
+			Hostname: "localhost",
+		}))
+	}
+	suite.dist.SegmentDistManager.Update(1, utils.CreateTestSegment(1, 1, 1, 1, 1, "v1"))
+	channel := utils.CreateTestChannel(1, 1, 1, "v1")
+	channel.View = utils.CreateTestLeaderView(1, 1, "v1", map[int64]int64{1: 1}, nil)
+	suite.dist.ChannelDistManager.Update(1, channel)
+	// the channel on node 1 is hot
+	suite.nodeMgr.Get(1).UpdateStats(session.WithChannelWorkloads(map[string]session.ChannelWorkload{
+		"v1": {Inflight: 100},
+	}))
+}
+
+func (suite *HotSpotCheckerTestSuite) TearDownTest() {
+	suite.kv.Close()
+}
+
+func (suite *HotSpotCheckerTestSuite) TestCheck() {
+	ctx := context.Background()
+	params := paramtable.Get()
+
+	// disabled by default
+	suite.Empty(suite.checker.Check(ctx))
+
+	params.Save(params.QueryCoordCfg.HotSpotCheckEnabled.Key, "true")
+	defer params.Reset(params.QueryCoordCfg.HotSpotCheckEnabled.Key)
+	tasks := suite.checker.Check(ctx)
+	suite.Len(tasks, 1)
+	suite.Equal(utils.HotSpotChecker, tasks[0].Source())
+	suite.Equal(task.TaskPriorityLow, tasks[0].Priority())
+	suite.Len(tasks[0].Actions(), 1)
+	action := tasks[0].Actions()[0].(*task.SegmentAction)
+	suite.Equal(task.ActionTypeGrow, action.Type())
+	suite.Equal(int64(2), action.Node())
+	suite.Equal(int64(1), action.GetSegmentID())
+	suite.True(suite.dist.HotCopyManager.Contains(1, 1, 2))
+
+	// the copy is retired after the check is disabled
+	suite.dist.SegmentDistManager.Update(2, utils.CreateTestSegment(1, 1, 1, 2, time.Now().UnixNano(), "v1"))
+	params.Save(params.QueryCoordCfg.HotSpotCheckEnabled.Key, "false")
+	tasks = suite.checker.Check(ctx)
+	suite.Len(tasks, 1)
+	action = tasks[0].Actions()[0].(*task.SegmentAction)
+	suite.Equal(task.ActionTypeReduce, action.Type())
+	suite.Equal(int64(2), action.Node())
+
+	// the copies of the released replica are forgotten
+	suite.meta.ReplicaManager.RemoveCollection(ctx, 1)
+	suite.checker.Check(ctx)
+	suite.Empty(suite.dist.HotCopyManager.GetReplicas())
+}
+
+func (suite *HotSpotCheckerTestSuite) TestInactive() {
+	ctx := context.Background()
+	params := paramtable.Get()
+	params.Save(params.QueryCoordCfg.HotSpotCheckEnabled.Key, "true")
+	defer params.Reset(params.QueryCoordCfg.HotSpotCheckEnabled.Key)
+
+	suite.checker.Deactivate()
+	suite.Empty(suite.checker.Check(ctx))
+	suite.checker.Activate()
+	suite.Len(suite.checker.Check(ctx), 1)
+}
+
+func TestHotSpotChecker(t *testing.T) {
+	suite.Run(t, new(HotSpotCheckerTestSuite))
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/samber/lo"
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
@@ -29,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/streamingutil"
 	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
 var _ Checker = (*LeaderChecker)(nil)
@@ -165,7 +167,7 @@ func (c *LeaderChecker) findNeedLoadedSegments(ctx context.Context, replica *met
 	)
 	ret := make([]task.Task, 0)
 
-	latestNodeDist := utils.FindMaxVersionSegments(dist)
+	latestNodeDist := utils.FindMaxVersionSegments(c.filterOutHotCopies(replica, dist))
 	for _, s := range latestNodeDist {
 		segment := c.target.GetSealedSegment(ctx, leaderView.CollectionID, s.GetID(), meta.CurrentTargetFirst)
 		if segment == nil {
@@ -201,6 +203,23 @@ func (c *LeaderChecker) findNeedLoadedSegments(ctx context.Context, replica *met
 	return ret
 }
 
+// filterOutHotCopies filters out the hot copies, which share the reads with the serving copies instead of replacing them,
+// unless there is no other copy of the segment.
+func (c *LeaderChecker) filterOutHotCopies(replica *meta.Replica, dist []*meta.Segment) []*meta.Segment {
+	isHotCopy := func(s *meta.Segment) bool {
+		return c.dist.HotCopyManager.Contains(replica.GetID(), s.GetID(), s.Node)
+	}
+	hasOtherCopy := typeutil.NewUniqueSet()
+	for _, s := range dist {
+		if !isHotCopy(s) {
+			hasOtherCopy.Insert(s.GetID())
+		}
+	}
+	return lo.Filter(dist, func(s *meta.Segment, _ int) bool {
+		return !isHotCopy(s) || !hasOtherCopy.Contain(s.GetID())
+	})
+}
+
 func (c *LeaderChecker) findNeedRemovedSegments(ctx context.Context, replica *meta.Replica, leaderView *meta.LeaderView, dists []*meta.Segment) []task.Task {
 	log := log.Ctx(ctx).With(
 		zap.Int64("collectionID", leaderView.CollectionID),
@@ -180,6 +180,18 @@ func (suite *LeaderCheckerTestSuite) TestSyncLoadedSegments() {
 	suite.Equal(tasks[0].Actions()[0].(*task.LeaderAction).GetLeaderID(), node2)
 	suite.Equal(tasks[0].Actions()[0].(*task.LeaderAction).SegmentID(), int64(1))
 	suite.Equal(tasks[0].Priority(), task.TaskPriorityLow)
+
+	// the latest replica on querynode-2 is a hot copy, which doesn't replace the serving one
+	observer.dist.SegmentDistManager.Update(node1, utils.CreateTestSegment(1, 1, 1, node1, version1, "test-insert-channel"))
+	observer.dist.HotCopyManager.Add(&meta.HotCopy{CollectionID: 1, ReplicaID: 1, Channel: "test-insert-channel", SegmentID: 1, NodeID: node2})
+	tasks = suite.checker.Check(context.TODO())
+	suite.Len(tasks, 0)
+
+	// the hot copy is routed if there is no other replica
+	observer.dist.SegmentDistManager.Update(node1)
+	tasks = suite.checker.Check(context.TODO())
+	suite.Len(tasks, 1)
+	suite.Equal(tasks[0].Actions()[0].Node(), node2)
 }
 
 func (suite *LeaderCheckerTestSuite) TestActivation() {
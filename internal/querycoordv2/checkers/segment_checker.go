@@ -285,6 +285,10 @@ func (c *SegmentChecker) findRepeatedSealedSegments(ctx context.Context, replica
 	dist := c.dist.SegmentDistManager.GetByFilter(meta.WithCollectionID(replica.GetCollectionID()), meta.WithReplica(replica))
 	versions := make(map[int64]*meta.Segment)
 	for _, s := range dist {
+		// the hot copies are retired by hot spot checker
+		if c.dist.HotCopyManager.Contains(replicaID, s.GetID(), s.Node) {
+			continue
+		}
 		maxVer, ok := versions[s.GetID()]
 		if !ok {
 			versions[s.GetID()] = s
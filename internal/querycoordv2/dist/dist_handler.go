@@ -155,6 +155,17 @@ func (dh *distHandler) handleDistResp(ctx context.Context, resp *querypb.GetData
 	node.SetLastHeartbeat(now)
 	metrics.QueryCoordLastHeartbeatTimeStamp.WithLabelValues(fmt.Sprint(resp.GetNodeID())).Set(float64(now.UnixNano()))
 
+	// the read workload is reported even if there is no distribution change
+	node.UpdateStats(
+		session.WithReadTaskNum(resp.GetWaitingTaskNum()+resp.GetExecutingTaskNum()),
+		session.WithChannelWorkloads(lo.SliceToMap(resp.GetLeaderViews(), func(view *querypb.LeaderView) (string, session.ChannelWorkload) {
+			return view.GetChannel(), session.ChannelWorkload{
+				Inflight: view.GetReadInflight(),
+				Latency:  time.Duration(view.GetReadLatency()) * time.Millisecond,
+			}
+		})),
+	)
+
 	// skip  update dist if no distribution change happens in query node
 	if resp.GetLastModifyTs() != 0 && resp.GetLastModifyTs() <= dh.lastUpdateTs {
 		log.RatedInfo(30, "skip update dist due to no distribution change", zap.Int64("lastModifyTs", resp.GetLastModifyTs()), zap.Int64("lastUpdateTs", dh.lastUpdateTs))
@@ -165,7 +176,7 @@ func (dh *distHandler) handleDistResp(ctx context.Context, resp *querypb.GetData
 			session.WithSegmentCnt(len(resp.GetSegments())),
 			session.WithChannelCnt(len(resp.GetChannels())),
 			session.WithMemCapacity(resp.GetMemCapacityInMB()),
-			session.WithChannelCnt(int(resp.GetCpuNum())),
+			session.WithCPUNum(resp.GetCpuNum()),
 		)
 		dh.updateSegmentsDistribution(ctx, resp)
 		dh.updateChannelsDistribution(ctx, resp)
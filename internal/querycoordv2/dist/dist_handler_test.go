@@ -114,15 +114,23 @@ func (suite *DistHandlerSuite) TestBasic() {
 				Collection:    1,
 				Channel:       "test-channel-1",
 				TargetVersion: 1011,
+				ReadInflight:  3,
+				ReadLatency:   20,
 			},
 		},
-		LastModifyTs: 1,
+		LastModifyTs:     1,
+		WaitingTaskNum:   2,
+		ExecutingTaskNum: 1,
 	}, nil)
 
 	suite.handler = newDistHandler(suite.ctx, suite.nodeID, suite.client, suite.nodeManager, suite.scheduler, suite.dist, suite.target, func(collectionID ...int64) {})
 	defer suite.handler.stop()
 
 	time.Sleep(3 * time.Second)
+
+	node := suite.nodeManager.Get(1)
+	suite.Equal(int64(3), node.ReadTaskNum())
+	suite.Equal(session.ChannelWorkload{Inflight: 3, Latency: 20 * time.Millisecond}, node.ChannelWorkload("test-channel-1"))
 }
 
 func (suite *DistHandlerSuite) TestGetDistributionFailed() {
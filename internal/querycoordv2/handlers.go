@@ -19,6 +19,8 @@ package querycoordv2
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/querycoordv2/balance"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/internal/querycoordv2/task"
@@ -320,6 +323,60 @@ func (s *Server) getSegmentsJSON(ctx context.Context, req *milvuspb.GetMetricsRe
 	return "", fmt.Errorf("invalid param value in=[%s], it should be qc or qn", in)
 }
 
+// simulateBalance runs the balancer on a snapshot of the distribution with the hypothetical node changes,
+// add_nodes is in the format of `rg:num[:memory capacity in MB]` and remove_nodes is the node id list, both separated by comma.
+func (s *Server) simulateBalance(ctx context.Context, jsonReq gjson.Result) (string, error) {
+	req := &balance.SimulationRequest{
+		Balancer:     jsonReq.Get(metricsinfo.MetricRequestParamBalancerKey).String(),
+		CollectionID: metricsinfo.GetCollectionIDFromRequest(jsonReq),
+		MaxRounds:    int(jsonReq.Get(metricsinfo.MetricRequestParamMaxRoundsKey).Int()),
+	}
+	for _, spec := range splitMetricsParam(jsonReq.Get(metricsinfo.MetricRequestParamAddNodesKey).String()) {
+		fields := strings.Split(spec, ":")
+		if len(fields) < 2 || len(fields) > 3 {
+			return "", merr.WrapErrParameterInvalid("rg:num[:memory]", spec)
+		}
+		num, err := strconv.Atoi(fields[1])
+		if err != nil || num <= 0 {
+			return "", merr.WrapErrParameterInvalid("positive node num", spec)
+		}
+		nodes := balance.SimulatedNodes{ResourceGroup: fields[0], Num: num}
+		if len(fields) == 3 {
+			nodes.MemCapacity, err = strconv.ParseFloat(fields[2], 64)
+			if err != nil {
+				return "", merr.WrapErrParameterInvalid("memory capacity in MB", spec)
+			}
+		}
+		req.AddNodes = append(req.AddNodes, nodes)
+	}
+	for _, spec := range splitMetricsParam(jsonReq.Get(metricsinfo.MetricRequestParamRemoveNodesKey).String()) {
+		nodeID, err := strconv.ParseInt(spec, 10, 64)
+		if err != nil {
+			return "", merr.WrapErrParameterInvalid("node id", spec)
+		}
+		req.RemoveNodes = append(req.RemoveNodes, nodeID)
+	}
+
+	report, err := balance.NewSimulator(s.nodeMgr, s.dist, s.meta, s.targetMgr).Simulate(ctx, req)
+	if err != nil {
+		log.Ctx(ctx).Warn("failed to simulate balance", zap.Error(err))
+		return "", err
+	}
+	bs, err := json.Marshal(report)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+func splitMetricsParam(value string) []string {
+	return lo.Filter(lo.Map(strings.Split(value, metricsinfo.MetricRequestParamsSeparator), func(v string, _ int) string {
+		return strings.TrimSpace(v)
+	}), func(v string, _ int) bool {
+		return v != ""
+	})
+}
+
 // TODO(dragondriver): add more detail metrics
 func (s *Server) getSystemInfoMetrics(
 	ctx context.Context,
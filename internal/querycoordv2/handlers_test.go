@@ -28,6 +28,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
 	"github.com/milvus-io/milvus/pkg/v2/util/metricsinfo"
 )
 
@@ -180,3 +181,21 @@ func TestServer_getSegmentsJSON(t *testing.T) {
 		assert.NotEmpty(t, result)
 	})
 }
+
+func TestServer_simulateBalance(t *testing.T) {
+	server := &Server{}
+	ctx := context.TODO()
+
+	assert.Equal(t, []string{"rg1:2", "rg2:1:4096"}, splitMetricsParam(" rg1:2, ,rg2:1:4096"))
+	for _, params := range []string{
+		`{"add_nodes": "rg1"}`,
+		`{"add_nodes": "rg1:0"}`,
+		`{"add_nodes": "rg1:x"}`,
+		`{"add_nodes": "rg1:1:x"}`,
+		`{"remove_nodes": "1,x"}`,
+	} {
+		result, err := server.simulateBalance(ctx, gjson.Parse(params))
+		assert.ErrorIs(t, err, merr.ErrParameterInvalid, params)
+		assert.Empty(t, result)
+	}
+}
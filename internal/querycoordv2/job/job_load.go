@@ -166,7 +166,15 @@ func (job *LoadCollectionJob) Execute() error {
 		}
 	}
 
-	// 2. create replica if not exist
+	// 2. check the quota of the resource groups
+	err = checkResourceGroupQuota(job.ctx, job.meta, job.broker, job.targetMgr, req.GetCollectionID(), lackPartitionIDs,
+		req.GetResourceGroups(), req.GetReplicaNumber())
+	if err != nil {
+		log.Warn("failed to check resource group quota", zap.Error(err))
+		return err
+	}
+
+	// 3. create replica if not exist
 	replicas := job.meta.ReplicaManager.GetByCollection(job.ctx, req.GetCollectionID())
 	if len(replicas) == 0 {
 		// API of LoadCollection is wired, we should use map[resourceGroupNames]replicaNumber as input, to keep consistency with `TransferReplica` API.
@@ -362,7 +370,15 @@ func (job *LoadPartitionJob) Execute() error {
 		}
 	}
 
-	// 2. create replica if not exist
+	// 2. check the quota of the resource groups
+	err = checkResourceGroupQuota(job.ctx, job.meta, job.broker, job.targetMgr, req.GetCollectionID(), lackPartitionIDs,
+		req.GetResourceGroups(), req.GetReplicaNumber())
+	if err != nil {
+		log.Warn("failed to check resource group quota", zap.Error(err))
+		return err
+	}
+
+	// 3. create replica if not exist
 	replicas := job.meta.ReplicaManager.GetByCollection(context.TODO(), req.GetCollectionID())
 	if len(replicas) == 0 {
 		_, err = utils.SpawnReplicasWithRG(job.ctx, job.meta, req.GetCollectionID(), req.GetResourceGroups(), req.GetReplicaNumber(),
@@ -130,6 +130,9 @@ func (suite *JobSuite) SetupSuite() {
 					ID:            segment,
 					PartitionID:   partition,
 					InsertChannel: suite.channels[collection][segment%2],
+					Binlogs: []*datapb.FieldBinlog{
+						{FieldID: 100, Binlogs: []*datapb.Binlog{{LogSize: 32 << 20, MemorySize: 64 << 20}}},
+					},
 				})
 			}
 		}
@@ -1413,6 +1416,80 @@ func (suite *JobSuite) TestLoadCreateReplicaFailed() {
 	}
 }
 
+func (suite *JobSuite) TestResourceGroupQuotaExceeded() {
+	ctx := context.Background()
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.ResourceGroupQuotaEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.ResourceGroupQuotaEnabled.Key)
+	// each replica of the collection takes 384MB, and the quota of the resource group is 691MB
+	for _, nodeID := range []int64{1000, 2000, 3000} {
+		suite.nodeMgr.Get(nodeID).UpdateStats(session.WithMemCapacity(256))
+	}
+	collection := suite.collections[0]
+
+	// 2 replicas exceed the quota
+	req := &querypb.LoadCollectionRequest{
+		CollectionID:  collection,
+		ReplicaNumber: 2,
+	}
+	job := NewLoadCollectionJob(
+		ctx,
+		req,
+		suite.dist,
+		suite.meta,
+		suite.broker,
+		suite.targetMgr,
+		suite.targetObserver,
+		suite.collectionObserver,
+		suite.nodeMgr,
+		false,
+	)
+	suite.scheduler.Add(job)
+	err := job.Wait()
+	suite.ErrorIs(err, merr.ErrResourceGroupQuotaExceeded)
+	suite.assertCollectionReleased(collection)
+
+	// 1 replica is within the quota
+	req = &querypb.LoadCollectionRequest{
+		CollectionID:  collection,
+		ReplicaNumber: 1,
+	}
+	job = NewLoadCollectionJob(
+		ctx,
+		req,
+		suite.dist,
+		suite.meta,
+		suite.broker,
+		suite.targetMgr,
+		suite.targetObserver,
+		suite.collectionObserver,
+		suite.nodeMgr,
+		false,
+	)
+	suite.scheduler.Add(job)
+	err = job.Wait()
+	suite.NoError(err)
+	suite.targetMgr.UpdateCollectionNextTarget(ctx, collection)
+
+	// increasing the replica number exceeds the quota
+	updateJob := NewUpdateLoadConfigJob(
+		ctx,
+		&querypb.UpdateLoadConfigRequest{
+			CollectionIDs:  []int64{collection},
+			ReplicaNumber:  2,
+			ResourceGroups: []string{meta.DefaultResourceGroupName},
+		},
+		suite.meta,
+		suite.targetMgr,
+		suite.targetObserver,
+		suite.collectionObserver,
+		false,
+	)
+	suite.scheduler.Add(updateJob)
+	err = updateJob.Wait()
+	suite.ErrorIs(err, merr.ErrResourceGroupQuotaExceeded)
+	suite.Len(suite.meta.ReplicaManager.GetByCollection(ctx, collection), 1)
+}
+
 func (suite *JobSuite) TestSyncNewCreatedPartition() {
 	newPartition := int64(999)
 	ctx := context.Background()
@@ -101,6 +101,20 @@ func (job *UpdateLoadConfigJob) Execute() error {
 		zap.Any("toTransfer", toTransfer),
 		zap.Any("toRelease", toRelease))
 
+	// check the quota of the resource groups which the replicas are spawned or transferred to
+	replicaNumInRG := make(map[string]int)
+	for rg, num := range toSpawn {
+		replicaNumInRG[rg] += num
+	}
+	for rg, replicas := range toTransfer {
+		replicaNumInRG[rg] += len(replicas)
+	}
+	replicaUsage := utils.EstimateReplicaUsage(job.ctx, job.targetMgr, job.collectionID)
+	if err := utils.CheckResourceGroupQuota(job.ctx, job.meta, job.targetMgr, replicaNumInRG, replicaUsage); err != nil {
+		log.Warn("failed to check resource group quota", zap.Error(err))
+		return err
+	}
+
 	// 3. try to spawn new replica
 	channels := job.targetMgr.GetDmChannelsByCollection(job.ctx, job.collectionID, meta.CurrentTargetFirst)
 	newReplicas, spawnErr := job.meta.ReplicaManager.Spawn(job.ctx, job.collectionID, toSpawn, lo.Keys(channels), commonpb.LoadPriority_LOW)
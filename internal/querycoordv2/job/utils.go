@@ -26,7 +26,9 @@ import (
 	"github.com/milvus-io/milvus/internal/querycoordv2/checkers"
 	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
 	"github.com/milvus-io/milvus/internal/querycoordv2/observers"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
@@ -82,3 +84,40 @@ func waitCurrentTargetUpdated(ctx context.Context, targetObserver *observers.Tar
 		return ctx.Err()
 	}
 }
+
+// checkResourceGroupQuota checks whether the resource groups have enough quota to load the partitions,
+// the partitions are loaded into all the existing replicas, or the new replicas spawned in the given resource groups.
+// The shards use the cpu quota only if the new replicas are spawned.
+func checkResourceGroupQuota(ctx context.Context, m *meta.Meta, broker meta.Broker, targetMgr meta.TargetManagerInterface,
+	collectionID int64, partitionIDs []int64, resourceGroups []string, replicaNumber int32,
+) error {
+	if !paramtable.Get().QueryCoordCfg.ResourceGroupQuotaEnabled.GetAsBool() {
+		return nil
+	}
+
+	vchannels, segments, err := broker.GetRecoveryInfoV2(ctx, collectionID)
+	if err != nil {
+		return err
+	}
+	partitionSet := typeutil.NewUniqueSet(partitionIDs...)
+	var usage utils.ReplicaUsage
+	for _, segment := range segments {
+		if partitionSet.Contain(segment.GetPartitionID()) {
+			usage.MemSize += utils.SegmentMemorySize(segment)
+		}
+	}
+
+	replicaNumInRG := make(map[string]int)
+	if replicas := m.ReplicaManager.GetByCollection(ctx, collectionID); len(replicas) > 0 {
+		for _, replica := range replicas {
+			replicaNumInRG[replica.GetResourceGroup()]++
+		}
+	} else {
+		usage.ShardNum = int64(len(vchannels))
+		replicaNumInRG, err = utils.AssignReplica(ctx, m, resourceGroups, replicaNumber, false)
+		if err != nil {
+			return err
+		}
+	}
+	return utils.CheckResourceGroupQuota(ctx, m, targetMgr, replicaNumInRG, usage)
+}
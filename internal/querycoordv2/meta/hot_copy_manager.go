@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"sync"
+	"time"
+)
+
+// HotCopy is a temporary extra copy of a sealed segment of the hot channel,
+// which shares the reads of the segment with the serving copy on the delegator.
+type HotCopy struct {
+	CollectionID int64
+	ReplicaID    int64
+	Channel      string
+	SegmentID    int64
+	NodeID       int64
+	CreatedAt    time.Time
+}
+
+type hotCopyKey struct {
+	segmentID int64
+	nodeID    int64
+}
+
+// HotCopyManager records the hot copies created by query coord, the records are kept in memory only,
+// after query coord restarts, the remaining copies are released as the repeated segments.
+type HotCopyManager struct {
+	mu sync.RWMutex
+	// ReplicaID -> (SegmentID, NodeID) -> HotCopy
+	copies map[int64]map[hotCopyKey]*HotCopy
+}
+
+func NewHotCopyManager() *HotCopyManager {
+	return &HotCopyManager{
+		copies: make(map[int64]map[hotCopyKey]*HotCopy),
+	}
+}
+
+func (m *HotCopyManager) Add(copies ...*HotCopy) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range copies {
+		replicaCopies, ok := m.copies[c.ReplicaID]
+		if !ok {
+			replicaCopies = make(map[hotCopyKey]*HotCopy)
+			m.copies[c.ReplicaID] = replicaCopies
+		}
+		replicaCopies[hotCopyKey{segmentID: c.SegmentID, nodeID: c.NodeID}] = c
+	}
+}
+
+func (m *HotCopyManager) Remove(replicaID int64, segmentID int64, nodeID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	replicaCopies, ok := m.copies[replicaID]
+	if !ok {
+		return
+	}
+	delete(replicaCopies, hotCopyKey{segmentID: segmentID, nodeID: nodeID})
+	if len(replicaCopies) == 0 {
+		delete(m.copies, replicaID)
+	}
+}
+
+// RemoveReplica removes all the hot copies of the replica.
+func (m *HotCopyManager) RemoveReplica(replicaID int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.copies, replicaID)
+}
+
+// Contains returns whether the segment on the node is a hot copy of the replica.
+func (m *HotCopyManager) Contains(replicaID int64, segmentID int64, nodeID int64) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	_, ok := m.copies[replicaID][hotCopyKey{segmentID: segmentID, nodeID: nodeID}]
+	return ok
+}
+
+func (m *HotCopyManager) GetByReplica(replicaID int64) []*HotCopy {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make([]*HotCopy, 0, len(m.copies[replicaID]))
+	for _, c := range m.copies[replicaID] {
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+// GetReplicas returns the IDs of the replicas which have hot copies.
+func (m *HotCopyManager) GetReplicas() []int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ret := make([]int64, 0, len(m.copies))
+	for replicaID := range m.copies {
+		ret = append(ret, replicaID)
+	}
+	return ret
+}
@@ -0,0 +1,51 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package meta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHotCopyManager(t *testing.T) {
+	m := NewHotCopyManager()
+	m.Add(
+		&HotCopy{CollectionID: 100, ReplicaID: 1, Channel: "v1", SegmentID: 1, NodeID: 2, CreatedAt: time.Now()},
+		&HotCopy{CollectionID: 100, ReplicaID: 1, Channel: "v1", SegmentID: 2, NodeID: 3, CreatedAt: time.Now()},
+		&HotCopy{CollectionID: 100, ReplicaID: 2, Channel: "v1", SegmentID: 1, NodeID: 5, CreatedAt: time.Now()},
+	)
+
+	assert.True(t, m.Contains(1, 1, 2))
+	assert.False(t, m.Contains(1, 1, 3))
+	assert.False(t, m.Contains(2, 1, 2))
+	assert.Len(t, m.GetByReplica(1), 2)
+	assert.ElementsMatch(t, []int64{1, 2}, m.GetReplicas())
+
+	m.Remove(1, 1, 2)
+	assert.False(t, m.Contains(1, 1, 2))
+	assert.Len(t, m.GetByReplica(1), 1)
+	m.Remove(1, 2, 3)
+	assert.Empty(t, m.GetByReplica(1))
+	assert.ElementsMatch(t, []int64{2}, m.GetReplicas())
+
+	m.RemoveReplica(2)
+	assert.Empty(t, m.GetReplicas())
+	// removing the absent copy is a no-op
+	m.Remove(3, 1, 1)
+}
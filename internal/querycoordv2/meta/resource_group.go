@@ -7,6 +7,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
 	"github.com/milvus-io/milvus/internal/querycoordv2/session"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
 	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
 )
 
@@ -30,6 +31,14 @@ func newResourceGroupConfig(request int32, limit int32) *rgpb.ResourceGroupConfi
 	}
 }
 
+// ResourceGroupCapacity is the total capacity of the nodes in a resource group.
+type ResourceGroupCapacity struct {
+	MemCapacity int64 // total memory capacity in bytes.
+	MemQuota    int64 // the memory can be used by the loaded replicas in bytes.
+	CPUNum      int64
+	CPUQuota    int64 // the number of shards can be loaded, 0 means no limit.
+}
+
 type ResourceGroup struct {
 	name    string
 	nodes   typeutil.UniqueSet
@@ -117,6 +126,23 @@ func (rg *ResourceGroup) NodeNum() int {
 	return len(rg.GetNodes())
 }
 
+// GetCapacityOfNodes return the total capacity of the nodes.
+// The nodes which haven't reported their capacity are not counted.
+func (rg *ResourceGroup) GetCapacityOfNodes() ResourceGroupCapacity {
+	capacity := ResourceGroupCapacity{}
+	for _, nodeID := range rg.GetNodes() {
+		node := rg.nodeMgr.Get(nodeID)
+		if node == nil || node.MemCapacity() <= 0 {
+			continue
+		}
+		capacity.MemCapacity += int64(node.MemCapacity() * 1024 * 1024)
+		capacity.CPUNum += node.CPUNum()
+	}
+	capacity.MemQuota = int64(float64(capacity.MemCapacity) * paramtable.Get().QueryCoordCfg.ResourceGroupMemoryQuotaRatio.GetAsFloat())
+	capacity.CPUQuota = int64(float64(capacity.CPUNum) * paramtable.Get().QueryCoordCfg.ResourceGroupShardsPerCPUQuota.GetAsFloat())
+	return capacity
+}
+
 // ContainNode return whether resource group contain node.
 func (rg *ResourceGroup) ContainNode(id int64) bool {
 	return rg.nodes.Contain(id)
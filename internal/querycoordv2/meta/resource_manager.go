@@ -361,6 +361,16 @@ func (rm *ResourceManager) GetNodes(ctx context.Context, rgName string) ([]int64
 	return rm.groups[rgName].GetNodes(), nil
 }
 
+// GetCapacityOfNodes return the total capacity of the nodes in given resource group.
+func (rm *ResourceManager) GetCapacityOfNodes(ctx context.Context, rgName string) (ResourceGroupCapacity, error) {
+	rm.rwmutex.RLock()
+	defer rm.rwmutex.RUnlock()
+	if rm.groups[rgName] == nil {
+		return ResourceGroupCapacity{}, merr.WrapErrResourceGroupNotFound(rgName)
+	}
+	return rm.groups[rgName].GetCapacityOfNodes(), nil
+}
+
 // GetResourceGroupByNodeID return whether resource group's node match required node count
 func (rm *ResourceManager) VerifyNodeCount(ctx context.Context, requiredNodeCount map[string]int) error {
 	rm.rwmutex.RLock()
@@ -988,15 +998,23 @@ func (rm *ResourceManager) setupInMemResourceGroup(r *ResourceGroup) {
 	rm.groups[r.GetName()] = r
 }
 
-func (rm *ResourceManager) GetResourceGroupsJSON(ctx context.Context) string {
+// GetResourceGroupsJSON return the resource groups with the capacity of their nodes,
+// memUsed is the estimated memory usage of the loaded replicas in each resource group.
+func (rm *ResourceManager) GetResourceGroupsJSON(ctx context.Context, memUsed map[string]int64) string {
 	rm.rwmutex.RLock()
 	defer rm.rwmutex.RUnlock()
 
 	rgs := lo.MapToSlice(rm.groups, func(i string, r *ResourceGroup) *metricsinfo.ResourceGroup {
+		capacity := r.GetCapacityOfNodes()
 		return &metricsinfo.ResourceGroup{
-			Name:  r.GetName(),
-			Nodes: r.GetNodes(),
-			Cfg:   r.GetConfig(),
+			Name:        r.GetName(),
+			Nodes:       r.GetNodes(),
+			Cfg:         r.GetConfig(),
+			MemCapacity: capacity.MemCapacity,
+			MemQuota:    capacity.MemQuota,
+			MemUsed:     memUsed[r.GetName()],
+			CPUNum:      capacity.CPUNum,
+			CPUQuota:    capacity.CPUQuota,
 		}
 	})
 	ret, err := json.Marshal(rgs)
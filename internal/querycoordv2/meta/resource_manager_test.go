@@ -659,8 +659,11 @@ func TestGetResourceGroupsJSON(t *testing.T) {
 	rg2.nodes = typeutil.NewUniqueSet(3, 4)
 	manager.groups["rg1"] = rg1
 	manager.groups["rg2"] = rg2
+	node1 := session.NewNodeInfo(session.ImmutableNodeInfo{NodeID: 1})
+	node1.UpdateStats(session.WithMemCapacity(1024), session.WithCPUNum(8))
+	nodeManager.Add(node1)
 
-	jsonOutput := manager.GetResourceGroupsJSON(ctx)
+	jsonOutput := manager.GetResourceGroupsJSON(ctx, map[string]int64{"rg1": 1024})
 	var resourceGroups []*metricsinfo.ResourceGroup
 	err := json.Unmarshal([]byte(jsonOutput), &resourceGroups)
 	assert.NoError(t, err)
@@ -669,6 +672,9 @@ func TestGetResourceGroupsJSON(t *testing.T) {
 	checkResult := func(rg *metricsinfo.ResourceGroup) {
 		if rg.Name == "rg1" {
 			assert.ElementsMatch(t, []int64{1, 2}, rg.Nodes)
+			assert.Equal(t, int64(1024*1024*1024), rg.MemCapacity)
+			assert.Equal(t, int64(1024), rg.MemUsed)
+			assert.Equal(t, int64(8), rg.CPUNum)
 		} else if rg.Name == "rg2" {
 			assert.ElementsMatch(t, []int64{3, 4}, rg.Nodes)
 		} else {
@@ -681,6 +687,44 @@ func TestGetResourceGroupsJSON(t *testing.T) {
 	}
 }
 
+func (suite *ResourceManagerSuite) TestCapacityOfNodes() {
+	ctx := suite.ctx
+	for nodeID, memCapacity := range map[int64]float64{1: 1024, 2: 2048, 3: 0} {
+		node := session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "localhost",
+			Hostname: "localhost",
+		})
+		node.UpdateStats(session.WithMemCapacity(memCapacity), session.WithCPUNum(4))
+		suite.manager.nodeMgr.Add(node)
+	}
+	suite.NoError(suite.manager.AddResourceGroup(ctx, "rg1", newResourceGroupConfig(3, 3)))
+	suite.manager.HandleNodeUp(ctx, 1)
+	suite.manager.HandleNodeUp(ctx, 2)
+	suite.manager.HandleNodeUp(ctx, 3)
+
+	capacity, err := suite.manager.GetCapacityOfNodes(ctx, "rg1")
+	suite.NoError(err)
+	suite.Equal(int64(3072*1024*1024), capacity.MemCapacity)
+	suite.Equal(int64(float64(capacity.MemCapacity)*0.9), capacity.MemQuota)
+	// node 3 hasn't reported its memory capacity, so its cpus are not counted
+	suite.Equal(int64(8), capacity.CPUNum)
+	suite.Zero(capacity.CPUQuota)
+
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.ResourceGroupShardsPerCPUQuota.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.ResourceGroupShardsPerCPUQuota.Key)
+	capacity, err = suite.manager.GetCapacityOfNodes(ctx, "rg1")
+	suite.NoError(err)
+	suite.Equal(int64(16), capacity.CPUQuota)
+
+	capacity, err = suite.manager.GetCapacityOfNodes(ctx, DefaultResourceGroupName)
+	suite.NoError(err)
+	suite.Zero(capacity.MemCapacity)
+
+	_, err = suite.manager.GetCapacityOfNodes(ctx, "rg2")
+	suite.ErrorIs(err, merr.ErrResourceGroupNotFound)
+}
+
 func (suite *ResourceManagerSuite) TestNodeLabels_NodeAssign() {
 	ctx := suite.ctx
 	suite.manager.AddResourceGroup(ctx, "rg1", &rgpb.ResourceGroupConfig{
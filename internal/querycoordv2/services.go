@@ -1158,6 +1158,13 @@ func (s *Server) DescribeResourceGroup(ctx context.Context, req *querypb.Describ
 		}
 	}
 
+	usage, err := utils.GetResourceGroupUsage(ctx, s.meta, s.targetMgr, req.GetResourceGroup())
+	if err != nil {
+		log.Warn("failed to get resource group usage", zap.Error(err))
+		resp.Status = merr.Status(err)
+		return resp, nil
+	}
+
 	resp.ResourceGroup = &querypb.ResourceGroupInfo{
 		Name:             req.GetResourceGroup(),
 		Capacity:         int32(rg.GetCapacity()),
@@ -1167,6 +1174,10 @@ func (s *Server) DescribeResourceGroup(ctx context.Context, req *querypb.Describ
 		NumIncomingNode:  incomingNodes,
 		Config:           rg.GetConfig(),
 		Nodes:            nodes,
+		MemCapacity:      usage.MemCapacity,
+		MemQuota:         usage.MemQuota,
+		MemUsed:          usage.MemUsed,
+		CpuNum:           usage.CPUNum,
 	}
 	return resp, nil
 }
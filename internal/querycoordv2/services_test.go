@@ -499,6 +499,7 @@ func (suite *ServiceSuite) TestResourceGroup() {
 		Address:  "localhost",
 		Hostname: "localhost",
 	}))
+	server.nodeMgr.Get(1011).UpdateStats(session.WithMemCapacity(1024), session.WithCPUNum(4))
 	server.meta.ResourceManager.AddResourceGroup(ctx, "rg11", &rgpb.ResourceGroupConfig{
 		Requests: &rgpb.ResourceGroupLimit{NodeNum: 2},
 		Limits:   &rgpb.ResourceGroupLimit{NodeNum: 2},
@@ -544,6 +545,8 @@ func (suite *ServiceSuite) TestResourceGroup() {
 	suite.Equal(map[int64]int32{1: 1}, resp2.GetResourceGroup().GetNumLoadedReplica())
 	suite.Equal(map[int64]int32{2: 1}, resp2.GetResourceGroup().GetNumIncomingNode())
 	suite.Equal(map[int64]int32{1: 1}, resp2.GetResourceGroup().GetNumOutgoingNode())
+	suite.Equal(int64(1024*1024*1024), resp2.GetResourceGroup().GetMemCapacity())
+	suite.Equal(int64(4), resp2.GetResourceGroup().GetCpuNum())
 
 	dropRG := &milvuspb.DropResourceGroupRequest{
 		ResourceGroup: "rg1",
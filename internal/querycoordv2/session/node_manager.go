@@ -182,6 +182,20 @@ func (n *NodeInfo) CPUNum() int64 {
 	return n.stats.getCPUNum()
 }
 
+// ReadTaskNum returns the number of the waiting and executing read tasks on the node.
+func (n *NodeInfo) ReadTaskNum() int64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.stats.getReadTaskNum()
+}
+
+// ChannelWorkload returns the read workload of the channel served by the delegator on the node.
+func (n *NodeInfo) ChannelWorkload(channel string) ChannelWorkload {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.stats.getChannelWorkload(channel)
+}
+
 func (n *NodeInfo) SetLastHeartbeat(time time.Time) {
 	n.lastHeartbeat.Store(time.UnixNano())
 }
@@ -253,3 +267,15 @@ func WithCPUNum(num int64) StatsOption {
 		n.setCPUNum(num)
 	}
 }
+
+func WithReadTaskNum(num int64) StatsOption {
+	return func(n *NodeInfo) {
+		n.setReadTaskNum(num)
+	}
+}
+
+func WithChannelWorkloads(workloads map[string]ChannelWorkload) StatsOption {
+	return func(n *NodeInfo) {
+		n.setChannelWorkloads(workloads)
+	}
+}
@@ -120,6 +120,24 @@ func (s *NodeManagerSuite) TestCPUNumFunctionality() {
 	s.Equal(4096.0, node.MemCapacity())
 }
 
+func (s *NodeManagerSuite) TestReadWorkload() {
+	node := NewNodeInfo(ImmutableNodeInfo{
+		NodeID:   1,
+		Address:  "localhost:19530",
+		Hostname: "test-host",
+	})
+	s.Equal(int64(0), node.ReadTaskNum())
+	s.Equal(ChannelWorkload{}, node.ChannelWorkload("ch1"))
+
+	node.UpdateStats(
+		WithReadTaskNum(10),
+		WithChannelWorkloads(map[string]ChannelWorkload{"ch1": {Inflight: 5, Latency: time.Second}}),
+	)
+	s.Equal(int64(10), node.ReadTaskNum())
+	s.Equal(ChannelWorkload{Inflight: 5, Latency: time.Second}, node.ChannelWorkload("ch1"))
+	s.Equal(ChannelWorkload{}, node.ChannelWorkload("ch2"))
+}
+
 // TestMemCapacityFunctionality tests memory capacity related methods
 func (s *NodeManagerSuite) TestMemCapacityFunctionality() {
 	node := NewNodeInfo(ImmutableNodeInfo{
@@ -16,11 +16,21 @@
 
 package session
 
+import "time"
+
+// ChannelWorkload is the read workload of the channel reported by the delegator.
+type ChannelWorkload struct {
+	Inflight int64
+	Latency  time.Duration
+}
+
 type stats struct {
-	segmentCnt      int
-	channelCnt      int
-	memCapacityInMB float64
-	CPUNum          int64
+	segmentCnt       int
+	channelCnt       int
+	memCapacityInMB  float64
+	CPUNum           int64
+	readTaskNum      int64
+	channelWorkloads map[string]ChannelWorkload
 }
 
 func (s *stats) setSegmentCnt(cnt int) {
@@ -55,6 +65,22 @@ func (s *stats) getCPUNum() int64 {
 	return s.CPUNum
 }
 
+func (s *stats) setReadTaskNum(num int64) {
+	s.readTaskNum = num
+}
+
+func (s *stats) getReadTaskNum() int64 {
+	return s.readTaskNum
+}
+
+func (s *stats) setChannelWorkloads(workloads map[string]ChannelWorkload) {
+	s.channelWorkloads = workloads
+}
+
+func (s *stats) getChannelWorkload(channel string) ChannelWorkload {
+	return s.channelWorkloads[channel]
+}
+
 func newStats() stats {
 	return stats{}
 }
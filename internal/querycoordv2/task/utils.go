@@ -175,6 +175,8 @@ func packLoadSegmentRequest(
 		NeedTransfer:   true,
 		IndexInfoList:  indexInfo,
 		LoadScope:      loadScope,
+		// the copy loaded by hot spot checker shares the reads with the serving one on the delegator
+		HotCopy: task.Source() == utils.HotSpotChecker && action.Type() == ActionTypeGrow,
 	}
 }
 
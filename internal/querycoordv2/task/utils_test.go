@@ -26,6 +26,7 @@ import (
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/querycoordv2/utils"
 	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/proto/querypb"
 )
@@ -83,6 +84,7 @@ func (s *UtilsSuite) TestPackLoadSegmentRequest() {
 	s.Equal(task.CollectionID(), req.CollectionID)
 	s.Equal(task.ReplicaID(), req.ReplicaID)
 	s.Equal(action.Node(), req.GetDstNodeID())
+	s.False(req.GetHotCopy())
 	for _, field := range req.GetSchema().GetFields() {
 		mmapEnable, ok := common.IsMmapDataEnabled(field.GetTypeParams()...)
 		s.False(mmapEnable)
@@ -90,6 +92,36 @@ func (s *UtilsSuite) TestPackLoadSegmentRequest() {
 	}
 }
 
+func (s *UtilsSuite) TestPackLoadSegmentRequestHotCopy() {
+	ctx := context.Background()
+
+	action := NewSegmentAction(1, ActionTypeGrow, "test-ch", 100)
+	task, err := NewSegmentTask(
+		ctx,
+		time.Second,
+		utils.HotSpotChecker,
+		1,
+		newReplicaDefaultRG(10),
+		commonpb.LoadPriority_LOW,
+		action,
+	)
+	s.NoError(err)
+
+	req := packLoadSegmentRequest(
+		task,
+		action,
+		&schemapb.CollectionSchema{},
+		nil,
+		&querypb.LoadMetaInfo{
+			LoadType: querypb.LoadType_LoadCollection,
+		},
+		&querypb.SegmentLoadInfo{},
+		nil,
+	)
+	s.True(req.GetHotCopy())
+	s.Equal(querypb.LoadScope_Full, req.GetLoadScope())
+}
+
 func (s *UtilsSuite) TestPackLoadSegmentRequestMmap() {
 	ctx := context.Background()
 
@@ -0,0 +1,160 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// ResourceGroupUsage is the capacity of the nodes and the estimated usage of the loaded replicas in a resource group.
+type ResourceGroupUsage struct {
+	meta.ResourceGroupCapacity
+	MemUsed  int64
+	ShardNum int64 // the shards of the loaded replicas, which use the cpu quota.
+}
+
+// ReplicaUsage is the estimated resource usage of one replica of a collection.
+type ReplicaUsage struct {
+	MemSize  int64
+	ShardNum int64
+}
+
+// SegmentMemorySize estimates the memory usage of the loaded segment by its binlogs.
+func SegmentMemorySize(segment *datapb.SegmentInfo) int64 {
+	var size int64
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if binlog.GetMemorySize() > 0 {
+				size += binlog.GetMemorySize()
+			} else {
+				size += binlog.GetLogSize()
+			}
+		}
+	}
+	return size
+}
+
+// EstimateReplicaMemorySize estimates the memory usage of one replica of the collection by the sealed segments in its target.
+func EstimateReplicaMemorySize(ctx context.Context, targetMgr meta.TargetManagerInterface, collectionID int64) int64 {
+	var size int64
+	for _, segment := range targetMgr.GetSealedSegmentsByCollection(ctx, collectionID, meta.CurrentTargetFirst) {
+		size += SegmentMemorySize(segment)
+	}
+	return size
+}
+
+// EstimateReplicaUsage estimates the resource usage of one replica of the collection by its current or next target.
+func EstimateReplicaUsage(ctx context.Context, targetMgr meta.TargetManagerInterface, collectionID int64) ReplicaUsage {
+	return ReplicaUsage{
+		MemSize:  EstimateReplicaMemorySize(ctx, targetMgr, collectionID),
+		ShardNum: int64(len(targetMgr.GetDmChannelsByCollection(ctx, collectionID, meta.CurrentTargetFirst))),
+	}
+}
+
+// EstimateMemUsedOfResourceGroups estimates the memory usage of the loaded replicas in each resource group.
+func EstimateMemUsedOfResourceGroups(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface) map[string]int64 {
+	memUsed := make(map[string]int64)
+	for _, rgName := range m.ResourceManager.ListResourceGroups(ctx) {
+		memUsed[rgName] = estimateUsageOfResourceGroup(ctx, m, targetMgr, rgName).MemSize
+	}
+	return memUsed
+}
+
+// estimateUsageOfResourceGroup returns the total usage of the loaded replicas in the resource group.
+func estimateUsageOfResourceGroup(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, rgName string) ReplicaUsage {
+	var total ReplicaUsage
+	replicaUsage := make(map[int64]ReplicaUsage)
+	for _, replica := range m.ReplicaManager.GetByResourceGroup(ctx, rgName) {
+		usage, ok := replicaUsage[replica.GetCollectionID()]
+		if !ok {
+			usage = EstimateReplicaUsage(ctx, targetMgr, replica.GetCollectionID())
+			replicaUsage[replica.GetCollectionID()] = usage
+		}
+		total.MemSize += usage.MemSize
+		total.ShardNum += usage.ShardNum
+	}
+	return total
+}
+
+// GetResourceGroupUsage returns the capacity of the nodes and the estimated memory usage of the loaded replicas in given resource group.
+func GetResourceGroupUsage(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, rgName string) (*ResourceGroupUsage, error) {
+	capacity, err := m.ResourceManager.GetCapacityOfNodes(ctx, rgName)
+	if err != nil {
+		return nil, err
+	}
+	usage := estimateUsageOfResourceGroup(ctx, m, targetMgr, rgName)
+	return &ResourceGroupUsage{
+		ResourceGroupCapacity: capacity,
+		MemUsed:               usage.MemSize,
+		ShardNum:              usage.ShardNum,
+	}, nil
+}
+
+// CheckResourceGroupQuota checks whether the resource groups have enough memory and cpu quota for the new replicas,
+// replicaNumInRG is the replica number to load in each resource group, replica is the estimated usage of each replica.
+// The resource groups whose nodes haven't reported their capacity are not checked.
+func CheckResourceGroupQuota(ctx context.Context, m *meta.Meta, targetMgr meta.TargetManagerInterface, replicaNumInRG map[string]int, replica ReplicaUsage) error {
+	if !paramtable.Get().QueryCoordCfg.ResourceGroupQuotaEnabled.GetAsBool() || (replica.MemSize <= 0 && replica.ShardNum <= 0) {
+		return nil
+	}
+
+	for rgName, num := range replicaNumInRG {
+		if num <= 0 {
+			continue
+		}
+		usage, err := GetResourceGroupUsage(ctx, m, targetMgr, rgName)
+		if err != nil {
+			return err
+		}
+		if usage.MemCapacity <= 0 {
+			log.Ctx(ctx).Info("skip checking resource group quota, the capacity of its nodes is unknown", zap.String("rgName", rgName))
+			continue
+		}
+		predict := usage.MemUsed + replica.MemSize*int64(num)
+		if predict > usage.MemQuota {
+			log.Ctx(ctx).Warn("resource group memory quota exceeded",
+				zap.String("rgName", rgName),
+				zap.Int("replicaNum", num),
+				zap.Int64("replicaSize", replica.MemSize),
+				zap.Int64("memUsed", usage.MemUsed),
+				zap.Int64("memQuota", usage.MemQuota))
+			return merr.WrapErrResourceGroupQuotaExceeded(rgName, predict, usage.MemQuota)
+		}
+		if usage.CPUQuota <= 0 {
+			continue
+		}
+		predictShards := usage.ShardNum + replica.ShardNum*int64(num)
+		if predictShards > usage.CPUQuota {
+			log.Ctx(ctx).Warn("resource group cpu quota exceeded",
+				zap.String("rgName", rgName),
+				zap.Int("replicaNum", num),
+				zap.Int64("replicaShardNum", replica.ShardNum),
+				zap.Int64("shardNum", usage.ShardNum),
+				zap.Int64("cpuQuota", usage.CPUQuota))
+			return merr.WrapErrResourceGroupCPUQuotaExceeded(rgName, predictShards, usage.CPUQuota)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/rgpb"
+	etcdKV "github.com/milvus-io/milvus/internal/kv/etcd"
+	"github.com/milvus-io/milvus/internal/metastore/kv/querycoord"
+	"github.com/milvus-io/milvus/internal/querycoordv2/meta"
+	. "github.com/milvus-io/milvus/internal/querycoordv2/params"
+	"github.com/milvus-io/milvus/internal/querycoordv2/session"
+	"github.com/milvus-io/milvus/pkg/v2/proto/datapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/etcd"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestResourceGroupQuota(t *testing.T) {
+	paramtable.Init()
+	config := GenerateEtcdConfig()
+	cli, err := etcd.GetEtcdClient(
+		config.UseEmbedEtcd.GetAsBool(),
+		config.EtcdUseSSL.GetAsBool(),
+		config.Endpoints.GetAsStrings(),
+		config.EtcdTLSCert.GetValue(),
+		config.EtcdTLSKey.GetValue(),
+		config.EtcdTLSCACert.GetValue(),
+		config.EtcdTLSMinVersion.GetValue())
+	require.NoError(t, err)
+	kv := etcdKV.NewEtcdKV(cli, config.MetaRootPath.GetValue())
+	defer kv.Close()
+
+	ctx := context.Background()
+	nodeMgr := session.NewNodeManager()
+	m := meta.NewMeta(RandomIncrementIDAllocator(), querycoord.NewCatalog(kv), nodeMgr)
+	// 2 nodes with 1GB memory and 4 cpus in default resource group
+	for _, nodeID := range []int64{1, 2} {
+		node := session.NewNodeInfo(session.ImmutableNodeInfo{
+			NodeID:   nodeID,
+			Address:  "localhost",
+			Hostname: "localhost",
+		})
+		node.UpdateStats(session.WithMemCapacity(1024), session.WithCPUNum(4))
+		nodeMgr.Add(node)
+		m.ResourceManager.HandleNodeUp(ctx, nodeID)
+	}
+
+	// 2 replicas of collection 1 with 256MB each
+	targetMgr := meta.NewMockTargetManager(t)
+	targetMgr.EXPECT().GetSealedSegmentsByCollection(mock.Anything, int64(1), meta.CurrentTargetFirst).Return(map[int64]*datapb.SegmentInfo{
+		1: {ID: 1, Binlogs: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{{LogSize: 64 << 20, MemorySize: 128 << 20}}}}},
+		2: {ID: 2, Binlogs: []*datapb.FieldBinlog{{FieldID: 100, Binlogs: []*datapb.Binlog{{LogSize: 128 << 20}}}}},
+	})
+	// with 2 shards
+	targetMgr.EXPECT().GetDmChannelsByCollection(mock.Anything, int64(1), meta.CurrentTargetFirst).Return(map[string]*meta.DmChannel{
+		"ch1": {}, "ch2": {},
+	})
+	require.NoError(t, m.ReplicaManager.Put(ctx, CreateTestReplica(1, 1, []int64{1}), CreateTestReplica(2, 1, []int64{2})))
+
+	usage, err := GetResourceGroupUsage(ctx, m, targetMgr, meta.DefaultResourceGroupName)
+	assert.NoError(t, err)
+	memCapacity := int64(2048 << 20)
+	assert.Equal(t, memCapacity, usage.MemCapacity)
+	assert.Equal(t, int64(float64(memCapacity)*0.9), usage.MemQuota)
+	assert.Equal(t, int64(512<<20), usage.MemUsed)
+	assert.Equal(t, int64(8), usage.CPUNum)
+	assert.Equal(t, int64(0), usage.CPUQuota)
+	assert.Equal(t, int64(4), usage.ShardNum)
+	assert.Equal(t, map[string]int64{meta.DefaultResourceGroupName: 512 << 20}, EstimateMemUsedOfResourceGroups(ctx, m, targetMgr))
+
+	_, err = GetResourceGroupUsage(ctx, m, targetMgr, "rg1")
+	assert.ErrorIs(t, err, merr.ErrResourceGroupNotFound)
+
+	// quota is disabled by default
+	replicaNumInRG := map[string]int{meta.DefaultResourceGroupName: 2}
+	assert.NoError(t, CheckResourceGroupQuota(ctx, m, targetMgr, replicaNumInRG, ReplicaUsage{MemSize: 1024 << 20}))
+
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.ResourceGroupQuotaEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.ResourceGroupQuotaEnabled.Key)
+	assert.NoError(t, CheckResourceGroupQuota(ctx, m, targetMgr, replicaNumInRG, ReplicaUsage{MemSize: 512 << 20, ShardNum: 8}))
+	err = CheckResourceGroupQuota(ctx, m, targetMgr, replicaNumInRG, ReplicaUsage{MemSize: 1024 << 20})
+	assert.ErrorIs(t, err, merr.ErrResourceGroupQuotaExceeded)
+	err = CheckResourceGroupQuota(ctx, m, targetMgr, map[string]int{"rg1": 1}, ReplicaUsage{MemSize: 1})
+	assert.ErrorIs(t, err, merr.ErrResourceGroupNotFound)
+
+	// 8 cpus allow 16 shards, 4 of them are loaded
+	paramtable.Get().Save(paramtable.Get().QueryCoordCfg.ResourceGroupShardsPerCPUQuota.Key, "2")
+	defer paramtable.Get().Reset(paramtable.Get().QueryCoordCfg.ResourceGroupShardsPerCPUQuota.Key)
+	assert.NoError(t, CheckResourceGroupQuota(ctx, m, targetMgr, replicaNumInRG, ReplicaUsage{MemSize: 1, ShardNum: 6}))
+	err = CheckResourceGroupQuota(ctx, m, targetMgr, replicaNumInRG, ReplicaUsage{MemSize: 1, ShardNum: 7})
+	assert.ErrorIs(t, err, merr.ErrResourceGroupQuotaExceeded)
+
+	// the resource group whose nodes haven't reported the capacity is not checked
+	require.NoError(t, m.ResourceManager.AddResourceGroup(ctx, "rg2", &rgpb.ResourceGroupConfig{
+		Requests: &rgpb.ResourceGroupLimit{NodeNum: 1},
+		Limits:   &rgpb.ResourceGroupLimit{NodeNum: 1},
+	}))
+	nodeMgr.Add(session.NewNodeInfo(session.ImmutableNodeInfo{
+		NodeID:   3,
+		Address:  "localhost",
+		Hostname: "localhost",
+	}))
+	m.ResourceManager.HandleNodeUp(ctx, 3)
+	nodes, err := m.ResourceManager.GetNodes(ctx, "rg2")
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, nodes)
+	usage, err = GetResourceGroupUsage(ctx, m, targetMgr, "rg2")
+	assert.NoError(t, err)
+	assert.Zero(t, usage.MemCapacity)
+	assert.Zero(t, usage.CPUNum)
+	assert.NoError(t, CheckResourceGroupQuota(ctx, m, targetMgr, map[string]int{"rg2": 1}, ReplicaUsage{MemSize: 1, ShardNum: 1}))
+}
@@ -48,6 +48,7 @@ import (
 	"github.com/milvus-io/milvus/internal/util/function"
 	"github.com/milvus-io/milvus/internal/util/reduce"
 	"github.com/milvus-io/milvus/internal/util/searchutil/optimizers"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/log"
@@ -91,6 +92,7 @@ type ShardDelegator interface {
 	SyncTargetVersion(action *querypb.SyncAction, partitions []int64)
 	GetChannelQueryView() *channelQueryView
 	GetDeleteBufferSize() (entryNum int64, memorySize int64)
+	GetWorkload() ChannelWorkload
 	DropIndex(ctx context.Context, req *querypb.DropIndexRequest) error
 
 	// manage exclude segments
@@ -129,6 +131,7 @@ type shardDelegator struct {
 
 	distribution *distribution
 	idfOracle    IDFOracle
+	workload     workloadTracker
 
 	segmentManager segments.SegmentManager
 	pkOracle       pkoracle.PkOracle
@@ -341,6 +344,9 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 		zap.Int("sealedNum", sealedNum),
 		zap.Int("growingNum", len(growing)),
 	)
+	stage := profile.FromContext(ctx)
+	stage.SetAttr("sealed_segment_num", sealedNum)
+	stage.SetAttr("growing_segment_num", len(growing))
 
 	req, err := optimizers.OptimizeSearchParams(ctx, req, sd.queryHook, sealedNum)
 	if err != nil {
@@ -365,6 +371,10 @@ func (sd *shardDelegator) search(ctx context.Context, req *querypb.SearchRequest
 		return nil, err
 	}
 
+	for _, result := range results {
+		stage.AddChild(profile.Extract(result.GetStatus()))
+	}
+
 	log.Debug("Delegator search done", zap.Int("results", len(results)))
 
 	return results, nil
@@ -377,6 +387,7 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 		return nil, err
 	}
 	defer sd.lifetime.Done()
+	defer sd.workload.begin()()
 
 	if !funcutil.SliceContain(req.GetDmlChannels(), sd.vchannelName) {
 		log.Warn("delegator received search request not belongs to it",
@@ -414,9 +425,11 @@ func (sd *shardDelegator) Search(ctx context.Context, req *querypb.SearchRequest
 		}
 	}
 
+	waitTSafeDuration := waitTr.ElapseSpan()
 	metrics.QueryNodeSQLatencyWaitTSafe.WithLabelValues(
 		fmt.Sprint(paramtable.GetNodeID()), metrics.SearchLabel).
-		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
+		Observe(float64(waitTSafeDuration.Milliseconds()))
+	profile.FromContext(ctx).SetAttr("wait_tsafe_us", waitTSafeDuration.Microseconds())
 
 	sealed, growing, sealedRowCount, version, err := sd.distribution.PinReadableSegments(partialResultRequiredDataRatio, req.GetReq().GetPartitionIDs()...)
 	if err != nil {
@@ -583,6 +596,7 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		return nil, err
 	}
 	defer sd.lifetime.Done()
+	defer sd.workload.begin()()
 
 	if !funcutil.SliceContain(req.GetDmlChannels(), sd.vchannelName) {
 		log.Warn("delegator received query request not belongs to it",
@@ -619,9 +633,11 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		}
 	}
 
+	waitTSafeDuration := waitTr.ElapseSpan()
 	metrics.QueryNodeSQLatencyWaitTSafe.WithLabelValues(
 		fmt.Sprint(paramtable.GetNodeID()), metrics.QueryLabel).
-		Observe(float64(waitTr.ElapseSpan().Milliseconds()))
+		Observe(float64(waitTSafeDuration.Milliseconds()))
+	profile.FromContext(ctx).SetAttr("wait_tsafe_us", waitTSafeDuration.Microseconds())
 
 	sealed, growing, sealedRowCount, version, err := sd.distribution.PinReadableSegments(partialResultRequiredDataRatio, req.GetReq().GetPartitionIDs()...)
 	if err != nil {
@@ -648,6 +664,9 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		zap.Int("sealedNum", sealedNum),
 		zap.Int("growingNum", len(growing)),
 	)
+	stage := profile.FromContext(ctx)
+	stage.SetAttr("sealed_segment_num", sealedNum)
+	stage.SetAttr("growing_segment_num", len(growing))
 	tasks, err := organizeSubTask(ctx, req, sealed, growing, sd, true, sd.modifyQueryRequest)
 	if err != nil {
 		log.Warn("query organizeSubTask failed", zap.Error(err))
@@ -667,6 +686,10 @@ func (sd *shardDelegator) Query(ctx context.Context, req *querypb.QueryRequest)
 		return nil, err
 	}
 
+	for _, result := range results {
+		stage.AddChild(profile.Extract(result.GetStatus()))
+	}
+
 	log.Debug("Delegator Query done")
 	if log.Core().Enabled(zap.DebugLevel) {
 		sealedIDs := lo.FlatMap(sealed, func(item SnapshotItem, _ int) []int64 {
@@ -745,6 +768,11 @@ func (sd *shardDelegator) GetDeleteBufferSize() (entryNum int64, memorySize int6
 	return sd.deleteBuffer.Size()
 }
 
+// GetWorkload returns the recent read workload of the channel.
+func (sd *shardDelegator) GetWorkload() ChannelWorkload {
+	return sd.workload.get()
+}
+
 type subTask[T any] struct {
 	req      T
 	targetID int64
@@ -511,10 +511,10 @@ func (sd *shardDelegator) LoadSegments(ctx context.Context, req *querypb.LoadSeg
 		return err
 	}
 
-	return sd.addDistributionIfVersionOK(req.GetLoadMeta().GetSchemaVersion(), entries...)
+	return sd.addDistributionIfVersionOK(req.GetLoadMeta().GetSchemaVersion(), req.GetHotCopy(), entries...)
 }
 
-func (sd *shardDelegator) addDistributionIfVersionOK(version uint64, entries ...SegmentEntry) error {
+func (sd *shardDelegator) addDistributionIfVersionOK(version uint64, hotCopy bool, entries ...SegmentEntry) error {
 	sd.schemaChangeMutex.Lock()
 	defer sd.schemaChangeMutex.Unlock()
 	if version < sd.schemaVersion {
@@ -522,6 +522,11 @@ func (sd *shardDelegator) addDistributionIfVersionOK(version uint64, entries ...
 	}
 
 	// alter distribution
+	if hotCopy {
+		// the extra copy shares the reads with the serving one, instead of replacing it
+		sd.distribution.AddHotCopies(entries...)
+		return nil
+	}
 	sd.distribution.AddDistributions(entries...)
 	return nil
 }
@@ -17,6 +17,7 @@
 package delegator
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/samber/lo"
@@ -110,6 +111,11 @@ type distribution struct {
 	// map[SegmentID]=>segmentEntry
 	growingSegments map[UniqueID]SegmentEntry
 	sealedSegments  map[UniqueID]SegmentEntry
+	// hotCopies stores the temporary extra copies of the hot sealed segments on the other nodes,
+	// the reads of a segment are spread among the copies and the one in sealedSegments.
+	// map[SegmentID]=>map[NodeID]=>segmentEntry
+	hotCopies     map[UniqueID]map[int64]SegmentEntry
+	hotCopyCursor *atomic.Int64
 
 	// snapshotVersion indicator
 	snapshotVersion int64
@@ -143,6 +149,8 @@ func NewDistribution(channelName string, queryView *channelQueryView) *distribut
 		channelName:     channelName,
 		growingSegments: make(map[UniqueID]SegmentEntry),
 		sealedSegments:  make(map[UniqueID]SegmentEntry),
+		hotCopies:       make(map[UniqueID]map[int64]SegmentEntry),
+		hotCopyCursor:   atomic.NewInt64(0),
 		snapshots:       typeutil.NewConcurrentMap[int64, *snapshot](),
 		current:         atomic.NewPointer[snapshot](nil),
 		queryView:       queryView,
@@ -213,6 +221,8 @@ func (d *distribution) PinReadableSegments(requiredLoadRatio float64, partitions
 		})
 	}
 
+	sealed = d.spreadHotCopies(sealed)
+
 	if len(d.queryView.unloadedSealedSegments) > 0 {
 		// append distribution of unloaded segment
 		sealed = append(sealed, SnapshotItem{
@@ -224,6 +234,39 @@ func (d *distribution) PinReadableSegments(requiredLoadRatio float64, partitions
 	return
 }
 
+// spreadHotCopies routes the reads of the segments which have hot copies to the copies in turn.
+// mutex RLock is required before calling this method.
+func (d *distribution) spreadHotCopies(sealed []SnapshotItem) []SnapshotItem {
+	if len(d.hotCopies) == 0 {
+		return sealed
+	}
+
+	cursor := d.hotCopyCursor.Inc()
+	nodeSegments := make(map[int64][]SegmentEntry)
+	for _, item := range sealed {
+		for _, entry := range item.Segments {
+			nodeID := item.NodeID
+			if copies := d.hotCopies[entry.SegmentID]; len(copies) > 0 && !entry.Offline {
+				copyNodes := lo.Keys(copies)
+				sort.Slice(copyNodes, func(i, j int) bool { return copyNodes[i] < copyNodes[j] })
+				nodes := append([]int64{item.NodeID}, copyNodes...)
+				nodeID = nodes[(cursor+entry.SegmentID)%int64(len(nodes))]
+				entry.NodeID = nodeID
+			}
+			nodeSegments[nodeID] = append(nodeSegments[nodeID], entry)
+		}
+	}
+
+	ret := make([]SnapshotItem, 0, len(nodeSegments))
+	for nodeID, entries := range nodeSegments {
+		ret = append(ret, SnapshotItem{
+			NodeID:   nodeID,
+			Segments: entries,
+		})
+	}
+	return ret
+}
+
 func (d *distribution) PinOnlineSegments(partitions ...int64) (sealed []SnapshotItem, growing []SegmentEntry, version int64) {
 	d.mut.RLock()
 	defer d.mut.RUnlock()
@@ -352,12 +395,56 @@ func (d *distribution) AddDistributions(entries ...SegmentEntry) {
 			entry.TargetVersion = unreadableTargetVersion
 		}
 		d.sealedSegments[entry.SegmentID] = entry
+		// the hot copy on the same node becomes the serving one
+		d.removeHotCopy(entry.SegmentID, entry.NodeID)
 	}
 
 	d.genSnapshot()
 	d.updateServiceable("AddDistributions")
 }
 
+// AddHotCopies adds the temporary extra copies of the sealed segments,
+// which share the reads of the segments with the serving ones.
+func (d *distribution) AddHotCopies(entries ...SegmentEntry) {
+	d.mut.Lock()
+	defer d.mut.Unlock()
+
+	for _, entry := range entries {
+		if serving, ok := d.sealedSegments[entry.SegmentID]; ok && serving.NodeID == entry.NodeID {
+			continue
+		}
+		copies, ok := d.hotCopies[entry.SegmentID]
+		if !ok {
+			copies = make(map[int64]SegmentEntry)
+			d.hotCopies[entry.SegmentID] = copies
+		}
+		copies[entry.NodeID] = entry
+	}
+
+	log.Info("add hot copies to distribution",
+		zap.String("channelName", d.channelName),
+		zap.Int64s("segmentIDs", lo.Map(entries, func(s SegmentEntry, _ int) int64 { return s.SegmentID })),
+		zap.Int64s("nodeIDs", lo.Map(entries, func(s SegmentEntry, _ int) int64 { return s.NodeID })),
+	)
+}
+
+// removeHotCopy removes the hot copy of the segment on the node, wildcardNodeID removes all copies of the segment.
+// mutex Lock is required before calling this method.
+func (d *distribution) removeHotCopy(segmentID int64, nodeID int64) {
+	copies, ok := d.hotCopies[segmentID]
+	if !ok {
+		return
+	}
+	if nodeID == wildcardNodeID {
+		delete(d.hotCopies, segmentID)
+		return
+	}
+	delete(copies, nodeID)
+	if len(copies) == 0 {
+		delete(d.hotCopies, segmentID)
+	}
+}
+
 // AddGrowing adds growing segment distribution.
 func (d *distribution) AddGrowing(entries ...SegmentEntry) {
 	d.mut.Lock()
@@ -382,6 +469,8 @@ func (d *distribution) MarkOfflineSegments(segmentIDs ...int64) {
 			continue
 		}
 		updated = true
+		// the failed node is unknown, drop the hot copies as well
+		d.removeHotCopy(segmentID, wildcardNodeID)
 		entry.Offline = true
 		entry.Version = unreadableTargetVersion
 		entry.NodeID = -1
@@ -486,6 +575,7 @@ func (d *distribution) RemoveDistributions(sealedSegments []SegmentEntry, growin
 	defer d.mut.Unlock()
 
 	for _, sealed := range sealedSegments {
+		d.removeHotCopy(sealed.SegmentID, sealed.NodeID)
 		entry, ok := d.sealedSegments[sealed.SegmentID]
 		if !ok {
 			continue
@@ -1583,3 +1583,60 @@ func TestPinReadableSegments_EdgeCases(t *testing.T) {
 	assert.NotNil(t, sealed)
 	assert.NotNil(t, growing)
 }
+
+func TestDistribution_HotCopies(t *testing.T) {
+	queryView := NewChannelQueryView(nil, nil, []int64{1}, initialTargetVersion)
+	dist := NewDistribution("test-channel", queryView)
+	dist.AddDistributions(
+		SegmentEntry{NodeID: 1, SegmentID: 1, PartitionID: 1, Version: 1},
+		SegmentEntry{NodeID: 1, SegmentID: 2, PartitionID: 1, Version: 1},
+	)
+	dist.SyncTargetVersion(&querypb.SyncAction{
+		TargetVersion:         1000,
+		SealedSegmentRowCount: map[int64]int64{1: 100, 2: 100},
+	}, []int64{1})
+
+	// the copy on the serving node is ignored
+	dist.AddHotCopies(SegmentEntry{NodeID: 1, SegmentID: 1, PartitionID: 1, Version: 2})
+	assert.Empty(t, dist.hotCopies)
+
+	// the reads of segment 1 are spread between node 1 and node 2 in turn
+	dist.AddHotCopies(SegmentEntry{NodeID: 2, SegmentID: 1, PartitionID: 1, Version: 2})
+	nodes := make(map[int64]struct{})
+	for i := 0; i < 4; i++ {
+		sealed, _, _, version, err := dist.PinReadableSegments(1.0)
+		assert.NoError(t, err)
+		for _, item := range sealed {
+			for _, entry := range item.Segments {
+				assert.Equal(t, item.NodeID, entry.NodeID)
+				if entry.SegmentID == 1 {
+					nodes[item.NodeID] = struct{}{}
+				} else {
+					assert.EqualValues(t, 1, item.NodeID)
+				}
+			}
+		}
+		dist.Unpin(version)
+	}
+	assert.ElementsMatch(t, []int64{1, 2}, lo.Keys(nodes))
+
+	// releasing the copy keeps the serving one
+	<-dist.RemoveDistributions([]SegmentEntry{{NodeID: 2, SegmentID: 1}}, nil)
+	assert.Empty(t, dist.hotCopies)
+	sealed, _, _, version, err := dist.PinReadableSegments(1.0)
+	assert.NoError(t, err)
+	assert.Len(t, sealed, 1)
+	assert.EqualValues(t, 1, sealed[0].NodeID)
+	assert.Len(t, sealed[0].Segments, 2)
+	dist.Unpin(version)
+
+	// the copy becomes the serving one when the segment is loaded on the same node
+	dist.AddHotCopies(SegmentEntry{NodeID: 2, SegmentID: 2, PartitionID: 1, Version: 2})
+	dist.AddDistributions(SegmentEntry{NodeID: 2, SegmentID: 2, PartitionID: 1, Version: 3})
+	assert.Empty(t, dist.hotCopies)
+
+	// the copies are dropped with the offline segment
+	dist.AddHotCopies(SegmentEntry{NodeID: 3, SegmentID: 1, PartitionID: 1, Version: 4})
+	dist.MarkOfflineSegments(1)
+	assert.Empty(t, dist.hotCopies)
+}
@@ -501,6 +501,51 @@ func (_c *MockShardDelegator_GetTSafe_Call) RunAndReturn(run func() uint64) *Moc
 	return _c
 }
 
+// GetWorkload provides a mock function with no fields
+func (_m *MockShardDelegator) GetWorkload() ChannelWorkload {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkload")
+	}
+
+	var r0 ChannelWorkload
+	if rf, ok := ret.Get(0).(func() ChannelWorkload); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(ChannelWorkload)
+	}
+
+	return r0
+}
+
+// MockShardDelegator_GetWorkload_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkload'
+type MockShardDelegator_GetWorkload_Call struct {
+	*mock.Call
+}
+
+// GetWorkload is a helper method to define mock.On call
+func (_e *MockShardDelegator_Expecter) GetWorkload() *MockShardDelegator_GetWorkload_Call {
+	return &MockShardDelegator_GetWorkload_Call{Call: _e.mock.On("GetWorkload")}
+}
+
+func (_c *MockShardDelegator_GetWorkload_Call) Run(run func()) *MockShardDelegator_GetWorkload_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockShardDelegator_GetWorkload_Call) Return(_a0 ChannelWorkload) *MockShardDelegator_GetWorkload_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockShardDelegator_GetWorkload_Call) RunAndReturn(run func() ChannelWorkload) *MockShardDelegator_GetWorkload_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // LoadGrowing provides a mock function with given fields: ctx, infos, version
 func (_m *MockShardDelegator) LoadGrowing(ctx context.Context, infos []*querypb.SegmentLoadInfo, version int64) error {
 	ret := _m.Called(ctx, infos, version)
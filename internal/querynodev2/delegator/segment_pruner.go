@@ -16,6 +16,7 @@ import (
 	"github.com/milvus-io/milvus/internal/storage"
 	"github.com/milvus-io/milvus/internal/util/clustering"
 	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/pkg/v2/common"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
@@ -181,6 +182,9 @@ func PruneSegments(ctx context.Context,
 				fmt.Sprint(collectionID),
 				pruneType,
 			).Set(float64(filterRatio))
+		stage := profile.FromContext(ctx)
+		stage.SetAttr("pruned_segment_num", realFilteredSegments)
+		stage.SetAttr("prune_type", pruneType)
 		log.Ctx(ctx).Debug("Pruned segment for search/query",
 			zap.Int("filtered_segment_num[stats]", len(filteredSegments)),
 			zap.Int("filtered_segment_num[excluded]", realFilteredSegments),
@@ -0,0 +1,79 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	// weight of the latest sample in the moving average of the latency
+	workloadLatencyWeight = 0.2
+	// the latency is reset if there is no request on the channel for a while
+	workloadLatencyExpiration = time.Minute
+)
+
+// ChannelWorkload is the recent read workload of the channel served by the delegator.
+type ChannelWorkload struct {
+	// Inflight is the number of the search and query requests executing on the channel.
+	Inflight int64
+	// Latency is the moving average of the request latency on the channel.
+	Latency time.Duration
+}
+
+// workloadTracker tracks the read workload of the channel.
+type workloadTracker struct {
+	inflight    atomic.Int64
+	latency     atomic.Int64 // in nanoseconds
+	lastRequest atomic.Int64 // unix nanoseconds
+}
+
+// begin records the start of a request, and returns the function to record the end of it.
+func (w *workloadTracker) begin() func() {
+	w.inflight.Inc()
+	start := time.Now()
+	return func() {
+		w.inflight.Dec()
+		w.observe(time.Since(start))
+	}
+}
+
+func (w *workloadTracker) observe(latency time.Duration) {
+	w.lastRequest.Store(time.Now().UnixNano())
+	for {
+		old := w.latency.Load()
+		updated := int64(latency)
+		if old > 0 {
+			updated = int64(float64(old)*(1-workloadLatencyWeight) + float64(latency)*workloadLatencyWeight)
+		}
+		if w.latency.CompareAndSwap(old, updated) {
+			return
+		}
+	}
+}
+
+func (w *workloadTracker) get() ChannelWorkload {
+	workload := ChannelWorkload{
+		Inflight: w.inflight.Load(),
+	}
+	if time.Since(time.Unix(0, w.lastRequest.Load())) < workloadLatencyExpiration {
+		workload.Latency = time.Duration(w.latency.Load())
+	}
+	return workload
+}
@@ -0,0 +1,48 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package delegator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadTracker(t *testing.T) {
+	w := &workloadTracker{}
+	assert.Equal(t, ChannelWorkload{}, w.get())
+
+	done1 := w.begin()
+	done2 := w.begin()
+	assert.EqualValues(t, 2, w.get().Inflight)
+	done1()
+	assert.EqualValues(t, 1, w.get().Inflight)
+	done2()
+	assert.EqualValues(t, 0, w.get().Inflight)
+
+	// the latency is the moving average of the samples
+	w = &workloadTracker{}
+	w.observe(100 * time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, w.get().Latency)
+	w.observe(200 * time.Millisecond)
+	assert.InDelta(t, float64(120*time.Millisecond), float64(w.get().Latency), float64(time.Microsecond))
+
+	// the latency expires without requests
+	w.lastRequest.Store(time.Now().Add(-2 * workloadLatencyExpiration).UnixNano())
+	assert.Zero(t, w.get().Latency)
+}
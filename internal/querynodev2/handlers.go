@@ -30,6 +30,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
 	"github.com/milvus-io/milvus/internal/querynodev2/tasks"
 	"github.com/milvus-io/milvus/internal/util/reduce"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
@@ -240,6 +241,8 @@ func (node *QueryNode) queryChannel(ctx context.Context, req *querypb.QueryReque
 	// add cancel when error occurs
 	queryCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	queryCtx, stage := profile.Start(queryCtx, req.GetReq().GetBase(), profile.DelegatorStage, node.GetNodeID())
+	stage.SetAttr("channel", channel)
 
 	// From Proxy
 	tr := timerecord.NewTimeRecorder("queryDelegator")
@@ -277,10 +280,21 @@ func (node *QueryNode) queryChannel(ctx context.Context, req *querypb.QueryReque
 
 	reducer := segments.CreateInternalReducer(req, collection.Schema())
 
-	resp, err := reducer.Reduce(ctx, results)
+	resp, err := func() (*internalpb.RetrieveResults, error) {
+		reduceStage := stage.NewChild(profile.ReduceStage, node.GetNodeID())
+		defer reduceStage.Done()
+		return reducer.Reduce(ctx, results)
+	}()
 	if err != nil {
 		return nil, err
 	}
+	resp.CostAggregation = node.attachWorkload(resp.GetCostAggregation(), sd)
+	if stage != nil {
+		if resp.Status == nil {
+			resp.Status = merr.Success()
+		}
+		profile.Attach(resp.Status, stage)
+	}
 
 	tr.CtxElapse(ctx, fmt.Sprintf("do query with channel done , vChannel = %s, segmentIDs = %v",
 		channel,
@@ -379,6 +393,20 @@ func (node *QueryNode) queryStreamSegments(ctx context.Context, req *querypb.Que
 	return nil
 }
 
+// attachWorkload piggybacks the workload of the channel and the read task scheduler on the cost of the result,
+// which is used by proxy to select the replica.
+func (node *QueryNode) attachWorkload(cost *internalpb.CostAggregation, sd delegator.ShardDelegator) *internalpb.CostAggregation {
+	if cost == nil {
+		cost = &internalpb.CostAggregation{}
+	}
+	workload := sd.GetWorkload()
+	cost.ChannelInflight = workload.Inflight
+	cost.ChannelLatency = workload.Latency.Milliseconds()
+	cost.WaitingTaskNum = node.scheduler.GetWaitingTaskTotal()
+	cost.ExecutingTaskNum = node.scheduler.GetExecutingTaskTotal()
+	return cost
+}
+
 func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchRequest, channel string) (*internalpb.SearchResults, error) {
 	log := log.Ctx(ctx).With(
 		zap.Int64("msgID", req.GetReq().GetBase().GetMsgID()),
@@ -407,6 +435,8 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 	)
 	searchCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	searchCtx, stage := profile.Start(searchCtx, req.GetReq().GetBase(), profile.DelegatorStage, node.GetNodeID())
+	stage.SetAttr("channel", channel)
 
 	// From Proxy
 	tr := timerecord.NewTimeRecorder("searchDelegator")
@@ -431,13 +461,24 @@ func (node *QueryNode) searchChannel(ctx context.Context, req *querypb.SearchReq
 		req.GetSegmentIDs(),
 	))
 
-	resp, err := segments.ReduceSearchOnQueryNode(ctx, results,
-		reduce.NewReduceSearchResultInfo(req.GetReq().GetNq(),
-			req.GetReq().GetTopk()).WithMetricType(req.GetReq().GetMetricType()).WithGroupByField(req.GetReq().GetGroupByFieldId()).
-			WithGroupSize(req.GetReq().GetGroupSize()).WithAdvance(req.GetReq().GetIsAdvanced()))
+	resp, err := func() (*internalpb.SearchResults, error) {
+		reduceStage := stage.NewChild(profile.ReduceStage, node.GetNodeID())
+		defer reduceStage.Done()
+		return segments.ReduceSearchOnQueryNode(ctx, results,
+			reduce.NewReduceSearchResultInfo(req.GetReq().GetNq(),
+				req.GetReq().GetTopk()).WithMetricType(req.GetReq().GetMetricType()).WithGroupByField(req.GetReq().GetGroupByFieldId()).
+				WithGroupSize(req.GetReq().GetGroupSize()).WithAdvance(req.GetReq().GetIsAdvanced()))
+	}()
 	if err != nil {
 		return nil, err
 	}
+	resp.CostAggregation = node.attachWorkload(resp.GetCostAggregation(), sd)
+	if stage != nil {
+		if resp.Status == nil {
+			resp.Status = merr.Success()
+		}
+		profile.Attach(resp.Status, stage)
+	}
 
 	tr.CtxElapse(ctx, fmt.Sprintf("do search with channel done , vChannel = %s, segmentIDs = %v",
 		channel,
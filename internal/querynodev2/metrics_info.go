@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/samber/lo"
 	"go.uber.org/zap"
@@ -228,7 +229,7 @@ func getSegmentJSON(node *QueryNode, collectionID int64) string {
 			})
 		}
 
-		ms = append(ms, &metricsinfo.Segment{
+		segment := &metricsinfo.Segment{
 			SegmentID:            s.ID(),
 			CollectionID:         s.Collection(),
 			PartitionID:          s.Partition(),
@@ -238,7 +239,14 @@ func getSegmentJSON(node *QueryNode, collectionID int64) string {
 			ResourceGroup:        s.ResourceGroup(),
 			LoadedInsertRowCount: s.InsertCount(),
 			NodeID:               node.GetNodeID(),
-		})
+		}
+		if lastAccess, fields, ok := node.manager.Evictor.LastAccess(s.ID()); ok {
+			segment.LastAccessTime = lastAccess.Format(time.DateTime)
+			segment.FieldsLastAccessTime = lo.MapValues(fields, func(t time.Time, _ int64) string {
+				return t.Format(time.DateTime)
+			})
+		}
+		ms = append(ms, segment)
 	}
 
 	ret, err := json.Marshal(ms)
@@ -0,0 +1,369 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/hardware"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// segmentAccess is the access recency of a segment and its fields.
+type segmentAccess struct {
+	lastAccess time.Time
+	fields     map[int64]time.Time
+}
+
+// EvictionStats is the statistics of the segment accesses and the evictions.
+type EvictionStats struct {
+	HitCount   int64
+	MissCount  int64
+	EvictCount int64
+	EvictBytes int64
+}
+
+// demoteGracePeriod is the time to wait before evicting a demoted segment,
+// the accesses which have checked the lazy load state before the demotion are pinned on the segment within it.
+const demoteGracePeriod = time.Second
+
+// demotableSegment is the segment which can be turned from loaded into the lazy load state.
+type demotableSegment interface {
+	isDataLoaded() bool
+	isPinned() bool
+	markLazyLoad()
+}
+
+// Evictor tracks the segment and field accesses of search and retrieve,
+// and evicts the data of the least recently accessed sealed segments when the memory usage exceeds the high watermark.
+// The lazy load segments are evicted from the disk cache, the data of all their fields and indexes is released.
+// The loaded segments are demoted first: they are put into the disk cache and turned into the lazy load state,
+// then evicted like the lazy load segments after the grace period, and loaded again by the disk cache on the next access.
+type Evictor struct {
+	manager *Manager
+
+	mu       sync.Mutex
+	accesses map[int64]*segmentAccess
+	demoted  map[int64]time.Time
+
+	usedMemory  func() uint64
+	totalMemory func() uint64
+
+	hitCount   atomic.Int64
+	missCount  atomic.Int64
+	evictCount atomic.Int64
+	evictBytes atomic.Int64
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewEvictor(manager *Manager) *Evictor {
+	return &Evictor{
+		manager:     manager,
+		accesses:    make(map[int64]*segmentAccess),
+		demoted:     make(map[int64]time.Time),
+		usedMemory:  hardware.GetUsedMemoryCount,
+		totalMemory: hardware.GetMemoryCount,
+		closeCh:     make(chan struct{}),
+	}
+}
+
+// Record records an access of the segment through the disk cache, missing is true if the segment data is not in memory.
+// The accesses are ignored if the evictor is nil, which happens in the tests building the manager directly.
+func (e *Evictor) Record(segmentID int64, missing bool, fieldIDs ...int64) {
+	if e == nil {
+		return
+	}
+	e.Touch(segmentID, fieldIDs...)
+
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	if missing {
+		e.missCount.Inc()
+		metrics.QueryNodeSegmentCacheAccessTotal.WithLabelValues(nodeID, metrics.CacheMissLabel).Inc()
+	} else {
+		e.hitCount.Inc()
+		metrics.QueryNodeSegmentCacheAccessTotal.WithLabelValues(nodeID, metrics.CacheHitLabel).Inc()
+	}
+}
+
+// Touch records an access of the loaded segment which doesn't go through the disk cache,
+// it refreshes the access recency without counting the cache hit.
+func (e *Evictor) Touch(segmentID int64, fieldIDs ...int64) {
+	if e == nil {
+		return
+	}
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	access, ok := e.accesses[segmentID]
+	if !ok {
+		access = &segmentAccess{fields: make(map[int64]time.Time)}
+		e.accesses[segmentID] = access
+	}
+	access.lastAccess = now
+	for _, fieldID := range fieldIDs {
+		access.fields[fieldID] = now
+	}
+}
+
+// RecordFields records the accesses of the fields of the segment, without counting the segment access.
+func (e *Evictor) RecordFields(segmentID int64, fieldIDs ...int64) {
+	if e == nil || len(fieldIDs) == 0 {
+		return
+	}
+	now := time.Now()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	access, ok := e.accesses[segmentID]
+	if !ok {
+		access = &segmentAccess{lastAccess: now, fields: make(map[int64]time.Time)}
+		e.accesses[segmentID] = access
+	}
+	for _, fieldID := range fieldIDs {
+		access.fields[fieldID] = now
+	}
+}
+
+// Remove forgets the accesses of the released segment.
+func (e *Evictor) Remove(segmentID int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.accesses, segmentID)
+	delete(e.demoted, segmentID)
+}
+
+// LastAccess returns the last access time of the segment and its fields, ok is false if it's never accessed.
+func (e *Evictor) LastAccess(segmentID int64) (lastAccess time.Time, fields map[int64]time.Time, ok bool) {
+	if e == nil {
+		return time.Time{}, nil, false
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	access, ok := e.accesses[segmentID]
+	if !ok {
+		return time.Time{}, nil, false
+	}
+	fields = make(map[int64]time.Time, len(access.fields))
+	for fieldID, t := range access.fields {
+		fields[fieldID] = t
+	}
+	return access.lastAccess, fields, true
+}
+
+func (e *Evictor) Stats() EvictionStats {
+	return EvictionStats{
+		HitCount:   e.hitCount.Load(),
+		MissCount:  e.missCount.Load(),
+		EvictCount: e.evictCount.Load(),
+		EvictBytes: e.evictBytes.Load(),
+	}
+}
+
+// Start starts the background eviction.
+func (e *Evictor) Start() {
+	e.startOnce.Do(func() {
+		e.wg.Add(1)
+		go e.loop()
+	})
+}
+
+func (e *Evictor) Stop() {
+	if e == nil {
+		return
+	}
+	e.stopOnce.Do(func() {
+		close(e.closeCh)
+		e.wg.Wait()
+	})
+}
+
+func (e *Evictor) loop() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(paramtable.Get().QueryNodeCfg.LazyLoadEvictionCheckInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-e.closeCh:
+			log.Info("segment evictor stopped")
+			return
+		case <-ticker.C:
+			if !paramtable.Get().QueryNodeCfg.LazyLoadEvictionEnabled.GetAsBool() {
+				continue
+			}
+			e.evict(context.Background(), false)
+		}
+	}
+}
+
+// EvictForLoading evicts the cold segments for the segments to be loaded when the memory is not enough,
+// it evicts until the memory usage is under the low watermark even if the high watermark is not reached.
+// Returns the number of the evicted segments.
+func (e *Evictor) EvictForLoading(ctx context.Context) int {
+	if e == nil || !paramtable.Get().QueryNodeCfg.LazyLoadEvictionEnabled.GetAsBool() {
+		return 0
+	}
+	evicted, demoted := e.evict(ctx, true)
+	if demoted == 0 {
+		return evicted
+	}
+	// the demoted segments are evictable after the grace period
+	select {
+	case <-ctx.Done():
+		return evicted
+	case <-time.After(demoteGracePeriod):
+	}
+	more, _ := e.evict(ctx, true)
+	return evicted + more
+}
+
+// evict evicts the least recently accessed sealed segments until the memory usage is under the low watermark,
+// the loaded segments are demoted and evicted in the later rounds.
+// Returns the number of the evicted and demoted segments.
+func (e *Evictor) evict(ctx context.Context, force bool) (evicted int, demoted int) {
+	total := e.totalMemory()
+	if total == 0 {
+		return 0, 0
+	}
+	used := e.usedMemory()
+	high := paramtable.Get().QueryNodeCfg.LazyLoadEvictionHighWatermark.GetAsFloat()
+	low := paramtable.Get().QueryNodeCfg.LazyLoadEvictionLowWatermark.GetAsFloat()
+	if !force && float64(used) < float64(total)*high {
+		return 0, 0
+	}
+	target := uint64(float64(total) * min(low, high))
+	if used <= target {
+		return 0, 0
+	}
+
+	type candidate struct {
+		segment    Segment
+		lastAccess time.Time
+		demotedAt  time.Time
+	}
+	candidates := make([]candidate, 0)
+	e.mu.Lock()
+	for _, segment := range e.manager.Segment.GetBy(WithType(SegmentTypeSealed)) {
+		c := candidate{segment: segment, demotedAt: e.demoted[segment.ID()]}
+		if access, ok := e.accesses[segment.ID()]; ok {
+			c.lastAccess = access.lastAccess
+		}
+		candidates = append(candidates, c)
+	}
+	e.mu.Unlock()
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastAccess.Before(candidates[j].lastAccess)
+	})
+
+	log := log.Ctx(ctx).With(zap.Uint64("usedMemory", used), zap.Uint64("totalMemory", total))
+	nodeID := fmt.Sprint(paramtable.GetNodeID())
+	// the memory of the demoted segments is released in the later rounds
+	reclaimable := used
+	for _, c := range candidates {
+		if reclaimable <= target {
+			break
+		}
+		size := c.segment.ResourceUsageEstimate().MemorySize
+		if !c.segment.IsLazyLoad() {
+			if e.demote(ctx, c.segment) {
+				reclaimable -= min(reclaimable, size)
+				demoted++
+			}
+			continue
+		}
+		if !e.evictable(c.segment, c.demotedAt) {
+			continue
+		}
+		// the segment in use or not loaded is skipped
+		if !e.manager.DiskCache.TryRemove(ctx, c.segment.ID()) {
+			continue
+		}
+		used -= min(used, size)
+		reclaimable -= min(reclaimable, size)
+		evicted++
+		e.evictCount.Inc()
+		e.evictBytes.Add(int64(size))
+		metrics.QueryNodeMemoryPressureEvictTotal.WithLabelValues(nodeID).Inc()
+		metrics.QueryNodeMemoryPressureEvictBytes.WithLabelValues(nodeID).Add(float64(size))
+		log.Info("evict cold segment under memory pressure",
+			zap.Int64("collectionID", c.segment.Collection()),
+			zap.Int64("segmentID", c.segment.ID()),
+			zap.Time("lastAccess", c.lastAccess),
+			zap.Uint64("memorySize", size))
+	}
+	if reclaimable > target {
+		log.Warn("memory usage is still above the watermark after eviction",
+			zap.Int("evicted", evicted),
+			zap.Int("demoted", demoted),
+			zap.Uint64("estimatedUsedMemory", used))
+	}
+	return evicted, demoted
+}
+
+// demote turns the loaded segment into the lazy load state and puts it into the disk cache,
+// so that the following accesses go through the disk cache, which loads the segment again after it's evicted.
+func (e *Evictor) demote(ctx context.Context, segment Segment) bool {
+	s, ok := segment.(demotableSegment)
+	if !ok || !s.isDataLoaded() || s.isPinned() {
+		return false
+	}
+	// turn it into lazy load first, the disk cache may release the data if there is no room for it
+	s.markLazyLoad()
+	e.mu.Lock()
+	e.demoted[segment.ID()] = time.Now()
+	e.mu.Unlock()
+
+	// the segment data is in memory, the disk cache takes it without loading
+	ctx, cancel := context.WithTimeout(ctx, demoteGracePeriod)
+	defer cancel()
+	if _, err := e.manager.DiskCache.Do(ctx, segment.ID(), func(context.Context, Segment) error { return nil }); err != nil {
+		log.Ctx(ctx).Warn("failed to put demoted segment into disk cache", zap.Int64("segmentID", segment.ID()), zap.Error(err))
+	}
+	log.Ctx(ctx).Info("demote cold segment to lazy load under memory pressure",
+		zap.Int64("collectionID", segment.Collection()),
+		zap.Int64("segmentID", segment.ID()))
+	return true
+}
+
+// evictable checks if the demoted segment can be evicted,
+// the accesses which went around the disk cache before the demotion must be done.
+func (e *Evictor) evictable(segment Segment, demotedAt time.Time) bool {
+	if demotedAt.IsZero() {
+		return true
+	}
+	if time.Since(demotedAt) < demoteGracePeriod {
+		return false
+	}
+	if s, ok := segment.(demotableSegment); ok && s.isPinned() {
+		return false
+	}
+	e.mu.Lock()
+	delete(e.demoted, segment.ID())
+	e.mu.Unlock()
+	return true
+}
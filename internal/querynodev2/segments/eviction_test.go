@@ -0,0 +1,237 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/atomic"
+
+	"github.com/milvus-io/milvus/pkg/v2/util/cache"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// demotableMockSegment is a loaded segment which can be demoted to lazy load.
+type demotableMockSegment struct {
+	*MockSegment
+	lazy   atomic.Bool
+	loaded atomic.Bool
+	pinned atomic.Bool
+}
+
+func (s *demotableMockSegment) IsLazyLoad() bool   { return s.lazy.Load() }
+func (s *demotableMockSegment) isDataLoaded() bool { return s.loaded.Load() }
+func (s *demotableMockSegment) isPinned() bool     { return s.pinned.Load() }
+func (s *demotableMockSegment) markLazyLoad()      { s.lazy.Store(true) }
+
+type EvictorSuite struct {
+	suite.Suite
+
+	segments  map[int64]Segment
+	loaded    *demotableMockSegment
+	evicted   []int64
+	used      uint64
+	evictor   *Evictor
+	diskCache cache.Cache[int64, Segment]
+}
+
+func (s *EvictorSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *EvictorSuite) SetupTest() {
+	s.evicted = nil
+	s.segments = make(map[int64]Segment)
+	// segment 1 and 2 are lazy load, segment 3 is loaded
+	for id, size := range map[int64]uint64{1: 20, 2: 10, 3: 50} {
+		segment := NewMockSegment(s.T())
+		segment.EXPECT().ID().Return(id).Maybe()
+		segment.EXPECT().Collection().Return(100).Maybe()
+		segment.EXPECT().ResourceUsageEstimate().Return(ResourceUsage{MemorySize: size}).Maybe()
+		if id == 3 {
+			s.loaded = &demotableMockSegment{MockSegment: segment}
+			s.loaded.loaded.Store(true)
+			s.segments[id] = s.loaded
+			continue
+		}
+		segment.EXPECT().IsLazyLoad().Return(true).Maybe()
+		s.segments[id] = segment
+	}
+
+	segmentManager := NewMockSegmentManager(s.T())
+	segmentManager.EXPECT().GetBy(mock.Anything).Return([]Segment{s.segments[1], s.segments[2], s.segments[3]}).Maybe()
+	s.diskCache = cache.NewCacheBuilder[int64, Segment]().WithLoader(func(ctx context.Context, key int64) (Segment, error) {
+		segment, ok := s.segments[key]
+		if !ok {
+			return nil, merr.ErrSegmentNotFound
+		}
+		return segment, nil
+	}).WithFinalizer(func(ctx context.Context, key int64, value Segment) error {
+		s.evicted = append(s.evicted, key)
+		return nil
+	}).WithCapacity(10).Build()
+
+	s.evictor = NewEvictor(&Manager{Segment: segmentManager, DiskCache: s.diskCache})
+	s.used = 90
+	s.evictor.usedMemory = func() uint64 { return s.used }
+	s.evictor.totalMemory = func() uint64 { return 100 }
+}
+
+func (s *EvictorSuite) load(segmentIDs ...int64) {
+	for _, id := range segmentIDs {
+		missing, err := s.diskCache.Do(context.Background(), id, func(ctx context.Context, segment Segment) error { return nil })
+		s.Require().NoError(err)
+		s.evictor.Record(id, missing)
+	}
+}
+
+func (s *EvictorSuite) TestRecord() {
+	s.load(1)
+	s.evictor.Record(1, false, 101)
+	s.evictor.RecordFields(1, 102)
+	s.evictor.RecordFields(2)
+
+	lastAccess, fields, ok := s.evictor.LastAccess(1)
+	s.True(ok)
+	s.WithinDuration(time.Now(), lastAccess, time.Minute)
+	s.Len(fields, 2)
+	s.Contains(fields, int64(101))
+	s.Contains(fields, int64(102))
+	_, _, ok = s.evictor.LastAccess(2)
+	s.False(ok)
+
+	// the access of the loaded segment refreshes the recency only
+	s.evictor.Touch(3, 101)
+	_, fields, ok = s.evictor.LastAccess(3)
+	s.True(ok)
+	s.Contains(fields, int64(101))
+
+	stats := s.evictor.Stats()
+	s.Equal(int64(1), stats.HitCount)
+	s.Equal(int64(1), stats.MissCount)
+
+	s.evictor.Remove(1)
+	_, _, ok = s.evictor.LastAccess(1)
+	s.False(ok)
+
+	// nil evictor ignores the accesses
+	var evictor *Evictor
+	evictor.Record(1, true)
+	evictor.Touch(1)
+	evictor.RecordFields(1, 101)
+	_, _, ok = evictor.LastAccess(1)
+	s.False(ok)
+	s.Zero(evictor.EvictForLoading(context.Background()))
+}
+
+func (s *EvictorSuite) TestEvict() {
+	ctx := context.Background()
+	s.load(1, 2)
+	// segment 1 is colder than segment 2, and segment 3 is the hottest
+	time.Sleep(time.Millisecond)
+	s.evictor.Record(2, false)
+	s.evictor.Touch(3)
+
+	// under the high watermark
+	s.used = 80
+	evicted, demoted := s.evictor.evict(ctx, false)
+	s.Zero(evicted)
+	s.Zero(demoted)
+	s.Empty(s.evicted)
+
+	// evicting segment 1 is enough to reach the low watermark
+	s.used = 90
+	evicted, demoted = s.evictor.evict(ctx, false)
+	s.Equal(1, evicted)
+	s.Zero(demoted)
+	s.Equal([]int64{1}, s.evicted)
+	stats := s.evictor.Stats()
+	s.Equal(int64(1), stats.EvictCount)
+	s.Equal(int64(20), stats.EvictBytes)
+
+	// the evicted segment is loaded again on access
+	s.load(1)
+	s.Equal(int64(3), s.evictor.Stats().MissCount)
+}
+
+func (s *EvictorSuite) TestEvictInUse() {
+	ctx := context.Background()
+	s.load(1, 2)
+	s.used = 99
+	s.loaded.pinned.Store(true)
+
+	// segment 1 and 3 are in use, only segment 2 is evicted
+	_, err := s.diskCache.Do(ctx, 1, func(ctx context.Context, segment Segment) error {
+		evicted, demoted := s.evictor.evict(ctx, false)
+		s.Equal(1, evicted)
+		s.Zero(demoted)
+		return nil
+	})
+	s.NoError(err)
+	s.Equal([]int64{2}, s.evicted)
+	s.False(s.loaded.IsLazyLoad())
+}
+
+func (s *EvictorSuite) TestDemote() {
+	ctx := context.Background()
+	// segment 3 is the coldest, it's demoted and put into the disk cache
+	evicted, demoted := s.evictor.evict(ctx, false)
+	s.Zero(evicted)
+	s.Equal(1, demoted)
+	s.True(s.loaded.IsLazyLoad())
+	s.Empty(s.evicted)
+
+	// the demoted segment isn't evicted within the grace period
+	evicted, demoted = s.evictor.evict(ctx, false)
+	s.Zero(evicted)
+	s.Zero(demoted)
+	s.Empty(s.evicted)
+
+	// nor when it's still being read
+	s.evictor.demoted[3] = time.Now().Add(-demoteGracePeriod)
+	s.loaded.pinned.Store(true)
+	evicted, _ = s.evictor.evict(ctx, false)
+	s.Zero(evicted)
+
+	s.loaded.pinned.Store(false)
+	evicted, _ = s.evictor.evict(ctx, false)
+	s.Equal(1, evicted)
+	s.Equal([]int64{3}, s.evicted)
+	s.Equal(int64(50), s.evictor.Stats().EvictBytes)
+}
+
+func (s *EvictorSuite) TestEvictForLoading() {
+	ctx := context.Background()
+	// disabled by default
+	s.used = 80
+	s.Zero(s.evictor.EvictForLoading(ctx))
+
+	paramtable.Get().Save(paramtable.Get().QueryNodeCfg.LazyLoadEvictionEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().QueryNodeCfg.LazyLoadEvictionEnabled.Key)
+	// the loading evicts to the low watermark, segment 3 is demoted then evicted after the grace period
+	s.Equal(1, s.evictor.EvictForLoading(ctx))
+	s.Equal([]int64{3}, s.evicted)
+}
+
+func TestEvictor(t *testing.T) {
+	suite.Run(t, new(EvictorSuite))
+}
@@ -74,6 +74,8 @@ type Manager struct {
 	Collection CollectionManager
 	Segment    SegmentManager
 	DiskCache  cache.Cache[int64, Segment]
+	Evictor    *Evictor
+	Warmer     *Warmer
 	Loader     Loader
 }
 
@@ -153,8 +155,11 @@ func NewManager() *Manager {
 
 		return segment, nil
 	}).Build()
+	manager.Evictor = NewEvictor(manager)
+	manager.Warmer = NewWarmer()
 
 	segMgr.registerReleaseCallback(func(s Segment) {
+		manager.Evictor.Remove(s.ID())
 		if s.Type() == SegmentTypeSealed {
 			// !!! We cannot use ctx of request to call Remove,
 			// Once context canceled, the segment will be leak in cache forever.
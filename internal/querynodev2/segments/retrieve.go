@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
@@ -61,12 +62,23 @@ func retrieveOnSegments(ctx context.Context, mgr *Manager, segments []Segment, s
 		label = metrics.GrowingSegmentLabel
 	}
 
+	if len(segments) > 0 {
+		mgr.Warmer.Record(segments[0].Collection(), req.GetReq().GetOutputFieldsId()...)
+	}
+
 	retriever := func(ctx context.Context, s Segment) error {
 		tr := timerecord.NewTimeRecorder("retrieveOnSegments")
+		stage := profile.FromContext(ctx).NewChild(profile.SegmentStage, paramtable.GetNodeID())
+		defer stage.Done()
+		mgr.Evictor.RecordFields(s.ID(), req.GetReq().GetOutputFieldsId()...)
 		result, err := s.Retrieve(ctx, plan)
 		if err != nil {
 			return err
 		}
+		stage.SetAttr("segment_id", s.ID())
+		stage.SetAttr("segment_type", segType.String())
+		stage.SetAttr("row_num", s.RowNum())
+		stage.SetAttr("retrieve_count", result.GetAllRetrieveCount())
 
 		log := log.Ctx(ctx)
 		if log.Core().Enabled(zap.DebugLevel) && req.GetReq().GetIsCount() {
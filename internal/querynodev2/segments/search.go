@@ -27,6 +27,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments/metricsutil"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/pkg/v2/log"
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
 	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
@@ -45,10 +46,16 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 	searcher := func(ctx context.Context, s Segment) error {
 		// record search time
 		tr := timerecord.NewTimeRecorder("searchOnSegments")
+		stage := profile.FromContext(ctx).NewChild(profile.SegmentStage, paramtable.GetNodeID())
+		defer stage.Done()
 		searchResult, err := s.Search(ctx, searchReq)
 		if err != nil {
 			return err
 		}
+		stage.SetAttr("segment_id", s.ID())
+		stage.SetAttr("segment_type", segType.String())
+		stage.SetAttr("row_num", s.RowNum())
+		stage.SetAttr("vector_index", s.ExistIndex(searchReq.SearchFieldID()))
 		resultCh <- searchResult
 		// update metrics
 		elapsed := tr.ElapseSpan().Milliseconds()
@@ -59,6 +66,10 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 		return nil
 	}
 
+	if len(segments) > 0 {
+		mgr.Warmer.Record(segments[0].Collection(), searchReq.SearchFieldID())
+	}
+
 	// calling segment search in goroutines
 	errGroup, ctx := errgroup.WithContext(ctx)
 	segmentsWithoutIndex := make([]int64, 0)
@@ -87,11 +98,13 @@ func searchSegments(ctx context.Context, mgr *Manager, segments []Segment, segTy
 				if missing {
 					accessRecord.CacheMissing()
 				}
+				mgr.Evictor.Record(seg.ID(), missing, searchReq.SearchFieldID())
 				if err != nil {
 					log.Warn("failed to do search for disk cache", zap.Int64("segID", seg.ID()), zap.Error(err))
 				}
 				return err
 			}
+			mgr.Evictor.Touch(seg.ID(), searchReq.SearchFieldID())
 			return searcher(ctx, seg)
 		})
 	}
@@ -152,6 +165,10 @@ func searchSegmentsStreamly(ctx context.Context,
 		return nil
 	}
 
+	if len(segments) > 0 {
+		mgr.Warmer.Record(segments[0].Collection(), searchReq.SearchFieldID())
+	}
+
 	// calling segment search in goroutines
 	errGroup, ctx := errgroup.WithContext(ctx)
 	log := log.Ctx(ctx)
@@ -177,12 +194,14 @@ func searchSegmentsStreamly(ctx context.Context,
 				if missing {
 					accessRecord.CacheMissing()
 				}
+				mgr.Evictor.Record(seg.ID(), missing, searchReq.SearchFieldID())
 				if err != nil {
 					log.Warn("failed to do search for disk cache", zap.Int64("segID", seg.ID()), zap.Error(err))
 				}
 				log.Debug("after doing stream search in DiskCache", zap.Int64("segID", seg.ID()), zap.Error(err))
 				return err
 			}
+			mgr.Evictor.Touch(seg.ID(), searchReq.SearchFieldID())
 			return searcher(ctx, seg)
 		})
 	}
@@ -90,7 +90,7 @@ type baseSegment struct {
 	segmentType    SegmentType
 	bloomFilterSet *pkoracle.BloomFilterSet
 	loadInfo       *atomic.Pointer[querypb.SegmentLoadInfo]
-	isLazyLoad     bool
+	isLazyLoad     *atomic.Bool
 	skipGrowingBF  bool // Skip generating or maintaining BF for growing segments; deletion checks will be handled in segcore.
 	channel        metautil.Channel
 
@@ -114,7 +114,7 @@ func newBaseSegment(collection *Collection, segmentType SegmentType, version int
 		bloomFilterSet: pkoracle.NewBloomFilterSet(loadInfo.GetSegmentID(), loadInfo.GetPartitionID(), segmentType),
 		bm25Stats:      make(map[int64]*storage.BM25Stats),
 		channel:        channel,
-		isLazyLoad:     isLazyLoad(collection, segmentType),
+		isLazyLoad:     atomic.NewBool(isLazyLoad(collection, segmentType)),
 		skipGrowingBF:  segmentType == SegmentTypeGrowing && paramtable.Get().QueryNodeCfg.SkipGrowingSegmentBF.GetAsBool(),
 
 		resourceUsageCache: atomic.NewPointer[ResourceUsage](nil),
@@ -257,7 +257,7 @@ func (s *baseSegment) ResourceUsageEstimate() ResourceUsage {
 }
 
 func (s *baseSegment) IsLazyLoad() bool {
-	return s.isLazyLoad
+	return s.isLazyLoad.Load()
 }
 
 func (s *baseSegment) NeedUpdatedVersion() int64 {
@@ -1402,6 +1402,26 @@ func (s *LocalSegment) Release(ctx context.Context, opts ...releaseOption) {
 	log.Info("delete segment from memory")
 }
 
+// isDataLoaded checks if the segment data is in memory.
+func (s *LocalSegment) isDataLoaded() bool {
+	if !s.ptrLock.PinIf(state.IsDataLoaded) {
+		return false
+	}
+	s.ptrLock.Unpin()
+	return true
+}
+
+// isPinned checks if the segment is being read.
+func (s *LocalSegment) isPinned() bool {
+	return s.ptrLock.IsPinned()
+}
+
+// markLazyLoad turns the loaded segment into the lazy load state,
+// its data is released and loaded again by the disk cache since then.
+func (s *LocalSegment) markLazyLoad() {
+	s.isLazyLoad.Store(true)
+}
+
 // ReleaseSegmentData releases the segment data.
 func (s *LocalSegment) ReleaseSegmentData() {
 	GetDynamicPool().Submit(func() (any, error) {
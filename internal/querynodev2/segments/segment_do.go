@@ -29,11 +29,13 @@ func doOnSegment(ctx context.Context, mgr *Manager, seg Segment, do doOnSegmentF
 		if missing {
 			accessRecord.CacheMissing()
 		}
+		mgr.Evictor.Record(seg.ID(), missing)
 		if err != nil {
 			log.Ctx(ctx).Warn("failed to do query disk cache", zap.Int64("segID", seg.ID()), zap.Error(err))
 		}
 		return err
 	}
+	mgr.Evictor.Touch(seg.ID())
 	return do(ctx, seg)
 }
 
@@ -443,6 +443,19 @@ func (loader *segmentLoader) notifyLoadFinish(segments ...*querypb.SegmentLoadIn
 // requestResource requests memory & storage to load segments,
 // returns the memory usage, disk usage and concurrency with the gained memory.
 func (loader *segmentLoader) requestResource(ctx context.Context, infos ...*querypb.SegmentLoadInfo) (requestResourceResult, error) {
+	result, err := loader.tryRequestResource(ctx, infos...)
+	if err != nil && errors.Is(err, merr.ErrServiceMemoryLimitExceeded) &&
+		!paramtable.Get().QueryNodeCfg.TieredEvictionEnabled.GetAsBool() {
+		// evict the cold segments to make room for loading, then try again
+		if evicted := loader.manager.Evictor.EvictForLoading(ctx); evicted > 0 {
+			log.Ctx(ctx).Info("evicted cold segments for loading, request resource again", zap.Int("evicted", evicted))
+			return loader.tryRequestResource(ctx, infos...)
+		}
+	}
+	return result, err
+}
+
+func (loader *segmentLoader) tryRequestResource(ctx context.Context, infos ...*querypb.SegmentLoadInfo) (requestResourceResult, error) {
 	// we need to deal with empty infos case separately,
 	// because the following judgement for requested resources are based on current status and static config
 	// which may block empty-load operations by accident
@@ -930,6 +943,13 @@ func (loader *segmentLoader) loadSealedSegment(ctx context.Context, loadInfo *qu
 		return err
 	}
 	patchEntryNumberSpan := tr.RecordSpan()
+
+	// 5. warm up the recently accessed fields before the segment is ready,
+	// the lazy load segment is loaded by the requests, which shouldn't wait for it.
+	if !segment.IsLazyLoad() {
+		loader.manager.Warmer.Warmup(ctx, segment.Collection(), segment.ID())
+	}
+	warmupSpan := tr.RecordSpan()
 	log.Info("Finish loading segment",
 		zap.Duration("loadFieldsIndexSpan", loadFieldsIndexSpan),
 		zap.Duration("complementScalarDataSpan", complementScalarDataSpan),
@@ -937,6 +957,7 @@ func (loader *segmentLoader) loadSealedSegment(ctx context.Context, loadInfo *qu
 		zap.Duration("patchEntryNumberSpan", patchEntryNumberSpan),
 		zap.Duration("loadTextIndexesSpan", loadTextIndexesSpan),
 		zap.Duration("loadJsonKeyIndexSpan", loadJSONKeyIndexesSpan),
+		zap.Duration("warmupSpan", warmupSpan),
 	)
 	return nil
 }
@@ -1009,6 +1030,10 @@ func (loader *segmentLoader) LoadLazySegment(ctx context.Context,
 	segment Segment,
 	loadInfo *querypb.SegmentLoadInfo,
 ) (err error) {
+	// the segment demoted to lazy load by the evictor is still in memory, no need to load it again
+	if s, ok := segment.(demotableSegment); ok && s.isDataLoaded() {
+		return nil
+	}
 	resource, err := loader.requestResourceWithTimeout(ctx, loadInfo)
 	if err != nil {
 		log.Ctx(ctx).Warn("request resource failed", zap.Error(err))
@@ -1636,12 +1661,12 @@ func (loader *segmentLoader) checkSegmentSize(ctx context.Context, segmentLoadIn
 	} else {
 		// fallback to original segment loading logic
 		if predictMemUsage > uint64(float64(totalMem)*paramtable.Get().QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat()) {
-			return 0, 0, fmt.Errorf("load segment failed, OOM if load, maxSegmentSize = %v MB,  memUsage = %v MB, predictMemUsage = %v MB, totalMem = %v MB thresholdFactor = %f",
+			return 0, 0, merr.WrapErrServiceMemoryLimitExceeded(float32(predictMemUsage), float32(totalMem), fmt.Sprintf("load segment failed, OOM if load, maxSegmentSize = %v MB,  memUsage = %v MB, predictMemUsage = %v MB, totalMem = %v MB thresholdFactor = %f",
 				logutil.ToMB(float64(maxSegmentSize)),
 				logutil.ToMB(float64(memUsage)),
 				logutil.ToMB(float64(predictMemUsage)),
 				logutil.ToMB(float64(totalMem)),
-				paramtable.Get().QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat())
+				paramtable.Get().QueryNodeCfg.OverloadedMemoryThresholdPercentage.GetAsFloat()))
 		}
 
 		if predictDiskUsage > uint64(float64(paramtable.Get().QueryNodeCfg.DiskCapacityLimit.GetAsInt64())*paramtable.Get().QueryNodeCfg.MaxDiskUsagePercentage.GetAsFloat()) {
@@ -100,6 +100,11 @@ func (ls *LoadStateLock) Unpin() {
 	}
 }
 
+// IsPinned checks if the segment is pinned by any operation.
+func (ls *LoadStateLock) IsPinned() bool {
+	return ls.refCnt.Load() > 0
+}
+
 // PinIfNotReleased pin the segment if the state is not released.
 // grammar suger for PinIf(IsNotReleased).
 func (ls *LoadStateLock) PinIfNotReleased() bool {
@@ -232,7 +232,9 @@ func TestPinIf(t *testing.T) {
 
 	l = NewLoadStateLock(LoadStateDataLoaded)
 	assert.True(t, l.PinIf(IsNotReleased))
+	assert.True(t, l.IsPinned())
 	l.Unpin()
+	assert.False(t, l.IsPinned())
 	assert.True(t, l.PinIf(IsDataLoaded))
 	l.Unpin()
 
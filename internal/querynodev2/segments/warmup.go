@@ -0,0 +1,329 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/util/pathutil"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const warmupReadBufferSize = 1 << 20
+
+// warmupProfile is the persisted form of the accessed fields, collectionID -> fieldID -> last access unix seconds.
+type warmupProfile map[int64]map[int64]int64
+
+// Warmer records the fields accessed by search and retrieve of each collection,
+// and pre-reads the local files of these fields into page cache when a sealed segment is loaded,
+// so that the first requests after a restart or a balance don't read the mmap'd data and disk index from the disk.
+// The profile is persisted to the local storage, which survives the restarts of the query node.
+type Warmer struct {
+	mu       sync.Mutex
+	profiles map[int64]map[int64]time.Time
+	dirty    atomic.Bool
+
+	// the root path of the local chunk manager of segcore, and the folder of the mmap'd data
+	chunkRoot   string
+	mmapDir     string
+	profilePath string
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	closeCh   chan struct{}
+	wg        sync.WaitGroup
+}
+
+func NewWarmer() *Warmer {
+	return &Warmer{
+		profiles:    make(map[int64]map[int64]time.Time),
+		chunkRoot:   pathutil.GetPath(pathutil.LocalChunkPath, paramtable.GetNodeID()),
+		mmapDir:     paramtable.Get().QueryNodeCfg.MmapDirPath.GetValue(),
+		profilePath: paramtable.Get().QueryNodeCfg.WarmupProfilePath.GetValue(),
+		closeCh:     make(chan struct{}),
+	}
+}
+
+// Record records the accesses of the fields of the collection.
+func (w *Warmer) Record(collectionID int64, fieldIDs ...int64) {
+	if w == nil || len(fieldIDs) == 0 {
+		return
+	}
+	now := time.Now()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fields, ok := w.profiles[collectionID]
+	if !ok {
+		fields = make(map[int64]time.Time)
+		w.profiles[collectionID] = fields
+	}
+	for _, fieldID := range fieldIDs {
+		fields[fieldID] = now
+	}
+	w.dirty.Store(true)
+}
+
+// HotFields returns the fields of the collection accessed in the profile TTL.
+func (w *Warmer) HotFields(collectionID int64) []int64 {
+	if w == nil {
+		return nil
+	}
+	ttl := paramtable.Get().QueryNodeCfg.WarmupProfileTTL.GetAsDuration(time.Second)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	ret := make([]int64, 0, len(w.profiles[collectionID]))
+	for fieldID, lastAccess := range w.profiles[collectionID] {
+		if time.Since(lastAccess) < ttl {
+			ret = append(ret, fieldID)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i] < ret[j] })
+	return ret
+}
+
+// fieldFiles returns the local files of the field of the segment, following the layout of segcore:
+// the raw data cached by the local chunk manager is under {chunkRoot}/raw_datas/{segmentID}/{fieldID}/,
+// the disk index is under {chunkRoot}/index_files/{buildID}_{version}_{segmentID}_{fieldID}/,
+// and the mmap'd data is under {mmapDir}/{segmentID}/{fieldID}/.
+func (w *Warmer) fieldFiles(segmentID int64, fieldID int64) []string {
+	patterns := []string{
+		filepath.Join(w.chunkRoot, "raw_datas", fmt.Sprint(segmentID), fmt.Sprint(fieldID)),
+		filepath.Join(w.chunkRoot, "index_files", fmt.Sprintf("*_*_%d_%d", segmentID, fieldID)),
+		filepath.Join(w.mmapDir, fmt.Sprint(segmentID), fmt.Sprint(fieldID)),
+	}
+	files := make([]string, 0)
+	for _, pattern := range patterns {
+		dirs, err := filepath.Glob(pattern)
+		if err != nil {
+			continue
+		}
+		for _, dir := range dirs {
+			filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+				if err == nil && d.Type().IsRegular() {
+					files = append(files, path)
+				}
+				return nil
+			})
+		}
+	}
+	return files
+}
+
+// Warmup pre-reads the local files of the hot fields of the segment in background,
+// and waits until it's done or the time budget runs out, returns the bytes read.
+func (w *Warmer) Warmup(ctx context.Context, collectionID int64, segmentID int64) int64 {
+	params := paramtable.Get()
+	if w == nil || !params.QueryNodeCfg.WarmupEnabled.GetAsBool() {
+		return 0
+	}
+	budget := params.QueryNodeCfg.WarmupTimeBudget.GetAsDuration(time.Millisecond)
+	fields := w.HotFields(collectionID)
+	if budget <= 0 || len(fields) == 0 {
+		return 0
+	}
+
+	log := log.Ctx(ctx).With(
+		zap.Int64("collectionID", collectionID),
+		zap.Int64("segmentID", segmentID),
+		zap.Int64s("fieldIDs", fields),
+	)
+	ctx, cancel := context.WithTimeout(ctx, budget)
+	defer cancel()
+
+	start := time.Now()
+	bytes := atomic.NewInt64(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		buf := make([]byte, warmupReadBufferSize)
+		for _, fieldID := range fields {
+			for _, file := range w.fieldFiles(segmentID, fieldID) {
+				n, err := preRead(ctx, file, buf)
+				bytes.Add(n)
+				if ctx.Err() != nil {
+					return
+				}
+				if err != nil {
+					log.Warn("failed to warm up file", zap.String("file", file), zap.Error(err))
+				}
+			}
+		}
+	}()
+
+	select {
+	case <-done:
+		log.Info("segment warmup done", zap.Int64("bytes", bytes.Load()), zap.Duration("elapsed", time.Since(start)))
+	case <-ctx.Done():
+		// the reading goroutine exits at the next chunk
+		log.Warn("segment warmup stopped since the time budget runs out", zap.Int64("bytes", bytes.Load()), zap.Duration("budget", budget))
+	}
+	return bytes.Load()
+}
+
+// preRead reads the file through to load it into page cache.
+func preRead(ctx context.Context, file string, buf []byte) (int64, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var total int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+		n, err := f.Read(buf)
+		total += int64(n)
+		if err == io.EOF {
+			return total, nil
+		}
+		if err != nil {
+			return total, err
+		}
+	}
+}
+
+// Start loads the persisted profile and starts persisting the profile periodically.
+func (w *Warmer) Start() {
+	if w == nil {
+		return
+	}
+	w.startOnce.Do(func() {
+		if err := w.load(); err != nil {
+			log.Warn("failed to load warmup profile", zap.String("path", w.profilePath), zap.Error(err))
+		}
+		w.wg.Add(1)
+		go w.loop()
+	})
+}
+
+func (w *Warmer) Stop() {
+	if w == nil {
+		return
+	}
+	w.stopOnce.Do(func() {
+		close(w.closeCh)
+		w.wg.Wait()
+	})
+}
+
+func (w *Warmer) loop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(paramtable.Get().QueryNodeCfg.WarmupPersistInterval.GetAsDuration(time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.closeCh:
+			if err := w.persist(); err != nil {
+				log.Warn("failed to persist warmup profile", zap.String("path", w.profilePath), zap.Error(err))
+			}
+			log.Info("segment warmer stopped")
+			return
+		case <-ticker.C:
+			if err := w.persist(); err != nil {
+				log.Warn("failed to persist warmup profile", zap.String("path", w.profilePath), zap.Error(err))
+			}
+		}
+	}
+}
+
+// persist writes the profile to the local file if it's changed, the expired fields are dropped.
+func (w *Warmer) persist() error {
+	if !w.dirty.CompareAndSwap(true, false) {
+		return nil
+	}
+	ttl := paramtable.Get().QueryNodeCfg.WarmupProfileTTL.GetAsDuration(time.Second)
+	profile := make(warmupProfile)
+	w.mu.Lock()
+	for collectionID, fields := range w.profiles {
+		for fieldID, lastAccess := range fields {
+			if time.Since(lastAccess) >= ttl {
+				delete(fields, fieldID)
+				continue
+			}
+			if _, ok := profile[collectionID]; !ok {
+				profile[collectionID] = make(map[int64]int64)
+			}
+			profile[collectionID][fieldID] = lastAccess.Unix()
+		}
+		if len(fields) == 0 {
+			delete(w.profiles, collectionID)
+		}
+	}
+	w.mu.Unlock()
+
+	b, err := json.Marshal(profile)
+	if err == nil {
+		err = writeFileAtomic(w.profilePath, b)
+	}
+	if err != nil {
+		// retry in the next round
+		w.dirty.Store(true)
+	}
+	return err
+}
+
+func (w *Warmer) load() error {
+	b, err := os.ReadFile(w.profilePath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	profile := make(warmupProfile)
+	if err := json.Unmarshal(b, &profile); err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for collectionID, fields := range profile {
+		if _, ok := w.profiles[collectionID]; !ok {
+			w.profiles[collectionID] = make(map[int64]time.Time)
+		}
+		for fieldID, lastAccess := range fields {
+			w.profiles[collectionID][fieldID] = time.Unix(lastAccess, 0)
+		}
+	}
+	log.Info("warmup profile loaded", zap.String("path", w.profilePath), zap.Int("collectionNum", len(profile)))
+	return nil
+}
+
+func writeFileAtomic(path string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,163 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segments
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus/internal/util/pathutil"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type WarmerSuite struct {
+	suite.Suite
+
+	root   string
+	warmer *Warmer
+}
+
+func (s *WarmerSuite) SetupSuite() {
+	paramtable.Init()
+}
+
+func (s *WarmerSuite) SetupTest() {
+	params := paramtable.Get()
+	s.root = s.T().TempDir()
+	params.Save(params.LocalStorageCfg.Path.Key, s.root)
+	params.Save(params.QueryNodeCfg.WarmupProfilePath.Key, filepath.Join(s.root, "warmup_profile.json"))
+	params.Save(params.QueryNodeCfg.WarmupEnabled.Key, "true")
+	s.warmer = NewWarmer()
+}
+
+func (s *WarmerSuite) TearDownTest() {
+	params := paramtable.Get()
+	params.Reset(params.LocalStorageCfg.Path.Key)
+	params.Reset(params.QueryNodeCfg.WarmupProfilePath.Key)
+	params.Reset(params.QueryNodeCfg.WarmupEnabled.Key)
+	params.Reset(params.QueryNodeCfg.WarmupTimeBudget.Key)
+	params.Reset(params.QueryNodeCfg.WarmupProfileTTL.Key)
+}
+
+func (s *WarmerSuite) writeFile(path string, size int) {
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, make([]byte, size), 0o600))
+}
+
+func (s *WarmerSuite) TestRecord() {
+	s.warmer.Record(100, 101, 102)
+	s.warmer.Record(100, 101)
+	s.warmer.Record(200)
+	s.Equal([]int64{101, 102}, s.warmer.HotFields(100))
+	s.Empty(s.warmer.HotFields(200))
+
+	// the expired fields are not hot
+	s.warmer.profiles[100][102] = time.Now().Add(-48 * time.Hour)
+	s.Equal([]int64{101}, s.warmer.HotFields(100))
+
+	var warmer *Warmer
+	warmer.Record(100, 101)
+	s.Empty(warmer.HotFields(100))
+	s.Zero(warmer.Warmup(context.Background(), 100, 1))
+}
+
+func (s *WarmerSuite) TestPersist() {
+	s.warmer.Record(100, 101, 102)
+	s.warmer.profiles[100][102] = time.Now().Add(-48 * time.Hour)
+	s.NoError(s.warmer.persist())
+	s.False(s.warmer.dirty.Load())
+
+	// the expired fields are dropped
+	warmer := NewWarmer()
+	s.NoError(warmer.load())
+	s.Len(warmer.profiles, 1)
+	s.Equal([]int64{101}, warmer.HotFields(100))
+
+	// nothing changed, nothing to write
+	s.NoError(os.Remove(s.warmer.profilePath))
+	s.NoError(s.warmer.persist())
+	s.NoFileExists(s.warmer.profilePath)
+
+	// the profile is persisted after stopped
+	s.warmer.Start()
+	s.warmer.Record(200, 201)
+	s.warmer.Stop()
+	warmer = NewWarmer()
+	s.NoError(warmer.load())
+	s.Equal([]int64{201}, warmer.HotFields(200))
+}
+
+func (s *WarmerSuite) TestLoadBrokenProfile() {
+	s.writeFile(s.warmer.profilePath, 0)
+	s.Error(s.warmer.load())
+
+	s.NoError(os.Remove(s.warmer.profilePath))
+	s.NoError(s.warmer.load())
+}
+
+func (s *WarmerSuite) TestWarmup() {
+	ctx := context.Background()
+	params := paramtable.Get()
+	// the layout of segcore under the local storage path
+	chunkRoot := pathutil.GetPath(pathutil.LocalChunkPath, paramtable.GetNodeID())
+	s.Equal(filepath.Join(s.root, "cache", fmt.Sprint(paramtable.GetNodeID()), "local_chunk"), chunkRoot)
+	mmapDir := params.QueryNodeCfg.MmapDirPath.GetValue()
+	s.Equal(filepath.Join(s.root, "mmap"), mmapDir)
+
+	// raw data, disk index and mmap'd data of the segment 1
+	s.writeFile(filepath.Join(chunkRoot, "raw_datas", "1", "101", "0"), 1024)
+	s.writeFile(filepath.Join(chunkRoot, "index_files", "1000_1_1_101", "index"), 2048)
+	s.writeFile(filepath.Join(mmapDir, "1", "101", "0"), 1024)
+	s.writeFile(filepath.Join(chunkRoot, "raw_datas", "1", "102", "0"), 4096)
+	s.writeFile(filepath.Join(mmapDir, "1", "102", "0"), 4096)
+	// the other segment
+	s.writeFile(filepath.Join(chunkRoot, "raw_datas", "11", "101", "0"), 4096)
+	s.writeFile(filepath.Join(chunkRoot, "index_files", "1001_1_11_101", "index"), 4096)
+	s.writeFile(filepath.Join(mmapDir, "11", "101", "0"), 4096)
+
+	// no hot fields
+	s.Zero(s.warmer.Warmup(ctx, 100, 1))
+
+	s.warmer.Record(100, 101)
+	s.Equal(int64(4096), s.warmer.Warmup(ctx, 100, 1))
+
+	params.Save(params.QueryNodeCfg.WarmupTimeBudget.Key, "0")
+	s.Zero(s.warmer.Warmup(ctx, 100, 1))
+
+	params.Reset(params.QueryNodeCfg.WarmupTimeBudget.Key)
+	params.Save(params.QueryNodeCfg.WarmupEnabled.Key, "false")
+	s.Zero(s.warmer.Warmup(ctx, 100, 1))
+}
+
+func (s *WarmerSuite) TestWarmupCanceled() {
+	s.writeFile(filepath.Join(s.warmer.mmapDir, "1", "101", "0"), 1024)
+	s.warmer.Record(100, 101)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.Zero(s.warmer.Warmup(ctx, 100, 1))
+}
+
+func TestWarmer(t *testing.T) {
+	suite.Run(t, new(WarmerSuite))
+}
@@ -501,6 +501,8 @@ func (node *QueryNode) Start() error {
 	log := log.Ctx(node.ctx)
 	node.startOnce.Do(func() {
 		node.scheduler.Start()
+		node.manager.Evictor.Start()
+		node.manager.Warmer.Start()
 
 		paramtable.SetCreateTime(time.Now())
 		paramtable.SetUpdateTime(time.Now())
@@ -610,6 +612,8 @@ func (node *QueryNode) Stop() error {
 			node.dispClient.Close()
 		}
 		if node.manager != nil {
+			node.manager.Evictor.Stop()
+			node.manager.Warmer.Stop()
 			node.manager.Segment.Clear(context.Background())
 		}
 
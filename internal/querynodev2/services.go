@@ -37,6 +37,7 @@ import (
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
 	"github.com/milvus-io/milvus/internal/querynodev2/tasks"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/internal/util/searchutil/scheduler"
 	"github.com/milvus-io/milvus/internal/util/streamrpc"
 	"github.com/milvus-io/milvus/pkg/v2/common"
@@ -770,6 +771,7 @@ func (node *QueryNode) SearchSegments(ctx context.Context, req *querypb.SearchRe
 	)
 	searchCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	searchCtx, stage := profile.Start(searchCtx, req.GetReq().GetBase(), profile.WorkerStage, node.GetNodeID())
 
 	tr := timerecord.NewTimeRecorder("searchSegments")
 	log.Debug("search segments...")
@@ -821,6 +823,7 @@ func (node *QueryNode) SearchSegments(ctx context.Context, req *querypb.SearchRe
 		resp.IsTopkReduce = true
 	}
 	resp.IsRecallEvaluation = req.GetReq().GetIsRecallEvaluation()
+	profile.Attach(resp.GetStatus(), stage)
 	return resp, nil
 }
 
@@ -877,7 +880,12 @@ func (node *QueryNode) Search(ctx context.Context, req *querypb.SearchRequest) (
 	}
 
 	tr.RecordSpan()
+	// keep the profile of the channel search, the rest of status is reset
+	extraInfo := ret.GetStatus().GetExtraInfo()
 	ret.Status = merr.Success()
+	if stage, ok := extraInfo[common.ProfileKey]; ok {
+		ret.Status.ExtraInfo = map[string]string{common.ProfileKey: stage}
+	}
 
 	reduceLatency := tr.RecordSpan()
 	metrics.QueryNodeReduceLatency.
@@ -924,6 +932,7 @@ func (node *QueryNode) QuerySegments(ctx context.Context, req *querypb.QueryRequ
 	// add cancel when error occurs
 	queryCtx, cancel := context.WithCancel(ctx)
 	defer cancel()
+	queryCtx, stage := profile.Start(queryCtx, req.GetReq().GetBase(), profile.WorkerStage, node.GetNodeID())
 
 	tr := timerecord.NewTimeRecorder("querySegments")
 	if !node.manager.Collection.Ref(req.Req.GetCollectionID(), 1) {
@@ -963,6 +972,7 @@ func (node *QueryNode) QuerySegments(ctx context.Context, req *querypb.QueryRequ
 	result := task.Result()
 	result.GetCostAggregation().ResponseTime = latency.Milliseconds()
 	result.GetCostAggregation().TotalNQ = node.scheduler.GetWaitingTaskTotalNQ()
+	profile.Attach(result.GetStatus(), stage)
 	return result, nil
 }
 
@@ -1021,6 +1031,11 @@ func (node *QueryNode) Query(ctx context.Context, req *querypb.QueryRequest) (*i
 	}
 
 	tr.RecordSpan()
+	// channel stages must be taken out before reduce, the reducer drops them
+	channelStages := lo.FilterMap(toMergeResults, func(result *internalpb.RetrieveResults, _ int) (*profile.Stage, bool) {
+		stage := profile.Extract(result.GetStatus())
+		return stage, stage != nil
+	})
 	reducer := segments.CreateInternalReducer(req, node.manager.Collection.Get(req.GetReq().GetCollectionID()).Schema())
 	ret, err := reducer.Reduce(ctx, toMergeResults)
 	if err != nil {
@@ -1028,6 +1043,18 @@ func (node *QueryNode) Query(ctx context.Context, req *querypb.QueryRequest) (*i
 			Status: merr.Status(err),
 		}, nil
 	}
+	if len(channelStages) > 0 {
+		if ret.Status == nil {
+			ret.Status = merr.Success()
+		}
+		if len(channelStages) == 1 {
+			profile.Attach(ret.Status, channelStages[0])
+		} else {
+			stage := profile.NewStage(profile.ShardStage, node.GetNodeID())
+			stage.AddChild(channelStages...)
+			profile.Attach(ret.Status, stage)
+		}
+	}
 	reduceLatency := tr.RecordSpan()
 	metrics.QueryNodeReduceLatency.WithLabelValues(fmt.Sprint(node.GetNodeID()),
 		metrics.QueryLabel, metrics.ReduceShards, metrics.BatchReduce).
@@ -1242,10 +1269,14 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 	}
 
 	if !distributionChange() {
+		// the read workload changes without distribution change, keep reporting it by the leader views with only workload
 		return &querypb.GetDataDistributionResponse{
-			Status:       merr.Success(),
-			NodeID:       node.GetNodeID(),
-			LastModifyTs: lastModifyTs,
+			Status:           merr.Success(),
+			NodeID:           node.GetNodeID(),
+			LastModifyTs:     lastModifyTs,
+			LeaderViews:      node.getLeaderViewWorkloads(),
+			WaitingTaskNum:   node.scheduler.GetWaitingTaskTotal(),
+			ExecutingTaskNum: node.scheduler.GetExecutingTaskTotal(),
 		}, nil
 	}
 
@@ -1306,6 +1337,7 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 		}
 
 		queryView := delegator.GetChannelQueryView()
+		workload := delegator.GetWorkload()
 		leaderViews = append(leaderViews, &querypb.LeaderView{
 			Collection:             delegator.Collection(),
 			Channel:                key,
@@ -1317,22 +1349,45 @@ func (node *QueryNode) GetDataDistribution(ctx context.Context, req *querypb.Get
 			Status: &querypb.LeaderViewStatus{
 				Serviceable: queryView.Serviceable(),
 			},
+			ReadInflight: workload.Inflight,
+			ReadLatency:  workload.Latency.Milliseconds(),
 		})
 		return true
 	})
 
 	return &querypb.GetDataDistributionResponse{
-		Status:          merr.Success(),
-		NodeID:          node.GetNodeID(),
-		Segments:        segmentVersionInfos,
-		Channels:        channelVersionInfos,
-		LeaderViews:     leaderViews,
-		LastModifyTs:    lastModifyTs,
-		MemCapacityInMB: float64(hardware.GetMemoryCount() / 1024 / 1024),
-		CpuNum:          int64(hardware.GetCPUNum()),
+		Status:           merr.Success(),
+		NodeID:           node.GetNodeID(),
+		Segments:         segmentVersionInfos,
+		Channels:         channelVersionInfos,
+		LeaderViews:      leaderViews,
+		LastModifyTs:     lastModifyTs,
+		MemCapacityInMB:  float64(hardware.GetMemoryCount() / 1024 / 1024),
+		CpuNum:           int64(hardware.GetCPUNum()),
+		WaitingTaskNum:   node.scheduler.GetWaitingTaskTotal(),
+		ExecutingTaskNum: node.scheduler.GetExecutingTaskTotal(),
 	}, nil
 }
 
+// getLeaderViewWorkloads returns the leader views with only the read workload of the channels.
+func (node *QueryNode) getLeaderViewWorkloads() []*querypb.LeaderView {
+	leaderViews := make([]*querypb.LeaderView, 0)
+	node.delegators.Range(func(key string, delegator delegator.ShardDelegator) bool {
+		if !delegator.Serviceable() {
+			return true
+		}
+		workload := delegator.GetWorkload()
+		leaderViews = append(leaderViews, &querypb.LeaderView{
+			Collection:   delegator.Collection(),
+			Channel:      key,
+			ReadInflight: workload.Inflight,
+			ReadLatency:  workload.Latency.Milliseconds(),
+		})
+		return true
+	})
+	return leaderViews
+}
+
 func (node *QueryNode) SyncDistribution(ctx context.Context, req *querypb.SyncDistributionRequest) (*commonpb.Status, error) {
 	defer node.updateDistributionModifyTS()
 
@@ -0,0 +1,169 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/exprutil"
+	"github.com/milvus-io/milvus/internal/util/funcutil"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
+	"github.com/milvus-io/milvus/internal/util/segcore"
+	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
+)
+
+// recordFilterIndexUsage reports, for every field referenced by the filter expression,
+// how many of the executed segments evaluate it with a scalar index instead of brute force.
+func recordFilterIndexUsage(stage *profile.Stage, serializedPlan []byte, segs []segments.Segment) {
+	if stage == nil || len(serializedPlan) == 0 {
+		return
+	}
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return
+	}
+	expr, err := exprutil.ParseExprFromPlan(plan)
+	if err != nil || expr == nil {
+		return
+	}
+
+	usage := make(map[string]any)
+	for _, fieldID := range exprutil.ParseFieldIDsFromExpr(expr) {
+		indexed := 0
+		for _, seg := range segs {
+			if seg.ExistIndex(fieldID) {
+				indexed++
+			}
+		}
+		usage[fmt.Sprint(fieldID)] = map[string]int{
+			"indexed_segment_num":     indexed,
+			"brute_force_segment_num": len(segs) - indexed,
+		}
+	}
+	stage.SetAttr("filter_index_usage", usage)
+}
+
+// filterNode is one boolean node of a filter expression, path follows the keys of the explained plan.
+type filterNode struct {
+	path     string
+	exprType string
+	expr     *planpb.Expr
+}
+
+// collectFilterNodes flattens the boolean nodes of the filter expression in pre-order.
+func collectFilterNodes(expr *planpb.Expr, path string, nodes []filterNode) []filterNode {
+	switch e := expr.GetExpr().(type) {
+	case *planpb.Expr_BinaryExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: e.BinaryExpr.GetOp().String(), expr: expr})
+		nodes = collectFilterNodes(e.BinaryExpr.GetLeft(), path+".left_child", nodes)
+		nodes = collectFilterNodes(e.BinaryExpr.GetRight(), path+".right_child", nodes)
+	case *planpb.Expr_UnaryExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: e.UnaryExpr.GetOp().String(), expr: expr})
+		nodes = collectFilterNodes(e.UnaryExpr.GetChild(), path+".child", nodes)
+	case *planpb.Expr_TermExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "term", expr: expr})
+	case *planpb.Expr_CompareExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "compare", expr: expr})
+	case *planpb.Expr_UnaryRangeExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "unary_range", expr: expr})
+	case *planpb.Expr_BinaryRangeExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "binary_range", expr: expr})
+	case *planpb.Expr_BinaryArithOpEvalRangeExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "binary_arith_op_eval_range", expr: expr})
+	case *planpb.Expr_ExistsExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "exists", expr: expr})
+	case *planpb.Expr_AlwaysTrueExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "always_true", expr: expr})
+	case *planpb.Expr_JsonContainsExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "json_contains", expr: expr})
+	case *planpb.Expr_CallExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "call", expr: expr})
+	case *planpb.Expr_NullExpr:
+		nodes = append(nodes, filterNode{path: path, exprType: "null", expr: expr})
+	}
+	return nodes
+}
+
+// recordExprRowCounts reports how many rows of the executed segments match each boolean node
+// of the filter expression. Every node is evaluated by a count retrieve of its own,
+// so it only runs for profiled requests.
+func recordExprRowCounts(ctx context.Context,
+	stage *profile.Stage,
+	col *segcore.CCollection,
+	serializedPlan []byte,
+	ts uint64,
+	msgID int64,
+	consistencyLevel commonpb.ConsistencyLevel,
+	collectionTTL uint64,
+	segs []segments.Segment,
+) {
+	if stage == nil || len(serializedPlan) == 0 {
+		return
+	}
+	plan := &planpb.PlanNode{}
+	if err := proto.Unmarshal(serializedPlan, plan); err != nil {
+		return
+	}
+	expr, err := exprutil.ParseExprFromPlan(plan)
+	if err != nil || expr == nil {
+		return
+	}
+
+	counts := make([]map[string]any, 0)
+	for _, node := range collectFilterNodes(expr, "predicates", nil) {
+		cnt, err := countFilterRows(ctx, col, node.expr, ts, msgID, consistencyLevel, collectionTTL, segs)
+		if err != nil {
+			continue
+		}
+		counts = append(counts, map[string]any{
+			"path":      node.path,
+			"expr_type": node.exprType,
+			"row_count": cnt,
+		})
+	}
+	stage.SetAttr("expr_row_counts", counts)
+}
+
+func countFilterRows(ctx context.Context,
+	col *segcore.CCollection,
+	expr *planpb.Expr,
+	ts uint64,
+	msgID int64,
+	consistencyLevel commonpb.ConsistencyLevel,
+	collectionTTL uint64,
+	segs []segments.Segment,
+) (int64, error) {
+	bs, err := proto.Marshal(&planpb.PlanNode{
+		Node: &planpb.PlanNode_Query{
+			Query: &planpb.QueryPlanNode{
+				Predicates: expr,
+				IsCount:    true,
+			},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+	plan, err := segcore.NewRetrievePlan(col, bs, ts, msgID, consistencyLevel, collectionTTL)
+	if err != nil {
+		return 0, err
+	}
+	defer plan.Delete()
+
+	var total int64
+	for _, seg := range segs {
+		res, err := seg.Retrieve(ctx, plan)
+		if err != nil {
+			return 0, err
+		}
+		cnt, err := funcutil.CntOfSegCoreResult(res)
+		if err != nil {
+			return 0, err
+		}
+		total += cnt
+	}
+	return total, nil
+}
@@ -0,0 +1,60 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus/pkg/v2/proto/planpb"
+)
+
+func TestCollectFilterNodes(t *testing.T) {
+	column := &planpb.ColumnInfo{FieldId: 100}
+	rangeExpr := &planpb.Expr{Expr: &planpb.Expr_UnaryRangeExpr{UnaryRangeExpr: &planpb.UnaryRangeExpr{
+		ColumnInfo: column,
+		Op:         planpb.OpType_GreaterThan,
+		Value:      &planpb.GenericValue{Val: &planpb.GenericValue_Int64Val{Int64Val: 1}},
+	}}}
+	termExpr := &planpb.Expr{Expr: &planpb.Expr_TermExpr{TermExpr: &planpb.TermExpr{
+		ColumnInfo: column,
+		Values:     []*planpb.GenericValue{{Val: &planpb.GenericValue_Int64Val{Int64Val: 2}}},
+	}}}
+	expr := &planpb.Expr{Expr: &planpb.Expr_BinaryExpr{BinaryExpr: &planpb.BinaryExpr{
+		Op:   planpb.BinaryExpr_LogicalAnd,
+		Left: rangeExpr,
+		Right: &planpb.Expr{Expr: &planpb.Expr_UnaryExpr{UnaryExpr: &planpb.UnaryExpr{
+			Op:    planpb.UnaryExpr_Not,
+			Child: termExpr,
+		}}},
+	}}}
+
+	nodes := collectFilterNodes(expr, "predicates", nil)
+	assert.Len(t, nodes, 4)
+	assert.Equal(t, "predicates", nodes[0].path)
+	assert.Equal(t, "LogicalAnd", nodes[0].exprType)
+	assert.Equal(t, "predicates.left_child", nodes[1].path)
+	assert.Equal(t, "unary_range", nodes[1].exprType)
+	assert.Same(t, rangeExpr, nodes[1].expr)
+	assert.Equal(t, "predicates.right_child", nodes[2].path)
+	assert.Equal(t, "Not", nodes[2].exprType)
+	assert.Equal(t, "predicates.right_child.child", nodes[3].path)
+	assert.Equal(t, "term", nodes[3].exprType)
+
+	assert.Empty(t, collectFilterNodes(nil, "predicates", nil))
+}
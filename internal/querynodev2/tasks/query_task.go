@@ -12,6 +12,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/internal/util/searchutil/scheduler"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	"github.com/milvus-io/milvus/pkg/v2/metrics"
@@ -119,12 +120,27 @@ func (t *QueryTask) Execute() error {
 		return err
 	}
 
+	stage := profile.FromContext(t.ctx)
+	stage.SetAttr("scope", t.req.GetScope().String())
+	stage.SetAttr("segment_num", len(pinnedSegments))
+	recordFilterIndexUsage(stage, t.req.GetReq().GetSerializedExprPlan(), pinnedSegments)
+	recordExprRowCounts(t.ctx, stage,
+		t.collection.GetCCollection(),
+		t.req.GetReq().GetSerializedExprPlan(),
+		t.req.GetReq().GetMvccTimestamp(),
+		t.req.GetReq().GetBase().GetMsgID(),
+		t.req.GetReq().GetConsistencyLevel(),
+		t.req.GetReq().GetCollectionTtlTimestamps(),
+		pinnedSegments,
+	)
+
 	reducer := segments.CreateSegCoreReducer(
 		t.req,
 		t.collection.Schema(),
 		t.segmentManager,
 	)
 	beforeReduce := time.Now()
+	reduceStage := stage.NewChild(profile.ReduceStage, paramtable.GetNodeID())
 
 	reduceResults := make([]*segcorepb.RetrieveResults, 0, len(results))
 	querySegments := make([]segments.Segment, 0, len(results))
@@ -133,6 +149,7 @@ func (t *QueryTask) Execute() error {
 		querySegments = append(querySegments, result.Segment)
 	}
 	reducedResult, err := reducer.Reduce(t.ctx, reduceResults, querySegments, retrievePlan)
+	reduceStage.Done()
 
 	metrics.QueryNodeReduceLatency.WithLabelValues(
 		fmt.Sprint(paramtable.GetNodeID()),
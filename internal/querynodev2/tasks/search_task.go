@@ -18,6 +18,7 @@ import (
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	"github.com/milvus-io/milvus/internal/querynodev2/segments"
+	"github.com/milvus-io/milvus/internal/util/searchutil/profile"
 	"github.com/milvus-io/milvus/internal/util/searchutil/scheduler"
 	"github.com/milvus-io/milvus/internal/util/segcore"
 	"github.com/milvus-io/milvus/pkg/v2/log"
@@ -181,6 +182,20 @@ func (t *SearchTask) Execute() error {
 	}
 	defer segments.DeleteSearchResults(results)
 
+	stage := profile.FromContext(t.ctx)
+	stage.SetAttr("scope", req.GetScope().String())
+	stage.SetAttr("segment_num", len(searchedSegments))
+	recordFilterIndexUsage(stage, req.GetReq().GetSerializedExprPlan(), searchedSegments)
+	recordExprRowCounts(t.ctx, stage,
+		t.collection.GetCCollection(),
+		req.GetReq().GetSerializedExprPlan(),
+		req.GetReq().GetMvccTimestamp(),
+		req.GetReq().GetBase().GetMsgID(),
+		req.GetReq().GetConsistencyLevel(),
+		req.GetReq().GetCollectionTtlTimestamps(),
+		searchedSegments,
+	)
+
 	// plan.MetricType is accurate, though req.MetricType may be empty
 	metricType := searchReq.Plan().GetMetricType()
 
@@ -216,6 +231,7 @@ func (t *SearchTask) Execute() error {
 	}, 0)
 
 	tr.RecordSpan()
+	reduceStage := stage.NewChild(profile.ReduceStage, t.GetNodeID())
 	blobs, err := segcore.ReduceSearchResultsAndFillData(
 		t.ctx,
 		searchReq.Plan(),
@@ -224,6 +240,7 @@ func (t *SearchTask) Execute() error {
 		t.originNqs,
 		t.originTopks,
 	)
+	reduceStage.Done()
 	if err != nil {
 		log.Warn("failed to reduce search results", zap.Error(err))
 		return err
@@ -297,7 +314,9 @@ func (t *SearchTask) Merge(other *SearchTask) bool {
 		diffTopk && ratio > paramtable.Get().QueryNodeCfg.TopKMergeRatio.GetAsFloat() ||
 		!funcutil.SliceSetEqual(t.req.GetReq().GetPartitionIDs(), other.req.GetReq().GetPartitionIDs()) ||
 		!funcutil.SliceSetEqual(t.req.GetSegmentIDs(), other.req.GetSegmentIDs()) ||
-		!bytes.Equal(t.req.GetReq().GetSerializedExprPlan(), other.req.GetReq().GetSerializedExprPlan()) {
+		!bytes.Equal(t.req.GetReq().GetSerializedExprPlan(), other.req.GetReq().GetSerializedExprPlan()) ||
+		// profiled requests keep their own stats, never merge them
+		profile.IsEnabled(t.req.GetReq().GetBase()) || profile.IsEnabled(other.req.GetReq().GetBase()) {
 		return false
 	}
 
@@ -641,3 +641,53 @@ func validatePartitionKeyIsolationFromBinaryRangeExpr(expr *planpb.BinaryRangeEx
 	}
 	return false, nil
 }
+
+// ParseFieldIDsFromExpr returns the distinct IDs of the fields referenced by the expression, in the order of appearance.
+func ParseFieldIDsFromExpr(expr *planpb.Expr) []int64 {
+	fieldIDs := make([]int64, 0)
+	var visit func(expr *planpb.Expr)
+	addColumn := func(info *planpb.ColumnInfo) {
+		if info != nil && !lo.Contains(fieldIDs, info.GetFieldId()) {
+			fieldIDs = append(fieldIDs, info.GetFieldId())
+		}
+	}
+	visit = func(expr *planpb.Expr) {
+		switch expr := expr.GetExpr().(type) {
+		case *planpb.Expr_TermExpr:
+			addColumn(expr.TermExpr.GetColumnInfo())
+		case *planpb.Expr_UnaryExpr:
+			visit(expr.UnaryExpr.GetChild())
+		case *planpb.Expr_BinaryExpr:
+			visit(expr.BinaryExpr.GetLeft())
+			visit(expr.BinaryExpr.GetRight())
+		case *planpb.Expr_CompareExpr:
+			addColumn(expr.CompareExpr.GetLeftColumnInfo())
+			addColumn(expr.CompareExpr.GetRightColumnInfo())
+		case *planpb.Expr_UnaryRangeExpr:
+			addColumn(expr.UnaryRangeExpr.GetColumnInfo())
+		case *planpb.Expr_BinaryRangeExpr:
+			addColumn(expr.BinaryRangeExpr.GetColumnInfo())
+		case *planpb.Expr_BinaryArithOpEvalRangeExpr:
+			addColumn(expr.BinaryArithOpEvalRangeExpr.GetColumnInfo())
+		case *planpb.Expr_BinaryArithExpr:
+			visit(expr.BinaryArithExpr.GetLeft())
+			visit(expr.BinaryArithExpr.GetRight())
+		case *planpb.Expr_ColumnExpr:
+			addColumn(expr.ColumnExpr.GetInfo())
+		case *planpb.Expr_ExistsExpr:
+			addColumn(expr.ExistsExpr.GetInfo())
+		case *planpb.Expr_JsonContainsExpr:
+			addColumn(expr.JsonContainsExpr.GetColumnInfo())
+		case *planpb.Expr_CallExpr:
+			for _, param := range expr.CallExpr.GetFunctionParameters() {
+				visit(param)
+			}
+		case *planpb.Expr_NullExpr:
+			addColumn(expr.NullExpr.GetColumnInfo())
+		case *planpb.Expr_RandomSampleExpr:
+			visit(expr.RandomSampleExpr.GetPredicate())
+		}
+	}
+	visit(expr)
+	return fieldIDs
+}
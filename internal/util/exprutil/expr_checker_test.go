@@ -492,3 +492,44 @@ func TestValidatePartitionKeyIsolation(t *testing.T) {
 		})
 	}
 }
+
+func TestParseFieldIDsFromExpr(t *testing.T) {
+	fieldName2Type := make(map[string]schemapb.DataType)
+	fieldName2Type["int64_field"] = schemapb.DataType_Int64
+	fieldName2Type["varChar_field"] = schemapb.DataType_VarChar
+	fieldName2Type["double_field"] = schemapb.DataType_Double
+	fieldName2Type["fvec_field"] = schemapb.DataType_FloatVector
+	schema := testutil.ConstructCollectionSchemaByDataType("TestParseFieldIDsFromExpr"+funcutil.GenRandomStr(), fieldName2Type,
+		"int64_field", false, 8)
+	fieldID := common.StartOfUserFieldID
+	for _, field := range schema.Fields {
+		field.FieldID = int64(fieldID)
+		fieldID++
+	}
+	schemaHelper, err := typeutil.CreateSchemaHelper(schema)
+	require.NoError(t, err)
+	getFieldID := func(name string) int64 {
+		field, err := schemaHelper.GetFieldFromName(name)
+		require.NoError(t, err)
+		return field.GetFieldID()
+	}
+
+	cases := []struct {
+		expr     string
+		expected []int64
+	}{
+		{"int64_field > 10", []int64{getFieldID("int64_field")}},
+		{"int64_field in [1, 2] && varChar_field == 'a'", []int64{getFieldID("int64_field"), getFieldID("varChar_field")}},
+		{"not (double_field < 1.0 or int64_field + 1 == 3) and double_field > 0", []int64{getFieldID("double_field"), getFieldID("int64_field")}},
+		{"int64_field < double_field", []int64{getFieldID("int64_field"), getFieldID("double_field")}},
+		{"varChar_field like 'a%'", []int64{getFieldID("varChar_field")}},
+	}
+	for _, c := range cases {
+		plan, err := planparserv2.CreateRetrievePlan(schemaHelper, c.expr, nil)
+		require.NoError(t, err, c.expr)
+		expr, err := ParseExprFromPlan(plan)
+		require.NoError(t, err)
+		assert.Equal(t, c.expected, ParseFieldIDsFromExpr(expr), c.expr)
+	}
+	assert.Empty(t, ParseFieldIDsFromExpr(nil))
+}
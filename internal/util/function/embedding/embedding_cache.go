@@ -0,0 +1,311 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package embedding
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/function/models"
+	"github.com/milvus-io/milvus/pkg/v2/metrics"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+// cacheParamKey is the function param to turn on or off the embedding cache of the function,
+// the function.textEmbedding.cache.enabled config is used if it's not set.
+const cacheParamKey string = "embedding_cache"
+
+// isCacheEnabled tells whether the embeddings of the function should be cached.
+func isCacheEnabled(functionSchema *schemapb.FunctionSchema) (bool, error) {
+	for _, param := range functionSchema.GetParams() {
+		if strings.ToLower(param.GetKey()) != cacheParamKey {
+			continue
+		}
+		enabled, err := strconv.ParseBool(param.GetValue())
+		if err != nil {
+			return false, fmt.Errorf("Illegal [%s:%s] param, ", cacheParamKey, param.GetValue())
+		}
+		return enabled, nil
+	}
+	return paramtable.Get().FunctionCfg.TextEmbeddingCacheEnabled.GetAsBool(), nil
+}
+
+// embeddingCacheKey identifies the embedding of a text by the function generating it,
+// the function is identified by its db, collection, name and params,
+// so the cached embeddings are not served once the function is recreated with different params.
+type embeddingCacheKey struct {
+	dbName         string
+	collectionName string
+	functionName   string
+	params         string
+	// the prompt or task type may differ between insert and search
+	mode models.TextEmbeddingMode
+	text string
+}
+
+func (k embeddingCacheKey) size() int64 {
+	return int64(len(k.dbName) + len(k.collectionName) + len(k.functionName) + len(k.params) + len(k.text))
+}
+
+type embeddingCacheEntry struct {
+	embedding any
+	expireAt  time.Time
+	size      int64
+}
+
+// embeddingCache caches the embeddings of all text embedding functions,
+// the entries are evicted by their total size, and the expired ones are dropped when read.
+type embeddingCache struct {
+	mu      sync.Mutex
+	entries *simplelru.LRU[embeddingCacheKey, *embeddingCacheEntry]
+	size    int64
+	maxSize int64
+}
+
+func newEmbeddingCache(maxSize int64) *embeddingCache {
+	c := &embeddingCache{maxSize: maxSize}
+	// entries are evicted by size rather than by count
+	c.entries, _ = simplelru.NewLRU[embeddingCacheKey, *embeddingCacheEntry](math.MaxInt32, c.onEvict)
+	return c
+}
+
+func (c *embeddingCache) onEvict(key embeddingCacheKey, entry *embeddingCacheEntry) {
+	c.size -= entry.size
+}
+
+// Get returns a copy of the cached embedding of key if it's not expired.
+func (c *embeddingCache) Get(key embeddingCacheKey) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(entry.expireAt) {
+		c.entries.Remove(key)
+		return nil, false
+	}
+	return cloneEmbedding(entry.embedding), true
+}
+
+// Add caches a copy of the embedding, the least recently used entries are evicted if the cache is full.
+func (c *embeddingCache) Add(key embeddingCacheKey, embedding any) {
+	size := key.size()
+	switch embd := embedding.(type) {
+	case []float32:
+		size += int64(len(embd) * 4)
+	case []int8:
+		size += int64(len(embd))
+	}
+	if size > c.maxSize {
+		return
+	}
+	entry := &embeddingCacheEntry{
+		embedding: cloneEmbedding(embedding),
+		expireAt:  time.Now().Add(paramtable.Get().FunctionCfg.TextEmbeddingCacheTTL.GetAsDuration(time.Second)),
+		size:      size,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Remove(key)
+	c.entries.Add(key, entry)
+	c.size += size
+	for c.size > c.maxSize {
+		c.entries.RemoveOldest()
+	}
+}
+
+func (c *embeddingCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// cloneEmbedding copies the embedding, so that the cached one is not modified by the callers.
+func cloneEmbedding(embedding any) any {
+	switch embd := embedding.(type) {
+	case []float32:
+		return slices.Clone(embd)
+	case []int8:
+		return slices.Clone(embd)
+	default:
+		return embedding
+	}
+}
+
+var (
+	globalEmbeddingCacheOnce sync.Once
+	globalEmbeddingCache     *embeddingCache
+)
+
+func getEmbeddingCache() *embeddingCache {
+	globalEmbeddingCacheOnce.Do(func() {
+		globalEmbeddingCache = newEmbeddingCache(paramtable.Get().FunctionCfg.TextEmbeddingCacheMaxSize.GetAsInt64() * 1024 * 1024)
+	})
+	return globalEmbeddingCache
+}
+
+// cachedEmbeddingProvider serves the embeddings of repeated texts from cache,
+// only the missed texts are sent to the model service.
+type cachedEmbeddingProvider struct {
+	textEmbeddingProvider
+
+	cache      *embeddingCache
+	provider   string
+	searchOnly bool
+
+	dbName         string
+	collectionName string
+	functionName   string
+	// the sorted params of the function
+	params string
+}
+
+func newCachedEmbeddingProvider(embP textEmbeddingProvider, base *FunctionBase, dbName string, coll *schemapb.CollectionSchema, functionSchema *schemapb.FunctionSchema) *cachedEmbeddingProvider {
+	params := make([]string, 0, len(functionSchema.GetParams()))
+	for _, param := range functionSchema.GetParams() {
+		key := strings.ToLower(param.GetKey())
+		// turning on or off the cache doesn't change the embeddings
+		if key == cacheParamKey {
+			continue
+		}
+		params = append(params, key+"="+param.GetValue())
+	}
+	sort.Strings(params)
+	return &cachedEmbeddingProvider{
+		textEmbeddingProvider: embP,
+		cache:                 getEmbeddingCache(),
+		provider:              base.provider,
+		searchOnly:            paramtable.Get().FunctionCfg.TextEmbeddingCacheSearchOnly.GetAsBool(),
+		dbName:                dbName,
+		collectionName:        coll.GetName(),
+		functionName:          base.functionName,
+		params:                strings.Join(params, ","),
+	}
+}
+
+func (p *cachedEmbeddingProvider) key(text string, mode models.TextEmbeddingMode) embeddingCacheKey {
+	return embeddingCacheKey{
+		dbName:         p.dbName,
+		collectionName: p.collectionName,
+		functionName:   p.functionName,
+		params:         p.params,
+		mode:           mode,
+		text:           text,
+	}
+}
+
+func (p *cachedEmbeddingProvider) CallEmbedding(texts []string, mode models.TextEmbeddingMode) (any, error) {
+	if p.searchOnly && mode == models.InsertMode {
+		return p.textEmbeddingProvider.CallEmbedding(texts, mode)
+	}
+
+	results := make([]any, len(texts))
+	// the positions of each missed text, the duplicated texts are embedded once
+	missed := make(map[string][]int)
+	missedTexts := make([]string, 0)
+	for i, text := range texts {
+		if embd, ok := p.cache.Get(p.key(text, mode)); ok {
+			results[i] = embd
+			continue
+		}
+		if _, ok := missed[text]; !ok {
+			missedTexts = append(missedTexts, text)
+		}
+		missed[text] = append(missed[text], i)
+	}
+
+	nodeID := strconv.FormatInt(paramtable.GetNodeID(), 10)
+	metrics.ProxyFunctionEmbeddingCacheCounter.WithLabelValues(nodeID, p.collectionName, p.provider, p.functionName, metrics.CacheHitLabel).
+		Add(float64(len(texts) - len(missedTexts)))
+	metrics.ProxyFunctionEmbeddingCacheCounter.WithLabelValues(nodeID, p.collectionName, p.provider, p.functionName, metrics.CacheMissLabel).
+		Add(float64(len(missedTexts)))
+
+	if len(missedTexts) > 0 {
+		embds, err := p.textEmbeddingProvider.CallEmbedding(missedTexts, mode)
+		if err != nil {
+			return nil, err
+		}
+		fill := func(i int, embd any) {
+			p.cache.Add(p.key(missedTexts[i], mode), embd)
+			for _, pos := range missed[missedTexts[i]] {
+				results[pos] = embd
+			}
+		}
+		switch embds := embds.(type) {
+		case [][]float32:
+			if len(embds) != len(missedTexts) {
+				return nil, fmt.Errorf("Get embedding failed. The number of texts and embeddings does not match text:[%d], embedding:[%d]", len(missedTexts), len(embds))
+			}
+			for i, embd := range embds {
+				fill(i, embd)
+			}
+		case [][]int8:
+			if len(embds) != len(missedTexts) {
+				return nil, fmt.Errorf("Get embedding failed. The number of texts and embeddings does not match text:[%d], embedding:[%d]", len(missedTexts), len(embds))
+			}
+			for i, embd := range embds {
+				fill(i, embd)
+			}
+		default:
+			return nil, fmt.Errorf("Unsupport embedding type: %T", embds)
+		}
+	}
+	return mergeEmbeddings(results)
+}
+
+func mergeEmbeddings(results []any) (any, error) {
+	if len(results) == 0 {
+		return [][]float32{}, nil
+	}
+	switch results[0].(type) {
+	case []float32:
+		embds := make([][]float32, 0, len(results))
+		for _, r := range results {
+			embd, ok := r.([]float32)
+			if !ok {
+				return nil, fmt.Errorf("Unsupport embedding type: %T", r)
+			}
+			embds = append(embds, embd)
+		}
+		return embds, nil
+	case []int8:
+		embds := make([][]int8, 0, len(results))
+		for _, r := range results {
+			embd, ok := r.([]int8)
+			if !ok {
+				return nil, fmt.Errorf("Unsupport embedding type: %T", r)
+			}
+			embds = append(embds, embd)
+		}
+		return embds, nil
+	default:
+		return nil, fmt.Errorf("Unsupport embedding type: %T", results[0])
+	}
+}
@@ -0,0 +1,315 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+package embedding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/function/models"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+type countingEmbeddingProvider struct {
+	dim   int64
+	int8  bool
+	calls [][]string
+}
+
+func (p *countingEmbeddingProvider) MaxBatch() int {
+	return 10
+}
+
+func (p *countingEmbeddingProvider) FieldDim() int64 {
+	return p.dim
+}
+
+func (p *countingEmbeddingProvider) CallEmbedding(texts []string, mode models.TextEmbeddingMode) (any, error) {
+	p.calls = append(p.calls, texts)
+	if p.int8 {
+		embds := make([][]int8, 0, len(texts))
+		for _, text := range texts {
+			embds = append(embds, []int8{int8(len(text)), int8(mode)})
+		}
+		return embds, nil
+	}
+	embds := make([][]float32, 0, len(texts))
+	for _, text := range texts {
+		embds = append(embds, []float32{float32(len(text)), float32(mode)})
+	}
+	return embds, nil
+}
+
+func TestEmbeddingCache(t *testing.T) {
+	suite.Run(t, new(EmbeddingCacheSuite))
+}
+
+type EmbeddingCacheSuite struct {
+	suite.Suite
+	dbName string
+	schema *schemapb.CollectionSchema
+}
+
+func (s *EmbeddingCacheSuite) SetupTest() {
+	paramtable.Init()
+	s.dbName = "db1"
+	s.schema = &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "int64", DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: "dim", Value: "2"},
+				},
+			},
+		},
+	}
+}
+
+func (s *EmbeddingCacheSuite) newProvider(inner textEmbeddingProvider, functionName string) *cachedEmbeddingProvider {
+	functionSchema := &schemapb.FunctionSchema{
+		Name:             functionName,
+		Type:             schemapb.FunctionType_TextEmbedding,
+		InputFieldNames:  []string{"text"},
+		OutputFieldNames: []string{"vector"},
+		Params: []*commonpb.KeyValuePair{
+			{Key: Provider, Value: openAIProvider},
+			{Key: models.ModelNameParamKey, Value: "text-embedding-3-small"},
+		},
+	}
+	base, err := NewFunctionBase(s.schema, functionSchema)
+	s.Require().NoError(err)
+	return newCachedEmbeddingProvider(inner, base, s.dbName, s.schema, functionSchema)
+}
+
+func (s *EmbeddingCacheSuite) TestSearch() {
+	inner := &countingEmbeddingProvider{dim: 2}
+	provider := s.newProvider(inner, s.T().Name())
+	s.Equal(int64(2), provider.FieldDim())
+	s.Equal(10, provider.MaxBatch())
+
+	r, err := provider.CallEmbedding([]string{"a", "bb", "a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]float32{{1, 1}, {2, 1}, {1, 1}}, r)
+	// duplicated texts are embedded once
+	s.Equal([][]string{{"a", "bb"}}, inner.calls)
+
+	r, err = provider.CallEmbedding([]string{"ccc", "bb", "a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]float32{{3, 1}, {2, 1}, {1, 1}}, r)
+	s.Equal([][]string{{"a", "bb"}, {"ccc"}}, inner.calls)
+
+	// the returned embeddings are copies of the cached ones
+	r.([][]float32)[2][0] = 100
+	r, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]float32{{1, 1}}, r)
+	r.([][]float32)[0][0] = 100
+	r, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]float32{{1, 1}}, r)
+
+	// all hit
+	_, err = provider.CallEmbedding([]string{"ccc"}, models.SearchMode)
+	s.NoError(err)
+	s.Len(inner.calls, 2)
+
+	// the cache is shared by the providers of the same function
+	another := s.newProvider(inner, s.T().Name())
+	_, err = another.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Len(inner.calls, 2)
+}
+
+func (s *EmbeddingCacheSuite) TestInt8() {
+	inner := &countingEmbeddingProvider{dim: 2, int8: true}
+	provider := s.newProvider(inner, s.T().Name())
+
+	r, err := provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]int8{{1, 1}}, r)
+	r, err = provider.CallEmbedding([]string{"a", "bb"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]int8{{1, 1}, {2, 1}}, r)
+	s.Equal([][]string{{"a"}, {"bb"}}, inner.calls)
+}
+
+func (s *EmbeddingCacheSuite) TestSearchOnly() {
+	inner := &countingEmbeddingProvider{dim: 2}
+	provider := s.newProvider(inner, s.T().Name())
+	s.True(provider.searchOnly)
+
+	for i := 0; i < 2; i++ {
+		r, err := provider.CallEmbedding([]string{"a"}, models.InsertMode)
+		s.NoError(err)
+		s.Equal([][]float32{{1, 0}}, r)
+	}
+	s.Len(inner.calls, 2)
+
+	// insert and search embeddings are cached separately
+	provider.searchOnly = false
+	_, err := provider.CallEmbedding([]string{"a"}, models.InsertMode)
+	s.NoError(err)
+	_, err = provider.CallEmbedding([]string{"a"}, models.InsertMode)
+	s.NoError(err)
+	s.Len(inner.calls, 3)
+	r, err := provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]float32{{1, 1}}, r)
+	s.Len(inner.calls, 4)
+}
+
+func (s *EmbeddingCacheSuite) TestDifferentDatabases() {
+	inner := &countingEmbeddingProvider{dim: 2}
+	provider := s.newProvider(inner, s.T().Name())
+	_, err := provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+
+	// the collection of the same name in another database doesn't share the cache
+	s.dbName = "db2"
+	another := s.newProvider(inner, s.T().Name())
+	_, err = another.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Len(inner.calls, 2)
+
+	// neither does the function with different params
+	functionSchema := &schemapb.FunctionSchema{
+		Name:             s.T().Name(),
+		Type:             schemapb.FunctionType_TextEmbedding,
+		InputFieldNames:  []string{"text"},
+		OutputFieldNames: []string{"vector"},
+		Params: []*commonpb.KeyValuePair{
+			{Key: Provider, Value: openAIProvider},
+			{Key: models.ModelNameParamKey, Value: "text-embedding-3-large"},
+		},
+	}
+	base, err := NewFunctionBase(s.schema, functionSchema)
+	s.Require().NoError(err)
+	_, err = newCachedEmbeddingProvider(inner, base, s.dbName, s.schema, functionSchema).CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Len(inner.calls, 3)
+}
+
+func (s *EmbeddingCacheSuite) TestMaxSize() {
+	inner := &countingEmbeddingProvider{dim: 2}
+	provider := s.newProvider(inner, s.T().Name())
+	// each embedding takes 8 bytes, only "bb" and "ccc" could be kept
+	entrySize := func(text string) int64 {
+		return provider.key(text, models.SearchMode).size() + 8
+	}
+	provider.cache = newEmbeddingCache(entrySize("bb") + entrySize("ccc"))
+
+	_, err := provider.CallEmbedding([]string{"a", "bb", "ccc"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal(2, provider.cache.Len())
+	s.Equal(entrySize("bb")+entrySize("ccc"), provider.cache.size)
+
+	// "a" is evicted
+	_, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]string{{"a", "bb", "ccc"}, {"a"}}, inner.calls)
+
+	// the entry larger than the cache is not cached
+	provider.cache = newEmbeddingCache(entrySize("a") - 1)
+	_, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal(0, provider.cache.Len())
+}
+
+func (s *EmbeddingCacheSuite) TestTTL() {
+	paramtable.Get().Save(paramtable.Get().FunctionCfg.TextEmbeddingCacheTTL.Key, "0.05")
+	defer paramtable.Get().Reset(paramtable.Get().FunctionCfg.TextEmbeddingCacheTTL.Key)
+
+	inner := &countingEmbeddingProvider{dim: 2}
+	provider := s.newProvider(inner, s.T().Name())
+	provider.cache = newEmbeddingCache(1024)
+	_, err := provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	_, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Len(inner.calls, 1)
+
+	// the expired entry is dropped when read
+	time.Sleep(100 * time.Millisecond)
+	_, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Len(inner.calls, 2)
+	s.Equal(1, provider.cache.Len())
+}
+
+func (s *EmbeddingCacheSuite) TestTextEmbeddingFunction() {
+	paramtable.Get().Save(paramtable.Get().FunctionCfg.TextEmbeddingCacheEnabled.Key, "true")
+	defer paramtable.Get().Reset(paramtable.Get().FunctionCfg.TextEmbeddingCacheEnabled.Key)
+
+	newFunction := func(cache string) (*TextEmbeddingFunction, error) {
+		functionSchema := &schemapb.FunctionSchema{
+			Name:             s.T().Name(),
+			Type:             schemapb.FunctionType_TextEmbedding,
+			InputFieldNames:  []string{"text"},
+			OutputFieldNames: []string{"vector"},
+			InputFieldIds:    []int64{101},
+			OutputFieldIds:   []int64{102},
+			Params: []*commonpb.KeyValuePair{
+				{Key: Provider, Value: openAICompatibleProvider},
+				{Key: models.EndpointParamKey, Value: "http://localhost:8080/v1/embeddings"},
+			},
+		}
+		if cache != "" {
+			functionSchema.Params = append(functionSchema.Params, &commonpb.KeyValuePair{Key: cacheParamKey, Value: cache})
+		}
+		return NewTextEmbeddingFunction(s.dbName, s.schema, functionSchema)
+	}
+
+	runner, err := newFunction("true")
+	s.NoError(err)
+	provider, ok := runner.embProvider.(*cachedEmbeddingProvider)
+	s.True(ok)
+	s.Equal(s.dbName, provider.dbName)
+	// the switch is not a part of the function params of the key
+	s.NotContains(provider.params, cacheParamKey)
+
+	// the cache could be turned off per function
+	runner, err = newFunction("false")
+	s.NoError(err)
+	_, ok = runner.embProvider.(*cachedEmbeddingProvider)
+	s.False(ok)
+	runner, err = newFunction("")
+	s.NoError(err)
+	_, ok = runner.embProvider.(*cachedEmbeddingProvider)
+	s.True(ok)
+
+	_, err = newFunction("invalid")
+	s.Error(err)
+
+	// or turned on per function
+	paramtable.Get().Save(paramtable.Get().FunctionCfg.TextEmbeddingCacheEnabled.Key, "false")
+	runner, err = newFunction("true")
+	s.NoError(err)
+	_, ok = runner.embProvider.(*cachedEmbeddingProvider)
+	s.True(ok)
+	runner, err = newFunction("")
+	s.NoError(err)
+	_, ok = runner.embProvider.(*cachedEmbeddingProvider)
+	s.False(ok)
+}
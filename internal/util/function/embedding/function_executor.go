@@ -57,12 +57,12 @@ type FunctionExecutor struct {
 	runners map[int64]Runner
 }
 
-func createFunction(coll *schemapb.CollectionSchema, schema *schemapb.FunctionSchema) (Runner, error) {
+func createFunction(dbName string, coll *schemapb.CollectionSchema, schema *schemapb.FunctionSchema) (Runner, error) {
 	switch schema.GetType() {
 	case schemapb.FunctionType_BM25: // ignore bm25 function
 		return nil, nil
 	case schemapb.FunctionType_TextEmbedding:
-		f, err := NewTextEmbeddingFunction(coll, schema)
+		f, err := NewTextEmbeddingFunction(dbName, coll, schema)
 		if err != nil {
 			return nil, err
 		}
@@ -75,7 +75,8 @@ func createFunction(coll *schemapb.CollectionSchema, schema *schemapb.FunctionSc
 // Since bm25 and embedding are implemented in different ways, the bm25 function is not verified here.
 func ValidateFunctions(schema *schemapb.CollectionSchema) error {
 	for _, fSchema := range schema.Functions {
-		f, err := createFunction(schema, fSchema)
+		// the embeddings of check are not cached, so the db name is not needed
+		f, err := createFunction("", schema, fSchema)
 		if err != nil {
 			return err
 		}
@@ -91,12 +92,13 @@ func ValidateFunctions(schema *schemapb.CollectionSchema) error {
 	return nil
 }
 
-func NewFunctionExecutor(schema *schemapb.CollectionSchema) (*FunctionExecutor, error) {
+// NewFunctionExecutor creates the executor of the functions of collection, the collection is identified by the db name and schema name.
+func NewFunctionExecutor(dbName string, schema *schemapb.CollectionSchema) (*FunctionExecutor, error) {
 	executor := &FunctionExecutor{
 		runners: make(map[int64]Runner),
 	}
 	for _, fSchema := range schema.Functions {
-		runner, err := createFunction(schema, fSchema)
+		runner, err := createFunction(dbName, schema, fSchema)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,190 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package embedding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/credentials"
+	"github.com/milvus-io/milvus/internal/util/function/models"
+	"github.com/milvus-io/milvus/internal/util/function/models/httpjson"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// HTTPJSONEmbeddingProvider calls the self-hosted model services, e.g. llama.cpp, Ollama, vLLM.
+// openai_compatible speaks the OpenAI embedding protocol by default, while the request and
+// response layout of http_json must be configured by function params.
+type HTTPJSONEmbeddingProvider struct {
+	fieldDim int64
+
+	client *httpjson.Client
+
+	ingestionPrompt string
+	searchPrompt    string
+
+	maxBatch   int
+	timeoutSec int64
+}
+
+func newHTTPJSONEmbeddingProvider(fieldSchema *schemapb.FieldSchema, functionSchema *schemapb.FunctionSchema, params map[string]string, credentials *credentials.Credentials, openaiCompatible bool) (*HTTPJSONEmbeddingProvider, error) {
+	if fieldSchema.GetDataType() != schemapb.DataType_FloatVector {
+		return nil, fmt.Errorf("Text embedding provider [%s] only supports FloatVector output field, but got %s", functionSchema.Name, fieldSchema.GetDataType().String())
+	}
+	fieldDim, err := typeutil.GetDim(fieldSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var endpoint, ingestionPrompt, searchPrompt string
+	config := &httpjson.ClientConfig{
+		AuthHeader: "Authorization",
+		AuthPrefix: "Bearer ",
+		ModelPath:  "model",
+	}
+	if openaiCompatible {
+		config.InputPath = "input"
+		config.EmbeddingPath = "data.*.embedding"
+	}
+	embeddingPathSet := false
+	maxBatch := 32
+
+	for _, param := range functionSchema.Params {
+		switch strings.ToLower(param.Key) {
+		case models.EndpointParamKey:
+			endpoint = param.Value
+		case models.ModelNameParamKey:
+			config.ModelName = param.Value
+		case models.DimParamKey:
+			if _, err := models.ParseAndCheckFieldDim(param.Value, fieldDim, fieldSchema.Name); err != nil {
+				return nil, err
+			}
+		case models.IngestionPromptParamKey:
+			ingestionPrompt = param.Value
+		case models.SearchPromptParamKey:
+			searchPrompt = param.Value
+		case models.MaxClientBatchSizeParamKey:
+			if maxBatch, err = strconv.Atoi(param.Value); err != nil || maxBatch <= 0 {
+				return nil, fmt.Errorf("[%s param's value: %s] is not a valid positive number", models.MaxClientBatchSizeParamKey, param.Value)
+			}
+		case models.RequestTemplateParamKey:
+			config.RequestTemplate = param.Value
+		case models.InputPathParamKey:
+			config.InputPath = param.Value
+		case models.ModelPathParamKey:
+			config.ModelPath = param.Value
+		case models.EmbeddingPathParamKey:
+			config.EmbeddingPath = param.Value
+			embeddingPathSet = true
+		case models.AuthHeaderParamKey:
+			config.AuthHeader = param.Value
+		case models.AuthPrefixParamKey:
+			config.AuthPrefix = param.Value
+		default:
+		}
+	}
+	if config.InputPath == "" {
+		return nil, fmt.Errorf("Function param [%s] is required", models.InputPathParamKey)
+	}
+	if !openaiCompatible && !embeddingPathSet {
+		return nil, fmt.Errorf("Function param [%s] is required", models.EmbeddingPathParamKey)
+	}
+
+	apiKey, url, err := models.ParseAKAndURL(credentials, functionSchema.Params, params, models.HTTPJSONAKEnvStr)
+	if err != nil {
+		return nil, err
+	}
+	if endpoint == "" {
+		endpoint = url
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("Function param [%s] is required", models.EndpointParamKey)
+	}
+	config.Endpoint = endpoint
+	config.APIKey = apiKey
+
+	c, err := httpjson.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &HTTPJSONEmbeddingProvider{
+		client:          c,
+		fieldDim:        fieldDim,
+		ingestionPrompt: ingestionPrompt,
+		searchPrompt:    searchPrompt,
+		maxBatch:        maxBatch,
+		timeoutSec:      30,
+	}, nil
+}
+
+func NewOpenAICompatibleEmbeddingProvider(fieldSchema *schemapb.FieldSchema, functionSchema *schemapb.FunctionSchema, params map[string]string, credentials *credentials.Credentials) (*HTTPJSONEmbeddingProvider, error) {
+	return newHTTPJSONEmbeddingProvider(fieldSchema, functionSchema, params, credentials, true)
+}
+
+func NewHTTPJSONEmbeddingProvider(fieldSchema *schemapb.FieldSchema, functionSchema *schemapb.FunctionSchema, params map[string]string, credentials *credentials.Credentials) (*HTTPJSONEmbeddingProvider, error) {
+	return newHTTPJSONEmbeddingProvider(fieldSchema, functionSchema, params, credentials, false)
+}
+
+func (provider *HTTPJSONEmbeddingProvider) MaxBatch() int {
+	return 5 * provider.maxBatch
+}
+
+func (provider *HTTPJSONEmbeddingProvider) FieldDim() int64 {
+	return provider.fieldDim
+}
+
+func (provider *HTTPJSONEmbeddingProvider) CallEmbedding(texts []string, mode models.TextEmbeddingMode) (any, error) {
+	numRows := len(texts)
+	data := make([][]float32, 0, numRows)
+	prompt := provider.searchPrompt
+	if mode == models.InsertMode {
+		prompt = provider.ingestionPrompt
+	}
+
+	for i := 0; i < numRows; i += provider.maxBatch {
+		end := i + provider.maxBatch
+		if end > numRows {
+			end = numRows
+		}
+		inputs := texts[i:end]
+		if prompt != "" {
+			inputs = make([]string, 0, end-i)
+			for _, text := range texts[i:end] {
+				inputs = append(inputs, prompt+text)
+			}
+		}
+		resp, err := provider.client.Embedding(inputs, provider.timeoutSec)
+		if err != nil {
+			return nil, err
+		}
+		if end-i != len(resp) {
+			return nil, fmt.Errorf("Get embedding failed. The number of texts and embeddings does not match text:[%d], embedding:[%d]", end-i, len(resp))
+		}
+		for _, item := range resp {
+			if len(item) != int(provider.fieldDim) {
+				return nil, fmt.Errorf("The required embedding dim is [%d], but the embedding obtained from the model is [%d]",
+					provider.fieldDim, len(item))
+			}
+			data = append(data, item)
+		}
+	}
+	return data, nil
+}
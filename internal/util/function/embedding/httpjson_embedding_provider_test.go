@@ -0,0 +1,263 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+package embedding
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/credentials"
+	"github.com/milvus-io/milvus/internal/util/function/models"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func TestHTTPJSONEmbeddingProvider(t *testing.T) {
+	suite.Run(t, new(HTTPJSONEmbeddingProviderSuite))
+}
+
+type HTTPJSONEmbeddingProviderSuite struct {
+	suite.Suite
+	schema *schemapb.CollectionSchema
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) SetupTest() {
+	paramtable.Init()
+	s.schema = &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "int64", DataType: schemapb.DataType_Int64},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: "dim", Value: "4"},
+				},
+			},
+			{
+				FieldID: 103, Name: "int8_vector", DataType: schemapb.DataType_Int8Vector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: "dim", Value: "4"},
+				},
+			},
+		},
+	}
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) functionSchema(params ...*commonpb.KeyValuePair) *schemapb.FunctionSchema {
+	return &schemapb.FunctionSchema{
+		Name:             "test",
+		Type:             schemapb.FunctionType_Unknown,
+		InputFieldNames:  []string{"text"},
+		OutputFieldNames: []string{"vector"},
+		InputFieldIds:    []int64{101},
+		OutputFieldIds:   []int64{102},
+		Params:           params,
+	}
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) credentials() *credentials.Credentials {
+	return credentials.NewCredentials(map[string]string{"mock.apikey": "mock"})
+}
+
+// createOpenAICompatibleServer returns the embeddings in OpenAI format, the value of
+// each embedding is the length of the text.
+func createOpenAICompatibleServer(dim int, requests *[]map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		if requests != nil {
+			*requests = append(*requests, req)
+		}
+		if r.Header.Get("Authorization") != "Bearer mock" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		data := make([]map[string]any, 0)
+		for i, text := range req["input"].([]any) {
+			embedding := make([]float32, dim)
+			for j := range embedding {
+				embedding[j] = float32(len(text.(string)))
+			}
+			data = append(data, map[string]any{"index": i, "embedding": embedding})
+		}
+		resp, _ := json.Marshal(map[string]any{"object": "list", "data": data})
+		w.WriteHeader(http.StatusOK)
+		w.Write(resp)
+	}))
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) TestOpenAICompatibleEmbedding() {
+	var requests []map[string]any
+	ts := createOpenAICompatibleServer(4, &requests)
+	defer ts.Close()
+
+	provider, err := NewOpenAICompatibleEmbeddingProvider(s.schema.Fields[2], s.functionSchema(
+		&commonpb.KeyValuePair{Key: models.CredentialParamKey, Value: "mock"},
+		&commonpb.KeyValuePair{Key: models.EndpointParamKey, Value: ts.URL},
+		&commonpb.KeyValuePair{Key: models.ModelNameParamKey, Value: "nomic-embed-text"},
+		&commonpb.KeyValuePair{Key: models.DimParamKey, Value: "4"},
+		&commonpb.KeyValuePair{Key: models.SearchPromptParamKey, Value: "q:"},
+		&commonpb.KeyValuePair{Key: models.MaxClientBatchSizeParamKey, Value: "2"},
+	), map[string]string{}, s.credentials())
+	s.NoError(err)
+	s.Equal(int64(4), provider.FieldDim())
+	s.Equal(10, provider.MaxBatch())
+
+	r, err := provider.CallEmbedding([]string{"a", "bb", "ccc"}, models.InsertMode)
+	s.NoError(err)
+	s.Equal([][]float32{{1, 1, 1, 1}, {2, 2, 2, 2}, {3, 3, 3, 3}}, r)
+	// split into batches
+	s.Len(requests, 2)
+	s.Equal("nomic-embed-text", requests[0]["model"])
+	s.Equal([]any{"a", "bb"}, requests[0]["input"])
+
+	r, err = provider.CallEmbedding([]string{"a"}, models.SearchMode)
+	s.NoError(err)
+	s.Equal([][]float32{{3, 3, 3, 3}}, r)
+	s.Equal([]any{"q:a"}, requests[2]["input"])
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) TestHTTPJSONEmbedding() {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "mock" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		texts := req["texts"].([]any)
+		embeddings := make([][]float32, 0)
+		for range texts {
+			embeddings = append(embeddings, []float32{0.1, 0.2, 0.3, 0.4})
+		}
+		resp, _ := json.Marshal(map[string]any{"model": req["model"], "embeddings": embeddings})
+		w.WriteHeader(http.StatusOK)
+		w.Write(resp)
+	}))
+	defer ts.Close()
+
+	provider, err := NewHTTPJSONEmbeddingProvider(s.schema.Fields[2], s.functionSchema(
+		&commonpb.KeyValuePair{Key: models.CredentialParamKey, Value: "mock"},
+		&commonpb.KeyValuePair{Key: models.EndpointParamKey, Value: ts.URL},
+		&commonpb.KeyValuePair{Key: models.ModelNameParamKey, Value: "bge-m3"},
+		&commonpb.KeyValuePair{Key: models.RequestTemplateParamKey, Value: `{"truncate": true}`},
+		&commonpb.KeyValuePair{Key: models.InputPathParamKey, Value: "texts"},
+		&commonpb.KeyValuePair{Key: models.EmbeddingPathParamKey, Value: "embeddings"},
+		&commonpb.KeyValuePair{Key: models.AuthHeaderParamKey, Value: "X-Api-Key"},
+		&commonpb.KeyValuePair{Key: models.AuthPrefixParamKey, Value: ""},
+	), map[string]string{}, s.credentials())
+	s.NoError(err)
+
+	r, err := provider.CallEmbedding([]string{"a", "b"}, models.InsertMode)
+	s.NoError(err)
+	s.Equal([][]float32{{0.1, 0.2, 0.3, 0.4}, {0.1, 0.2, 0.3, 0.4}}, r)
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) TestEmbeddingMismatch() {
+	for _, resp := range []string{
+		// dim not match
+		`{"data": [{"embedding": [0.1, 0.2, 0.3]}]}`,
+		// number not match
+		`{"data": [{"embedding": [0.1, 0.2, 0.3, 0.4]}, {"embedding": [0.1, 0.2, 0.3, 0.4]}]}`,
+	} {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(resp))
+		}))
+		provider, err := NewOpenAICompatibleEmbeddingProvider(s.schema.Fields[2], s.functionSchema(
+			&commonpb.KeyValuePair{Key: models.EndpointParamKey, Value: ts.URL},
+		), map[string]string{}, s.credentials())
+		s.NoError(err)
+		_, err = provider.CallEmbedding([]string{"a"}, models.InsertMode)
+		s.Error(err)
+		ts.Close()
+	}
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) TestNewProvider() {
+	// endpoint from milvus.yaml
+	{
+		_, err := NewOpenAICompatibleEmbeddingProvider(s.schema.Fields[2], s.functionSchema(), map[string]string{models.URLParamKey: "http://localhost:8080/v1/embeddings"}, s.credentials())
+		s.NoError(err)
+	}
+
+	invalidCases := []struct {
+		field    *schemapb.FieldSchema
+		params   []*commonpb.KeyValuePair
+		httpJSON bool
+	}{
+		// missing endpoint
+		{s.schema.Fields[2], nil, false},
+		// invalid endpoint
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "localhost"}}, false},
+		// int8 vector is not supported
+		{s.schema.Fields[3], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}}, false},
+		// dim not match
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}, {Key: models.DimParamKey, Value: "8"}}, false},
+		// invalid batch size
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}, {Key: models.MaxClientBatchSizeParamKey, Value: "0"}}, false},
+		// invalid template
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}, {Key: models.RequestTemplateParamKey, Value: "invalid"}}, false},
+		// http json requires the paths
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}}, true},
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}, {Key: models.InputPathParamKey, Value: "input"}}, true},
+		{s.schema.Fields[2], []*commonpb.KeyValuePair{{Key: models.EndpointParamKey, Value: "http://localhost"}, {Key: models.EmbeddingPathParamKey, Value: "data"}}, true},
+	}
+	for i, c := range invalidCases {
+		var err error
+		if c.httpJSON {
+			_, err = NewHTTPJSONEmbeddingProvider(c.field, s.functionSchema(c.params...), map[string]string{}, s.credentials())
+		} else {
+			_, err = NewOpenAICompatibleEmbeddingProvider(c.field, s.functionSchema(c.params...), map[string]string{}, s.credentials())
+		}
+		s.Error(err, fmt.Sprintf("case %d", i))
+	}
+}
+
+func (s *HTTPJSONEmbeddingProviderSuite) TestTextEmbeddingFunction() {
+	ts := createOpenAICompatibleServer(4, nil)
+	defer ts.Close()
+
+	for _, provider := range []string{openAICompatibleProvider, httpJSONProvider} {
+		_, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
+			Name:             "test",
+			Type:             schemapb.FunctionType_TextEmbedding,
+			InputFieldNames:  []string{"text"},
+			OutputFieldNames: []string{"vector"},
+			InputFieldIds:    []int64{101},
+			OutputFieldIds:   []int64{102},
+			Params: []*commonpb.KeyValuePair{
+				{Key: Provider, Value: provider},
+				{Key: models.EndpointParamKey, Value: ts.URL},
+				{Key: models.InputPathParamKey, Value: "input"},
+				{Key: models.EmbeddingPathParamKey, Value: "data.*.embedding"},
+			},
+		})
+		s.NoError(err, provider)
+	}
+}
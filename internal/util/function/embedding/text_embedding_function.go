@@ -48,6 +48,9 @@ const (
 	cohereProvider       string = "cohere"
 	siliconflowProvider  string = "siliconflow"
 	teiProvider          string = "tei"
+
+	openAICompatibleProvider string = "openai_compatible"
+	httpJSONProvider         string = "http_json"
 )
 
 func hasEmptyString(texts []string) bool {
@@ -83,7 +86,7 @@ func isValidInputDataType(dataType schemapb.DataType) bool {
 	return dataType == schemapb.DataType_VarChar || dataType == schemapb.DataType_Text
 }
 
-func NewTextEmbeddingFunction(coll *schemapb.CollectionSchema, functionSchema *schemapb.FunctionSchema) (*TextEmbeddingFunction, error) {
+func NewTextEmbeddingFunction(dbName string, coll *schemapb.CollectionSchema, functionSchema *schemapb.FunctionSchema) (*TextEmbeddingFunction, error) {
 	if len(functionSchema.GetOutputFieldNames()) != 1 {
 		return nil, fmt.Errorf("Text function should only have one output field, but now is %d", len(functionSchema.GetOutputFieldNames()))
 	}
@@ -123,13 +126,24 @@ func NewTextEmbeddingFunction(coll *schemapb.CollectionSchema, functionSchema *s
 		embP, newProviderErr = NewSiliconflowEmbeddingProvider(base.outputFields[0], functionSchema, conf, credentials)
 	case teiProvider:
 		embP, newProviderErr = NewTEIEmbeddingProvider(base.outputFields[0], functionSchema, conf, credentials)
+	case openAICompatibleProvider:
+		embP, newProviderErr = NewOpenAICompatibleEmbeddingProvider(base.outputFields[0], functionSchema, conf, credentials)
+	case httpJSONProvider:
+		embP, newProviderErr = NewHTTPJSONEmbeddingProvider(base.outputFields[0], functionSchema, conf, credentials)
 	default:
-		return nil, fmt.Errorf("Unsupported text embedding service provider: [%s] , list of supported [%s, %s, %s, %s, %s, %s, %s, %s, %s]", base.provider, openAIProvider, azureOpenAIProvider, aliDashScopeProvider, bedrockProvider, vertexAIProvider, voyageAIProvider, cohereProvider, siliconflowProvider, teiProvider)
+		return nil, fmt.Errorf("Unsupported text embedding service provider: [%s] , list of supported [%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s]", base.provider, openAIProvider, azureOpenAIProvider, aliDashScopeProvider, bedrockProvider, vertexAIProvider, voyageAIProvider, cohereProvider, siliconflowProvider, teiProvider, openAICompatibleProvider, httpJSONProvider)
 	}
 
 	if newProviderErr != nil {
 		return nil, newProviderErr
 	}
+	cacheEnabled, err := isCacheEnabled(functionSchema)
+	if err != nil {
+		return nil, err
+	}
+	if cacheEnabled {
+		embP = newCachedEmbeddingProvider(embP, base, dbName, coll, functionSchema)
+	}
 	return &TextEmbeddingFunction{
 		FunctionBase: *base,
 		embProvider:  embP,
@@ -137,7 +151,12 @@ func NewTextEmbeddingFunction(coll *schemapb.CollectionSchema, functionSchema *s
 }
 
 func (runner *TextEmbeddingFunction) Check() error {
-	embds, err := runner.embProvider.CallEmbedding([]string{"check"}, models.InsertMode)
+	embP := runner.embProvider
+	// check the model service rather than the cache
+	if cached, ok := embP.(*cachedEmbeddingProvider); ok {
+		embP = cached.textEmbeddingProvider
+	}
+	embds, err := embP.CallEmbedding([]string{"check"}, models.InsertMode)
 	if err != nil {
 		return err
 	}
@@ -124,7 +124,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessInsert() {
 				key: ts.URL,
 			}
 		}
-		runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+		runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 			Name:             "test",
 			Type:             schemapb.FunctionType_TextEmbedding,
 			InputFieldNames:  []string{"text"},
@@ -161,7 +161,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessInsert() {
 				key: ts.URL,
 			}
 		}
-		runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+		runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 			Name:             "test",
 			Type:             schemapb.FunctionType_TextEmbedding,
 			InputFieldNames:  []string{"text"},
@@ -203,7 +203,7 @@ func (s *TextEmbeddingFunctionSuite) TestAliEmbedding() {
 		}
 	}
 
-	runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -348,7 +348,7 @@ func (s *TextEmbeddingFunctionSuite) TestRunnerParamsErr() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(schema, &schemapb.FunctionSchema{
+		_, err := NewTextEmbeddingFunction("default", schema, &schemapb.FunctionSchema{
 			Name:             "test",
 			Type:             schemapb.FunctionType_TextEmbedding,
 			InputFieldNames:  []string{"text"},
@@ -386,7 +386,7 @@ func (s *TextEmbeddingFunctionSuite) TestRunnerParamsErr() {
 				},
 			},
 		}
-		_, err := NewTextEmbeddingFunction(schema, &schemapb.FunctionSchema{
+		_, err := NewTextEmbeddingFunction("default", schema, &schemapb.FunctionSchema{
 			Name:             "test",
 			Type:             schemapb.FunctionType_TextEmbedding,
 			InputFieldNames:  []string{"text"},
@@ -405,7 +405,7 @@ func (s *TextEmbeddingFunctionSuite) TestRunnerParamsErr() {
 
 	// outputfield miss
 	{
-		_, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+		_, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 			Name:             "test",
 			Type:             schemapb.FunctionType_TextEmbedding,
 			InputFieldNames:  []string{"text"},
@@ -424,7 +424,7 @@ func (s *TextEmbeddingFunctionSuite) TestRunnerParamsErr() {
 
 	// no openai api  key
 	{
-		_, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+		_, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 			Name:             "test",
 			Type:             schemapb.FunctionType_TextEmbedding,
 			InputFieldNames:  []string{"text"},
@@ -457,10 +457,10 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.NoError(err)
 		fSchema.Params = []*commonpb.KeyValuePair{}
-		_, err = NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err = NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -479,10 +479,10 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.NoError(err)
 		fSchema.Params = []*commonpb.KeyValuePair{}
-		_, err = NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err = NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -501,10 +501,10 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.NoError(err)
 		fSchema.Params = []*commonpb.KeyValuePair{}
-		_, err = NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err = NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -523,10 +523,10 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.NoError(err)
 		fSchema.Params = []*commonpb.KeyValuePair{}
-		_, err = NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err = NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -545,10 +545,10 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.NoError(err)
 		fSchema.Params = []*commonpb.KeyValuePair{}
-		_, err = NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err = NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -565,10 +565,10 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 				{Key: "endpoint", Value: "http://mock.com"},
 			},
 		}
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.NoError(err)
 		fSchema.Params = []*commonpb.KeyValuePair{}
-		_, err = NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err = NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -584,7 +584,7 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			Params:           []*commonpb.KeyValuePair{},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -601,7 +601,7 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 			},
 		}
 
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 
@@ -618,7 +618,7 @@ func (s *TextEmbeddingFunctionSuite) TestNewTextEmbeddings() {
 				{Key: Provider, Value: "tei"},
 			},
 		}
-		_, err := NewTextEmbeddingFunction(s.schema, fSchema)
+		_, err := NewTextEmbeddingFunction("default", s.schema, fSchema)
 		s.Error(err)
 	}
 }
@@ -632,7 +632,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessSearchFloat32() {
 			key: ts.URL,
 		}
 	}
-	runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -722,7 +722,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessInsertInt8() {
 			key: ts.URL,
 		}
 	}
-	runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -768,7 +768,7 @@ func (s *TextEmbeddingFunctionSuite) TestUnsupportedVec() {
 			},
 		},
 	}
-	_, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	_, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -809,7 +809,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessSearchInt8() {
 			key: ts.URL,
 		}
 	}
-	runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -885,7 +885,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessBulkInsertFloat32() {
 			key: ts.URL,
 		}
 	}
-	runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -975,7 +975,7 @@ func (s *TextEmbeddingFunctionSuite) TestProcessBulkInsertInt8() {
 			key: ts.URL,
 		}
 	}
-	runner, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	runner, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
@@ -1007,7 +1007,7 @@ func (s *TextEmbeddingFunctionSuite) TestDisable() {
 			key: "false",
 		}
 	}
-	_, err := NewTextEmbeddingFunction(s.schema, &schemapb.FunctionSchema{
+	_, err := NewTextEmbeddingFunction("default", s.schema, &schemapb.FunctionSchema{
 		Name:             "test",
 		Type:             schemapb.FunctionType_TextEmbedding,
 		InputFieldNames:  []string{"text"},
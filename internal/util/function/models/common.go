@@ -136,6 +136,19 @@ const (
 	TeiTruncateParamName string = "truncate"
 )
 
+// openai compatible and http json
+
+const (
+	RequestTemplateParamKey string = "request_template"
+	InputPathParamKey       string = "input_path"
+	ModelPathParamKey       string = "model_path"
+	EmbeddingPathParamKey   string = "embedding_path"
+	AuthHeaderParamKey      string = "auth_header"
+	AuthPrefixParamKey      string = "auth_prefix"
+
+	HTTPJSONAKEnvStr string = "MILVUS_HTTP_JSON_API_KEY"
+)
+
 func ParseAKAndURL(credentials *credentials.Credentials, params []*commonpb.KeyValuePair, confParams map[string]string, apiKeyEnv string) (string, string, error) {
 	// function param > yaml > env
 	var err error
@@ -0,0 +1,231 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus/internal/util/function/models"
+)
+
+// Client calls an embedding service speaking a custom JSON protocol. The texts are put into
+// the request body at InputPath, and the embeddings are picked from the response at EmbeddingPath.
+//
+// A path is a dot separated list of object keys or array indexes, "*" matches every element
+// of an array, e.g. "data.*.embedding" picks the embeddings of an OpenAI compatible response.
+// An empty path refers to the whole document.
+type Client struct {
+	url     string
+	headers map[string]string
+
+	requestTemplate map[string]any
+	inputPath       string
+	embeddingPath   string
+}
+
+type ClientConfig struct {
+	Endpoint string
+	// AuthHeader and AuthPrefix compose the auth header, e.g. "Authorization: Bearer <api key>"
+	APIKey     string
+	AuthHeader string
+	AuthPrefix string
+
+	// RequestTemplate is the json object merged into every request, e.g. {"model": "bge-m3"}
+	RequestTemplate string
+	InputPath       string
+	EmbeddingPath   string
+	// ModelName is put into the request at ModelPath if it is not empty
+	ModelName string
+	ModelPath string
+}
+
+func NewClient(config *ClientConfig) (*Client, error) {
+	base, err := models.NewBaseURL(config.Endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if config.InputPath == "" {
+		return nil, fmt.Errorf("The input path of the request must be set")
+	}
+	template := map[string]any{}
+	if config.RequestTemplate != "" {
+		if err := json.Unmarshal([]byte(config.RequestTemplate), &template); err != nil {
+			return nil, fmt.Errorf("Request template [%s] is not a valid json object, errs:[%v]", config.RequestTemplate, err)
+		}
+		if template == nil {
+			template = map[string]any{}
+		}
+	}
+	if config.ModelName != "" {
+		if err := setPath(template, config.ModelPath, config.ModelName); err != nil {
+			return nil, err
+		}
+	}
+	headers := map[string]string{
+		"Content-Type": "application/json",
+	}
+	if config.APIKey != "" {
+		headers[config.AuthHeader] = config.AuthPrefix + config.APIKey
+	}
+	return &Client{
+		url:             base.String(),
+		headers:         headers,
+		requestTemplate: template,
+		inputPath:       config.InputPath,
+		embeddingPath:   config.EmbeddingPath,
+	}, nil
+}
+
+func (c *Client) newRequest(texts []string) (map[string]any, error) {
+	// deep copy the template, since the nested objects may be modified
+	data, err := json.Marshal(c.requestTemplate)
+	if err != nil {
+		return nil, err
+	}
+	req := map[string]any{}
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, err
+	}
+	if err := setPath(req, c.inputPath, texts); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (c *Client) Embedding(texts []string, timeoutSec int64) ([][]float32, error) {
+	req, err := c.newRequest(texts)
+	if err != nil {
+		return nil, err
+	}
+	res, err := models.PostRequest[any](req, c.url, c.headers, timeoutSec)
+	if err != nil {
+		return nil, err
+	}
+	values, err := getPath(*res, c.embeddingPath)
+	if err != nil {
+		return nil, err
+	}
+	// a single embedding is returned if the path doesn't contain "*"
+	items, ok := values.([]any)
+	if !ok {
+		return nil, fmt.Errorf("The embeddings at [%s] of the response should be a list", c.embeddingPath)
+	}
+	if len(items) > 0 {
+		if _, ok := items[0].([]any); !ok {
+			items = []any{items}
+		}
+	}
+	embeddings := make([][]float32, 0, len(items))
+	for _, item := range items {
+		embedding, err := toFloatVector(item)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, embedding)
+	}
+	return embeddings, nil
+}
+
+func toFloatVector(value any) ([]float32, error) {
+	arr, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("The embedding should be a list of numbers, but got [%v]", value)
+	}
+	vector := make([]float32, 0, len(arr))
+	for _, v := range arr {
+		f, ok := v.(float64)
+		if !ok {
+			return nil, fmt.Errorf("The embedding should be a list of numbers, but got [%v]", v)
+		}
+		vector = append(vector, float32(f))
+	}
+	return vector, nil
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// setPath sets the value into the json object, the missing objects on the path are created.
+func setPath(obj map[string]any, path string, value any) error {
+	keys := splitPath(path)
+	if len(keys) == 0 {
+		return fmt.Errorf("The path in the request must be set")
+	}
+	current := obj
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key]
+		if !ok {
+			child := map[string]any{}
+			current[key] = child
+			current = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("Can not set the value at [%s], [%s] of the request template is not an object", path, key)
+		}
+		current = child
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+// getPath picks the value from the json document, the values matched by "*" are returned as a list.
+func getPath(doc any, path string) (any, error) {
+	return getByKeys(doc, splitPath(path), path)
+}
+
+func getByKeys(doc any, keys []string, path string) (any, error) {
+	if len(keys) == 0 {
+		return doc, nil
+	}
+	key := keys[0]
+	switch v := doc.(type) {
+	case map[string]any:
+		child, ok := v[key]
+		if !ok {
+			return nil, fmt.Errorf("Can not find [%s] of path [%s] in the response", key, path)
+		}
+		return getByKeys(child, keys[1:], path)
+	case []any:
+		if key == "*" {
+			values := make([]any, 0, len(v))
+			for _, item := range v {
+				value, err := getByKeys(item, keys[1:], path)
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+			}
+			return values, nil
+		}
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx >= len(v) {
+			return nil, fmt.Errorf("Invalid index [%s] of path [%s], the length of the list is %d", key, path, len(v))
+		}
+		return getByKeys(v[idx], keys[1:], path)
+	default:
+		return nil, fmt.Errorf("Can not find [%s] of path [%s] in the response", key, path)
+	}
+}
@@ -0,0 +1,143 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package httpjson
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewClient(t *testing.T) {
+	_, err := NewClient(&ClientConfig{Endpoint: "localhost", InputPath: "input"})
+	assert.Error(t, err)
+
+	_, err = NewClient(&ClientConfig{Endpoint: "http://localhost"})
+	assert.Error(t, err)
+
+	_, err = NewClient(&ClientConfig{Endpoint: "http://localhost", InputPath: "input", RequestTemplate: "[1]"})
+	assert.Error(t, err)
+
+	_, err = NewClient(&ClientConfig{Endpoint: "http://localhost", InputPath: "input", RequestTemplate: `{"model": "m"}`, ModelName: "m2", ModelPath: "model.name"})
+	assert.Error(t, err)
+
+	c, err := NewClient(&ClientConfig{Endpoint: "http://localhost", InputPath: "input", APIKey: "key", AuthHeader: "X-Api-Key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "key", c.headers["X-Api-Key"])
+}
+
+func TestEmbedding(t *testing.T) {
+	var received map[string]any
+	var auth string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &received)
+		auth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"result": {"items": [{"vector": [0.1, 0.2]}, {"vector": [1, 2]}]}, "usage": {"tokens": 4}}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(&ClientConfig{
+		Endpoint:        ts.URL,
+		APIKey:          "key",
+		AuthHeader:      "Authorization",
+		AuthPrefix:      "Bearer ",
+		RequestTemplate: `{"options": {"normalize": true}}`,
+		InputPath:       "options.texts",
+		EmbeddingPath:   "result.items.*.vector",
+		ModelName:       "bge",
+		ModelPath:       "model",
+	})
+	assert.NoError(t, err)
+
+	ret, err := c.Embedding([]string{"a", "b"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.1, 0.2}, {1, 2}}, ret)
+	assert.Equal(t, "Bearer key", auth)
+	assert.Equal(t, map[string]any{
+		"model":   "bge",
+		"options": map[string]any{"normalize": true, "texts": []any{"a", "b"}},
+	}, received)
+
+	// the template is not modified by requests
+	_, err = c.Embedding([]string{"c"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"normalize": true}, c.requestTemplate["options"])
+}
+
+func TestGetPath(t *testing.T) {
+	var doc any
+	assert.NoError(t, json.Unmarshal([]byte(`{"data": [{"embedding": [1, 2]}, {"embedding": [3, 4]}], "embeddings": [[5, 6]]}`), &doc))
+
+	v, err := getPath(doc, "data.*.embedding")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{[]any{1.0, 2.0}, []any{3.0, 4.0}}, v)
+
+	v, err = getPath(doc, "data.1.embedding")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{3.0, 4.0}, v)
+
+	v, err = getPath(doc, "embeddings")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{[]any{5.0, 6.0}}, v)
+
+	_, err = getPath(doc, "data.2.embedding")
+	assert.Error(t, err)
+	_, err = getPath(doc, "data.*.vector")
+	assert.Error(t, err)
+	_, err = getPath(doc, "embeddings.0.0.x")
+	assert.Error(t, err)
+}
+
+func TestEmbeddingInvalidResponse(t *testing.T) {
+	responses := []string{
+		`{"data": {"embedding": [1, 2]}}`,
+		`{"embeddings": "invalid"}`,
+		`{"embeddings": [["a", "b"]]}`,
+		`{"other": []}`,
+	}
+	for _, resp := range responses {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(resp))
+		}))
+		c, err := NewClient(&ClientConfig{Endpoint: ts.URL, InputPath: "input", EmbeddingPath: "embeddings"})
+		assert.NoError(t, err)
+		_, err = c.Embedding([]string{"a"}, 0)
+		assert.Error(t, err, resp)
+		ts.Close()
+	}
+}
+
+func TestSingleEmbedding(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"embedding": [0.5, 1.5]}`))
+	}))
+	defer ts.Close()
+
+	c, err := NewClient(&ClientConfig{Endpoint: ts.URL, InputPath: "prompt", EmbeddingPath: "embedding"})
+	assert.NoError(t, err)
+	ret, err := c.Embedding([]string{"a"}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]float32{{0.5, 1.5}}, ret)
+}
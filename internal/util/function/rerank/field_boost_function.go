@@ -0,0 +1,185 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package rerank
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+)
+
+const (
+	modifierKey string = "modifier"
+	factorKey   string = "factor"
+)
+
+const (
+	noneModifier   string = "none"
+	logModifier    string = "log"
+	log1pModifier  string = "log1p"
+	lnModifier     string = "ln"
+	ln1pModifier   string = "ln1p"
+	sqrtModifier   string = "sqrt"
+	squareModifier string = "square"
+)
+
+// FieldBoostFunction multiplies the search score by a numeric scalar field,
+// e.g. popularity, after applying factor and modifier to the field value.
+type FieldBoostFunction[T PKType, R int32 | int64 | float32 | float64] struct {
+	RerankBase
+
+	modifierName string
+	factor       float64
+	modifier     boostModifier
+}
+
+func newFieldBoostFunction(collSchema *schemapb.CollectionSchema, funcSchema *schemapb.FunctionSchema) (Reranker, error) {
+	base, err := newRerankBase(collSchema, funcSchema, FieldBoostFunctionName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(base.GetInputFieldNames()) != 1 {
+		return nil, fmt.Errorf("Field boost function only supports single input, but gets [%s] input", base.GetInputFieldNames())
+	}
+
+	inputType := base.GetInputFieldTypes()[0]
+	if base.pkType == schemapb.DataType_Int64 {
+		switch inputType {
+		case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+			return newBoostFunction[int64, int32](base, funcSchema)
+		case schemapb.DataType_Int64:
+			return newBoostFunction[int64, int64](base, funcSchema)
+		case schemapb.DataType_Float:
+			return newBoostFunction[int64, float32](base, funcSchema)
+		case schemapb.DataType_Double:
+			return newBoostFunction[int64, float64](base, funcSchema)
+		default:
+			return nil, fmt.Errorf("Field boost rerank: unsupported input field type:%s, only support numberic field", inputType.String())
+		}
+	} else {
+		switch inputType {
+		case schemapb.DataType_Int8, schemapb.DataType_Int16, schemapb.DataType_Int32:
+			return newBoostFunction[string, int32](base, funcSchema)
+		case schemapb.DataType_Int64:
+			return newBoostFunction[string, int64](base, funcSchema)
+		case schemapb.DataType_Float:
+			return newBoostFunction[string, float32](base, funcSchema)
+		case schemapb.DataType_Double:
+			return newBoostFunction[string, float64](base, funcSchema)
+		default:
+			return nil, fmt.Errorf("Field boost rerank: unsupported input field type:%s, only support numberic field", inputType.String())
+		}
+	}
+}
+
+// T: PK Type, R: field type
+func newBoostFunction[T PKType, R int32 | int64 | float32 | float64](base *RerankBase, funcSchema *schemapb.FunctionSchema) (Reranker, error) {
+	var err error
+	boostFunc := &FieldBoostFunction[T, R]{RerankBase: *base, modifierName: noneModifier, factor: 1}
+	for _, param := range funcSchema.Params {
+		switch strings.ToLower(param.Key) {
+		case modifierKey:
+			boostFunc.modifierName = strings.ToLower(param.Value)
+		case factorKey:
+			if boostFunc.factor, err = strconv.ParseFloat(param.Value, 64); err != nil {
+				return nil, fmt.Errorf("Param factor:%s is not a number", param.Value)
+			}
+		default:
+		}
+	}
+
+	if boostFunc.factor <= 0 {
+		return nil, fmt.Errorf("Field boost function param: factor must > 0, but got %f", boostFunc.factor)
+	}
+
+	switch boostFunc.modifierName {
+	case noneModifier:
+		boostFunc.modifier = func(v float64) float64 { return v }
+	case logModifier:
+		boostFunc.modifier = math.Log10
+	case log1pModifier:
+		boostFunc.modifier = func(v float64) float64 { return math.Log10(v + 1) }
+	case lnModifier:
+		boostFunc.modifier = math.Log
+	case ln1pModifier:
+		boostFunc.modifier = math.Log1p
+	case sqrtModifier:
+		boostFunc.modifier = math.Sqrt
+	case squareModifier:
+		boostFunc.modifier = func(v float64) float64 { return v * v }
+	default:
+		return nil, fmt.Errorf("Invalid field boost modifier: %s, only support [%s,%s,%s,%s,%s,%s,%s]", boostFunc.modifierName,
+			noneModifier, logModifier, log1pModifier, lnModifier, ln1pModifier, sqrtModifier, squareModifier)
+	}
+	return boostFunc, nil
+}
+
+func (boost *FieldBoostFunction[T, R]) processOneSearchData(ctx context.Context, searchParams *SearchParams, cols []*columns, idGroup map[any]any) (*IDScores[T], error) {
+	srcScores := maxMerge[T](cols)
+	boostScores := map[T]float32{}
+	for _, col := range cols {
+		if col.size == 0 {
+			continue
+		}
+		nums := col.data[0].([]R)
+		ids := col.ids.([]T)
+		for idx, id := range ids {
+			if _, ok := boostScores[id]; ok {
+				continue
+			}
+			boostValue := boost.modifier(boost.factor * float64(nums[idx]))
+			if math.IsNaN(boostValue) || math.IsInf(boostValue, 0) {
+				return nil, fmt.Errorf("Field boost rerank: %s modifier produces invalid value for field %s value %v", boost.modifierName, boost.GetInputFieldNames()[0], nums[idx])
+			}
+			boostScores[id] = float32(boostValue)
+		}
+	}
+	for id := range boostScores {
+		boostScores[id] = boostScores[id] * srcScores[id]
+	}
+	if searchParams.isGrouping() {
+		return newGroupingIDScores(boostScores, searchParams, idGroup)
+	}
+	return newIDScores(boostScores, searchParams), nil
+}
+
+func (boost *FieldBoostFunction[T, R]) Process(ctx context.Context, searchParams *SearchParams, inputs *rerankInputs) (*rerankOutputs, error) {
+	outputs := newRerankOutputs(searchParams)
+	for _, cols := range inputs.data {
+		for i, col := range cols {
+			metricType := searchParams.searchMetrics[i]
+			for j, score := range col.scores {
+				col.scores[j] = toGreaterScore(score, metricType)
+			}
+		}
+		idScore, err := boost.processOneSearchData(ctx, searchParams, cols, inputs.idGroupValue)
+		if err != nil {
+			return nil, err
+		}
+		appendResult(outputs, idScore.ids, idScore.scores)
+	}
+	return outputs, nil
+}
+
+type boostModifier func(float64) float64
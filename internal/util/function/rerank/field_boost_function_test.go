@@ -0,0 +1,198 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package rerank
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/function/embedding"
+)
+
+func TestFieldBoostFunction(t *testing.T) {
+	suite.Run(t, new(FieldBoostFunctionSuite))
+}
+
+type FieldBoostFunctionSuite struct {
+	suite.Suite
+}
+
+func (s *FieldBoostFunctionSuite) schema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: "dim", Value: "4"},
+				},
+			},
+			{FieldID: 103, Name: "popularity", DataType: schemapb.DataType_Int64},
+		},
+	}
+}
+
+func (s *FieldBoostFunctionSuite) TestNewFieldBoostErrors() {
+	schema := s.schema()
+	functionSchema := &schemapb.FunctionSchema{
+		Name:            "test",
+		Type:            schemapb.FunctionType_Rerank,
+		InputFieldNames: []string{"popularity", "pk"},
+		Params: []*commonpb.KeyValuePair{
+			{Key: modifierKey, Value: logModifier},
+			{Key: factorKey, Value: "2"},
+		},
+	}
+
+	{
+		_, err := newFieldBoostFunction(schema, functionSchema)
+		s.ErrorContains(err, "Field boost function only supports single input")
+	}
+	{
+		functionSchema.InputFieldNames = []string{"text"}
+		_, err := newFieldBoostFunction(schema, functionSchema)
+		s.ErrorContains(err, "Field boost rerank: unsupported input field type")
+	}
+	{
+		functionSchema.InputFieldNames = []string{"popularity"}
+		f, err := newFieldBoostFunction(schema, functionSchema)
+		s.NoError(err)
+		s.Equal(FieldBoostFunctionName, f.GetRankName())
+		s.Equal(2.0, f.(*FieldBoostFunction[int64, int64]).factor)
+	}
+	{
+		functionSchema.Params[1].Value = "NotNum"
+		_, err := newFieldBoostFunction(schema, functionSchema)
+		s.ErrorContains(err, "is not a number")
+	}
+	{
+		functionSchema.Params[1].Value = "0"
+		_, err := newFieldBoostFunction(schema, functionSchema)
+		s.ErrorContains(err, "factor must > 0")
+		functionSchema.Params[1].Value = "1"
+	}
+	{
+		for _, m := range []string{noneModifier, logModifier, log1pModifier, lnModifier, ln1pModifier, sqrtModifier, squareModifier} {
+			functionSchema.Params[0].Value = m
+			_, err := newFieldBoostFunction(schema, functionSchema)
+			s.NoError(err)
+		}
+		functionSchema.Params[0].Value = "NotExist"
+		_, err := newFieldBoostFunction(schema, functionSchema)
+		s.ErrorContains(err, "Invalid field boost modifier")
+	}
+}
+
+func (s *FieldBoostFunctionSuite) TestAllTypesInput() {
+	schema := s.schema()
+	functionSchema := &schemapb.FunctionSchema{
+		Name:            "test",
+		Type:            schemapb.FunctionType_Rerank,
+		InputFieldNames: []string{"popularity"},
+		Params:          []*commonpb.KeyValuePair{},
+	}
+	inputTypes := []schemapb.DataType{schemapb.DataType_Int64, schemapb.DataType_Int32, schemapb.DataType_Int16, schemapb.DataType_Int8, schemapb.DataType_Float, schemapb.DataType_Double, schemapb.DataType_Bool}
+	for _, pkType := range []schemapb.DataType{schemapb.DataType_Int64, schemapb.DataType_VarChar} {
+		schema.Fields[0].DataType = pkType
+		for i, inputType := range inputTypes {
+			schema.Fields[3].DataType = inputType
+			_, err := newFieldBoostFunction(schema, functionSchema)
+			if i < len(inputTypes)-1 {
+				s.NoError(err)
+			} else {
+				s.ErrorContains(err, "Field boost rerank: unsupported input field type")
+			}
+		}
+	}
+}
+
+func (s *FieldBoostFunctionSuite) TestRerankProcess() {
+	schema := s.schema()
+	functionSchema := &schemapb.FunctionSchema{
+		Name:            "test",
+		Type:            schemapb.FunctionType_Rerank,
+		InputFieldNames: []string{"popularity"},
+		Params:          []*commonpb.KeyValuePair{},
+	}
+
+	// empty
+	{
+		nq := int64(1)
+		f, err := newFieldBoostFunction(schema, functionSchema)
+		s.NoError(err)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{}, f.GetInputFieldIDs(), false)
+		ret, err := f.Process(context.Background(), NewSearchParams(nq, 3, 2, -1, -1, 1, false, "", []string{"COSINE"}), inputs)
+		s.NoError(err)
+		s.Equal([]int64{}, ret.searchResultData.Topks)
+	}
+
+	// source scores: [0 1 2 ... 9], popularity: [0 1 2 ... 9]
+	// none modifier: i * i
+	{
+		nq := int64(1)
+		f, err := newFieldBoostFunction(schema, functionSchema)
+		s.NoError(err)
+		data := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "popularity", 103)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{data}, f.GetInputFieldIDs(), false)
+		ret, err := f.Process(context.Background(), NewSearchParams(nq, 3, 0, -1, -1, 1, false, "", []string{"COSINE"}), inputs)
+		s.NoError(err)
+		s.Equal([]int64{9, 8, 7}, ret.searchResultData.Ids.GetIntId().Data)
+		s.Equal([]float32{81, 64, 49}, ret.searchResultData.Scores)
+	}
+
+	// sqrt modifier with factor 4: i * sqrt(4 * i), nq = 2 with two search results
+	{
+		nq := int64(2)
+		functionSchema.Params = []*commonpb.KeyValuePair{
+			{Key: modifierKey, Value: sqrtModifier},
+			{Key: factorKey, Value: "4"},
+		}
+		f, err := newFieldBoostFunction(schema, functionSchema)
+		s.NoError(err)
+		data1 := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "popularity", 103)
+		data2 := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "popularity", 103)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{data1, data2}, f.GetInputFieldIDs(), false)
+		ret, err := f.Process(context.Background(), NewSearchParams(nq, 1, 0, -1, -1, 1, false, "", []string{"COSINE", "COSINE"}), inputs)
+		s.NoError(err)
+		s.Equal([]int64{1, 1}, ret.searchResultData.Topks)
+		s.Equal([]int64{9, 19}, ret.searchResultData.Ids.GetIntId().Data)
+		s.InDeltaSlice([]float32{float32(19 * math.Sqrt(4*19))}, ret.searchResultData.Scores[1:], 1e-3)
+	}
+
+	// ln of 0 is invalid
+	{
+		nq := int64(1)
+		functionSchema.Params = []*commonpb.KeyValuePair{
+			{Key: modifierKey, Value: lnModifier},
+		}
+		f, err := newFieldBoostFunction(schema, functionSchema)
+		s.NoError(err)
+		data := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "popularity", 103)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{data}, f.GetInputFieldIDs(), false)
+		_, err = f.Process(context.Background(), NewSearchParams(nq, 3, 0, -1, -1, 1, false, "", []string{"COSINE"}), inputs)
+		s.ErrorContains(err, "modifier produces invalid value")
+	}
+}
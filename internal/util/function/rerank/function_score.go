@@ -36,10 +36,12 @@ import (
 )
 
 const (
-	DecayFunctionName string = "decay"
-	ModelFunctionName string = "model"
-	RRFName           string = "rrf"
-	WeightedName      string = "weighted"
+	DecayFunctionName      string = "decay"
+	ModelFunctionName      string = "model"
+	RRFName                string = "rrf"
+	WeightedName           string = "weighted"
+	NormalizeFunctionName  string = "normalize"
+	FieldBoostFunctionName string = "field_boost"
 )
 
 const (
@@ -147,10 +149,14 @@ func createFunction(collSchema *schemapb.CollectionSchema, funcSchema *schemapb.
 		rerankFunc, newRerankErr = newRRFFunction(collSchema, funcSchema)
 	case WeightedName:
 		rerankFunc, newRerankErr = newWeightedFunction(collSchema, funcSchema)
+	case NormalizeFunctionName:
+		rerankFunc, newRerankErr = newNormalizeFunction(collSchema, funcSchema)
+	case FieldBoostFunctionName:
+		rerankFunc, newRerankErr = newFieldBoostFunction(collSchema, funcSchema)
 	case BoostName:
 		return nil, nil
 	default:
-		return nil, fmt.Errorf("Unsupported rerank function: [%s] , list of supported [%s,%s,%s,%s]", rerankerName, DecayFunctionName, ModelFunctionName, RRFName, WeightedName)
+		return nil, fmt.Errorf("Unsupported rerank function: [%s] , list of supported [%s,%s,%s,%s,%s,%s]", rerankerName, DecayFunctionName, ModelFunctionName, RRFName, WeightedName, NormalizeFunctionName, FieldBoostFunctionName)
 	}
 
 	if newRerankErr != nil {
@@ -0,0 +1,177 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package rerank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+const (
+	NormalizationKey string = "normalization"
+)
+
+const (
+	minMaxNormalization string = "min_max"
+	zScoreNormalization string = "z_score"
+)
+
+// NormalizeFunction rescales the scores of every sub-search to a comparable
+// range before combining them with a weighted sum, so unbounded metrics such
+// as BM25 do not dominate bounded ones such as COSINE.
+type NormalizeFunction[T PKType] struct {
+	RerankBase
+
+	normalization string
+	weight        []float32
+}
+
+func newNormalizeFunction(collSchema *schemapb.CollectionSchema, funcSchema *schemapb.FunctionSchema) (Reranker, error) {
+	base, err := newRerankBase(collSchema, funcSchema, NormalizeFunctionName, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(base.GetInputFieldNames()) != 0 {
+		return nil, fmt.Errorf("The normalize function does not support input parameters, but got %s", base.GetInputFieldNames())
+	}
+
+	normalization := minMaxNormalization
+	var weights []float32
+	for _, param := range funcSchema.Params {
+		switch strings.ToLower(param.Key) {
+		case NormalizationKey:
+			normalization = strings.ToLower(param.Value)
+		case WeightsParamsKey:
+			if err := json.Unmarshal([]byte(param.Value), &weights); err != nil {
+				return nil, fmt.Errorf("Parse %s param failed, weight should be []float, bug got: %s", WeightsParamsKey, param.Value)
+			}
+			for _, weight := range weights {
+				if weight < 0 {
+					return nil, fmt.Errorf("rank param weight should be >= 0, but got %f", weight)
+				}
+			}
+		}
+	}
+
+	switch normalization {
+	case minMaxNormalization, zScoreNormalization:
+	default:
+		return nil, fmt.Errorf("Invalid normalization: %s, only support [%s,%s]", normalization, minMaxNormalization, zScoreNormalization)
+	}
+
+	if base.pkType == schemapb.DataType_Int64 {
+		return &NormalizeFunction[int64]{RerankBase: *base, normalization: normalization, weight: weights}, nil
+	} else {
+		return &NormalizeFunction[string]{RerankBase: *base, normalization: normalization, weight: weights}, nil
+	}
+}
+
+func (normalize *NormalizeFunction[T]) processOneSearchData(ctx context.Context, searchParams *SearchParams, cols []*columns, idGroup map[any]any) (*IDScores[T], error) {
+	// weights are optional, all sub-searches contribute equally when absent
+	if len(normalize.weight) != 0 && len(cols) != len(normalize.weight) {
+		return nil, merr.WrapErrParameterInvalid(fmt.Sprint(len(cols)), fmt.Sprint(len(normalize.weight)), "the length of weights param mismatch with ann search requests")
+	}
+	normScores := map[T]float32{}
+	for i, col := range cols {
+		if col.size == 0 {
+			continue
+		}
+		weight := float32(1.0)
+		if len(normalize.weight) != 0 {
+			weight = normalize.weight[i]
+		}
+		scores := normalizeScores(normalize.normalization, col.scores)
+		ids := col.ids.([]T)
+		for j, id := range ids {
+			normScores[id] += weight * scores[j]
+		}
+	}
+	if searchParams.isGrouping() {
+		return newGroupingIDScores(normScores, searchParams, idGroup)
+	}
+	return newIDScores(normScores, searchParams), nil
+}
+
+func (normalize *NormalizeFunction[T]) Process(ctx context.Context, searchParams *SearchParams, inputs *rerankInputs) (*rerankOutputs, error) {
+	outputs := newRerankOutputs(searchParams)
+	for _, cols := range inputs.data {
+		for i, col := range cols {
+			metricType := searchParams.searchMetrics[i]
+			for j, score := range col.scores {
+				col.scores[j] = toGreaterScore(score, metricType)
+			}
+		}
+		idScore, err := normalize.processOneSearchData(ctx, searchParams, cols, inputs.idGroupValue)
+		if err != nil {
+			return nil, err
+		}
+		appendResult(outputs, idScore.ids, idScore.scores)
+	}
+	return outputs, nil
+}
+
+// normalizeScores returns the normalized copy of the scores of one sub-search.
+func normalizeScores(normalization string, scores []float32) []float32 {
+	ret := make([]float32, len(scores))
+	if len(scores) == 0 {
+		return ret
+	}
+	switch normalization {
+	case zScoreNormalization:
+		var sum float64
+		for _, score := range scores {
+			sum += float64(score)
+		}
+		mean := sum / float64(len(scores))
+		var variance float64
+		for _, score := range scores {
+			variance += math.Pow(float64(score)-mean, 2)
+		}
+		std := math.Sqrt(variance / float64(len(scores)))
+		for i, score := range scores {
+			if std == 0 {
+				ret[i] = 0
+			} else {
+				ret[i] = float32((float64(score) - mean) / std)
+			}
+		}
+	default:
+		minScore, maxScore := scores[0], scores[0]
+		for _, score := range scores {
+			minScore = min(minScore, score)
+			maxScore = max(maxScore, score)
+		}
+		for i, score := range scores {
+			if maxScore == minScore {
+				// a single hit or all hits tie, they are all the best match
+				ret[i] = 1
+			} else {
+				ret[i] = (score - minScore) / (maxScore - minScore)
+			}
+		}
+	}
+	return ret
+}
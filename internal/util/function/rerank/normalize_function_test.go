@@ -0,0 +1,186 @@
+/*
+ * # Licensed to the LF AI & Data foundation under one
+ * # or more contributor license agreements. See the NOTICE file
+ * # distributed with this work for additional information
+ * # regarding copyright ownership. The ASF licenses this file
+ * # to you under the Apache License, Version 2.0 (the
+ * # "License"); you may not use this file except in compliance
+ * # with the License. You may obtain a copy of the License at
+ * #
+ * #     http://www.apache.org/licenses/LICENSE-2.0
+ * #
+ * # Unless required by applicable law or agreed to in writing, software
+ * # distributed under the License is distributed on an "AS IS" BASIS,
+ * # WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * # See the License for the specific language governing permissions and
+ * # limitations under the License.
+ */
+
+package rerank
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/util/function/embedding"
+)
+
+func TestNormalizeFunction(t *testing.T) {
+	suite.Run(t, new(NormalizeFunctionSuite))
+}
+
+type NormalizeFunctionSuite struct {
+	suite.Suite
+}
+
+func (s *NormalizeFunctionSuite) schema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Name: "test",
+		Fields: []*schemapb.FieldSchema{
+			{FieldID: 100, Name: "pk", DataType: schemapb.DataType_Int64, IsPrimaryKey: true},
+			{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+			{
+				FieldID: 102, Name: "vector", DataType: schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{
+					{Key: "dim", Value: "4"},
+				},
+			},
+			{FieldID: 103, Name: "ts", DataType: schemapb.DataType_Int64},
+		},
+	}
+}
+
+func (s *NormalizeFunctionSuite) TestNewNormalizeFunction() {
+	schema := s.schema()
+	functionSchema := &schemapb.FunctionSchema{
+		Name:            "test",
+		Type:            schemapb.FunctionType_Rerank,
+		InputFieldNames: []string{},
+		Params:          []*commonpb.KeyValuePair{},
+	}
+
+	{
+		f, err := newNormalizeFunction(schema, functionSchema)
+		s.NoError(err)
+		s.Equal(minMaxNormalization, f.(*NormalizeFunction[int64]).normalization)
+		s.Equal(NormalizeFunctionName, f.GetRankName())
+		s.True(f.IsSupportGroup())
+	}
+	{
+		functionSchema.Params = []*commonpb.KeyValuePair{
+			{Key: NormalizationKey, Value: "Z_SCORE"},
+			{Key: WeightsParamsKey, Value: `[1, 2.5]`},
+		}
+		schema.Fields[0] = &schemapb.FieldSchema{FieldID: 100, Name: "pk", DataType: schemapb.DataType_VarChar, IsPrimaryKey: true}
+		f, err := newNormalizeFunction(schema, functionSchema)
+		s.NoError(err)
+		s.Equal(zScoreNormalization, f.(*NormalizeFunction[string]).normalization)
+		s.Equal([]float32{1, 2.5}, f.(*NormalizeFunction[string]).weight)
+	}
+	{
+		functionSchema.Params[0].Value = "NotExist"
+		_, err := newNormalizeFunction(schema, functionSchema)
+		s.ErrorContains(err, "Invalid normalization")
+		functionSchema.Params[0].Value = minMaxNormalization
+	}
+	{
+		functionSchema.Params[1].Value = "NotNum"
+		_, err := newNormalizeFunction(schema, functionSchema)
+		s.ErrorContains(err, "param failed, weight should be []float")
+	}
+	{
+		functionSchema.Params[1].Value = `[-1, 1]`
+		_, err := newNormalizeFunction(schema, functionSchema)
+		s.ErrorContains(err, "rank param weight should be >= 0")
+		functionSchema.Params[1].Value = `[1, 1]`
+	}
+	{
+		functionSchema.InputFieldNames = []string{"ts"}
+		_, err := newNormalizeFunction(schema, functionSchema)
+		s.ErrorContains(err, "The normalize function does not support input parameters")
+	}
+}
+
+func (s *NormalizeFunctionSuite) TestNormalizeScores() {
+	s.Equal([]float32{}, normalizeScores(minMaxNormalization, []float32{}))
+	s.Equal([]float32{0, 0.5, 1}, normalizeScores(minMaxNormalization, []float32{2, 4, 6}))
+	s.Equal([]float32{1, 1}, normalizeScores(minMaxNormalization, []float32{3, 3}))
+	s.InDeltaSlice([]float32{-1.2247449, 0, 1.2247449}, normalizeScores(zScoreNormalization, []float32{2, 4, 6}), 1e-6)
+	s.Equal([]float32{0, 0}, normalizeScores(zScoreNormalization, []float32{3, 3}))
+}
+
+func (s *NormalizeFunctionSuite) TestRerankProcess() {
+	schema := s.schema()
+	functionSchema := &schemapb.FunctionSchema{
+		Name:            "test",
+		Type:            schemapb.FunctionType_Rerank,
+		InputFieldNames: []string{},
+		Params:          []*commonpb.KeyValuePair{},
+	}
+
+	// empty
+	{
+		nq := int64(1)
+		f, err := newNormalizeFunction(schema, functionSchema)
+		s.NoError(err)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{}, f.GetInputFieldIDs(), false)
+		ret, err := f.Process(context.Background(), NewSearchParams(nq, 3, 2, -1, -1, 1, false, "", []string{"COSINE"}), inputs)
+		s.NoError(err)
+		s.Equal(int64(3), ret.searchResultData.TopK)
+		s.Equal([]int64{}, ret.searchResultData.Topks)
+	}
+
+	// cosine scores: [0 1 2 ... 9], bm25 scores: [0 10 20 ... 90]
+	// min-max normalized both to [0 1/9 ... 1], so the sum of id i is 2i/9
+	{
+		nq := int64(1)
+		f, err := newNormalizeFunction(schema, functionSchema)
+		s.NoError(err)
+		data1 := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "", 0)
+		data2 := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "", 0)
+		for i := range data2.Scores {
+			data2.Scores[i] *= 10
+		}
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{data1, data2}, f.GetInputFieldIDs(), false)
+		ret, err := f.Process(context.Background(), NewSearchParams(nq, 3, 0, -1, -1, 1, false, "", []string{"COSINE", "BM25"}), inputs)
+		s.NoError(err)
+		s.Equal([]int64{3}, ret.searchResultData.Topks)
+		s.Equal([]int64{9, 8, 7}, ret.searchResultData.Ids.GetIntId().Data)
+		s.InDeltaSlice([]float32{2, 16.0 / 9, 14.0 / 9}, ret.searchResultData.Scores, 1e-6)
+	}
+
+	// weighted z-score, nq = 3
+	{
+		nq := int64(3)
+		functionSchema.Params = []*commonpb.KeyValuePair{
+			{Key: NormalizationKey, Value: zScoreNormalization},
+			{Key: WeightsParamsKey, Value: `[1, 0.5]`},
+		}
+		f, err := newNormalizeFunction(schema, functionSchema)
+		s.NoError(err)
+		data1 := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "", 0)
+		data2 := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "", 0)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{data1, data2}, f.GetInputFieldIDs(), false)
+		ret, err := f.Process(context.Background(), NewSearchParams(nq, 1, 0, -1, -1, 1, false, "", []string{"IP", "BM25"}), inputs)
+		s.NoError(err)
+		s.Equal([]int64{1, 1, 1}, ret.searchResultData.Topks)
+		s.Equal([]int64{9, 19, 29}, ret.searchResultData.Ids.GetIntId().Data)
+		// (9 - 4.5) / sqrt(8.25) * 1.5
+		s.InDeltaSlice([]float32{2.3500483, 2.3500483, 2.3500483}, ret.searchResultData.Scores, 1e-5)
+	}
+
+	// weights mismatch
+	{
+		nq := int64(1)
+		f, err := newNormalizeFunction(schema, functionSchema)
+		s.NoError(err)
+		data := embedding.GenSearchResultData(nq, 10, schemapb.DataType_Int64, "", 0)
+		inputs, _ := newRerankInputs([]*schemapb.SearchResultData{data}, f.GetInputFieldIDs(), false)
+		_, err = f.Process(context.Background(), NewSearchParams(nq, 3, 0, -1, -1, 1, false, "", []string{"COSINE"}), inputs)
+		s.ErrorContains(err, "the length of weights param mismatch with ann search requests")
+	}
+}
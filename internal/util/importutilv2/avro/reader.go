@@ -0,0 +1,164 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/json"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+const schemaMetaKey = "avro.schema"
+
+type reader struct {
+	ctx    context.Context
+	cm     storage.ChunkManager
+	cmr    storage.FileReader
+	schema *schemapb.CollectionSchema
+
+	dec          *ocf.Decoder
+	fieldSchemas map[string]avro.Schema // avro field name -> avro field schema
+	parser       json.RowParser
+
+	fileSize   *atomic.Int64
+	bufferSize int
+	count      int64
+	filePath   string
+}
+
+func NewReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string, bufferSize int) (*reader, error) {
+	cmReader, err := cm.Reader(ctx, path)
+	if err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("read avro file failed, path=%s, err=%s", path, err.Error()))
+	}
+	count, err := common.EstimateReadCountPerBatch(bufferSize, schema)
+	if err != nil {
+		cmReader.Close()
+		return nil, err
+	}
+
+	dec, err := ocf.NewDecoder(cmReader)
+	if err != nil {
+		cmReader.Close()
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("new avro decoder failed, path=%s, err=%v", path, err))
+	}
+	avroSchema, err := avro.Parse(string(dec.Metadata()[schemaMetaKey]))
+	if err != nil {
+		cmReader.Close()
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("parse avro schema failed, path=%s, err=%v", path, err))
+	}
+	fieldSchemas, err := checkSchema(schema, avroSchema)
+	if err != nil {
+		cmReader.Close()
+		return nil, err
+	}
+	log.Info("avro schema parsed", zap.String("path", path), zap.String("schema", avroSchema.String()))
+
+	parser, err := json.NewRowParser(schema)
+	if err != nil {
+		cmReader.Close()
+		return nil, err
+	}
+	return &reader{
+		ctx:          ctx,
+		cm:           cm,
+		cmr:          cmReader,
+		schema:       schema,
+		dec:          dec,
+		fieldSchemas: fieldSchemas,
+		parser:       parser,
+		fileSize:     atomic.NewInt64(0),
+		bufferSize:   bufferSize,
+		count:        count,
+		filePath:     path,
+	}, nil
+}
+
+func (r *reader) Read() (*storage.InsertData, error) {
+	insertData, err := storage.NewInsertData(r.schema)
+	if err != nil {
+		return nil, err
+	}
+	var cnt int64 = 0
+	for r.dec.HasNext() {
+		var record map[string]any
+		if err = r.dec.Decode(&record); err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to decode avro record, error: %v", err))
+		}
+		for name, value := range record {
+			record[name] = UnwrapUnion(r.fieldSchemas[name], value)
+		}
+		raw, err := json.NormalizeNativeRow(r.schema, record)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to parse row, error: %v", err))
+		}
+		row, err := r.parser.Parse(raw)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to parse row, error: %v", err))
+		}
+		err = insertData.Append(row)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to append row, error: %v", err))
+		}
+		cnt++
+		if cnt >= r.count {
+			cnt = 0
+			if insertData.GetMemorySize() >= r.bufferSize {
+				break
+			}
+		}
+	}
+	if err = r.dec.Error(); err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read avro file, error: %v", err))
+	}
+
+	// finish reading
+	if insertData.GetRowNum() == 0 {
+		return nil, io.EOF
+	}
+	return insertData, nil
+}
+
+func (r *reader) Close() {
+	if r.cmr != nil {
+		r.cmr.Close()
+	}
+}
+
+func (r *reader) Size() (int64, error) {
+	if size := r.fileSize.Load(); size != 0 {
+		return size, nil
+	}
+	size, err := r.cm.Size(r.ctx, r.filePath)
+	if err != nil {
+		return 0, err
+	}
+	r.fileSize.Store(size)
+	return size, nil
+}
@@ -0,0 +1,221 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/hamba/avro/v2/ocf"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const avroSchema = `{
+	"type": "record",
+	"name": "row",
+	"fields": [
+		{"name": "pk", "type": "long"},
+		{"name": "vec", "type": {"type": "array", "items": "float"}},
+		{"name": "bin", "type": "bytes"},
+		{"name": "str", "type": ["null", "string"]},
+		{"name": "arr", "type": ["null", {"type": "array", "items": "int"}]},
+		{"name": "extra", "type": "int"}
+	]
+}`
+
+type ReaderSuite struct {
+	suite.Suite
+
+	numRows int
+}
+
+func (suite *ReaderSuite) SetupSuite() {
+	paramtable.Get().Init(paramtable.NewBaseTable())
+}
+
+func (suite *ReaderSuite) SetupTest() {
+	suite.numRows = 100
+}
+
+func (suite *ReaderSuite) createSchema(enableDynamic bool) *schemapb.CollectionSchema {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			},
+			{
+				FieldID:    101,
+				Name:       "vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "4"}},
+			},
+			{
+				FieldID:    102,
+				Name:       "bin",
+				DataType:   schemapb.DataType_BinaryVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "16"}},
+			},
+			{
+				FieldID:    103,
+				Name:       "str",
+				DataType:   schemapb.DataType_VarChar,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "128"}},
+				Nullable:   true,
+			},
+			{
+				FieldID:     104,
+				Name:        "arr",
+				DataType:    schemapb.DataType_Array,
+				ElementType: schemapb.DataType_Int32,
+				TypeParams:  []*commonpb.KeyValuePair{{Key: common.MaxCapacityKey, Value: "16"}},
+				Nullable:    true,
+			},
+		},
+	}
+	if enableDynamic {
+		schema.EnableDynamicField = true
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			FieldID:   105,
+			Name:      "$meta",
+			DataType:  schemapb.DataType_JSON,
+			IsDynamic: true,
+		})
+	}
+	return schema
+}
+
+func (suite *ReaderSuite) writeFile() string {
+	filePath := fmt.Sprintf("/tmp/test_%d_reader.avro", rand.Int())
+	f, err := os.Create(filePath)
+	suite.NoError(err)
+	defer f.Close()
+
+	enc, err := ocf.NewEncoder(avroSchema, f)
+	suite.NoError(err)
+	for i := 0; i < suite.numRows; i++ {
+		var str any
+		var arr any
+		if i%2 == 0 {
+			str = fmt.Sprintf("str_%d", i)
+			arr = []any{i, i + 1}
+		}
+		err = enc.Encode(map[string]any{
+			"pk":    int64(i),
+			"vec":   []any{float32(i), float32(i) + 0.5, float32(0.25), float32(1)},
+			"bin":   []byte{byte(i), 0xff},
+			"str":   str,
+			"arr":   arr,
+			"extra": i,
+		})
+		suite.NoError(err)
+	}
+	suite.NoError(enc.Close())
+	return filePath
+}
+
+func (suite *ReaderSuite) TestRead() {
+	filePath := suite.writeFile()
+	defer os.Remove(filePath)
+
+	ctx := context.Background()
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath("/tmp/milvus_test/test_avro_reader/"))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	suite.NoError(err)
+
+	schema := suite.createSchema(true)
+	reader, err := NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	suite.NoError(err)
+	defer reader.Close()
+
+	size, err := reader.Size()
+	suite.NoError(err)
+	suite.True(size > 0)
+
+	data, err := reader.Read()
+	suite.NoError(err)
+	suite.Equal(suite.numRows, data.GetRowNum())
+	for i := 0; i < suite.numRows; i++ {
+		suite.Equal(int64(i), data.Data[100].GetRow(i))
+		suite.Equal([]float32{float32(i), float32(i) + 0.5, 0.25, 1}, data.Data[101].GetRow(i))
+		suite.Equal([]byte{byte(i), 0xff}, data.Data[102].GetRow(i))
+		if i%2 == 0 {
+			suite.Equal(fmt.Sprintf("str_%d", i), data.Data[103].GetRow(i))
+			suite.Equal([]int32{int32(i), int32(i + 1)},
+				data.Data[104].GetRow(i).(*schemapb.ScalarField).GetIntData().GetData())
+		} else {
+			suite.Nil(data.Data[103].GetRow(i))
+			suite.Nil(data.Data[104].GetRow(i))
+		}
+		suite.JSONEq(fmt.Sprintf(`{"extra": %d}`, i), string(data.Data[105].GetRow(i).([]byte)))
+	}
+
+	_, err = reader.Read()
+	suite.ErrorIs(err, io.EOF)
+}
+
+func (suite *ReaderSuite) TestCheckSchema() {
+	filePath := suite.writeFile()
+	defer os.Remove(filePath)
+
+	ctx := context.Background()
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath("/tmp/milvus_test/test_avro_reader/"))
+	cm, err := f.NewPersistentStorageChunkManager(ctx)
+	suite.NoError(err)
+
+	// the avro field is nullable but the milvus field is not
+	schema := suite.createSchema(false)
+	schema.Fields[3].Nullable = false
+	_, err = NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	suite.Error(err)
+	suite.Contains(err.Error(), "is nullable")
+
+	// auto-id field is provided
+	schema = suite.createSchema(false)
+	schema.Fields[0].AutoID = true
+	_, err = NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	suite.Error(err)
+	suite.Contains(err.Error(), "is auto-generated")
+
+	// required field is missed
+	schema = suite.createSchema(false)
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		FieldID:  106,
+		Name:     "missed",
+		DataType: schemapb.DataType_Int64,
+	})
+	_, err = NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	suite.Error(err)
+	suite.Contains(err.Error(), "no avro field for milvus field 'missed'")
+}
+
+func TestAvroReader(t *testing.T) {
+	suite.Run(t, new(ReaderSuite))
+}
@@ -0,0 +1,140 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"fmt"
+
+	"github.com/hamba/avro/v2"
+	"github.com/samber/lo"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// checkSchema verifies the avro record schema against the collection schema,
+// returns the avro schema of each field by name.
+func checkSchema(schema *schemapb.CollectionSchema, avroSchema avro.Schema) (map[string]avro.Schema, error) {
+	recordSchema, ok := avroSchema.(*avro.RecordSchema)
+	if !ok {
+		return nil, merr.WrapErrImportFailed(
+			fmt.Sprintf("the avro schema should be a record, but got '%s'", avroSchema.Type()))
+	}
+	nameToField := lo.KeyBy(schema.GetFields(), func(field *schemapb.FieldSchema) string {
+		return field.GetName()
+	})
+
+	fieldSchemas := make(map[string]avro.Schema, len(recordSchema.Fields()))
+	for _, avroField := range recordSchema.Fields() {
+		fieldSchemas[avroField.Name()] = avroField.Type()
+		field, ok := nameToField[avroField.Name()]
+		if !ok {
+			// redundant fields, will be put into the dynamic field if the collection has one
+			continue
+		}
+		// auto-id field must not provided
+		if typeutil.IsAutoPKField(field) {
+			return nil, merr.WrapErrImportFailed(
+				fmt.Sprintf("the primary key '%s' is auto-generated, no need to provide", field.GetName()))
+		}
+		// function output field must not provided
+		if field.GetIsFunctionOutput() {
+			return nil, merr.WrapErrImportFailed(
+				fmt.Sprintf("the field '%s' is output by function, no need to provide", field.GetName()))
+		}
+		if isNullableSchema(avroField.Type()) && !field.GetNullable() && field.GetDefaultValue() == nil {
+			return nil, merr.WrapErrImportFailed(
+				fmt.Sprintf("the avro field '%s' is nullable, but the milvus field is not nullable", field.GetName()))
+		}
+	}
+
+	for _, field := range nameToField {
+		// dynamic field, nullable field, default value field, not provided or provided both ok
+		if typeutil.IsAutoPKField(field) || field.GetIsDynamic() || field.GetIsFunctionOutput() ||
+			field.GetNullable() || field.GetDefaultValue() != nil {
+			continue
+		}
+		// the other field must be provided
+		if _, ok := fieldSchemas[field.GetName()]; !ok {
+			return nil, merr.WrapErrImportFailed(
+				fmt.Sprintf("no avro field for milvus field '%s'", field.GetName()))
+		}
+	}
+	return fieldSchemas, nil
+}
+
+func isNullableSchema(s avro.Schema) bool {
+	union, ok := s.(*avro.UnionSchema)
+	return ok && union.Nullable()
+}
+
+// UnwrapUnion removes the type wrapper of union values. The avro decoder decodes
+// a union value whose type cannot be resolved into a single-entry map keyed by
+// the type name, e.g. {"array": [1, 2, 3]} for ["null", {"type": "array", "items": "int"}].
+func UnwrapUnion(s avro.Schema, value any) any {
+	if s == nil || value == nil {
+		return value
+	}
+	switch schema := s.(type) {
+	case *avro.UnionSchema:
+		if mp, ok := value.(map[string]any); ok && len(mp) == 1 {
+			for name, inner := range mp {
+				for _, branch := range schema.Types() {
+					if unionTypeName(branch) == name {
+						return UnwrapUnion(branch, inner)
+					}
+				}
+			}
+		}
+		// the decoder resolved the type itself, the value is not wrapped
+		branches := lo.Filter(schema.Types(), func(branch avro.Schema, _ int) bool {
+			return branch.Type() != avro.Null
+		})
+		if len(branches) == 1 {
+			return UnwrapUnion(branches[0], value)
+		}
+	case *avro.ArraySchema:
+		if arr, ok := value.([]any); ok {
+			for i := range arr {
+				arr[i] = UnwrapUnion(schema.Items(), arr[i])
+			}
+		}
+	case *avro.MapSchema:
+		if mp, ok := value.(map[string]any); ok {
+			for k := range mp {
+				mp[k] = UnwrapUnion(schema.Values(), mp[k])
+			}
+		}
+	case *avro.RecordSchema:
+		if mp, ok := value.(map[string]any); ok {
+			for _, f := range schema.Fields() {
+				if v, ok := mp[f.Name()]; ok {
+					mp[f.Name()] = UnwrapUnion(f.Type(), v)
+				}
+			}
+		}
+	}
+	return value
+}
+
+func unionTypeName(s avro.Schema) string {
+	if named, ok := s.(avro.NamedSchema); ok {
+		return named.FullName()
+	}
+	return string(s.Type())
+}
@@ -0,0 +1,250 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+const jsonlReadBufferSize = 1024 * 1024
+
+// jsonlReader reads a JSON Lines(NDJSON) file line by line, each line is a JSON object of one row.
+// The reader can read a byte range [startOffset, endOffset) of the file, so that a large file
+// can be split into multiple import tasks. A line belongs to the range if the line starts in the range,
+// which means the first partial line is skipped if the range doesn't start from the beginning of the file,
+// and the last line is read through even if it crosses the end of the range.
+type jsonlReader struct {
+	ctx    context.Context
+	cm     storage.ChunkManager
+	cmr    storage.FileReader
+	schema *schemapb.CollectionSchema
+
+	fileSize *atomic.Int64
+	filePath string
+	br       *bufio.Reader
+
+	startOffset int64
+	endOffset   int64
+	offset      int64 // offset of the next line to read
+	lineNum     int64 // number of lines read in the range
+	eof         bool
+
+	bufferSize int
+	count      int64
+
+	parser RowParser
+}
+
+func NewJSONLReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema,
+	path string, bufferSize int, startOffset int64, endOffset int64,
+) (*jsonlReader, error) {
+	if startOffset < 0 || endOffset < 0 || (endOffset != 0 && endOffset <= startOffset) {
+		return nil, merr.WrapErrImportFailed(
+			fmt.Sprintf("invalid byte range [%d, %d) for file %s", startOffset, endOffset, path))
+	}
+	r, err := cm.Reader(ctx, path)
+	if err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("read jsonl file failed, path=%s, err=%s", path, err.Error()))
+	}
+	count, err := common.EstimateReadCountPerBatch(bufferSize, schema)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	parser, err := NewRowParser(schema)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	reader := &jsonlReader{
+		ctx:         ctx,
+		cm:          cm,
+		cmr:         r,
+		schema:      schema,
+		fileSize:    atomic.NewInt64(0),
+		filePath:    path,
+		startOffset: startOffset,
+		endOffset:   endOffset,
+		bufferSize:  bufferSize,
+		count:       count,
+		parser:      parser,
+	}
+	err = reader.init()
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	return reader, nil
+}
+
+// init seeks to the first line which starts in the range.
+func (j *jsonlReader) init() error {
+	if j.startOffset == 0 {
+		j.br = bufio.NewReaderSize(j.cmr, jsonlReadBufferSize)
+		return nil
+	}
+	// Seek to the byte before the start offset, if it is a line break,
+	// the line starts exactly at the start offset. Otherwise, skip the partial line
+	// which belongs to the previous range.
+	_, err := j.cmr.Seek(j.startOffset-1, io.SeekStart)
+	if err != nil {
+		return merr.WrapErrImportFailed(fmt.Sprintf("seek jsonl file failed, path=%s, offset=%d, err=%v",
+			j.filePath, j.startOffset-1, err))
+	}
+	j.br = bufio.NewReaderSize(j.cmr, jsonlReadBufferSize)
+	j.offset = j.startOffset - 1
+	skipped, err := j.br.ReadSlice('\n')
+	j.offset += int64(len(skipped))
+	for err == bufio.ErrBufferFull {
+		skipped, err = j.br.ReadSlice('\n')
+		j.offset += int64(len(skipped))
+	}
+	if err == io.EOF {
+		j.eof = true
+		return nil
+	}
+	if err != nil {
+		return merr.WrapErrImportFailed(fmt.Sprintf("read jsonl file failed, path=%s, err=%v", j.filePath, err))
+	}
+	log.Info("jsonl reader skipped partial line", zap.String("path", j.filePath),
+		zap.Int64("startOffset", j.startOffset), zap.Int64("firstLineOffset", j.offset))
+	return nil
+}
+
+// readLine returns the next line in the range, nil if there is no more line.
+func (j *jsonlReader) readLine() ([]byte, error) {
+	for !j.eof {
+		if j.endOffset != 0 && j.offset >= j.endOffset {
+			return nil, nil
+		}
+		line, err := j.br.ReadBytes('\n')
+		if err == io.EOF {
+			j.eof = true
+		} else if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("read jsonl file failed, path=%s, err=%v", j.filePath, err))
+		}
+		j.offset += int64(len(line))
+		j.lineNum++
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+	}
+	return nil, nil
+}
+
+// wrapLineError reports the line number in the range and the byte offset in the file,
+// the line number is the line number of the file if the range starts from the beginning of the file.
+func (j *jsonlReader) wrapLineError(lineOffset int64, err error) error {
+	if j.startOffset == 0 {
+		return merr.WrapErrImportFailed(fmt.Sprintf("failed to parse line %d (byte offset %d) of file %s, error: %v",
+			j.lineNum, lineOffset, j.filePath, err))
+	}
+	return merr.WrapErrImportFailed(fmt.Sprintf("failed to parse line %d of the range starting at byte offset %d (byte offset %d) of file %s, error: %v",
+		j.lineNum, j.startOffset, lineOffset, j.filePath, err))
+}
+
+func (j *jsonlReader) Read() (*storage.InsertData, error) {
+	insertData, err := storage.NewInsertData(j.schema)
+	if err != nil {
+		return nil, err
+	}
+	var cnt int64 = 0
+	for {
+		lineOffset := j.offset
+		line, err := j.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if line == nil {
+			break
+		}
+		// Treat number value as a string instead of a float64,
+		// the same as the JSON reader does.
+		dec := json.NewDecoder(bytes.NewReader(line))
+		dec.UseNumber()
+		var value any
+		if err = dec.Decode(&value); err != nil {
+			return nil, j.wrapLineError(lineOffset, err)
+		}
+		if dec.More() {
+			return nil, j.wrapLineError(lineOffset, fmt.Errorf("unexpected content after the JSON object"))
+		}
+		row, err := j.parser.Parse(value)
+		if err != nil {
+			return nil, j.wrapLineError(lineOffset, err)
+		}
+		err = insertData.Append(row)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to append row, err=%s", err.Error()))
+		}
+		cnt++
+		if cnt >= j.count {
+			cnt = 0
+			if insertData.GetMemorySize() >= j.bufferSize {
+				break
+			}
+		}
+	}
+
+	// finish reading
+	if insertData.GetRowNum() == 0 {
+		return nil, io.EOF
+	}
+	return insertData, nil
+}
+
+// Size returns the size of the range, so that the import tasks of the split ranges
+// are estimated by the bytes they really read.
+func (j *jsonlReader) Size() (int64, error) {
+	if size := j.fileSize.Load(); size != 0 {
+		return size, nil
+	}
+	size, err := j.cm.Size(j.ctx, j.filePath)
+	if err != nil {
+		return 0, err
+	}
+	if j.endOffset != 0 && j.endOffset < size {
+		size = j.endOffset
+	}
+	size -= j.startOffset
+	if size < 0 {
+		size = 0
+	}
+	j.fileSize.Store(size)
+	return size, nil
+}
+
+func (j *jsonlReader) Close() {
+	if j.cmr != nil {
+		j.cmr.Close()
+	}
+}
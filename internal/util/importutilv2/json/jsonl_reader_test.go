@@ -0,0 +1,170 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/mocks"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+func newJSONLTestSchema() *schemapb.CollectionSchema {
+	return &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			},
+			{
+				FieldID:    101,
+				Name:       "vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+			},
+		},
+	}
+}
+
+func newJSONLTestChunkManager(t *testing.T, content string) storage.ChunkManager {
+	cm := mocks.NewChunkManager(t)
+	cm.EXPECT().Reader(mock.Anything, mock.Anything).RunAndReturn(func(ctx context.Context, s string) (storage.FileReader, error) {
+		sr := strings.NewReader(content)
+		return &mockReader{Reader: sr, ReaderAt: sr, Seeker: sr, Closer: io.NopCloser(sr)}, nil
+	}).Maybe()
+	cm.EXPECT().Size(mock.Anything, mock.Anything).Return(int64(len(content)), nil).Maybe()
+	return cm
+}
+
+func readAllPKs(t *testing.T, r *jsonlReader) []int64 {
+	pks := make([]int64, 0)
+	for {
+		data, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		for i := 0; i < data.GetRowNum(); i++ {
+			pks = append(pks, data.Data[100].GetRow(i).(int64))
+		}
+	}
+	return pks
+}
+
+func TestJSONLReader(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	schema := newJSONLTestSchema()
+
+	const numRows = 20
+	lines := make([]string, 0, numRows+1)
+	expect := make([]int64, 0, numRows)
+	for i := 0; i < numRows; i++ {
+		lines = append(lines, fmt.Sprintf(`{"pk": %d, "vec": [%d.5, 0.25]}`, i, i))
+		expect = append(expect, int64(i))
+		if i == numRows/2 {
+			// blank lines are ignored
+			lines = append(lines, "")
+		}
+	}
+	content := strings.Join(lines, "\n") + "\n"
+	cm := newJSONLTestChunkManager(t, content)
+
+	t.Run("read whole file", func(t *testing.T) {
+		r, err := NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, 0, 0)
+		assert.NoError(t, err)
+		defer r.Close()
+		assert.Equal(t, expect, readAllPKs(t, r))
+		size, err := r.Size()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(content)), size)
+	})
+
+	t.Run("read split ranges", func(t *testing.T) {
+		// every row must be read exactly once no matter where the file is split
+		for splitSize := int64(1); splitSize <= int64(len(content)); splitSize += 7 {
+			pks := make([]int64, 0, numRows)
+			for start := int64(0); start < int64(len(content)); start += splitSize {
+				end := start + splitSize
+				if end >= int64(len(content)) {
+					end = 0
+				}
+				r, err := NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, start, end)
+				assert.NoError(t, err)
+				pks = append(pks, readAllPKs(t, r)...)
+				r.Close()
+			}
+			assert.Equal(t, expect, pks, "splitSize=%d", splitSize)
+		}
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		_, err := NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, 10, 5)
+		assert.Error(t, err)
+		_, err = NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, -1, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONLReaderError(t *testing.T) {
+	paramtable.Init()
+	ctx := context.Background()
+	schema := newJSONLTestSchema()
+
+	content := `{"pk": 1, "vec": [0.5, 0.25]}
+{"pk": 2, "vec": [0.5, 0.25]}
+{"pk": 3, "vec": [0.5]}
+`
+	cm := newJSONLTestChunkManager(t, content)
+	r, err := NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, 0, 0)
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 3")
+
+	content = `{"pk": 1, "vec": [0.5, 0.25]}
+{"pk": 2, "vec": [0.5, 0.25]
+`
+	cm = newJSONLTestChunkManager(t, content)
+	r, err = NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, 0, 0)
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+
+	content = `{"pk": 1, "vec": [0.5, 0.25]} {"pk": 2, "vec": [0.5, 0.25]}
+`
+	cm = newJSONLTestChunkManager(t, content)
+	r, err = NewJSONLReader(ctx, cm, schema, "a.jsonl", 1024, 0, 0)
+	assert.NoError(t, err)
+	_, err = r.Read()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected content")
+}
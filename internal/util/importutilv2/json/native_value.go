@@ -0,0 +1,220 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// NormalizeNativeRow converts a row decoded by a typed file reader(Avro, ORC, etc.)
+// into the representation accepted by RowParser, so that the typed readers share
+// the same schema mapping, nullable/default value and dynamic field handling with JSON import.
+// Keys which are not in the collection schema are normalized as generic JSON values,
+// they will be combined into the dynamic field if the collection has one.
+func NormalizeNativeRow(schema *schemapb.CollectionSchema, record map[string]any) (map[string]any, error) {
+	row := make(map[string]any, len(record))
+	for key, value := range record {
+		field := typeutil.GetFieldByName(schema, key)
+		normalized, err := NormalizeNativeValue(field, value)
+		if err != nil {
+			return nil, err
+		}
+		row[key] = normalized
+	}
+	return row, nil
+}
+
+// NormalizeNativeValue converts a value of Go native type into the JSON-compatible value for the field:
+// integers and floats are converted to json.Number, lists to []any, maps to map[string]any.
+// Vectors stored as bytes are accepted for binary/float16/bfloat16 vector fields.
+// The field can be nil, in this case the value is normalized as a generic JSON value.
+func NormalizeNativeValue(field *schemapb.FieldSchema, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if field == nil {
+		return normalizeGenericValue(value)
+	}
+	switch field.GetDataType() {
+	case schemapb.DataType_BinaryVector, schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
+		bytes, ok := asBytes(value)
+		if !ok {
+			return normalizeGenericValue(value)
+		}
+		return bytesToVector(field, bytes)
+	case schemapb.DataType_SparseFloatVector:
+		// sparse vector can be provided as a JSON string, such as '{"1": 0.5, "10": 0.3}'
+		if str, ok := value.(string); ok {
+			var mp map[string]any
+			dec := json.NewDecoder(strings.NewReader(str))
+			dec.UseNumber()
+			if err := dec.Decode(&mp); err != nil {
+				return nil, merr.WrapErrImportFailed(
+					fmt.Sprintf("invalid JSON string for sparse vector field '%s', err=%v", field.GetName(), err))
+			}
+			return mp, nil
+		}
+		return normalizeGenericValue(value)
+	case schemapb.DataType_JSON, schemapb.DataType_VarChar, schemapb.DataType_String:
+		// JSON field and varchar field could be stored as UTF-8 bytes
+		if bytes, ok := asBytes(value); ok {
+			return string(bytes), nil
+		}
+		return normalizeGenericValue(value)
+	case schemapb.DataType_Timestamptz:
+		if t, ok := value.(time.Time); ok {
+			return json.Number(strconv.FormatInt(t.UnixMicro(), 10)), nil
+		}
+		return normalizeGenericValue(value)
+	default:
+		return normalizeGenericValue(value)
+	}
+}
+
+func bytesToVector(field *schemapb.FieldSchema, bytes []byte) (any, error) {
+	switch field.GetDataType() {
+	case schemapb.DataType_BinaryVector:
+		vec := make([]any, len(bytes))
+		for i, b := range bytes {
+			vec[i] = json.Number(strconv.FormatUint(uint64(b), 10))
+		}
+		return vec, nil
+	case schemapb.DataType_Float16Vector, schemapb.DataType_BFloat16Vector:
+		if len(bytes)%2 != 0 {
+			return nil, merr.WrapErrImportFailed(
+				fmt.Sprintf("invalid bytes length %d for field '%s' with type '%s'",
+					len(bytes), field.GetName(), field.GetDataType().String()))
+		}
+		var floats []float32
+		if field.GetDataType() == schemapb.DataType_Float16Vector {
+			floats = typeutil.Float16BytesToFloat32Vector(bytes)
+		} else {
+			floats = typeutil.BFloat16BytesToFloat32Vector(bytes)
+		}
+		vec := make([]any, len(floats))
+		for i, f := range floats {
+			vec[i] = json.Number(strconv.FormatFloat(float64(f), 'g', -1, 32))
+		}
+		return vec, nil
+	default:
+		return nil, merr.WrapErrImportFailed(
+			fmt.Sprintf("bytes value is not acceptable for field '%s' with type '%s'",
+				field.GetName(), field.GetDataType().String()))
+	}
+}
+
+// asBytes returns the content if the value is a byte slice or a fixed-size byte array.
+func asBytes(value any) ([]byte, bool) {
+	if bytes, ok := value.([]byte); ok {
+		return bytes, true
+	}
+	rv := reflect.ValueOf(value)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		bytes := make([]byte, rv.Len())
+		reflect.Copy(reflect.ValueOf(bytes), rv)
+		return bytes, true
+	}
+	return nil, false
+}
+
+func normalizeGenericValue(value any) (any, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case bool, string, json.Number:
+		return v, nil
+	case float32:
+		return json.Number(strconv.FormatFloat(float64(v), 'g', -1, 32)), nil
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'g', -1, 64)), nil
+	case []byte:
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	case []any:
+		arr := make([]any, len(v))
+		for i, e := range v {
+			ne, err := normalizeGenericValue(e)
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = ne
+		}
+		return arr, nil
+	case map[string]any:
+		mp := make(map[string]any, len(v))
+		for k, e := range v {
+			ne, err := normalizeGenericValue(e)
+			if err != nil {
+				return nil, err
+			}
+			mp[k] = ne
+		}
+		return mp, nil
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return json.Number(strconv.FormatInt(rv.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return json.Number(strconv.FormatUint(rv.Uint(), 10)), nil
+	case reflect.Float32:
+		return json.Number(strconv.FormatFloat(rv.Float(), 'g', -1, 32)), nil
+	case reflect.Float64:
+		return json.Number(strconv.FormatFloat(rv.Float(), 'g', -1, 64)), nil
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		return normalizeGenericValue(rv.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if bytes, ok := asBytes(value); ok {
+			return string(bytes), nil
+		}
+		arr := make([]any, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			ne, err := normalizeGenericValue(rv.Index(i).Interface())
+			if err != nil {
+				return nil, err
+			}
+			arr[i] = ne
+		}
+		return arr, nil
+	case reflect.Map:
+		mp := make(map[string]any, rv.Len())
+		iter := rv.MapRange()
+		for iter.Next() {
+			ne, err := normalizeGenericValue(iter.Value().Interface())
+			if err != nil {
+				return nil, err
+			}
+			mp[fmt.Sprint(iter.Key().Interface())] = ne
+		}
+		return mp, nil
+	}
+	return nil, merr.WrapErrImportFailed(fmt.Sprintf("unsupported value type '%T' with value '%v'", value, value))
+}
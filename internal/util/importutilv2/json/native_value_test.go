@@ -0,0 +1,144 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+func TestNormalizeNativeValue(t *testing.T) {
+	// generic values
+	v, err := NormalizeNativeValue(nil, int32(8))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("8"), v)
+
+	v, err = NormalizeNativeValue(nil, float32(0.1))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("0.1"), v)
+
+	// named float types, such as orc.Float
+	type float float32
+	v, err = NormalizeNativeValue(nil, float(0.25))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("0.25"), v)
+
+	v, err = NormalizeNativeValue(nil, []int64{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{json.Number("1"), json.Number("2")}, v)
+
+	v, err = NormalizeNativeValue(nil, map[string]float64{"a": 1.5})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": json.Number("1.5")}, v)
+
+	v, err = NormalizeNativeValue(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	_, err = NormalizeNativeValue(nil, struct{}{})
+	assert.Error(t, err)
+
+	// vectors stored as bytes
+	binField := &schemapb.FieldSchema{
+		Name:       "bin",
+		DataType:   schemapb.DataType_BinaryVector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "16"}},
+	}
+	v, err = NormalizeNativeValue(binField, [2]byte{1, 255})
+	assert.NoError(t, err)
+	assert.Equal(t, []any{json.Number("1"), json.Number("255")}, v)
+
+	fp16Field := &schemapb.FieldSchema{
+		Name:       "fp16",
+		DataType:   schemapb.DataType_Float16Vector,
+		TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+	}
+	v, err = NormalizeNativeValue(fp16Field, typeutil.Float32ArrayToFloat16Bytes([]float32{0.5, 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, []any{json.Number("0.5"), json.Number("2")}, v)
+
+	_, err = NormalizeNativeValue(fp16Field, []byte{1, 2, 3})
+	assert.Error(t, err)
+
+	// sparse vector stored as JSON string
+	sparseField := &schemapb.FieldSchema{
+		Name:     "sparse",
+		DataType: schemapb.DataType_SparseFloatVector,
+	}
+	v, err = NormalizeNativeValue(sparseField, `{"1": 0.5}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"1": json.Number("0.5")}, v)
+
+	_, err = NormalizeNativeValue(sparseField, `{"1"`)
+	assert.Error(t, err)
+
+	// json field stored as bytes
+	jsonField := &schemapb.FieldSchema{
+		Name:     "json",
+		DataType: schemapb.DataType_JSON,
+	}
+	v, err = NormalizeNativeValue(jsonField, []byte(`{"a": 1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a": 1}`, v)
+
+	tsField := &schemapb.FieldSchema{
+		Name:     "ts",
+		DataType: schemapb.DataType_Timestamptz,
+	}
+	v, err = NormalizeNativeValue(tsField, time.UnixMicro(1000))
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("1000"), v)
+}
+
+func TestNormalizeNativeRow(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			},
+			{
+				FieldID:    101,
+				Name:       "vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "2"}},
+			},
+		},
+	}
+	raw, err := NormalizeNativeRow(schema, map[string]any{
+		"pk":  int64(1),
+		"vec": []float32{0.5, 1},
+	})
+	assert.NoError(t, err)
+
+	parser, err := NewRowParser(schema)
+	assert.NoError(t, err)
+	row, err := parser.Parse(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), row[100])
+	assert.Equal(t, []float32{0.5, 1}, row[101])
+}
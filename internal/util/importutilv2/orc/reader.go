@@ -0,0 +1,186 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/scritchley/orc"
+	"go.uber.org/atomic"
+	"go.uber.org/zap"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/common"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/json"
+	"github.com/milvus-io/milvus/pkg/v2/log"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+)
+
+type reader struct {
+	ctx    context.Context
+	cm     storage.ChunkManager
+	cmr    storage.FileReader
+	schema *schemapb.CollectionSchema
+
+	r            *orc.Reader
+	cursor       *orc.Cursor
+	columns      []string
+	stripeOpened bool
+	parser       json.RowParser
+
+	fileSize   *atomic.Int64
+	bufferSize int
+	count      int64
+	filePath   string
+}
+
+func NewReader(ctx context.Context, cm storage.ChunkManager, schema *schemapb.CollectionSchema, path string, bufferSize int) (*reader, error) {
+	cmReader, err := cm.Reader(ctx, path)
+	if err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("read orc file failed, path=%s, err=%s", path, err.Error()))
+	}
+	count, err := common.EstimateReadCountPerBatch(bufferSize, schema)
+	if err != nil {
+		cmReader.Close()
+		return nil, err
+	}
+
+	size, err := cmReader.Size()
+	if err != nil {
+		cmReader.Close()
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("get orc file size failed, path=%s, err=%v", path, err))
+	}
+	r, err := openOrc(&sizedReaderAt{ReaderAt: cmReader, size: size})
+	if err != nil {
+		cmReader.Close()
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("new orc reader failed, path=%s, err=%v", path, err))
+	}
+	columns := r.Schema().Columns()
+	err = checkColumns(schema, columns)
+	if err != nil {
+		r.Close()
+		cmReader.Close()
+		return nil, err
+	}
+	log.Info("orc file info", zap.String("path", path), zap.Strings("columns", columns),
+		zap.Int("num rows", r.NumRows()))
+
+	parser, err := json.NewRowParser(schema)
+	if err != nil {
+		r.Close()
+		cmReader.Close()
+		return nil, err
+	}
+	return &reader{
+		ctx:        ctx,
+		cm:         cm,
+		cmr:        cmReader,
+		schema:     schema,
+		r:          r,
+		cursor:     r.Select(columns...),
+		columns:    columns,
+		parser:     parser,
+		fileSize:   atomic.NewInt64(size),
+		bufferSize: bufferSize,
+		count:      count,
+		filePath:   path,
+	}, nil
+}
+
+// next moves the cursor to the next row, crossing the stripe boundaries.
+func (r *reader) next() bool {
+	for {
+		if r.stripeOpened && r.cursor.Next() {
+			return true
+		}
+		if !r.cursor.Stripes() {
+			return false
+		}
+		r.stripeOpened = true
+	}
+}
+
+func (r *reader) Read() (*storage.InsertData, error) {
+	insertData, err := storage.NewInsertData(r.schema)
+	if err != nil {
+		return nil, err
+	}
+	var cnt int64 = 0
+	for r.next() {
+		values := r.cursor.Row()
+		if len(values) != len(r.columns) {
+			return nil, merr.WrapErrImportFailed(
+				fmt.Sprintf("the number of values %d doesn't match the number of columns %d", len(values), len(r.columns)))
+		}
+		record := make(map[string]any, len(r.columns))
+		for i, column := range r.columns {
+			record[column] = values[i]
+		}
+		raw, err := json.NormalizeNativeRow(r.schema, record)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to parse row, error: %v", err))
+		}
+		row, err := r.parser.Parse(raw)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to parse row, error: %v", err))
+		}
+		err = insertData.Append(row)
+		if err != nil {
+			return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to append row, error: %v", err))
+		}
+		cnt++
+		if cnt >= r.count {
+			cnt = 0
+			if insertData.GetMemorySize() >= r.bufferSize {
+				break
+			}
+		}
+	}
+	if err = r.cursor.Err(); err != nil {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("failed to read orc file, error: %v", err))
+	}
+
+	// finish reading
+	if insertData.GetRowNum() == 0 {
+		return nil, io.EOF
+	}
+	return insertData, nil
+}
+
+func (r *reader) Close() {
+	if err := r.r.Close(); err != nil {
+		log.Warn("close orc reader failed", zap.Error(err))
+	}
+	if r.cmr != nil {
+		r.cmr.Close()
+	}
+}
+
+func (r *reader) Size() (int64, error) {
+	if size := r.fileSize.Load(); size != 0 {
+		return size, nil
+	}
+	size, err := r.cm.Size(r.ctx, r.filePath)
+	if err != nil {
+		return 0, err
+	}
+	r.fileSize.Store(size)
+	return size, nil
+}
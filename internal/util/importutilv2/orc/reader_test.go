@@ -0,0 +1,213 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"testing"
+
+	"github.com/scritchley/orc"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+	"github.com/milvus-io/milvus/pkg/v2/objectstorage"
+	"github.com/milvus-io/milvus/pkg/v2/util/paramtable"
+)
+
+const orcSchema = "struct<pk:bigint,vec:array<float>,str:string,arr:array<int>,extra:int>"
+
+type ReaderSuite struct {
+	suite.Suite
+
+	numRows int
+}
+
+func (suite *ReaderSuite) SetupSuite() {
+	paramtable.Get().Init(paramtable.NewBaseTable())
+}
+
+func (suite *ReaderSuite) SetupTest() {
+	suite.numRows = 100
+}
+
+func (suite *ReaderSuite) createSchema(enableDynamic bool) *schemapb.CollectionSchema {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			},
+			{
+				FieldID:    101,
+				Name:       "vec",
+				DataType:   schemapb.DataType_FloatVector,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.DimKey, Value: "4"}},
+			},
+			{
+				FieldID:    102,
+				Name:       "str",
+				DataType:   schemapb.DataType_VarChar,
+				TypeParams: []*commonpb.KeyValuePair{{Key: common.MaxLengthKey, Value: "128"}},
+				Nullable:   true,
+			},
+			{
+				FieldID:     103,
+				Name:        "arr",
+				DataType:    schemapb.DataType_Array,
+				ElementType: schemapb.DataType_Int32,
+				TypeParams:  []*commonpb.KeyValuePair{{Key: common.MaxCapacityKey, Value: "16"}},
+				Nullable:    true,
+			},
+		},
+	}
+	if enableDynamic {
+		schema.EnableDynamicField = true
+		schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+			FieldID:   104,
+			Name:      "$meta",
+			DataType:  schemapb.DataType_JSON,
+			IsDynamic: true,
+		})
+	}
+	return schema
+}
+
+func (suite *ReaderSuite) writeFile(stripeSize int64) string {
+	filePath := fmt.Sprintf("/tmp/test_%d_reader.orc", rand.Int())
+	f, err := os.Create(filePath)
+	suite.NoError(err)
+	defer f.Close()
+
+	schema, err := orc.ParseSchema(orcSchema)
+	suite.NoError(err)
+	w, err := orc.NewWriter(f, orc.SetSchema(schema), orc.SetStripeTargetSize(stripeSize))
+	suite.NoError(err)
+	for i := 0; i < suite.numRows; i++ {
+		var str any
+		var arr any
+		if i%2 == 0 {
+			str = fmt.Sprintf("str_%d", i)
+			arr = []any{int64(i), int64(i + 1)}
+		}
+		err = w.Write(int64(i), []any{float32(i), float32(i) + 0.5, float32(0.25), float32(1)}, str, arr, int64(i))
+		suite.NoError(err)
+	}
+	suite.NoError(w.Close())
+	return filePath
+}
+
+func (suite *ReaderSuite) newChunkManager() storage.ChunkManager {
+	f := storage.NewChunkManagerFactory("local", objectstorage.RootPath("/tmp/milvus_test/test_orc_reader/"))
+	cm, err := f.NewPersistentStorageChunkManager(context.Background())
+	suite.NoError(err)
+	return cm
+}
+
+func (suite *ReaderSuite) run(stripeSize int64, bufferSize int) {
+	filePath := suite.writeFile(stripeSize)
+	defer os.Remove(filePath)
+
+	schema := suite.createSchema(true)
+	reader, err := NewReader(context.Background(), suite.newChunkManager(), schema, filePath, bufferSize)
+	suite.NoError(err)
+	defer reader.Close()
+
+	size, err := reader.Size()
+	suite.NoError(err)
+	suite.True(size > 0)
+
+	rowNum := 0
+	for {
+		data, err := reader.Read()
+		if err != nil {
+			suite.ErrorIs(err, io.EOF)
+			break
+		}
+		for i := 0; i < data.GetRowNum(); i++ {
+			pk := rowNum + i
+			suite.Equal(int64(pk), data.Data[100].GetRow(i))
+			suite.Equal([]float32{float32(pk), float32(pk) + 0.5, 0.25, 1}, data.Data[101].GetRow(i))
+			if pk%2 == 0 {
+				suite.Equal(fmt.Sprintf("str_%d", pk), data.Data[102].GetRow(i))
+				suite.Equal([]int32{int32(pk), int32(pk + 1)},
+					data.Data[103].GetRow(i).(*schemapb.ScalarField).GetIntData().GetData())
+			} else {
+				suite.Nil(data.Data[102].GetRow(i))
+				suite.Nil(data.Data[103].GetRow(i))
+			}
+			suite.JSONEq(fmt.Sprintf(`{"extra": %d}`, pk), string(data.Data[104].GetRow(i).([]byte)))
+		}
+		rowNum += data.GetRowNum()
+	}
+	suite.Equal(suite.numRows, rowNum)
+}
+
+func (suite *ReaderSuite) TestRead() {
+	suite.run(64*1024*1024, 64*1024*1024)
+}
+
+func (suite *ReaderSuite) TestReadMultiStripesAndBatches() {
+	suite.numRows = 5000
+	// small stripes and buffer, the rows cross the stripe boundaries and are returned in several batches
+	suite.run(1024, 16*1024)
+}
+
+func (suite *ReaderSuite) TestCheckSchema() {
+	filePath := suite.writeFile(64 * 1024 * 1024)
+	defer os.Remove(filePath)
+
+	ctx := context.Background()
+	cm := suite.newChunkManager()
+
+	// auto-id field is provided
+	schema := suite.createSchema(false)
+	schema.Fields[0].AutoID = true
+	_, err := NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	suite.Error(err)
+	suite.Contains(err.Error(), "is auto-generated")
+
+	// required field is missed
+	schema = suite.createSchema(false)
+	schema.Fields = append(schema.Fields, &schemapb.FieldSchema{
+		FieldID:  105,
+		Name:     "missed",
+		DataType: schemapb.DataType_Int64,
+	})
+	_, err = NewReader(ctx, cm, schema, filePath, 64*1024*1024)
+	suite.Error(err)
+	suite.Contains(err.Error(), "no orc column for milvus field 'missed'")
+
+	// not an orc file
+	notOrc := fmt.Sprintf("/tmp/test_%d_reader.orc", rand.Int())
+	suite.NoError(os.WriteFile(notOrc, []byte("not an orc file"), 0o600))
+	defer os.Remove(notOrc)
+	_, err = NewReader(ctx, cm, suite.createSchema(false), notOrc, 64*1024*1024)
+	suite.Error(err)
+}
+
+func TestOrcReader(t *testing.T) {
+	suite.Run(t, new(ReaderSuite))
+}
@@ -0,0 +1,93 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/samber/lo"
+	"github.com/scritchley/orc"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus/pkg/v2/util/merr"
+	"github.com/milvus-io/milvus/pkg/v2/util/typeutil"
+)
+
+// sizedReaderAt adapts storage.FileReader to the orc.SizedReaderAt interface.
+type sizedReaderAt struct {
+	io.ReaderAt
+	size int64
+}
+
+func (s *sizedReaderAt) Size() int64 {
+	return s.size
+}
+
+// openOrc opens the orc reader, the orc library panics rather than returns an error
+// if the file is truncated or not an orc file at all.
+func openOrc(r orc.SizedReaderAt) (reader *orc.Reader, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("invalid orc file: %v", p)
+		}
+	}()
+	return orc.NewReader(r)
+}
+
+// checkColumns verifies the top-level columns of the orc file against the collection schema.
+func checkColumns(schema *schemapb.CollectionSchema, columns []string) error {
+	nameToField := lo.KeyBy(schema.GetFields(), func(field *schemapb.FieldSchema) string {
+		return field.GetName()
+	})
+	readFields := make(map[string]struct{}, len(columns))
+	for _, column := range columns {
+		if _, ok := readFields[column]; ok {
+			return merr.WrapErrImportFailed(fmt.Sprintf("there is multi column with name: %s", column))
+		}
+		readFields[column] = struct{}{}
+		field, ok := nameToField[column]
+		if !ok {
+			// redundant columns, will be put into the dynamic field if the collection has one
+			continue
+		}
+		// auto-id field must not provided
+		if typeutil.IsAutoPKField(field) {
+			return merr.WrapErrImportFailed(
+				fmt.Sprintf("the primary key '%s' is auto-generated, no need to provide", field.GetName()))
+		}
+		// function output field must not provided
+		if field.GetIsFunctionOutput() {
+			return merr.WrapErrImportFailed(
+				fmt.Sprintf("the field '%s' is output by function, no need to provide", field.GetName()))
+		}
+	}
+
+	for _, field := range nameToField {
+		// dynamic field, nullable field, default value field, not provided or provided both ok
+		if typeutil.IsAutoPKField(field) || field.GetIsDynamic() || field.GetIsFunctionOutput() ||
+			field.GetNullable() || field.GetDefaultValue() != nil {
+			continue
+		}
+		// the other field must be provided
+		if _, ok := readFields[field.GetName()]; !ok {
+			return merr.WrapErrImportFailed(
+				fmt.Sprintf("no orc column for milvus field '%s'", field.GetName()))
+		}
+	}
+	return nil
+}
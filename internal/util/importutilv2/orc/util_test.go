@@ -0,0 +1,67 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+)
+
+func TestCheckColumns(t *testing.T) {
+	schema := &schemapb.CollectionSchema{
+		Fields: []*schemapb.FieldSchema{
+			{
+				FieldID:      100,
+				Name:         "pk",
+				IsPrimaryKey: true,
+				DataType:     schemapb.DataType_Int64,
+			},
+			{
+				FieldID:  101,
+				Name:     "str",
+				DataType: schemapb.DataType_VarChar,
+				Nullable: true,
+			},
+			{
+				FieldID:          102,
+				Name:             "sparse",
+				DataType:         schemapb.DataType_SparseFloatVector,
+				IsFunctionOutput: true,
+			},
+		},
+	}
+
+	assert.NoError(t, checkColumns(schema, []string{"pk", "str"}))
+	// nullable field and redundant columns are ok
+	assert.NoError(t, checkColumns(schema, []string{"pk", "extra"}))
+
+	err := checkColumns(schema, []string{"str"})
+	assert.ErrorContains(t, err, "no orc column for milvus field 'pk'")
+
+	err = checkColumns(schema, []string{"pk", "pk"})
+	assert.ErrorContains(t, err, "multi column")
+
+	err = checkColumns(schema, []string{"pk", "sparse"})
+	assert.ErrorContains(t, err, "is output by function")
+
+	schema.Fields[0].AutoID = true
+	err = checkColumns(schema, []string{"pk"})
+	assert.ErrorContains(t, err, "is auto-generated")
+}
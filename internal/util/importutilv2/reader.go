@@ -18,13 +18,16 @@ package importutilv2
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	"github.com/milvus-io/milvus/internal/storage"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/avro"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/binlog"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/csv"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/json"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/numpy"
+	"github.com/milvus-io/milvus/internal/util/importutilv2/orc"
 	"github.com/milvus-io/milvus/internal/util/importutilv2/parquet"
 	"github.com/milvus-io/milvus/pkg/v2/proto/indexpb"
 	"github.com/milvus-io/milvus/pkg/v2/proto/internalpb"
@@ -70,6 +73,9 @@ func NewReader(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	if fileType != JSONL && (importFile.GetStartOffset() != 0 || importFile.GetEndOffset() != 0) {
+		return nil, merr.WrapErrImportFailed(fmt.Sprintf("byte range is not supported for %s file", fileType.String()))
+	}
 	switch fileType {
 	case JSON:
 		return json.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize)
@@ -87,6 +93,13 @@ func NewReader(ctx context.Context,
 			return nil, err
 		}
 		return csv.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize, sep, nullkey)
+	case Avro:
+		return avro.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize)
+	case ORC:
+		return orc.NewReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize)
+	case JSONL:
+		return json.NewJSONLReader(ctx, cm, schema, importFile.GetPaths()[0], bufferSize,
+			importFile.GetStartOffset(), importFile.GetEndOffset())
 	}
 	return nil, merr.WrapErrImportFailed("unexpected import file")
 }
@@ -135,6 +135,35 @@ func TestImportNewReader(t *testing.T) {
 	}
 	checkFunc("io error", req, options)
 
+	// accepts only one avro file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.avro", "2.avro"},
+	}
+	checkFunc("accepts only one file", req, options)
+
+	// avro file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.avro"},
+	}
+	checkFunc("io error", req, options)
+
+	// accepts only one orc file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.orc", "2.orc"},
+	}
+	checkFunc("accepts only one file", req, options)
+
+	// orc file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.orc"},
+	}
+	checkFunc("io error", req, options)
+
+	// csv file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.csv"},
+	}
+
 	// illegal sep
 	options = []*commonpb.KeyValuePair{
 		{
@@ -144,6 +173,25 @@ func TestImportNewReader(t *testing.T) {
 	}
 	checkFunc("unsupported csv separator", req, options)
 
+	// accepts only one jsonl file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.jsonl", "2.jsonl"},
+	}
+	checkFunc("accepts only one file", req, options)
+
+	// jsonl file
+	req = &internalpb.ImportFile{
+		Paths: []string{"1.ndjson"},
+	}
+	checkFunc("io error", req, options)
+
+	// byte range is only supported by jsonl file
+	req = &internalpb.ImportFile{
+		Paths:       []string{"1.json"},
+		StartOffset: 10,
+	}
+	checkFunc("byte range is not supported", req, options)
+
 	// invalid file type
 	req = &internalpb.ImportFile{
 		Paths: []string{"1.txt"},
@@ -34,11 +34,18 @@ const (
 	Numpy   FileType = 2
 	Parquet FileType = 3
 	CSV     FileType = 4
+	Avro    FileType = 5
+	ORC     FileType = 6
+	JSONL   FileType = 7
 
 	JSONFileExt    = ".json"
 	NumpyFileExt   = ".npy"
 	ParquetFileExt = ".parquet"
 	CSVFileExt     = ".csv"
+	AvroFileExt    = ".avro"
+	ORCFileExt     = ".orc"
+	JSONLFileExt   = ".jsonl"
+	NDJSONFileExt  = ".ndjson"
 )
 
 var FileTypeName = map[int]string{
@@ -47,6 +54,9 @@ var FileTypeName = map[int]string{
 	2: "Numpy",
 	3: "Parquet",
 	4: "CSV",
+	5: "Avro",
+	6: "ORC",
+	7: "JSONL",
 }
 
 func (f FileType) String() string {
@@ -88,6 +98,21 @@ func GetFileType(file *internalpb.ImportFile) (FileType, error) {
 			return Invalid, merr.WrapErrImportFailed("for CSV import, accepts only one file")
 		}
 		return CSV, nil
+	case AvroFileExt:
+		if len(file.GetPaths()) != 1 {
+			return Invalid, merr.WrapErrImportFailed("for Avro import, accepts only one file")
+		}
+		return Avro, nil
+	case ORCFileExt:
+		if len(file.GetPaths()) != 1 {
+			return Invalid, merr.WrapErrImportFailed("for ORC import, accepts only one file")
+		}
+		return ORC, nil
+	case JSONLFileExt, NDJSONFileExt:
+		if len(file.GetPaths()) != 1 {
+			return Invalid, merr.WrapErrImportFailed("for JSON Lines import, accepts only one file")
+		}
+		return JSONL, nil
 	}
 	return Invalid, merr.WrapErrImportFailed(fmt.Sprintf("unexpected file type, files=%v", file.GetPaths()))
 }
@@ -0,0 +1,208 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package profile collects per-stage execution stats of a profiled search or query.
+// The proxy marks the request through MsgBase properties, every node records its
+// stages into the context and returns them in the ExtraInfo of the result status,
+// so the normal result path is left untouched.
+package profile
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/internal/json"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+)
+
+const (
+	ProxyStage     = "proxy"
+	ShardStage     = "shard"
+	DelegatorStage = "delegator"
+	WorkerStage    = "worker"
+	SegmentStage   = "segment"
+	ReduceStage    = "reduce"
+	RerankStage    = "rerank"
+	RequeryStage   = "requery"
+)
+
+type ctxKey struct{}
+
+// Stage is the execution stats of one step, the stats of the steps it triggers are its children.
+// All methods are no-op on a nil Stage, so callers do not need to check whether profiling is enabled.
+type Stage struct {
+	mu    sync.Mutex
+	start time.Time
+
+	Name       string         `json:"name"`
+	NodeID     int64          `json:"node_id,omitempty"`
+	DurationUs int64          `json:"duration_us"`
+	Attrs      map[string]any `json:"attrs,omitempty"`
+	Children   []*Stage       `json:"children,omitempty"`
+}
+
+func NewStage(name string, nodeID int64) *Stage {
+	return &Stage{
+		start:  time.Now(),
+		Name:   name,
+		NodeID: nodeID,
+	}
+}
+
+// NewChild starts a new stage as the child of s.
+func (s *Stage) NewChild(name string, nodeID int64) *Stage {
+	if s == nil {
+		return nil
+	}
+	child := NewStage(name, nodeID)
+	s.AddChild(child)
+	return child
+}
+
+func (s *Stage) AddChild(children ...*Stage) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, child := range children {
+		if child != nil {
+			s.Children = append(s.Children, child)
+		}
+	}
+}
+
+func (s *Stage) SetAttr(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.Attrs == nil {
+		s.Attrs = make(map[string]any)
+	}
+	s.Attrs[key] = value
+}
+
+// Done records the time elapsed since the stage started.
+func (s *Stage) Done() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.DurationUs = time.Since(s.start).Microseconds()
+}
+
+// MarshalJSON holds the lock of the stage while encoding it,
+// the children lock themselves, so the stages still being recorded by other goroutines are encoded safely.
+func (s *Stage) MarshalJSON() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return json.Marshal(&struct {
+		Name       string         `json:"name"`
+		NodeID     int64          `json:"node_id,omitempty"`
+		DurationUs int64          `json:"duration_us"`
+		Attrs      map[string]any `json:"attrs,omitempty"`
+		Children   []*Stage       `json:"children,omitempty"`
+	}{
+		Name:       s.Name,
+		NodeID:     s.NodeID,
+		DurationUs: s.DurationUs,
+		Attrs:      s.Attrs,
+		Children:   s.Children,
+	})
+}
+
+func (s *Stage) Marshal() (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	bs, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(bs), nil
+}
+
+// NewContext returns a context carrying the stage, nil stage leaves ctx unchanged.
+func NewContext(ctx context.Context, s *Stage) context.Context {
+	if s == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, s)
+}
+
+// FromContext returns the stage carried by ctx, or nil if the request is not profiled.
+func FromContext(ctx context.Context) *Stage {
+	s, _ := ctx.Value(ctxKey{}).(*Stage)
+	return s
+}
+
+// Enable marks the request to be profiled by the downstream nodes.
+func Enable(base *commonpb.MsgBase) {
+	if base == nil {
+		return
+	}
+	if base.Properties == nil {
+		base.Properties = make(map[string]string)
+	}
+	base.Properties[common.ProfileKey] = "true"
+}
+
+func IsEnabled(base *commonpb.MsgBase) bool {
+	return base.GetProperties()[common.ProfileKey] == "true"
+}
+
+// Start creates the stage of a request and puts it into ctx if the request is profiled.
+func Start(ctx context.Context, base *commonpb.MsgBase, name string, nodeID int64) (context.Context, *Stage) {
+	if !IsEnabled(base) {
+		return ctx, nil
+	}
+	s := NewStage(name, nodeID)
+	return NewContext(ctx, s), s
+}
+
+// Attach finishes the stage and puts it into the ExtraInfo of status.
+func Attach(status *commonpb.Status, s *Stage) {
+	if status == nil || s == nil {
+		return
+	}
+	s.Done()
+	value, err := s.Marshal()
+	if err != nil {
+		return
+	}
+	if status.ExtraInfo == nil {
+		status.ExtraInfo = make(map[string]string)
+	}
+	status.ExtraInfo[common.ProfileKey] = value
+}
+
+// Extract returns the stage attached to status, or nil if there is none.
+func Extract(status *commonpb.Status) *Stage {
+	value, ok := status.GetExtraInfo()[common.ProfileKey]
+	if !ok {
+		return nil
+	}
+	s := &Stage{}
+	if err := json.Unmarshal([]byte(value), s); err != nil {
+		return nil
+	}
+	return s
+}
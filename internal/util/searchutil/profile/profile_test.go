@@ -0,0 +1,119 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package profile
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus/pkg/v2/common"
+)
+
+func TestNilStage(t *testing.T) {
+	var s *Stage
+	assert.NotPanics(t, func() {
+		child := s.NewChild(SegmentStage, 1)
+		assert.Nil(t, child)
+		s.AddChild(NewStage(ReduceStage, 1))
+		s.SetAttr("key", "value")
+		s.Done()
+	})
+
+	ctx := context.Background()
+	assert.Equal(t, ctx, NewContext(ctx, nil))
+	assert.Nil(t, FromContext(ctx))
+
+	status := &commonpb.Status{}
+	Attach(status, nil)
+	assert.Nil(t, Extract(status))
+}
+
+func TestEnable(t *testing.T) {
+	base := &commonpb.MsgBase{}
+	assert.False(t, IsEnabled(base))
+	assert.False(t, IsEnabled(nil))
+	ctx, s := Start(context.Background(), base, WorkerStage, 1)
+	assert.Nil(t, s)
+	assert.Nil(t, FromContext(ctx))
+
+	Enable(base)
+	assert.True(t, IsEnabled(base))
+	ctx, s = Start(context.Background(), base, WorkerStage, 1)
+	assert.NotNil(t, s)
+	assert.Same(t, s, FromContext(ctx))
+}
+
+func TestAttachAndExtract(t *testing.T) {
+	root := NewStage(DelegatorStage, 1)
+	root.SetAttr("pruned_segment_num", 2)
+	child := root.NewChild(SegmentStage, 1)
+	child.SetAttr("segment_id", 100)
+	child.Done()
+
+	status := &commonpb.Status{}
+	Attach(status, root)
+	assert.Contains(t, status.GetExtraInfo(), common.ProfileKey)
+
+	s := Extract(status)
+	assert.NotNil(t, s)
+	assert.Equal(t, DelegatorStage, s.Name)
+	assert.Equal(t, int64(1), s.NodeID)
+	assert.EqualValues(t, 2, s.Attrs["pruned_segment_num"])
+	assert.Len(t, s.Children, 1)
+	assert.Equal(t, SegmentStage, s.Children[0].Name)
+	assert.EqualValues(t, 100, s.Children[0].Attrs["segment_id"])
+
+	status.ExtraInfo[common.ProfileKey] = "invalid"
+	assert.Nil(t, Extract(status))
+}
+
+func TestConcurrentMarshal(t *testing.T) {
+	root := NewStage(DelegatorStage, 1)
+	child := root.NewChild(SegmentStage, 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			child.SetAttr(fmt.Sprint(i), i)
+			child.NewChild(ReduceStage, 1).Done()
+			root.SetAttr(fmt.Sprint(i), i)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		_, err := root.Marshal()
+		assert.NoError(t, err)
+	}
+	<-done
+
+	value, err := root.Marshal()
+	assert.NoError(t, err)
+	status := &commonpb.Status{ExtraInfo: map[string]string{common.ProfileKey: value}}
+	s := Extract(status)
+	assert.NotNil(t, s)
+	assert.Len(t, s.Attrs, 100)
+	assert.Len(t, s.Children[0].Children, 100)
+
+	var nilStage *Stage
+	value, err = nilStage.Marshal()
+	assert.NoError(t, err)
+	assert.Empty(t, value)
+}
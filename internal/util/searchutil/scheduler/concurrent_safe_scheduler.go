@@ -239,12 +239,14 @@ func (s *scheduler) exec() {
 			// Update concurrency metric and notify task done.
 			metrics.QueryNodeReadTaskConcurrency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Inc()
 			collector.Counter.Inc(metricsinfo.ExecuteQueueType)
+			s.executingTaskTotal.Inc()
 
 			err := t.Execute()
 
 			// Update all metric after task finished.
 			metrics.QueryNodeReadTaskConcurrency.WithLabelValues(fmt.Sprint(paramtable.GetNodeID())).Dec()
 			collector.Counter.Dec(metricsinfo.ExecuteQueueType)
+			s.executingTaskTotal.Dec()
 
 			// Notify task done.
 			t.Done(err)
@@ -292,6 +294,7 @@ func (s *scheduler) setupReadyLenMetric() {
 type schedulerCounter struct {
 	waitingTaskTotal   atomic.Int64
 	waitingTaskTotalNQ atomic.Int64
+	executingTaskTotal atomic.Int64
 }
 
 // GetWaitingTaskTotal get ready task counts.
@@ -304,6 +307,11 @@ func (s *schedulerCounter) GetWaitingTaskTotalNQ() int64 {
 	return s.waitingTaskTotalNQ.Load()
 }
 
+// GetExecutingTaskTotal get executing task counts.
+func (s *schedulerCounter) GetExecutingTaskTotal() int64 {
+	return s.executingTaskTotal.Load()
+}
+
 // updateWaitingTaskCounter update the waiting task counter for observing.
 func (s *schedulerCounter) updateWaitingTaskCounter(num int64, nq int64) {
 	s.waitingTaskTotal.Add(num)
@@ -79,6 +79,7 @@ func testScheduler(t *testing.T, policy schedulePolicy) {
 	assert.Equal(t, cnt.Load(), int32(n))
 	assert.Equal(t, 0, int(scheduler.GetWaitingTaskTotal()))
 	assert.Equal(t, 0, int(scheduler.GetWaitingTaskTotalNQ()))
+	assert.Equal(t, 0, int(scheduler.GetExecutingTaskTotal()))
 
 	// Test Push
 	for i := 1; i <= n; i++ {
@@ -54,6 +54,9 @@ type Scheduler interface {
 
 	// GetWaitingTaskTotal
 	GetWaitingTaskTotal() int64
+
+	// GetExecutingTaskTotal
+	GetExecutingTaskTotal() int64
 }
 
 // schedulePolicy is the policy of scheduler.
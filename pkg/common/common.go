@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/samber/lo"
@@ -160,12 +161,22 @@ const (
 	IgnoreGrowing             = "ignore_growing"
 	ConsistencyLevel          = "consistency_level"
 	HintsKey                  = "hints"
+	ExplainKey                = "explain"
+	ProfileKey                = "profile"
 
 	JSONCastTypeKey     = "json_cast_type"
 	JSONPathKey         = "json_path"
 	JSONCastFunctionKey = "json_cast_function"
 )
 
+// resource group usage keys, returned in the ExtraInfo of the DescribeResourceGroup status
+const (
+	ResourceGroupMemCapacityKey = "mem_capacity"
+	ResourceGroupMemQuotaKey    = "mem_quota"
+	ResourceGroupMemUsedKey     = "mem_used"
+	ResourceGroupCPUNumKey      = "cpu_num"
+)
+
 // expr query params
 const (
 	ExprUseJSONStatsKey = "expr_use_json_stats"
@@ -199,6 +210,9 @@ const (
 	CollectionSearchRateMinKey   = "collection.searchRate.min.vps"
 	CollectionDiskQuotaKey       = "collection.diskProtection.diskQuota.mb"
 
+	// the JSON spec of the ingestion connector which ingests into the collection
+	CollectionIngestionConnectorKey = "collection.ingestion.connector"
+
 	PartitionDiskQuotaKey = "partition.diskProtection.diskQuota.mb"
 
 	// database level properties
@@ -234,6 +248,9 @@ const (
 	IndexNonEncoding           = "index.nonEncoding"
 	EnableDynamicSchemaKey     = `dynamicfield.enabled`
 	NamespaceEnabledKey        = "namespace.enabled"
+	ResultCacheEnabledKey      = "result_cache.enabled"
+	HedgedRequestEnabledKey    = "hedged_request.enabled"
+	MaxExecutionTimeKey        = "max_execution_time.ms"
 )
 
 const (
@@ -308,6 +325,45 @@ func IsCollectionLazyLoadEnabled(kvs ...*commonpb.KeyValuePair) bool {
 	return false
 }
 
+// IsResultCacheEnabled returns whether the search and query results of the collection could be cached by proxy.
+func IsResultCacheEnabled(kvs ...*commonpb.KeyValuePair) bool {
+	for _, kv := range kvs {
+		if kv.Key == ResultCacheEnabledKey {
+			enable, _ := strconv.ParseBool(strings.ToLower(kv.Value))
+			return enable
+		}
+	}
+	return false
+}
+
+// GetMaxExecutionTime returns the max execution time of the search and query requests on the collection
+// or database, and whether it is set in the properties.
+func GetMaxExecutionTime(kvs ...*commonpb.KeyValuePair) (time.Duration, bool) {
+	for _, kv := range kvs {
+		if kv.Key == MaxExecutionTimeKey {
+			ms, err := strconv.ParseInt(kv.Value, 10, 64)
+			if err != nil || ms < 0 {
+				log.Warn("invalid max execution time property, ignore it", zap.String("value", kv.Value))
+				return 0, false
+			}
+			return time.Duration(ms) * time.Millisecond, true
+		}
+	}
+	return 0, false
+}
+
+// IsHedgedRequestEnabled returns whether proxy could hedge the shard requests of the collection or database,
+// and whether it is set in the properties.
+func IsHedgedRequestEnabled(kvs ...*commonpb.KeyValuePair) (bool, bool) {
+	for _, kv := range kvs {
+		if kv.Key == HedgedRequestEnabledKey {
+			enable, _ := strconv.ParseBool(strings.ToLower(kv.Value))
+			return enable, true
+		}
+	}
+	return false, false
+}
+
 func IsPartitionKeyIsolationKvEnabled(kvs ...*commonpb.KeyValuePair) (bool, error) {
 	for _, kv := range kvs {
 		if kv.Key == PartitionKeyIsolationKey {
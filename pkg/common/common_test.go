@@ -3,6 +3,7 @@ package common
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -288,3 +289,17 @@ func TestIsEnableDynamicSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMaxExecutionTime(t *testing.T) {
+	_, ok := GetMaxExecutionTime()
+	assert.False(t, ok)
+
+	timeout, ok := GetMaxExecutionTime(&commonpb.KeyValuePair{Key: MaxExecutionTimeKey, Value: "1500"})
+	assert.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, timeout)
+
+	_, ok = GetMaxExecutionTime(&commonpb.KeyValuePair{Key: MaxExecutionTimeKey, Value: "-1"})
+	assert.False(t, ok)
+	_, ok = GetMaxExecutionTime(&commonpb.KeyValuePair{Key: MaxExecutionTimeKey, Value: "abc"})
+	assert.False(t, ok)
+}
@@ -292,6 +292,11 @@ func (m *Manager) SetMapConfig(key, value string) {
 	m.overlays.Insert(strings.ToLower(key), value)
 }
 
+// ResetMapConfig removes the config set by SetMapConfig
+func (m *Manager) ResetMapConfig(key string) {
+	m.overlays.Remove(strings.ToLower(key))
+}
+
 // Delete config at runtime, which has the highest priority to override all other sources
 func (m *Manager) DeleteConfig(key string) {
 	m.overlays.Insert(formatKey(key), TombValue)
@@ -464,6 +464,61 @@ var (
 			Help:      "latency of function call",
 			Buckets:   buckets,
 		}, []string{nodeIDLabelName, collectionName, functionTypeName, functionProvider, functionName})
+
+	// ProxyFunctionEmbeddingCacheCounter records the hits and misses of the text embedding cache
+	ProxyFunctionEmbeddingCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "function_embedding_cache_count",
+			Help:      "count of text embedding cache hits/miss",
+		}, []string{nodeIDLabelName, collectionName, functionProvider, functionName, cacheStateLabelName})
+
+	// ProxyResultCacheCounter records the hits and misses of the search and query result cache
+	ProxyResultCacheCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "result_cache_count",
+			Help:      "count of search and query result cache hits/miss",
+		}, []string{nodeIDLabelName, queryTypeLabelName, databaseLabelName, collectionName, cacheStateLabelName})
+
+	// ProxyAdmissionQueueLength records the number of rate limited requests waiting for admission
+	ProxyAdmissionQueueLength = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "admission_queue_length",
+			Help:      "number of rate limited requests waiting for admission",
+		}, []string{nodeIDLabelName})
+
+	// ProxyAdmissionWaitLatency records the time rate limited requests waited before admitted or rejected
+	ProxyAdmissionWaitLatency = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "admission_wait_latency",
+			Help:      "latency which rate limited requests waited for admission",
+			Buckets:   buckets, // unit: ms
+		}, []string{nodeIDLabelName, msgTypeLabelName, statusLabelName})
+
+	// ProxyHedgedRequestCount records the hedged shard requests issued, won and skipped for the hedge budget
+	ProxyHedgedRequestCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "hedged_request_count",
+			Help:      "count of hedged search and query shard requests",
+		}, []string{nodeIDLabelName, statusLabelName})
+
+	// ProxyResultCacheSize records the size in bytes of the cached search and query results
+	ProxyResultCacheSize = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.ProxyRole,
+			Name:      "result_cache_size",
+			Help:      "size in bytes of the cached search and query results",
+		}, []string{nodeIDLabelName})
 )
 
 // RegisterProxy registers Proxy metrics
@@ -533,6 +588,12 @@ func RegisterProxy(registry *prometheus.Registry) {
 	registry.MustRegister(ProxyParseExpressionLatency)
 
 	registry.MustRegister(ProxyFunctionlatency)
+	registry.MustRegister(ProxyFunctionEmbeddingCacheCounter)
+	registry.MustRegister(ProxyResultCacheCounter)
+	registry.MustRegister(ProxyResultCacheSize)
+	registry.MustRegister(ProxyAdmissionQueueLength)
+	registry.MustRegister(ProxyAdmissionWaitLatency)
+	registry.MustRegister(ProxyHedgedRequestCount)
 
 	RegisterStreamingServiceClient(registry)
 }
@@ -569,6 +630,11 @@ func CleanupProxyDBMetrics(nodeID int64, dbName string) {
 }
 
 func CleanupProxyCollectionMetrics(nodeID int64, dbName string, collection string) {
+	ProxyResultCacheCounter.DeletePartialMatch(prometheus.Labels{
+		nodeIDLabelName:   strconv.FormatInt(nodeID, 10),
+		databaseLabelName: dbName,
+		collectionName:    collection,
+	})
 	ProxySearchVectors.DeletePartialMatch(prometheus.Labels{
 		nodeIDLabelName:   strconv.FormatInt(nodeID, 10),
 		databaseLabelName: dbName,
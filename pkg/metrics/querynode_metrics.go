@@ -753,6 +753,41 @@ var (
 			ResourceGroupLabelName,
 		})
 
+	// QueryNodeSegmentCacheAccessTotal records the number of segment accesses of search and retrieve,
+	// the access misses if the segment data is not in memory.
+	QueryNodeSegmentCacheAccessTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "segment_access_cache_total",
+			Help:      "number of segment accesses by cache state",
+		}, []string{
+			nodeIDLabelName,
+			cacheStateLabelName,
+		})
+
+	// QueryNodeMemoryPressureEvictTotal records the number of segments evicted due to memory pressure.
+	QueryNodeMemoryPressureEvictTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "memory_pressure_evict_total",
+			Help:      "number of segments evicted due to memory pressure",
+		}, []string{
+			nodeIDLabelName,
+		})
+
+	// QueryNodeMemoryPressureEvictBytes records the estimated memory bytes released due to memory pressure.
+	QueryNodeMemoryPressureEvictBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: milvusNamespace,
+			Subsystem: typeutil.QueryNodeRole,
+			Name:      "memory_pressure_evict_bytes",
+			Help:      "estimated memory bytes released due to memory pressure",
+		}, []string{
+			nodeIDLabelName,
+		})
+
 	// QueryNodeDiskCacheEvictDuration records the total time cost of evicting segments from disk cache.
 	QueryNodeDiskCacheEvictDuration = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -888,6 +923,9 @@ func RegisterQueryNode(registry *prometheus.Registry) {
 	registry.MustRegister(QueryNodeDiskCacheEvictBytes)
 	registry.MustRegister(QueryNodeDiskCacheEvictDuration)
 	registry.MustRegister(QueryNodeDiskCacheEvictGlobalDuration)
+	registry.MustRegister(QueryNodeSegmentCacheAccessTotal)
+	registry.MustRegister(QueryNodeMemoryPressureEvictTotal)
+	registry.MustRegister(QueryNodeMemoryPressureEvictBytes)
 	registry.MustRegister(QueryNodeSegmentPruneRatio)
 	registry.MustRegister(QueryNodeSegmentPruneLatency)
 	registry.MustRegister(QueryNodeSegmentPruneBias)
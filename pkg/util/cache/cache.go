@@ -157,6 +157,10 @@ type Cache[K comparable, V any] interface {
 	// Return nil if the item is removed.
 	// Return error if the Remove operation is canceled.
 	Remove(ctx context.Context, key K) error
+
+	// TryRemove removes the item from the cache if it's not in use.
+	// Return true if the item is removed, false if the item is not found or in use.
+	TryRemove(ctx context.Context, key K) bool
 }
 
 // lruCache extends the ccache library to provide pinning and unpinning of items.
@@ -467,6 +471,25 @@ func (c *lruCache[K, V]) tryToRemoveKey(ctx context.Context, key K) (removed boo
 	return false
 }
 
+func (c *lruCache[K, V]) TryRemove(ctx context.Context, key K) bool {
+	c.rwlock.Lock()
+	defer c.rwlock.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	item := e.Value.(*cacheItem[K, V])
+	if item.pinCount.Load() > 0 {
+		return false
+	}
+	c.evict(ctx, key)
+	// the space is released, wake up the waiters
+	c.waitNotifier.NotifyAll()
+	return true
+}
+
 func (c *lruCache[K, V]) evict(ctx context.Context, key K) {
 	c.stats.EvictionCount.Inc()
 	e := c.items[key]
@@ -475,4 +475,29 @@ func TestLRUCacheConcurrency(t *testing.T) {
 		}
 		assert.Equal(t, 5, evicted)
 	})
+	t.Run("test try remove", func(t *testing.T) {
+		finalized := make([]int, 0)
+		cache := NewCacheBuilder[int, int]().WithLoader(func(ctx context.Context, key int) (int, error) {
+			return key, nil
+		}).WithCapacity(5).WithFinalizer(func(ctx context.Context, key, value int) error {
+			finalized = append(finalized, key)
+			return nil
+		}).Build()
+
+		_, err := cache.Do(context.Background(), 1, func(ctx context.Context, v int) error { return nil })
+		assert.NoError(t, err)
+
+		// in use
+		_, err = cache.Do(context.Background(), 2, func(ctx context.Context, v int) error {
+			assert.False(t, cache.TryRemove(ctx, 2))
+			return nil
+		})
+		assert.NoError(t, err)
+
+		assert.True(t, cache.TryRemove(context.Background(), 1))
+		assert.False(t, cache.TryRemove(context.Background(), 1))
+		assert.False(t, cache.TryRemove(context.Background(), 3))
+		assert.Equal(t, []int{1}, finalized)
+		assert.Equal(t, uint64(1), cache.Stats().EvictionCount.Load())
+	})
 }
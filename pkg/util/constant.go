@@ -69,6 +69,7 @@ const (
 
 	HeaderUserAgent = "user-agent"
 	HeaderDBName    = "dbName"
+	HeaderPriority  = "priority"
 
 	RoleConfigPrivileges = "privileges"
 	RoleConfigObjectType = "object_type"
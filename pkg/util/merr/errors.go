@@ -60,6 +60,10 @@ var (
 	ErrServiceUnimplemented        = newMilvusError("service unimplemented", 10, false)
 	ErrServiceTimeTickLongDelay    = newMilvusError("time tick long delay", 11, false)
 	ErrServiceResourceInsufficient = newMilvusError("service resource insufficient", 12, true)
+	ErrServiceScopeRateLimit       = newMilvusError("rate limit of scope exceeded", 13, true)
+	ErrServiceConnectionLimit      = newMilvusError("connection limit exceeded", 14, false)
+	ErrServiceClientDisconnected   = newMilvusError("client disconnected", 15, false)
+	ErrServiceRequestTimeout       = newMilvusError("request execution timeout", 16, false)
 
 	// Collection related
 	ErrCollectionNotFound                      = newMilvusError("collection not found", 100, false)
@@ -88,6 +92,7 @@ var (
 	// go:deprecated
 	ErrResourceGroupNodeNotEnough    = newMilvusError("resource group node not enough", 304, false)
 	ErrResourceGroupServiceAvailable = newMilvusError("resource group service available", 305, true)
+	ErrResourceGroupQuotaExceeded    = newMilvusError("resource group quota exceeded", 306, false)
 
 	// Replica related
 	ErrReplicaNotFound     = newMilvusError("replica not found", 400, false)
@@ -20,6 +20,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/stretchr/testify/suite"
@@ -80,6 +81,10 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrServiceDiskLimitExceeded(110, 100, "DLE"), ErrServiceDiskLimitExceeded)
 	s.ErrorIs(WrapErrNodeNotMatch(0, 1, "SIM"), ErrNodeNotMatch)
 	s.ErrorIs(WrapErrServiceUnimplemented(errors.New("mock grpc err")), ErrServiceUnimplemented)
+	s.ErrorIs(WrapErrServiceScopeRateLimit("user:alice", 10, "RL"), ErrServiceScopeRateLimit)
+	s.ErrorIs(WrapErrServiceConnectionLimit("user:alice", 10, "CL"), ErrServiceConnectionLimit)
+	s.ErrorIs(WrapErrServiceClientDisconnected(100, "disconnected by admin"), ErrServiceClientDisconnected)
+	s.ErrorIs(WrapErrServiceRequestTimeout(time.Second, "search"), ErrServiceRequestTimeout)
 
 	// Collection related
 	s.ErrorIs(WrapErrCollectionNotFound("test_collection", "failed to get collection"), ErrCollectionNotFound)
@@ -101,6 +106,8 @@ func (s *ErrSuite) TestWrap() {
 	s.ErrorIs(WrapErrResourceGroupIllegalConfig("test_ResourceGroup", nil, "failed to get ResourceGroup"), ErrResourceGroupIllegalConfig)
 	s.ErrorIs(WrapErrResourceGroupNodeNotEnough("test_ResourceGroup", 1, 2, "failed to get ResourceGroup"), ErrResourceGroupNodeNotEnough)
 	s.ErrorIs(WrapErrResourceGroupServiceAvailable("test_ResourceGroup", "failed to get ResourceGroup"), ErrResourceGroupServiceAvailable)
+	s.ErrorIs(WrapErrResourceGroupQuotaExceeded("test_ResourceGroup", 1024, 512, "failed to load collection"), ErrResourceGroupQuotaExceeded)
+	s.ErrorIs(WrapErrResourceGroupCPUQuotaExceeded("test_ResourceGroup", 16, 8, "failed to load collection"), ErrResourceGroupQuotaExceeded)
 
 	// Replica related
 	s.ErrorIs(WrapErrReplicaNotFound(1, "failed to get replica"), ErrReplicaNotFound)
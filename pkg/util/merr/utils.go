@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"go.uber.org/zap"
@@ -170,7 +171,7 @@ func oldCode(code int32) commonpb.ErrorCode {
 	case ErrServiceTimeTickLongDelay.code():
 		return commonpb.ErrorCode_TimeTickLongDelay
 
-	case ErrServiceRateLimit.code():
+	case ErrServiceRateLimit.code(), ErrServiceScopeRateLimit.code():
 		return commonpb.ErrorCode_RateLimit
 
 	case ErrServiceQuotaExceeded.code():
@@ -413,6 +414,42 @@ func WrapErrServiceRateLimit(rate float64, msg ...string) error {
 	return err
 }
 
+// WrapErrServiceScopeRateLimit returns the rate limit error of the scope other than the data hierarchy, like user or role.
+func WrapErrServiceScopeRateLimit(scope string, rate float64, msg ...string) error {
+	err := wrapFields(ErrServiceScopeRateLimit, value("scope", scope), value("rate", rate))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrServiceConnectionLimit returns the error that the connections of the scope, like user or database, exceed the limit.
+func WrapErrServiceConnectionLimit(scope string, limit int64, msg ...string) error {
+	err := wrapFields(ErrServiceConnectionLimit, value("scope", scope), value("limit", limit))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrServiceClientDisconnected returns the error that the client has been disconnected and should connect again.
+func WrapErrServiceClientDisconnected(identifier int64, msg ...string) error {
+	err := wrapFields(ErrServiceClientDisconnected, value("identifier", identifier))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrServiceRequestTimeout returns the error that the request is not finished within the max execution time.
+func WrapErrServiceRequestTimeout(timeout time.Duration, msg ...string) error {
+	err := wrapFields(ErrServiceRequestTimeout, value("timeout", timeout))
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 func WrapErrServiceQuotaExceeded(reason string, msg ...string) error {
 	err := wrapFields(ErrServiceQuotaExceeded, value("reason", reason))
 	if len(msg) > 0 {
@@ -687,6 +724,32 @@ func WrapErrResourceGroupServiceAvailable(msg ...string) error {
 	return err
 }
 
+// WrapErrResourceGroupQuotaExceeded wraps ErrResourceGroupQuotaExceeded with resource group and the memory usage
+func WrapErrResourceGroupQuotaExceeded(rg any, predict, quota int64, msg ...string) error {
+	err := wrapFields(ErrResourceGroupQuotaExceeded,
+		value("rg", rg),
+		value("predict(MB)", logutil.ToMB(float64(predict))),
+		value("quota(MB)", logutil.ToMB(float64(quota))),
+	)
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
+// WrapErrResourceGroupCPUQuotaExceeded wraps ErrResourceGroupQuotaExceeded with resource group and the number of shards
+func WrapErrResourceGroupCPUQuotaExceeded(rg any, predict, quota int64, msg ...string) error {
+	err := wrapFields(ErrResourceGroupQuotaExceeded,
+		value("rg", rg),
+		value("predict(shards)", predict),
+		value("quota(shards)", quota),
+	)
+	if len(msg) > 0 {
+		err = errors.Wrap(err, strings.Join(msg, "->"))
+	}
+	return err
+}
+
 // Replica related
 func WrapErrReplicaNotFound(id int64, msg ...string) error {
 	err := wrapFields(ErrReplicaNotFound, value("replica", id))
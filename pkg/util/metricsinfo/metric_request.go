@@ -82,6 +82,12 @@ const (
 	// SyncTaskKey request for get sync tasks from the datanode
 	SyncTaskKey = "sync_tasks"
 
+	// DisconnectClientKey request for disconnecting a client from the proxy
+	DisconnectClientKey = "disconnect_client"
+
+	// BalanceSimulationKey request for simulating the balance plan on the querycoord
+	BalanceSimulationKey = "qc_balance_simulation"
+
 	// MetricRequestParamVerboseKey as a request parameter decide to whether return verbose value
 	MetricRequestParamVerboseKey = "verbose"
 
@@ -89,6 +95,13 @@ const (
 
 	MetricRequestParamCollectionIDKey = "collection_id"
 
+	MetricRequestParamIdentifierKey = "identifier"
+
+	MetricRequestParamBalancerKey    = "balancer"
+	MetricRequestParamAddNodesKey    = "add_nodes"
+	MetricRequestParamRemoveNodesKey = "remove_nodes"
+	MetricRequestParamMaxRoundsKey   = "max_rounds"
+
 	MetricRequestParamINKey  = "in"
 	MetricsRequestParamsInDC = "dc"
 	MetricsRequestParamsInQC = "qc"
@@ -142,12 +142,14 @@ type Segment struct {
 	NodeID       int64  `json:"node_id,omitempty"`
 
 	// load related
-	IsInvisible          bool            `json:"is_invisible,omitempty"`
-	LoadedTimestamp      string          `json:"loaded_timestamp,omitempty,string"`
-	IndexedFields        []*IndexedField `json:"index_fields,omitempty"`
-	ResourceGroup        string          `json:"resource_group,omitempty"`
-	LoadedInsertRowCount int64           `json:"loaded_insert_row_count,omitempty,string"` // inert row count for growing segment that excludes the deleted row count in QueryNode
-	MemSize              int64           `json:"mem_size,omitempty,string"`                // memory size of segment in QueryNode
+	IsInvisible          bool             `json:"is_invisible,omitempty"`
+	LoadedTimestamp      string           `json:"loaded_timestamp,omitempty,string"`
+	IndexedFields        []*IndexedField  `json:"index_fields,omitempty"`
+	ResourceGroup        string           `json:"resource_group,omitempty"`
+	LoadedInsertRowCount int64            `json:"loaded_insert_row_count,omitempty,string"` // inert row count for growing segment that excludes the deleted row count in QueryNode
+	MemSize              int64            `json:"mem_size,omitempty,string"`                // memory size of segment in QueryNode
+	LastAccessTime       string           `json:"last_access_time,omitempty"`               // last search or retrieve time of segment in QueryNode
+	FieldsLastAccessTime map[int64]string `json:"fields_last_access_time,omitempty"`
 
 	// flush related
 	FlushedRows    int64 `json:"flushed_rows,omitempty,string"`
@@ -207,6 +209,13 @@ type ResourceGroup struct {
 	Name  string                    `json:"name,omitempty"`
 	Nodes []int64                   `json:"nodes,omitempty"`
 	Cfg   *rgpb.ResourceGroupConfig `json:"cfg,omitempty"`
+	// the memory capacity and quota of the nodes, the estimated memory usage of the loaded replicas, in bytes
+	MemCapacity int64 `json:"mem_capacity,omitempty,string"`
+	MemQuota    int64 `json:"mem_quota,omitempty,string"`
+	MemUsed     int64 `json:"mem_used,omitempty,string"`
+	CPUNum      int64 `json:"cpu_num,omitempty"`
+	// the max number of the shards of the loaded replicas
+	CPUQuota int64 `json:"cpu_quota,omitempty"`
 }
 
 type Replica struct {
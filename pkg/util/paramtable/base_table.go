@@ -301,6 +301,14 @@ func (bt *BaseTable) SaveGroup(group map[string]string) error {
 	return nil
 }
 
+// ResetGroup removes the configs saved by SaveGroup.
+func (bt *BaseTable) ResetGroup(keys ...string) error {
+	for _, key := range keys {
+		bt.mgr.ResetMapConfig(key)
+	}
+	return nil
+}
+
 // Reset Config to default value
 func (bt *BaseTable) Reset(key string) error {
 	bt.mgr.ResetConfig(key)
@@ -1628,11 +1628,35 @@ type AccessLogConfig struct {
 	RemotePath    ParamItem  `refreshable:"false"`
 	RemoteMaxTime ParamItem  `refreshable:"false"`
 	Formatter     ParamGroup `refreshable:"false"`
+	OutputMode    ParamItem  `refreshable:"false"`
 
 	CacheSize          ParamItem `refreshable:"false"`
 	CacheFlushInterval ParamItem `refreshable:"false"`
 }
 
+type AuditLogConfig struct {
+	Enable        ParamItem `refreshable:"false"`
+	MinioEnable   ParamItem `refreshable:"false"`
+	LocalPath     ParamItem `refreshable:"false"`
+	Filename      ParamItem `refreshable:"false"`
+	MaxSize       ParamItem `refreshable:"false"`
+	RotatedTime   ParamItem `refreshable:"false"`
+	MaxBackups    ParamItem `refreshable:"false"`
+	RemotePath    ParamItem `refreshable:"false"`
+	RemoteMaxTime ParamItem `refreshable:"false"`
+}
+
+type SlowQueryLogConfig struct {
+	Enable          ParamItem `refreshable:"false"`
+	LocalPath       ParamItem `refreshable:"false"`
+	Filename        ParamItem `refreshable:"false"`
+	MinioEnable     ParamItem `refreshable:"false"`
+	RemotePath      ParamItem `refreshable:"false"`
+	FlushInterval   ParamItem `refreshable:"false"`
+	Retention       ParamItem `refreshable:"false"`
+	MaxFingerprints ParamItem `refreshable:"false"`
+}
+
 type proxyConfig struct {
 	// Alias  string
 	SoPath ParamItem `refreshable:"false"`
@@ -1674,18 +1698,52 @@ type proxyConfig struct {
 	MaxResultEntries               ParamItem `refreshable:"true"`
 	EnableCachedServiceProvider    ParamItem `refreshable:"true"`
 
-	AccessLog AccessLogConfig
+	AccessLog    AccessLogConfig
+	AuditLog     AuditLogConfig
+	SlowQueryLog SlowQueryLogConfig
+
+	// search and query result cache
+	ResultCacheEnabled ParamItem `refreshable:"true"`
+	ResultCacheMaxSize ParamItem `refreshable:"false"`
+	ResultCacheTTL     ParamItem `refreshable:"true"`
+
+	// admission control of the rate limited requests
+	AdmissionEnabled               ParamItem  `refreshable:"true"`
+	AdmissionMaxQueueLength        ParamItem  `refreshable:"true"`
+	AdmissionMaxWaitTime           ParamItem  `refreshable:"true"`
+	AdmissionCheckInterval         ParamItem  `refreshable:"true"`
+	AdmissionClientPriorityEnabled ParamItem  `refreshable:"true"`
+	AdmissionRolePriorities        ParamGroup `refreshable:"true"`
+	AdmissionTypePriorities        ParamGroup `refreshable:"true"`
+
+	// hedged requests of search and query
+	HedgedRequestPercentile  ParamItem `refreshable:"true"`
+	HedgedRequestMinDelay    ParamItem `refreshable:"true"`
+	HedgedRequestBudgetRatio ParamItem `refreshable:"true"`
+
+	// execution timeout of search and query
+	MaxExecutionTime ParamItem `refreshable:"true"`
 
 	// connection manager
 	ConnectionCheckIntervalSeconds ParamItem `refreshable:"true"`
 	ConnectionClientInfoTTLSeconds ParamItem `refreshable:"true"`
 	MaxConnectionNum               ParamItem `refreshable:"true"`
+	MaxConnectionNumPerUser        ParamItem `refreshable:"true"`
+	MaxConnectionNumPerDatabase    ParamItem `refreshable:"true"`
+	MaxInflightRequestsPerUser     ParamItem `refreshable:"true"`
+	MaxInflightRequestsPerDatabase ParamItem `refreshable:"true"`
+	ConnectionIdleTimeoutSeconds   ParamItem `refreshable:"true"`
 
 	GracefulStopTimeout ParamItem `refreshable:"true"`
 
 	SlowQuerySpanInSeconds ParamItem `refreshable:"true"`
 	SlowLogSpanInSeconds   ParamItem `refreshable:"true"`
 	QueryNodePoolingSize   ParamItem `refreshable:"false"`
+
+	// ingestion connectors
+	IngestionEnabled         ParamItem  `refreshable:"false"`
+	IngestionConnectors      ParamGroup `refreshable:"false"`
+	IngestionRefreshInterval ParamItem  `refreshable:"false"`
 }
 
 func (p *proxyConfig) init(base *BaseTable) {
@@ -1996,6 +2054,298 @@ please adjust in embedded Milvus: false`,
 	}
 	p.AccessLog.Formatter.Init(base.mgr)
 
+	p.AccessLog.OutputMode = ParamItem{
+		Key:          "proxy.accessLog.outputMode",
+		Version:      "2.6.0",
+		DefaultValue: "text",
+		Doc: `Output mode of access log, text, json or logfmt.
+text renders the format of formatters, json and logfmt output the configured fields as escaped keys,
+the fields are taken from the format in order unless set by formatters.<name>.fields.`,
+		Export: true,
+	}
+	p.AccessLog.OutputMode.Init(base.mgr)
+
+	p.ResultCacheEnabled = ParamItem{
+		Key:          "proxy.resultCache.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc: `Whether to cache the search and query results in proxy.
+Only the collections with property result_cache.enabled=true are cached, and strong consistency requests never hit the cache.`,
+		Export: true,
+	}
+	p.ResultCacheEnabled.Init(base.mgr)
+
+	p.ResultCacheMaxSize = ParamItem{
+		Key:          "proxy.resultCache.maxSize",
+		Version:      "2.6.0",
+		DefaultValue: "256",
+		Doc:          "The maximum size of the cached results, the least recently used results are evicted when exceeded. Unit: MB",
+		Export:       true,
+	}
+	p.ResultCacheMaxSize.Init(base.mgr)
+
+	p.ResultCacheTTL = ParamItem{
+		Key:          "proxy.resultCache.ttl",
+		Version:      "2.6.0",
+		DefaultValue: "60",
+		Doc:          "The maximum time a cached result could be served, it bounds the staleness of eventually consistent requests. Unit: seconds",
+		Export:       true,
+	}
+	p.ResultCacheTTL.Init(base.mgr)
+
+	p.AdmissionEnabled = ParamItem{
+		Key:          "proxy.admission.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc: `Whether the rate limited requests wait in the admission queue instead of being rejected immediately.
+The waiting requests are admitted in the order of priority when the rate allows, and rejected after proxy.admission.maxWaitTime.`,
+		Export: true,
+	}
+	p.AdmissionEnabled.Init(base.mgr)
+
+	p.AdmissionMaxQueueLength = ParamItem{
+		Key:          "proxy.admission.maxQueueLength",
+		Version:      "2.6.0",
+		DefaultValue: "1024",
+		Doc:          "The maximum number of requests waiting for admission, the rate limited requests are rejected immediately when the queue is full.",
+		Export:       true,
+	}
+	p.AdmissionMaxQueueLength.Init(base.mgr)
+
+	p.AdmissionMaxWaitTime = ParamItem{
+		Key:          "proxy.admission.maxWaitTime",
+		Version:      "2.6.0",
+		DefaultValue: "1000",
+		Doc:          "The maximum time a request waits for admission, it is shortened by the deadline of the request. Unit: ms",
+		Export:       true,
+	}
+	p.AdmissionMaxWaitTime.Init(base.mgr)
+
+	p.AdmissionCheckInterval = ParamItem{
+		Key:          "proxy.admission.checkInterval",
+		Version:      "2.6.0",
+		DefaultValue: "10",
+		Doc:          "The interval to check the rate limits for the waiting requests. Unit: ms",
+		Export:       true,
+	}
+	p.AdmissionCheckInterval.Init(base.mgr)
+
+	p.AdmissionClientPriorityEnabled = ParamItem{
+		Key:          "proxy.admission.clientPriorityEnabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "Whether to take the priority set by the client in the priority header, it could only lower the priority derived from the role and request type.",
+		Export:       true,
+	}
+	p.AdmissionClientPriorityEnabled.Init(base.mgr)
+
+	p.AdmissionRolePriorities = ParamGroup{
+		KeyPrefix: "proxy.admission.rolePriorities.",
+		Version:   "2.6.0",
+		Export:    true,
+		Doc:       "The admission priority of each role, e.g. proxy.admission.rolePriorities.<role>: 10, the highest one of the roles of the user is taken. The default priority is 0.",
+	}
+	p.AdmissionRolePriorities.Init(base.mgr)
+
+	p.AdmissionTypePriorities = ParamGroup{
+		KeyPrefix: "proxy.admission.typePriorities.",
+		Version:   "2.6.0",
+		Export:    true,
+		Doc:       "The admission priority of each request type, dml, dql or ddl, e.g. proxy.admission.typePriorities.dql: 10. The default priority is 0.",
+	}
+	p.AdmissionTypePriorities.Init(base.mgr)
+
+	p.HedgedRequestPercentile = ParamItem{
+		Key:          "proxy.hedgedRequest.percentile",
+		Version:      "2.6.0",
+		DefaultValue: "0.95",
+		Doc: `The percentile of the recent shard request latencies of the collection, after which a duplicate request is sent to another replica.
+Hedged requests are only sent for the collections or databases with property hedged_request.enabled set to true.`,
+		Export: true,
+	}
+	p.HedgedRequestPercentile.Init(base.mgr)
+
+	p.HedgedRequestMinDelay = ParamItem{
+		Key:          "proxy.hedgedRequest.minDelay",
+		Version:      "2.6.0",
+		DefaultValue: "10",
+		Doc:          "The minimum time to wait before sending a hedged request. Unit: ms",
+		Export:       true,
+	}
+	p.HedgedRequestMinDelay.Init(base.mgr)
+
+	p.HedgedRequestBudgetRatio = ParamItem{
+		Key:          "proxy.hedgedRequest.budgetRatio",
+		Version:      "2.6.0",
+		DefaultValue: "0.05",
+		Doc:          "The maximum ratio of the hedged requests to the shard requests, which bounds the extra load on the query nodes.",
+		Export:       true,
+	}
+	p.HedgedRequestBudgetRatio.Init(base.mgr)
+
+	p.MaxExecutionTime = ParamItem{
+		Key:          "proxy.maxExecutionTime",
+		Version:      "2.6.0",
+		DefaultValue: "0",
+		Doc: `The maximum execution time of search, hybrid search and query requests, 0 means no limit. Unit: ms
+It could be overridden by the collection or database property max_execution_time.ms, and never extends the deadline set by the client.`,
+		Export: true,
+	}
+	p.MaxExecutionTime.Init(base.mgr)
+
+	p.AuditLog.Enable = ParamItem{
+		Key:          "proxy.auditLog.enable",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "Whether to write audit records for DDL, RBAC and data-mutating operations.",
+		Export:       true,
+	}
+	p.AuditLog.Enable.Init(base.mgr)
+
+	p.AuditLog.MinioEnable = ParamItem{
+		Key:          "proxy.auditLog.minioEnable",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "Whether to upload sealed audit log files to MinIO. This parameter can be specified when proxy.auditLog.filename is not empty.",
+		Export:       true,
+	}
+	p.AuditLog.MinioEnable.Init(base.mgr)
+
+	p.AuditLog.LocalPath = ParamItem{
+		Key:          "proxy.auditLog.localPath",
+		Version:      "2.6.0",
+		DefaultValue: "/tmp/milvus_audit",
+		Doc:          "The local folder path where the audit log file is stored.",
+		Export:       true,
+	}
+	p.AuditLog.LocalPath.Init(base.mgr)
+
+	p.AuditLog.Filename = ParamItem{
+		Key:          "proxy.auditLog.filename",
+		Version:      "2.6.0",
+		DefaultValue: "milvus_audit.log",
+		Doc:          "The name of the audit log file. If you leave this parameter empty, audit records will be printed to stdout.",
+		Export:       true,
+	}
+	p.AuditLog.Filename.Init(base.mgr)
+
+	p.AuditLog.MaxSize = ParamItem{
+		Key:          "proxy.auditLog.maxSize",
+		Version:      "2.6.0",
+		DefaultValue: "64",
+		Doc:          "The maximum size allowed for a single audit log file before it is sealed and rotated. Unit: MB.",
+		Export:       true,
+	}
+	p.AuditLog.MaxSize.Init(base.mgr)
+
+	p.AuditLog.RotatedTime = ParamItem{
+		Key:          "proxy.auditLog.rotatedTime",
+		Version:      "2.6.0",
+		DefaultValue: "0",
+		Doc:          "The maximum time interval allowed for rotating a single audit log file. Unit: seconds",
+		Export:       true,
+	}
+	p.AuditLog.RotatedTime.Init(base.mgr)
+
+	p.AuditLog.MaxBackups = ParamItem{
+		Key:          "proxy.auditLog.maxBackups",
+		Version:      "2.6.0",
+		DefaultValue: "0",
+		Doc:          "The maximum number of sealed audit log files that can be retained locally. 0 means keeping all of them.",
+		Export:       true,
+	}
+	p.AuditLog.MaxBackups.Init(base.mgr)
+
+	p.AuditLog.RemotePath = ParamItem{
+		Key:          "proxy.auditLog.remotePath",
+		Version:      "2.6.0",
+		DefaultValue: "audit_log/",
+		Doc:          "The path of the object storage for uploading audit log files.",
+		Export:       true,
+	}
+	p.AuditLog.RemotePath.Init(base.mgr)
+
+	p.AuditLog.RemoteMaxTime = ParamItem{
+		Key:          "proxy.auditLog.remoteMaxTime",
+		Version:      "2.6.0",
+		DefaultValue: "0",
+		Doc:          "The time interval allowed for keeping uploaded audit log files. Files older than this are deleted. Setting the value to 0 disables this feature.",
+		Export:       true,
+	}
+	p.AuditLog.RemoteMaxTime.Init(base.mgr)
+
+	p.SlowQueryLog.Enable = ParamItem{
+		Key:          "proxy.slowQueryLog.enable",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "Whether to aggregate the slow search and query requests by fingerprint and persist the aggregations.",
+		Export:       true,
+	}
+	p.SlowQueryLog.Enable.Init(base.mgr)
+
+	p.SlowQueryLog.LocalPath = ParamItem{
+		Key:          "proxy.slowQueryLog.localPath",
+		Version:      "2.6.0",
+		DefaultValue: "/tmp/milvus_slow_query",
+		Doc:          "The local folder path where the slow query aggregations are stored.",
+		Export:       true,
+	}
+	p.SlowQueryLog.LocalPath.Init(base.mgr)
+
+	p.SlowQueryLog.Filename = ParamItem{
+		Key:          "proxy.slowQueryLog.filename",
+		Version:      "2.6.0",
+		DefaultValue: "slow_query_fingerprints.json",
+		Doc:          "The name of the file storing the slow query aggregations.",
+		Export:       true,
+	}
+	p.SlowQueryLog.Filename.Init(base.mgr)
+
+	p.SlowQueryLog.MinioEnable = ParamItem{
+		Key:          "proxy.slowQueryLog.minioEnable",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "Whether to store the slow query aggregations in the object storage instead of the local folder.",
+		Export:       true,
+	}
+	p.SlowQueryLog.MinioEnable.Init(base.mgr)
+
+	p.SlowQueryLog.RemotePath = ParamItem{
+		Key:          "proxy.slowQueryLog.remotePath",
+		Version:      "2.6.0",
+		DefaultValue: "slow_query_log/",
+		Doc:          "The path of the object storage for the slow query aggregations. Proxies sharing the object storage should use different paths.",
+		Export:       true,
+	}
+	p.SlowQueryLog.RemotePath.Init(base.mgr)
+
+	p.SlowQueryLog.FlushInterval = ParamItem{
+		Key:          "proxy.slowQueryLog.flushInterval",
+		Version:      "2.6.0",
+		DefaultValue: "60",
+		Doc:          "The interval to persist the slow query aggregations, unit: second.",
+		Export:       true,
+	}
+	p.SlowQueryLog.FlushInterval.Init(base.mgr)
+
+	p.SlowQueryLog.Retention = ParamItem{
+		Key:          "proxy.slowQueryLog.retention",
+		Version:      "2.6.0",
+		DefaultValue: "168",
+		Doc:          "The slow queries older than the retention are dropped from the aggregations, unit: hour.",
+		Export:       true,
+	}
+	p.SlowQueryLog.Retention.Init(base.mgr)
+
+	p.SlowQueryLog.MaxFingerprints = ParamItem{
+		Key:          "proxy.slowQueryLog.maxFingerprints",
+		Version:      "2.6.0",
+		DefaultValue: "1000",
+		Doc:          "The maximum number of the fingerprints kept, the one seen least recently is dropped when exceeded.",
+		Export:       true,
+	}
+	p.SlowQueryLog.MaxFingerprints.Init(base.mgr)
+
 	p.ShardLeaderCacheInterval = ParamItem{
 		Key:          "proxy.shardLeaderCacheInterval",
 		Version:      "2.2.4",
@@ -2165,6 +2515,51 @@ Disabled if the value is less or equal to 0.`,
 	}
 	p.MaxConnectionNum.Init(base.mgr)
 
+	p.MaxConnectionNumPerUser = ParamItem{
+		Key:          "proxy.maxConnectionNumPerUser",
+		Version:      "2.6.0",
+		Doc:          "the max number of clients connected by a user, the client exceeding the limit fails to connect, 0 means no limit",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxConnectionNumPerUser.Init(base.mgr)
+
+	p.MaxConnectionNumPerDatabase = ParamItem{
+		Key:          "proxy.maxConnectionNumPerDatabase",
+		Version:      "2.6.0",
+		Doc:          "the max number of clients connected to a database, the client exceeding the limit fails to connect, 0 means no limit",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxConnectionNumPerDatabase.Init(base.mgr)
+
+	p.MaxInflightRequestsPerUser = ParamItem{
+		Key:          "proxy.maxInflightRequestsPerUser",
+		Version:      "2.6.0",
+		Doc:          "the max number of in-flight requests of a user, the requests exceeding the limit are rejected, 0 means no limit",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxInflightRequestsPerUser.Init(base.mgr)
+
+	p.MaxInflightRequestsPerDatabase = ParamItem{
+		Key:          "proxy.maxInflightRequestsPerDatabase",
+		Version:      "2.6.0",
+		Doc:          "the max number of in-flight requests to a database, the requests exceeding the limit are rejected, 0 means no limit",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.MaxInflightRequestsPerDatabase.Init(base.mgr)
+
+	p.ConnectionIdleTimeoutSeconds = ParamItem{
+		Key:          "proxy.connectionIdleTimeoutSeconds",
+		Version:      "2.6.0",
+		Doc:          "the clients without in-flight requests and inactive for the duration are evicted and no longer count towards the connection limits, in seconds, 0 means no eviction",
+		DefaultValue: "0",
+		Export:       true,
+	}
+	p.ConnectionIdleTimeoutSeconds.Init(base.mgr)
+
 	p.SlowQuerySpanInSeconds = ParamItem{
 		Key:          "proxy.slowQuerySpanInSeconds",
 		Version:      "2.3.11",
@@ -2192,6 +2587,34 @@ Disabled if the value is less or equal to 0.`,
 		Export:       true,
 	}
 	p.QueryNodePoolingSize.Init(base.mgr)
+
+	p.IngestionEnabled = ParamItem{
+		Key:          "proxy.ingestion.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "whether to run the ingestion connectors, which consume records from kafka topics and insert them into collections",
+		Export:       true,
+	}
+	p.IngestionEnabled.Init(base.mgr)
+
+	p.IngestionConnectors = ParamGroup{
+		KeyPrefix: "proxy.ingestion.connectors.",
+		Version:   "2.6.0",
+		Doc: `Ingestion connectors keyed by connector name, each value is a JSON spec of the connector, e.g.
+{"collection": "events", "brokers": "localhost:9092", "topic": "events", "groupID": "milvus-events",
+ "format": "json", "mapping": {"title": "payload.title", "embedding": "payload.vector"}}`,
+	}
+	p.IngestionConnectors.Init(base.mgr)
+
+	p.IngestionRefreshInterval = ParamItem{
+		Key:          "proxy.ingestion.refreshInterval",
+		Version:      "2.6.0",
+		DefaultValue: "60",
+		Doc: `interval in seconds to refresh the ingestion connectors, the connectors configured by the collection property
+collection.ingestion.connector are started or stopped accordingly, and the ones failed to start are retried`,
+		Export: true,
+	}
+	p.IngestionRefreshInterval.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -2285,6 +2708,19 @@ type queryCoordConfig struct {
 
 	// query node task parallelism factor
 	QueryNodeTaskParallelismFactor ParamItem `refreshable:"true"`
+
+	// resource group quota
+	ResourceGroupQuotaEnabled      ParamItem `refreshable:"true"`
+	ResourceGroupMemoryQuotaRatio  ParamItem `refreshable:"true"`
+	ResourceGroupShardsPerCPUQuota ParamItem `refreshable:"true"`
+
+	// hot spot
+	HotSpotCheckEnabled        ParamItem `refreshable:"true"`
+	HotSpotCheckInterval       ParamItem `refreshable:"true"`
+	HotSpotInflightThreshold   ParamItem `refreshable:"true"`
+	HotSpotLatencyThreshold    ParamItem `refreshable:"true"`
+	HotSpotMaxCopiesPerSegment ParamItem `refreshable:"true"`
+	HotSpotMaxCopiesPerChannel ParamItem `refreshable:"true"`
 }
 
 func (p *queryCoordConfig) init(base *BaseTable) {
@@ -2917,6 +3353,100 @@ If this parameter is set false, Milvus simply searches the growing segments with
 		Export:       false,
 	}
 	p.QueryNodeTaskParallelismFactor.Init(base.mgr)
+
+	p.ResourceGroupQuotaEnabled = ParamItem{
+		Key:          "queryCoord.resourceGroupQuota.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "whether to reject the load and update replica requests which make the estimated memory or cpu usage of the resource group exceed its quota",
+		Export:       true,
+	}
+	p.ResourceGroupQuotaEnabled.Init(base.mgr)
+
+	p.ResourceGroupMemoryQuotaRatio = ParamItem{
+		Key:          "queryCoord.resourceGroupQuota.memoryRatio",
+		Version:      "2.6.0",
+		DefaultValue: "0.9",
+		Doc:          "the memory quota of the resource group, as the ratio to the total memory capacity of its query nodes",
+		Formatter: func(v string) string {
+			ratio := getAsFloat(v)
+			if ratio <= 0 || ratio > 1 {
+				return "0.9"
+			}
+			return v
+		},
+		Export: true,
+	}
+	p.ResourceGroupMemoryQuotaRatio.Init(base.mgr)
+
+	p.ResourceGroupShardsPerCPUQuota = ParamItem{
+		Key:          "queryCoord.resourceGroupQuota.shardsPerCPU",
+		Version:      "2.6.0",
+		DefaultValue: "0",
+		Doc:          "the cpu quota of the resource group, as the max number of the shards of the loaded replicas per cpu of its query nodes, 0 means no limit",
+		Formatter: func(v string) string {
+			if getAsFloat(v) < 0 {
+				return "0"
+			}
+			return v
+		},
+		Export: true,
+	}
+	p.ResourceGroupShardsPerCPUQuota.Init(base.mgr)
+
+	p.HotSpotCheckEnabled = ParamItem{
+		Key:          "queryCoord.hotSpot.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "whether to load temporary extra copies of the segments of the hot channels on the idle query nodes in the same replica",
+		Export:       true,
+	}
+	p.HotSpotCheckEnabled.Init(base.mgr)
+
+	p.HotSpotCheckInterval = ParamItem{
+		Key:          "queryCoord.hotSpot.checkInterval",
+		Version:      "2.6.0",
+		DefaultValue: "10000",
+		Doc:          "the interval of checking the workload of the channels, in milliseconds",
+		Export:       true,
+	}
+	p.HotSpotCheckInterval.Init(base.mgr)
+
+	p.HotSpotInflightThreshold = ParamItem{
+		Key:          "queryCoord.hotSpot.inflightThreshold",
+		Version:      "2.6.0",
+		DefaultValue: "32",
+		Doc:          "the channel is hot if the number of the inflight search and query requests on its delegator reaches the threshold",
+		Export:       true,
+	}
+	p.HotSpotInflightThreshold.Init(base.mgr)
+
+	p.HotSpotLatencyThreshold = ParamItem{
+		Key:          "queryCoord.hotSpot.latencyThreshold",
+		Version:      "2.6.0",
+		DefaultValue: "1000",
+		Doc:          "the channel is hot if the recent average latency of the search and query requests on its delegator reaches the threshold, in milliseconds",
+		Export:       true,
+	}
+	p.HotSpotLatencyThreshold.Init(base.mgr)
+
+	p.HotSpotMaxCopiesPerSegment = ParamItem{
+		Key:          "queryCoord.hotSpot.maxCopiesPerSegment",
+		Version:      "2.6.0",
+		DefaultValue: "1",
+		Doc:          "the max number of the temporary extra copies of a segment in one replica",
+		Export:       true,
+	}
+	p.HotSpotMaxCopiesPerSegment.Init(base.mgr)
+
+	p.HotSpotMaxCopiesPerChannel = ParamItem{
+		Key:          "queryCoord.hotSpot.maxCopiesPerChannel",
+		Version:      "2.6.0",
+		DefaultValue: "8",
+		Doc:          "the max number of the temporary extra segment copies of a hot channel in one replica, added in each round of check",
+		Export:       true,
+	}
+	p.HotSpotMaxCopiesPerChannel.Init(base.mgr)
 }
 
 // /////////////////////////////////////////////////////////////////////////////
@@ -2997,6 +3527,16 @@ type queryNodeConfig struct {
 	LazyLoadRequestResourceRetryInterval ParamItem `refreshable:"true"`
 	LazyLoadMaxRetryTimes                ParamItem `refreshable:"true"`
 	LazyLoadMaxEvictPerRetry             ParamItem `refreshable:"true"`
+	LazyLoadEvictionEnabled              ParamItem `refreshable:"true"`
+	LazyLoadEvictionHighWatermark        ParamItem `refreshable:"true"`
+	LazyLoadEvictionLowWatermark         ParamItem `refreshable:"true"`
+	LazyLoadEvictionCheckInterval        ParamItem `refreshable:"false"`
+
+	WarmupEnabled         ParamItem `refreshable:"true"`
+	WarmupTimeBudget      ParamItem `refreshable:"true"`
+	WarmupProfileTTL      ParamItem `refreshable:"true"`
+	WarmupProfilePath     ParamItem `refreshable:"false"`
+	WarmupPersistInterval ParamItem `refreshable:"false"`
 
 	IndexOffsetCacheEnabled ParamItem `refreshable:"true"`
 
@@ -3772,6 +4312,93 @@ However, this optimization may come at the cost of a slight decrease in query la
 	}
 	p.LazyLoadMaxEvictPerRetry.Init(base.mgr)
 
+	p.LazyLoadEvictionEnabled = ParamItem{
+		Key:          "queryNode.lazyload.eviction.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "evict the data of the least recently accessed sealed segments when the memory usage exceeds the high watermark or the memory is not enough for loading, the evicted segments turn into lazy load",
+		Export:       true,
+	}
+	p.LazyLoadEvictionEnabled.Init(base.mgr)
+
+	p.LazyLoadEvictionHighWatermark = ParamItem{
+		Key:          "queryNode.lazyload.eviction.highWatermark",
+		Version:      "2.6.0",
+		DefaultValue: "0.85",
+		Doc:          "the memory usage ratio to start evicting the sealed segments",
+		Export:       true,
+	}
+	p.LazyLoadEvictionHighWatermark.Init(base.mgr)
+
+	p.LazyLoadEvictionLowWatermark = ParamItem{
+		Key:          "queryNode.lazyload.eviction.lowWatermark",
+		Version:      "2.6.0",
+		DefaultValue: "0.75",
+		Doc:          "the memory usage ratio to stop evicting the sealed segments",
+		Export:       true,
+	}
+	p.LazyLoadEvictionLowWatermark.Init(base.mgr)
+
+	p.LazyLoadEvictionCheckInterval = ParamItem{
+		Key:          "queryNode.lazyload.eviction.checkInterval",
+		Version:      "2.6.0",
+		DefaultValue: "10",
+		Doc:          "interval in seconds to check the memory usage for eviction",
+		Export:       true,
+	}
+	p.LazyLoadEvictionCheckInterval.Init(base.mgr)
+
+	p.WarmupEnabled = ParamItem{
+		Key:          "queryNode.warmup.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "pre-read the local files of the recently accessed fields into page cache before the loaded segment is ready",
+		Export:       true,
+	}
+	p.WarmupEnabled.Init(base.mgr)
+
+	p.WarmupTimeBudget = ParamItem{
+		Key:          "queryNode.warmup.timeBudget",
+		Version:      "2.6.0",
+		DefaultValue: "5000",
+		Doc:          "the max time in milliseconds to warm up a loaded segment, the segment is ready after the warmup finishes or the time runs out",
+		Export:       true,
+	}
+	p.WarmupTimeBudget.Init(base.mgr)
+
+	p.WarmupProfileTTL = ParamItem{
+		Key:          "queryNode.warmup.profileTTL",
+		Version:      "2.6.0",
+		DefaultValue: "86400",
+		Doc:          "the fields not accessed in the time, in seconds, are removed from the warmup profile",
+		Export:       true,
+	}
+	p.WarmupProfileTTL.Init(base.mgr)
+
+	p.WarmupProfilePath = ParamItem{
+		Key:          "queryNode.warmup.profilePath",
+		Version:      "2.6.0",
+		DefaultValue: "",
+		Doc:          "the file to persist the accessed fields of the collections, localStorage.path/warmup_profile.json if empty",
+		Formatter: func(v string) string {
+			if len(v) == 0 {
+				return path.Join(base.Get("localStorage.path"), "warmup_profile.json")
+			}
+			return v
+		},
+		Export: true,
+	}
+	p.WarmupProfilePath.Init(base.mgr)
+
+	p.WarmupPersistInterval = ParamItem{
+		Key:          "queryNode.warmup.persistInterval",
+		Version:      "2.6.0",
+		DefaultValue: "60",
+		Doc:          "interval in seconds to persist the warmup profile",
+		Export:       true,
+	}
+	p.WarmupPersistInterval.Init(base.mgr)
+
 	p.ReadAheadPolicy = ParamItem{
 		Key:          "queryNode.cache.readAheadPolicy",
 		Version:      "2.3.2",
@@ -4316,6 +4943,7 @@ type dataCoordConfig struct {
 	FilesPerPreImportTask           ParamItem `refreshable:"true"`
 	ImportTaskRetention             ParamItem `refreshable:"true"`
 	MaxSizeInMBPerImportTask        ParamItem `refreshable:"true"`
+	JSONLSplitSizeInMB              ParamItem `refreshable:"true"`
 	ImportScheduleInterval          ParamItem `refreshable:"true"`
 	ImportCheckIntervalHigh         ParamItem `refreshable:"true"`
 	ImportCheckIntervalLow          ParamItem `refreshable:"true"`
@@ -5185,6 +5813,17 @@ if param targetVecIndexVersion is not set, the default value is -1, which means
 	}
 	p.MaxSizeInMBPerImportTask.Init(base.mgr)
 
+	p.JSONLSplitSizeInMB = ParamItem{
+		Key:     "dataCoord.import.jsonlSplitSizeInMB",
+		Version: "2.6.3",
+		Doc: "A JSON Lines file larger than this size will be split into multiple byte ranges, " +
+			"and each range is pre-imported and imported by a separate task. Set to 0 to disable splitting.",
+		DefaultValue: "1024",
+		PanicIfEmpty: false,
+		Export:       true,
+	}
+	p.JSONLSplitSizeInMB.Init(base.mgr)
+
 	p.ImportScheduleInterval = ParamItem{
 		Key:          "dataCoord.import.scheduleInterval",
 		Version:      "2.4.0",
@@ -6392,6 +7031,10 @@ func (params *ComponentParam) SaveGroup(group map[string]string) error {
 	return params.baseTable.SaveGroup(group)
 }
 
+func (params *ComponentParam) ResetGroup(keys ...string) error {
+	return params.baseTable.ResetGroup(keys...)
+}
+
 func (params *ComponentParam) Remove(key string) error {
 	return params.baseTable.Remove(key)
 }
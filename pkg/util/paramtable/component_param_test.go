@@ -17,6 +17,7 @@
 package paramtable
 
 import (
+	"path"
 	"testing"
 	"time"
 
@@ -198,6 +199,15 @@ func TestComponentParam(t *testing.T) {
 
 		t.Logf("AccessLog.MaxDays: %d", Params.AccessLog.RotatedTime.GetAsInt64())
 
+		assert.False(t, Params.AuditLog.Enable.GetAsBool())
+		assert.Equal(t, "milvus_audit.log", Params.AuditLog.Filename.GetValue())
+		assert.Equal(t, "audit_log/", Params.AuditLog.RemotePath.GetValue())
+		assert.False(t, Params.SlowQueryLog.Enable.GetAsBool())
+		assert.Equal(t, "slow_query_fingerprints.json", Params.SlowQueryLog.Filename.GetValue())
+		assert.Equal(t, 168*time.Hour, Params.SlowQueryLog.Retention.GetAsDuration(time.Hour))
+		assert.Equal(t, 1000, Params.SlowQueryLog.MaxFingerprints.GetAsInt())
+		assert.Equal(t, int64(0), Params.MaxExecutionTime.GetAsInt64())
+
 		t.Logf("ShardLeaderCacheInterval: %d", Params.ShardLeaderCacheInterval.GetAsInt64())
 
 		assert.Equal(t, Params.ReplicaSelectionPolicy.GetValue(), "look_aside")
@@ -236,6 +246,9 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 72, Params.MaxPasswordLength.GetAsInt())
 		params.Save("proxy.maxPasswordLength", "-10")
 		assert.Equal(t, 72, Params.MaxPasswordLength.GetAsInt())
+
+		assert.False(t, Params.IngestionEnabled.GetAsBool())
+		assert.Equal(t, 60*time.Second, Params.IngestionRefreshInterval.GetAsDuration(time.Second))
 	})
 
 	// t.Run("test proxyConfig panic", func(t *testing.T) {
@@ -393,6 +406,25 @@ func TestComponentParam(t *testing.T) {
 		assert.Equal(t, 1, Params.QueryNodeTaskParallelismFactor.GetAsInt())
 		params.Save("queryCoord.queryNodeTaskParallelismFactor", "2")
 		assert.Equal(t, 2, Params.QueryNodeTaskParallelismFactor.GetAsInt())
+
+		assert.False(t, Params.ResourceGroupQuotaEnabled.GetAsBool())
+		assert.Equal(t, 0.9, Params.ResourceGroupMemoryQuotaRatio.GetAsFloat())
+		assert.Equal(t, 0.0, Params.ResourceGroupShardsPerCPUQuota.GetAsFloat())
+		params.Save("queryCoord.resourceGroupQuota.memoryRatio", "0.8")
+		assert.Equal(t, 0.8, Params.ResourceGroupMemoryQuotaRatio.GetAsFloat())
+		params.Save("queryCoord.resourceGroupQuota.memoryRatio", "1.5")
+		assert.Equal(t, 0.9, Params.ResourceGroupMemoryQuotaRatio.GetAsFloat())
+		params.Save("queryCoord.resourceGroupQuota.shardsPerCPU", "-1")
+		assert.Equal(t, 0.0, Params.ResourceGroupShardsPerCPUQuota.GetAsFloat())
+		params.Save("queryCoord.resourceGroupQuota.shardsPerCPU", "2")
+		assert.Equal(t, 2.0, Params.ResourceGroupShardsPerCPUQuota.GetAsFloat())
+
+		assert.False(t, Params.HotSpotCheckEnabled.GetAsBool())
+		assert.Equal(t, 10*time.Second, Params.HotSpotCheckInterval.GetAsDuration(time.Millisecond))
+		assert.Equal(t, int64(32), Params.HotSpotInflightThreshold.GetAsInt64())
+		assert.Equal(t, time.Second, Params.HotSpotLatencyThreshold.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 1, Params.HotSpotMaxCopiesPerSegment.GetAsInt())
+		assert.Equal(t, 8, Params.HotSpotMaxCopiesPerChannel.GetAsInt())
 	})
 
 	t.Run("test queryNodeConfig", func(t *testing.T) {
@@ -491,6 +523,17 @@ func TestComponentParam(t *testing.T) {
 		params.Save("queryNode.lazyload.requestResourceRetryInterval", "3000")
 		assert.Equal(t, 3*time.Second, Params.LazyLoadRequestResourceRetryInterval.GetAsDuration(time.Millisecond))
 
+		assert.False(t, Params.LazyLoadEvictionEnabled.GetAsBool())
+		assert.Equal(t, 0.85, Params.LazyLoadEvictionHighWatermark.GetAsFloat())
+		assert.Equal(t, 0.75, Params.LazyLoadEvictionLowWatermark.GetAsFloat())
+		assert.Equal(t, 10*time.Second, Params.LazyLoadEvictionCheckInterval.GetAsDuration(time.Second))
+
+		assert.False(t, Params.WarmupEnabled.GetAsBool())
+		assert.Equal(t, 5*time.Second, Params.WarmupTimeBudget.GetAsDuration(time.Millisecond))
+		assert.Equal(t, 24*time.Hour, Params.WarmupProfileTTL.GetAsDuration(time.Second))
+		assert.Equal(t, path.Join(params.LocalStorageCfg.Path.GetValue(), "warmup_profile.json"), Params.WarmupProfilePath.GetValue())
+		assert.Equal(t, time.Minute, Params.WarmupPersistInterval.GetAsDuration(time.Second))
+
 		assert.Equal(t, 2, Params.BloomFilterApplyParallelFactor.GetAsInt())
 		assert.Equal(t, true, Params.SkipGrowingSegmentBF.GetAsBool())
 
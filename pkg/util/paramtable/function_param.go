@@ -25,6 +25,11 @@ type functionConfig struct {
 	RerankModelProviders   ParamGroup `refreshable:"true"`
 	LocalResourcePath      ParamItem  `refreshable:"true"`
 	LinderaDownloadUrls    ParamGroup `refreshable:"true"`
+
+	TextEmbeddingCacheEnabled    ParamItem `refreshable:"true"`
+	TextEmbeddingCacheSearchOnly ParamItem `refreshable:"true"`
+	TextEmbeddingCacheMaxSize    ParamItem `refreshable:"false"`
+	TextEmbeddingCacheTTL        ParamItem `refreshable:"true"`
 }
 
 func (p *functionConfig) init(base *BaseTable) {
@@ -86,6 +91,18 @@ func (p *functionConfig) init(base *BaseTable) {
 				return "The name in the crendential configuration item"
 			case "vertexai.enable":
 				return "Whether to enable vertexai model service"
+			case "openai_compatible.credential":
+				return "The name in the crendential configuration item"
+			case "openai_compatible.url":
+				return "Your OpenAI compatible embedding url, used if the endpoint is not set in function params"
+			case "openai_compatible.enable":
+				return "Whether to enable OpenAI compatible model service"
+			case "http_json.credential":
+				return "The name in the crendential configuration item"
+			case "http_json.url":
+				return "Your embedding url, used if the endpoint is not set in function params"
+			case "http_json.enable":
+				return "Whether to enable http json model service"
 			default:
 				return ""
 			}
@@ -145,6 +162,42 @@ func (p *functionConfig) init(base *BaseTable) {
 		Version:   "2.5.16",
 	}
 	p.LinderaDownloadUrls.Init(base.mgr)
+
+	p.TextEmbeddingCacheEnabled = ParamItem{
+		Key:          "function.textEmbedding.cache.enabled",
+		Version:      "2.6.0",
+		DefaultValue: "false",
+		Doc:          "Whether to cache the embeddings of texts by default, it could be overridden by the function param embedding_cache. The cache is shared by all text embedding functions",
+		Export:       true,
+	}
+	p.TextEmbeddingCacheEnabled.Init(base.mgr)
+
+	p.TextEmbeddingCacheSearchOnly = ParamItem{
+		Key:          "function.textEmbedding.cache.searchOnly",
+		Version:      "2.6.0",
+		DefaultValue: "true",
+		Doc:          "Only use the cache for the embeddings of search texts, the inserted data is always embedded by the model service",
+		Export:       true,
+	}
+	p.TextEmbeddingCacheSearchOnly.Init(base.mgr)
+
+	p.TextEmbeddingCacheMaxSize = ParamItem{
+		Key:          "function.textEmbedding.cache.maxSize",
+		Version:      "2.6.0",
+		DefaultValue: "64",
+		Doc:          "The maximum size of the cached embeddings of all functions, the least recently used ones are evicted when exceeded. Unit: MB",
+		Export:       true,
+	}
+	p.TextEmbeddingCacheMaxSize.Init(base.mgr)
+
+	p.TextEmbeddingCacheTTL = ParamItem{
+		Key:          "function.textEmbedding.cache.ttl",
+		Version:      "2.6.0",
+		DefaultValue: "3600",
+		Doc:          "The time to live of cached embeddings, in seconds",
+		Export:       true,
+	}
+	p.TextEmbeddingCacheTTL.Init(base.mgr)
 }
 
 const (
@@ -168,6 +168,10 @@ type quotaConfig struct {
 
 	// limit reading
 	ForceDenyReading ParamItem `refreshable:"true"`
+
+	// limits of callers
+	UserRateLimits ParamGroup `refreshable:"true"`
+	RoleRateLimits ParamGroup `refreshable:"true"`
 }
 
 func (p *quotaConfig) init(base *BaseTable) {
@@ -208,6 +212,26 @@ seconds, (0 ~ 65536)`,
 	}
 	p.ForceDenyAllDDL.Init(base.mgr)
 
+	p.UserRateLimits = ParamGroup{
+		KeyPrefix: "quotaAndLimits.users.",
+		Version:   "2.6.0",
+		Export:    true,
+		Doc: `Rate limits of each user across all the databases, e.g. quotaAndLimits.users.<user>.dml.insertRate: 4,
+dml.insertRate, dml.upsertRate, dml.deleteRate and dml.bulkLoadRate are in MB/s, dql.searchRate is in vps,
+dql.queryRate and ddl.collectionRate, ddl.partitionRate, ddl.indexRate, ddl.flushRate, ddl.compactionRate, ddl.dbRate are in qps.
+The user names are case insensitive, and the limits are enforced by each proxy.`,
+	}
+	p.UserRateLimits.Init(base.mgr)
+
+	p.RoleRateLimits = ParamGroup{
+		KeyPrefix: "quotaAndLimits.roles.",
+		Version:   "2.6.0",
+		Export:    true,
+		Doc: `Rate limits of each role, shared by all the users granted the role, e.g. quotaAndLimits.roles.<role>.dql.searchRate: 100,
+the rate types are the same as quotaAndLimits.users.`,
+	}
+	p.RoleRateLimits.Init(base.mgr)
+
 	// ddl
 	max := fmt.Sprintf("%f", defaultMax)
 	min := fmt.Sprintf("%f", defaultMin)
@@ -225,6 +225,22 @@ func TestQuotaParam(t *testing.T) {
 		assert.False(t, qc.ForceDenyReading.GetAsBool())
 	})
 
+	t.Run("test caller limits", func(t *testing.T) {
+		params.Init(NewBaseTable(SkipRemote(true)))
+		qc := &params.QuotaConfig
+		assert.Empty(t, qc.UserRateLimits.GetValue())
+		params.SaveGroup(map[string]string{
+			"quotaAndLimits.users.alice.dml.insertRate":  "4",
+			"quotaAndLimits.roles.reader.dql.searchRate": "100",
+		})
+		assert.Equal(t, "4", qc.UserRateLimits.GetValue()["alice.dml.insertrate"])
+		assert.Equal(t, "100", qc.RoleRateLimits.GetValue()["reader.dql.searchrate"])
+
+		params.ResetGroup("quotaAndLimits.users.alice.dml.insertRate", "quotaAndLimits.roles.reader.dql.searchRate")
+		assert.Empty(t, qc.UserRateLimits.GetValue())
+		assert.Empty(t, qc.RoleRateLimits.GetValue())
+	})
+
 	t.Run("test disk quota", func(t *testing.T) {
 		assert.Equal(t, defaultMax, qc.DiskQuota.GetAsFloat())
 		assert.Equal(t, defaultMax, qc.DiskQuotaPerCollection.GetAsFloat())